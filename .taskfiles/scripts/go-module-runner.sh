@@ -7,7 +7,7 @@
 # - test/lint/codegen: Uses MODULES var (app modules only) - tests/integration has separate task
 set -euo pipefail
 
-MODULES="${MODULES:-./proofwatch}"
+MODULES="${MODULES:-./proofwatch ./receiver/xccdfreceiver ./receiver/policyreportreceiver ./exporter/oscalexporter ./connector/postureconnector ./processor/signingprocessor ./exporter/evidencebundleexporter ./exporter/ocsfexporter ./receiver/opadecisionlogreceiver ./receiver/awssecurityreceiver ./receiver/azurepolicyreceiver ./receiver/gcpsccreceiver ./receiver/auditdreceiver ./connector/assessmentsessionconnector ./exporter/c2pexporter ./processor/correlationprocessor ./processor/dedupprocessor ./processor/rollupprocessor ./receiver/wazuhreceiver ./receiver/inspecreceiver ./receiver/trivyoperatorreceiver ./exporter/poamexporter ./receiver/cklreceiver ./exporter/opensearchexporter ./exporter/transparencylogexporter ./connector/compliancebudgetconnector ./receiver/ciwebhookreceiver ./processor/redactionprocessor ./receiver/admissionauditreceiver ./exporter/kafkaevidenceexporter}"
 GAZE_COVERPROFILE="${GAZE_COVERPROFILE:-coverage.out}"
 GAZE_NEW_FUNC_THRESHOLD="${GAZE_NEW_FUNC_THRESHOLD:-30}"
 
@@ -0,0 +1,59 @@
+package assessmentsessionconnector
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+const (
+	defaultIdleTimeout = time.Minute
+	defaultMaxSessions = 1000
+
+	// minIdleTimeout keeps the idle check, which runs every half idle
+	// timeout, from spinning.
+	minIdleTimeout = time.Second
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines the configuration for the assessment session connector.
+type Config struct {
+	// SessionAttribute identifies the assessment run a record belongs to. It is
+	// looked up in the record attributes first, then in the resource attributes.
+	SessionAttribute string `mapstructure:"session_attribute"`
+
+	// IdleTimeout ends a session once no evidence for it arrived within this
+	// duration. Its summary is emitted then.
+	IdleTimeout time.Duration `mapstructure:"idle_timeout"`
+
+	// MaxSessions bounds the number of open sessions. When it is reached, the
+	// least recently active session is ended early.
+	MaxSessions int `mapstructure:"max_sessions"`
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		SessionAttribute: proofwatch.COMPLIANCE_ASSESSMENT_ID,
+		IdleTimeout:      defaultIdleTimeout,
+		MaxSessions:      defaultMaxSessions,
+	}
+}
+
+// Validate checks the connector configuration is valid.
+func (c *Config) Validate() error {
+	if c.SessionAttribute == "" {
+		return errors.New("session_attribute must not be empty")
+	}
+	if c.IdleTimeout < minIdleTimeout {
+		return fmt.Errorf("idle_timeout must be at least %s", minIdleTimeout)
+	}
+	if c.MaxSessions <= 0 {
+		return errors.New("max_sessions must be positive")
+	}
+	return nil
+}
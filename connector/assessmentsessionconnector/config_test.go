@@ -0,0 +1,94 @@
+package assessmentsessionconnector
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id       component.ID
+		expected *Config
+	}{
+		{
+			id:       component.NewID(componentType),
+			expected: createDefaultConfig().(*Config),
+		},
+		{
+			id: component.NewIDWithName(componentType, "custom"),
+			expected: &Config{
+				SessionAttribute: "scan.id",
+				IdleTimeout:      5 * time.Minute,
+				MaxSessions:      50,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, sub.Unmarshal(cfg))
+			require.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "empty session attribute",
+			mutate:  func(cfg *Config) { cfg.SessionAttribute = "" },
+			wantErr: "session_attribute must not be empty",
+		},
+		{
+			name:    "zero idle timeout",
+			mutate:  func(cfg *Config) { cfg.IdleTimeout = 0 },
+			wantErr: "idle_timeout must be at least 1s",
+		},
+		{
+			name:    "idle timeout below minimum",
+			mutate:  func(cfg *Config) { cfg.IdleTimeout = time.Nanosecond },
+			wantErr: "idle_timeout must be at least 1s",
+		},
+		{
+			name:    "zero max sessions",
+			mutate:  func(cfg *Config) { cfg.MaxSessions = 0 },
+			wantErr: "max_sessions must be positive",
+		},
+		{
+			name:   "valid",
+			mutate: func(*Config) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package assessmentsessionconnector
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+var _ connector.Logs = (*sessionConnector)(nil)
+
+// emitFunc delivers the summaries of ended sessions to the next consumer.
+type emitFunc func(ctx context.Context, sessions []*session, now time.Time) error
+
+type sessionConnector struct {
+	cfg      *Config
+	settings connector.Settings
+	emit     emitFunc
+	now      func() time.Time
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu       sync.Mutex
+	sessions map[string]*session
+	// ended holds sessions ended early because MaxSessions was reached.
+	ended []*session
+}
+
+func newSessionConnector(cfg *Config, set connector.Settings, emit emitFunc) *sessionConnector {
+	return &sessionConnector{
+		cfg:      cfg,
+		settings: set,
+		emit:     emit,
+		now:      time.Now,
+		sessions: make(map[string]*session),
+	}
+}
+
+// Capabilities implements consumer.Logs.
+func (c *sessionConnector) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// Start begins checking for idle sessions twice per idle timeout.
+func (c *sessionConnector) Start(context.Context, component.Host) error {
+	runCtx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		ticker := time.NewTicker(c.cfg.IdleTimeout / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-runCtx.Done():
+				return
+			case <-ticker.C:
+				if err := c.flush(runCtx, false); err != nil {
+					c.settings.Logger.Warn("failed to emit assessment session summaries", zap.Error(err))
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the idle check and emits the summaries of all open sessions,
+// so a run still in progress is reported with the evidence seen so far.
+func (c *sessionConnector) Shutdown(ctx context.Context) error {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.wg.Wait()
+	return c.flush(ctx, true)
+}
+
+// ConsumeLogs adds every record with a session identifier to its session.
+// Records without one are ignored.
+func (c *sessionConnector) ConsumeLogs(_ context.Context, logs plog.Logs) error {
+	now := c.now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		rl := logs.ResourceLogs().At(i)
+		resourceID := ""
+		if v, ok := rl.Resource().Attributes().Get(c.cfg.SessionAttribute); ok {
+			resourceID = v.AsString()
+		}
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				c.record(rl.Resource(), resourceID, lrs.At(k), now)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *sessionConnector) record(resource pcommon.Resource, resourceID string, lr plog.LogRecord, now time.Time) {
+	id := resourceID
+	if v, ok := lr.Attributes().Get(c.cfg.SessionAttribute); ok {
+		id = v.AsString()
+	}
+	if id == "" {
+		return
+	}
+
+	s, ok := c.sessions[id]
+	if !ok {
+		if len(c.sessions) >= c.cfg.MaxSessions {
+			c.endLeastRecent()
+		}
+		s = newSession(id, resource, now)
+		c.sessions[id] = s
+	}
+
+	result := ""
+	if v, ok := lr.Attributes().Get(proofwatch.POLICY_EVALUATION_RESULT); ok {
+		result = v.AsString()
+	}
+	s.add(result, recordTime(lr, now), now)
+}
+
+// endLeastRecent ends the session that has been idle the longest.
+func (c *sessionConnector) endLeastRecent() {
+	var oldest *session
+	for _, s := range c.sessions {
+		if oldest == nil || s.lastSeen.Before(oldest.lastSeen) {
+			oldest = s
+		}
+	}
+	if oldest != nil {
+		c.settings.Logger.Warn("max_sessions reached; ending the least recently active session early",
+			zap.String("session", oldest.id))
+		delete(c.sessions, oldest.id)
+		c.ended = append(c.ended, oldest)
+	}
+}
+
+// flush emits the summaries of sessions idle for the idle timeout, or of all
+// sessions when all is set. Summaries that fail to emit are dropped: the
+// evidence they summarize was already passed on.
+func (c *sessionConnector) flush(ctx context.Context, all bool) error {
+	now := c.now()
+
+	c.mu.Lock()
+	ended := c.ended
+	c.ended = nil
+	for id, s := range c.sessions {
+		if all || now.Sub(s.lastSeen) >= c.cfg.IdleTimeout {
+			ended = append(ended, s)
+			delete(c.sessions, id)
+		}
+	}
+	c.mu.Unlock()
+
+	if len(ended) == 0 {
+		return nil
+	}
+	sort.Slice(ended, func(i, j int) bool { return ended[i].start.Before(ended[j].start) })
+	return c.emit(ctx, ended, now)
+}
+
+func recordTime(lr plog.LogRecord, now time.Time) time.Time {
+	if lr.Timestamp() != 0 {
+		return lr.Timestamp().AsTime()
+	}
+	if lr.ObservedTimestamp() != 0 {
+		return lr.ObservedTimestamp().AsTime()
+	}
+	return now
+}
@@ -0,0 +1,203 @@
+package assessmentsessionconnector
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+var start = time.Date(2026, 6, 2, 12, 0, 0, 0, time.UTC)
+
+type testRecord struct {
+	attrs map[string]any
+	ts    time.Time
+}
+
+func evidence(session, result string, ts time.Time) testRecord {
+	attrs := map[string]any{proofwatch.POLICY_EVALUATION_RESULT: result}
+	if session != "" {
+		attrs[proofwatch.COMPLIANCE_ASSESSMENT_ID] = session
+	}
+	return testRecord{attrs: attrs, ts: ts}
+}
+
+func evidenceLogs(resource map[string]any, records ...testRecord) plog.Logs {
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	_ = rl.Resource().Attributes().FromRaw(resource)
+	lrs := rl.ScopeLogs().AppendEmpty().LogRecords()
+	for _, r := range records {
+		lr := lrs.AppendEmpty()
+		lr.SetTimestamp(pcommon.NewTimestampFromTime(r.ts))
+		_ = lr.Attributes().FromRaw(r.attrs)
+	}
+	return logs
+}
+
+// newTestConnector returns a connector whose clock is set by the caller and
+// which records the sessions it emits.
+func newTestConnector(now *time.Time, emitted *[]*session) *sessionConnector {
+	c := newSessionConnector(createDefaultConfig().(*Config), connectortest.NewNopSettings(componentType),
+		func(_ context.Context, sessions []*session, _ time.Time) error {
+			*emitted = append(*emitted, sessions...)
+			return nil
+		})
+	c.now = func() time.Time { return *now }
+	return c
+}
+
+func TestFlushEmitsIdleSessions(t *testing.T) {
+	now := start
+	var emitted []*session
+	c := newTestConnector(&now, &emitted)
+
+	require.NoError(t, c.ConsumeLogs(context.Background(), evidenceLogs(map[string]any{"host.name": "web-01"},
+		evidence("scan-1", "Passed", start.Add(2*time.Second)),
+		evidence("scan-1", "Failed", start),
+		evidence("scan-1", "Not Applicable", start.Add(5*time.Second)),
+		evidence("", "Failed", start),
+	)))
+	now = start.Add(40 * time.Second)
+	require.NoError(t, c.ConsumeLogs(context.Background(), evidenceLogs(nil,
+		evidence("scan-2", "Passed", start.Add(40*time.Second)),
+	)))
+
+	now = start.Add(61 * time.Second)
+	require.NoError(t, c.flush(context.Background(), false))
+	require.Len(t, emitted, 1, "only the idle session ends")
+
+	s := emitted[0]
+	assert.Equal(t, "scan-1", s.id)
+	assert.Equal(t, start, s.start)
+	assert.Equal(t, start.Add(5*time.Second), s.end)
+	assert.Equal(t, int64(3), s.evaluations)
+	assert.Equal(t, map[string]int64{"Passed": 1, "Failed": 1, "Not Applicable": 1}, s.results)
+	assert.Equal(t, map[string]any{"host.name": "web-01"}, s.resource.Attributes().AsRaw())
+
+	require.NoError(t, c.flush(context.Background(), true))
+	require.Len(t, emitted, 2)
+	assert.Equal(t, "scan-2", emitted[1].id)
+}
+
+func TestSessionFromResource(t *testing.T) {
+	now := start
+	var emitted []*session
+	c := newTestConnector(&now, &emitted)
+
+	require.NoError(t, c.ConsumeLogs(context.Background(), evidenceLogs(
+		map[string]any{proofwatch.COMPLIANCE_ASSESSMENT_ID: "scan-1"},
+		evidence("", "Passed", start),
+		evidence("scan-2", "Failed", start),
+	)))
+	require.NoError(t, c.flush(context.Background(), true))
+
+	require.Len(t, emitted, 2)
+	ids := []string{emitted[0].id, emitted[1].id}
+	assert.ElementsMatch(t, []string{"scan-1", "scan-2"}, ids, "record attributes take precedence")
+}
+
+func TestMaxSessionsEndsLeastRecent(t *testing.T) {
+	now := start
+	var emitted []*session
+	c := newTestConnector(&now, &emitted)
+	c.cfg.MaxSessions = 2
+
+	for i, id := range []string{"scan-1", "scan-2", "scan-3"} {
+		now = start.Add(time.Duration(i) * time.Second)
+		require.NoError(t, c.ConsumeLogs(context.Background(), evidenceLogs(nil, evidence(id, "Passed", now))))
+	}
+	assert.Len(t, c.sessions, 2)
+
+	require.NoError(t, c.flush(context.Background(), false))
+	require.Len(t, emitted, 1)
+	assert.Equal(t, "scan-1", emitted[0].id)
+}
+
+func TestFlushError(t *testing.T) {
+	c := newSessionConnector(createDefaultConfig().(*Config), connectortest.NewNopSettings(componentType),
+		func(context.Context, []*session, time.Time) error { return errors.New("pipeline unavailable") })
+	require.NoError(t, c.ConsumeLogs(context.Background(), evidenceLogs(nil, evidence("scan-1", "Passed", start))))
+
+	assert.EqualError(t, c.flush(context.Background(), true), "pipeline unavailable")
+	assert.Empty(t, c.sessions)
+}
+
+func testSession() *session {
+	s := newSession("scan-1", pcommon.NewResource(), start)
+	s.resource.Attributes().PutStr("host.name", "web-01")
+	s.add("Passed", start, start)
+	s.add("Failed", start.Add(time.Minute), start)
+	s.add("Passed", start.Add(30*time.Second), start)
+	return s
+}
+
+func TestBuildSummaryLogs(t *testing.T) {
+	observed := start.Add(2 * time.Minute)
+	logs := buildSummaryLogs([]*session{testSession()}, proofwatch.COMPLIANCE_ASSESSMENT_ID, observed)
+	require.Equal(t, 1, logs.LogRecordCount())
+
+	rl := logs.ResourceLogs().At(0)
+	assert.Equal(t, map[string]any{"host.name": "web-01"}, rl.Resource().Attributes().AsRaw())
+	assert.Equal(t, scopeName, rl.ScopeLogs().At(0).Scope().Name())
+
+	lr := rl.ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, proofwatch.EVENT_COMPLIANCE_ASSESSMENT, lr.EventName())
+	assert.Equal(t, start.Add(time.Minute), lr.Timestamp().AsTime())
+	assert.Equal(t, observed, lr.ObservedTimestamp().AsTime())
+	assert.Equal(t, map[string]any{
+		proofwatch.COMPLIANCE_ASSESSMENT_ID:          "scan-1",
+		proofwatch.COMPLIANCE_ASSESSMENT_EVALUATIONS: int64(3),
+		proofwatch.COMPLIANCE_ASSESSMENT_PASSED:      int64(2),
+		proofwatch.COMPLIANCE_ASSESSMENT_FAILED:      int64(1),
+	}, lr.Attributes().AsRaw())
+	assert.Equal(t, map[string]any{
+		"assessment_id": "scan-1",
+		"start":         "2026-06-02T12:00:00Z",
+		"end":           "2026-06-02T12:01:00Z",
+		"evaluations":   int64(3),
+		"results":       map[string]any{"Failed": int64(1), "Passed": int64(2)},
+	}, lr.Body().AsRaw())
+}
+
+func TestBuildSessionTraces(t *testing.T) {
+	passing := newSession("scan-2", pcommon.NewResource(), start)
+	passing.add("Passed", start, start)
+
+	traces := buildSessionTraces([]*session{testSession(), passing}, proofwatch.COMPLIANCE_ASSESSMENT_ID)
+	require.Equal(t, 2, traces.SpanCount())
+
+	span := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, proofwatch.EVENT_COMPLIANCE_ASSESSMENT, span.Name())
+	assert.False(t, span.TraceID().IsEmpty())
+	assert.False(t, span.SpanID().IsEmpty())
+	assert.Equal(t, start, span.StartTimestamp().AsTime())
+	assert.Equal(t, start.Add(time.Minute), span.EndTimestamp().AsTime())
+	assert.Equal(t, ptrace.StatusCodeError, span.Status().Code())
+	assert.Equal(t, "1 of 3 evaluations failed", span.Status().Message())
+	assert.Equal(t, int64(2), span.Attributes().AsRaw()[proofwatch.COMPLIANCE_ASSESSMENT_PASSED])
+
+	ok := traces.ResourceSpans().At(1).ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, ptrace.StatusCodeOk, ok.Status().Code())
+	assert.NotEqual(t, span.TraceID(), ok.TraceID())
+}
+
+func TestLogsToTraces(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	conn, err := NewFactory().CreateLogsToTraces(context.Background(), connectortest.NewNopSettings(componentType), createDefaultConfig(), sink)
+	require.NoError(t, err)
+
+	require.NoError(t, conn.ConsumeLogs(context.Background(), evidenceLogs(nil, evidence("scan-1", "Passed", start))))
+	require.NoError(t, conn.Shutdown(context.Background()))
+	assert.Equal(t, 1, sink.SpanCount())
+}
@@ -0,0 +1,48 @@
+package assessmentsessionconnector
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+const stability = component.StabilityLevelAlpha
+
+var componentType = component.MustNewType("assessmentsession")
+
+// NewFactory creates a factory for the assessment session connector.
+func NewFactory() connector.Factory {
+	return connector.NewFactory(
+		componentType,
+		createDefaultConfig,
+		connector.WithLogsToLogs(createLogsToLogs, stability),
+		connector.WithLogsToTraces(createLogsToTraces, stability),
+	)
+}
+
+func createLogsToLogs(
+	_ context.Context,
+	set connector.Settings,
+	cfg component.Config,
+	next consumer.Logs,
+) (connector.Logs, error) {
+	c := cfg.(*Config)
+	return newSessionConnector(c, set, func(ctx context.Context, sessions []*session, now time.Time) error {
+		return next.ConsumeLogs(ctx, buildSummaryLogs(sessions, c.SessionAttribute, now))
+	}), nil
+}
+
+func createLogsToTraces(
+	_ context.Context,
+	set connector.Settings,
+	cfg component.Config,
+	next consumer.Traces,
+) (connector.Logs, error) {
+	c := cfg.(*Config)
+	return newSessionConnector(c, set, func(ctx context.Context, sessions []*session, _ time.Time) error {
+		return next.ConsumeTraces(ctx, buildSessionTraces(sessions, c.SessionAttribute))
+	}), nil
+}
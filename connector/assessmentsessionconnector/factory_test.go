@@ -0,0 +1,41 @@
+package assessmentsessionconnector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestNewFactory(t *testing.T) {
+	factory := NewFactory()
+	assert.Equal(t, componentType, factory.Type())
+
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, componenttest.CheckConfigStruct(cfg))
+
+	logs, err := factory.CreateLogsToLogs(context.Background(), connectortest.NewNopSettings(componentType), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.NotNil(t, logs)
+
+	traces, err := factory.CreateLogsToTraces(context.Background(), connectortest.NewNopSettings(componentType), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.NotNil(t, traces)
+}
+
+func TestConnectorLifecycle(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	conn, err := NewFactory().CreateLogsToLogs(context.Background(), connectortest.NewNopSettings(componentType), createDefaultConfig(), sink)
+	require.NoError(t, err)
+
+	require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, conn.ConsumeLogs(context.Background(), evidenceLogs(nil, evidence("scan-1", "Passed", start))))
+	require.NoError(t, conn.Shutdown(context.Background()))
+
+	// Shutdown reports the session that was still open.
+	assert.Equal(t, 1, sink.LogRecordCount())
+}
@@ -0,0 +1,149 @@
+package assessmentsessionconnector
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+const (
+	scopeName = "github.com/complytime/complybeacon/connector/assessmentsessionconnector"
+
+	resultPassed = "Passed"
+	resultFailed = "Failed"
+)
+
+// session accumulates the evidence of one assessment run.
+type session struct {
+	id string
+	// resource holds the resource attributes of the first evidence of the run.
+	resource pcommon.Resource
+	// start and end are the earliest and latest evidence timestamps.
+	start, end time.Time
+	// lastSeen is when evidence for the run last arrived.
+	lastSeen time.Time
+	// results counts evaluations by policy.evaluation.result.
+	results     map[string]int64
+	evaluations int64
+}
+
+func newSession(id string, resource pcommon.Resource, now time.Time) *session {
+	s := &session{
+		id:       id,
+		resource: pcommon.NewResource(),
+		results:  make(map[string]int64),
+		lastSeen: now,
+	}
+	resource.CopyTo(s.resource)
+	return s
+}
+
+// add records one piece of evidence observed at ts.
+func (s *session) add(result string, ts, now time.Time) {
+	if s.start.IsZero() || ts.Before(s.start) {
+		s.start = ts
+	}
+	if ts.After(s.end) {
+		s.end = ts
+	}
+	s.lastSeen = now
+	s.evaluations++
+	if result != "" {
+		s.results[result]++
+	}
+}
+
+func (s *session) putAttributes(attrs pcommon.Map, sessionAttribute string) {
+	attrs.PutStr(sessionAttribute, s.id)
+	attrs.PutInt(proofwatch.COMPLIANCE_ASSESSMENT_EVALUATIONS, s.evaluations)
+	attrs.PutInt(proofwatch.COMPLIANCE_ASSESSMENT_PASSED, s.results[resultPassed])
+	attrs.PutInt(proofwatch.COMPLIANCE_ASSESSMENT_FAILED, s.results[resultFailed])
+}
+
+// buildSummaryLogs emits one summary record per session, under the resource of
+// the session's first evidence.
+func buildSummaryLogs(sessions []*session, sessionAttribute string, now time.Time) plog.Logs {
+	logs := plog.NewLogs()
+	for _, s := range sessions {
+		rl := logs.ResourceLogs().AppendEmpty()
+		s.resource.CopyTo(rl.Resource())
+		sl := rl.ScopeLogs().AppendEmpty()
+		sl.Scope().SetName(scopeName)
+
+		lr := sl.LogRecords().AppendEmpty()
+		lr.SetEventName(proofwatch.EVENT_COMPLIANCE_ASSESSMENT)
+		lr.SetTimestamp(pcommon.NewTimestampFromTime(s.end))
+		lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(now))
+		lr.SetSeverityNumber(plog.SeverityNumberInfo)
+		lr.SetSeverityText(plog.SeverityNumberInfo.String())
+		s.putAttributes(lr.Attributes(), sessionAttribute)
+
+		body := lr.Body().SetEmptyMap()
+		body.PutStr("assessment_id", s.id)
+		body.PutStr("start", s.start.UTC().Format(time.RFC3339Nano))
+		body.PutStr("end", s.end.UTC().Format(time.RFC3339Nano))
+		body.PutInt("evaluations", s.evaluations)
+		results := body.PutEmptyMap("results")
+		for _, result := range sortedResults(s.results) {
+			results.PutInt(result, s.results[result])
+		}
+	}
+	return logs
+}
+
+// buildSessionTraces emits one span per session, from its first to its last
+// evidence. The span status is an error when an evaluation failed.
+func buildSessionTraces(sessions []*session, sessionAttribute string) ptrace.Traces {
+	traces := ptrace.NewTraces()
+	for _, s := range sessions {
+		rs := traces.ResourceSpans().AppendEmpty()
+		s.resource.CopyTo(rs.Resource())
+		ss := rs.ScopeSpans().AppendEmpty()
+		ss.Scope().SetName(scopeName)
+
+		span := ss.Spans().AppendEmpty()
+		span.SetName(proofwatch.EVENT_COMPLIANCE_ASSESSMENT)
+		span.SetKind(ptrace.SpanKindInternal)
+		span.SetTraceID(newTraceID())
+		span.SetSpanID(newSpanID())
+		span.SetStartTimestamp(pcommon.NewTimestampFromTime(s.start))
+		span.SetEndTimestamp(pcommon.NewTimestampFromTime(s.end))
+		s.putAttributes(span.Attributes(), sessionAttribute)
+
+		if failed := s.results[resultFailed]; failed > 0 {
+			span.Status().SetCode(ptrace.StatusCodeError)
+			span.Status().SetMessage(fmt.Sprintf("%d of %d evaluations failed", failed, s.evaluations))
+		} else {
+			span.Status().SetCode(ptrace.StatusCodeOk)
+		}
+	}
+	return traces
+}
+
+func sortedResults(results map[string]int64) []string {
+	keys := make([]string, 0, len(results))
+	for k := range results {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func newTraceID() pcommon.TraceID {
+	var id pcommon.TraceID
+	_, _ = rand.Read(id[:])
+	return id
+}
+
+func newSpanID() pcommon.SpanID {
+	var id pcommon.SpanID
+	_, _ = rand.Read(id[:])
+	return id
+}
@@ -0,0 +1,261 @@
+package compliancebudgetconnector
+
+import (
+	"maps"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+const (
+	scopeName = "github.com/complytime/complybeacon/connector/compliancebudgetconnector"
+
+	// bucketSize is the resolution evaluations are counted at.
+	bucketSize = time.Minute
+
+	// windowAttribute names the rolling window of a data point.
+	windowAttribute = "window"
+
+	resultPassed = "Passed"
+	resultFailed = "Failed"
+)
+
+// counts are the passed and failed evaluations of one bucket.
+type counts struct {
+	passed, failed int64
+}
+
+// budget counts the evaluations of one group in buckets keyed by their start
+// in Unix seconds.
+type budget struct {
+	attrs   map[string]string
+	buckets map[int64]*counts
+}
+
+// sum adds the buckets that start at or after since.
+func (b *budget) sum(since time.Time) counts {
+	var total counts
+	cutoff := since.Truncate(bucketSize).Unix()
+	for start, c := range b.buckets {
+		if start >= cutoff {
+			total.passed += c.passed
+			total.failed += c.failed
+		}
+	}
+	return total
+}
+
+// tracker holds the budgets of every group.
+type tracker struct {
+	budgets map[string]*budget
+}
+
+func newTracker() *tracker {
+	return &tracker{budgets: make(map[string]*budget)}
+}
+
+// add counts one evaluation with result at ts toward the budget of attrs.
+func (t *tracker) add(attrs map[string]string, result string, ts time.Time) {
+	key := groupKey(attrs)
+	b, ok := t.budgets[key]
+	if !ok {
+		b = &budget{attrs: attrs, buckets: make(map[int64]*counts)}
+		t.budgets[key] = b
+	}
+	start := ts.Truncate(bucketSize).Unix()
+	c, ok := b.buckets[start]
+	if !ok {
+		c = &counts{}
+		b.buckets[start] = c
+	}
+	if result == resultPassed {
+		c.passed++
+	} else {
+		c.failed++
+	}
+}
+
+// expire drops buckets that started before since, and budgets left empty.
+func (t *tracker) expire(since time.Time) {
+	cutoff := since.Truncate(bucketSize).Unix()
+	for key, b := range t.budgets {
+		for start := range b.buckets {
+			if start < cutoff {
+				delete(b.buckets, start)
+			}
+		}
+		if len(b.buckets) == 0 {
+			delete(t.budgets, key)
+		}
+	}
+}
+
+// groupKey identifies a group by its sorted attributes.
+func groupKey(attrs map[string]string) string {
+	keys := make([]string, 0, len(attrs))
+	for k, v := range attrs {
+		keys = append(keys, k+"="+v)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
+// groups expands the group_by attributes of a record into the groups it counts
+// toward. An attribute with several values, such as compliance.frameworks,
+// counts toward each of them; a missing attribute is left out of the group.
+func groups(attrs pcommon.Map, groupBy []string) []map[string]string {
+	out := []map[string]string{{}}
+	for _, key := range groupBy {
+		values := stringValues(attrs, key)
+		if len(values) == 0 {
+			continue
+		}
+		next := make([]map[string]string, 0, len(out)*len(values))
+		for _, g := range out {
+			for _, v := range values {
+				group := maps.Clone(g)
+				group[key] = v
+				next = append(next, group)
+			}
+		}
+		out = next
+	}
+	return out
+}
+
+func stringValues(attrs pcommon.Map, key string) []string {
+	v, ok := attrs.Get(key)
+	if !ok {
+		return nil
+	}
+	if v.Type() != pcommon.ValueTypeSlice {
+		if s := v.AsString(); s != "" {
+			return []string{s}
+		}
+		return nil
+	}
+	var values []string
+	for i := 0; i < v.Slice().Len(); i++ {
+		if s := v.Slice().At(i).AsString(); s != "" {
+			values = append(values, s)
+		}
+	}
+	return values
+}
+
+// buildMetrics reports, for every group, the evaluations, error ratio and burn
+// rate of each window, and the budget remaining over the period.
+func buildMetrics(t *tracker, cfg *Config, now time.Time) pmetric.Metrics {
+	metrics := pmetric.NewMetrics()
+	sm := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName(scopeName)
+	ms := sm.Metrics()
+
+	evaluations := ms.AppendEmpty()
+	evaluations.SetName(proofwatch.METRIC_COMPLIANCE_BUDGET_EVALUATIONS)
+	evaluations.SetDescription(proofwatch.METRIC_COMPLIANCE_BUDGET_EVALUATIONS_DESCRIPTION)
+	evaluations.SetUnit(proofwatch.METRIC_COMPLIANCE_BUDGET_EVALUATIONS_UNIT)
+	evaluationPoints := evaluations.SetEmptyGauge().DataPoints()
+
+	errorRatio := ms.AppendEmpty()
+	errorRatio.SetName(proofwatch.METRIC_COMPLIANCE_BUDGET_ERROR_RATIO)
+	errorRatio.SetDescription(proofwatch.METRIC_COMPLIANCE_BUDGET_ERROR_RATIO_DESCRIPTION)
+	errorRatio.SetUnit(proofwatch.METRIC_COMPLIANCE_BUDGET_ERROR_RATIO_UNIT)
+	errorRatioPoints := errorRatio.SetEmptyGauge().DataPoints()
+
+	burnRate := ms.AppendEmpty()
+	burnRate.SetName(proofwatch.METRIC_COMPLIANCE_BUDGET_BURN_RATE)
+	burnRate.SetDescription(proofwatch.METRIC_COMPLIANCE_BUDGET_BURN_RATE_DESCRIPTION)
+	burnRate.SetUnit(proofwatch.METRIC_COMPLIANCE_BUDGET_BURN_RATE_UNIT)
+	burnRatePoints := burnRate.SetEmptyGauge().DataPoints()
+
+	remaining := ms.AppendEmpty()
+	remaining.SetName(proofwatch.METRIC_COMPLIANCE_BUDGET_REMAINING)
+	remaining.SetDescription(proofwatch.METRIC_COMPLIANCE_BUDGET_REMAINING_DESCRIPTION)
+	remaining.SetUnit(proofwatch.METRIC_COMPLIANCE_BUDGET_REMAINING_UNIT)
+	remainingPoints := remaining.SetEmptyGauge().DataPoints()
+
+	ts := pcommon.NewTimestampFromTime(now)
+	allowed := 1 - cfg.Objective
+	windows := append([]time.Duration(nil), cfg.Windows...)
+	sort.Slice(windows, func(i, j int) bool { return windows[i] < windows[j] })
+
+	keys := make([]string, 0, len(t.budgets))
+	for k := range t.budgets {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		b := t.budgets[key]
+		for _, w := range windows {
+			c := b.sum(now.Add(-w))
+			window := formatWindow(w)
+			for _, result := range []struct {
+				name  string
+				count int64
+			}{{resultFailed, c.failed}, {resultPassed, c.passed}} {
+				dp := evaluationPoints.AppendEmpty()
+				dp.SetTimestamp(ts)
+				putAttrs(dp.Attributes(), b.attrs)
+				dp.Attributes().PutStr(windowAttribute, window)
+				dp.Attributes().PutStr(proofwatch.POLICY_EVALUATION_RESULT, result.name)
+				dp.SetIntValue(result.count)
+			}
+
+			total := c.passed + c.failed
+			if total == 0 {
+				continue
+			}
+			ratio := float64(c.failed) / float64(total)
+			dp := errorRatioPoints.AppendEmpty()
+			dp.SetTimestamp(ts)
+			putAttrs(dp.Attributes(), b.attrs)
+			dp.Attributes().PutStr(windowAttribute, window)
+			dp.SetDoubleValue(ratio)
+
+			dp = burnRatePoints.AppendEmpty()
+			dp.SetTimestamp(ts)
+			putAttrs(dp.Attributes(), b.attrs)
+			dp.Attributes().PutStr(windowAttribute, window)
+			dp.SetDoubleValue(ratio / allowed)
+		}
+
+		c := b.sum(now.Add(-cfg.Period))
+		if total := c.passed + c.failed; total > 0 {
+			dp := remainingPoints.AppendEmpty()
+			dp.SetTimestamp(ts)
+			putAttrs(dp.Attributes(), b.attrs)
+			dp.SetDoubleValue(1 - float64(c.failed)/float64(total)/allowed)
+		}
+	}
+
+	ms.RemoveIf(func(m pmetric.Metric) bool { return m.Gauge().DataPoints().Len() == 0 })
+	return metrics
+}
+
+// formatWindow formats a window the way alert rules usually name it, e.g. 5m, 6h or 3d.
+func formatWindow(d time.Duration) string {
+	switch {
+	case d%(24*time.Hour) == 0:
+		return strconv.FormatInt(int64(d/(24*time.Hour)), 10) + "d"
+	case d%time.Hour == 0:
+		return strings.TrimSuffix(d.String(), "0m0s")
+	case d%time.Minute == 0:
+		return strings.TrimSuffix(d.String(), "0s")
+	default:
+		return d.String()
+	}
+}
+
+func putAttrs(dest pcommon.Map, attrs map[string]string) {
+	for k, v := range attrs {
+		dest.PutStr(k, v)
+	}
+}
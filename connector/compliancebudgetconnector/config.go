@@ -0,0 +1,82 @@
+package compliancebudgetconnector
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	defaultFlushInterval = time.Minute
+	defaultObjective     = 0.99
+	defaultPeriod        = 30 * 24 * time.Hour
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines the configuration for the compliance budget connector.
+type Config struct {
+	// FlushInterval is how often budget metrics are emitted.
+	FlushInterval time.Duration `mapstructure:"flush_interval"`
+
+	// Objective is the share of evaluations that must pass, e.g. 0.99. The
+	// remaining share is the compliance budget.
+	Objective float64 `mapstructure:"objective"`
+
+	// Windows are the rolling windows burn rates are reported for.
+	Windows []time.Duration `mapstructure:"windows"`
+
+	// Period is the rolling period the remaining budget is reported for.
+	Period time.Duration `mapstructure:"period"`
+
+	// RiskLevels selects the evaluations that count toward the budget by
+	// compliance.risk.level. Empty counts every evaluation.
+	RiskLevels []string `mapstructure:"risk_levels"`
+
+	// GroupBy lists record attributes that split the budget, e.g.
+	// compliance.frameworks. Empty keeps one budget for all evaluations.
+	GroupBy []string `mapstructure:"group_by"`
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		FlushInterval: defaultFlushInterval,
+		Objective:     defaultObjective,
+		// The short and long windows of multiwindow burn-rate alerts.
+		Windows: []time.Duration{
+			5 * time.Minute, 30 * time.Minute, time.Hour, 2 * time.Hour,
+			6 * time.Hour, 24 * time.Hour, 72 * time.Hour,
+		},
+		Period:     defaultPeriod,
+		RiskLevels: []string{"Critical"},
+	}
+}
+
+// Validate checks the connector configuration is valid.
+func (c *Config) Validate() error {
+	if c.FlushInterval <= 0 {
+		return errors.New("flush_interval must be positive")
+	}
+	if c.Objective <= 0 || c.Objective >= 1 {
+		return fmt.Errorf("objective must be between 0 and 1, got %g", c.Objective)
+	}
+	if len(c.Windows) == 0 {
+		return errors.New("windows must not be empty")
+	}
+	seen := make(map[time.Duration]bool, len(c.Windows))
+	for _, w := range c.Windows {
+		if w < bucketSize {
+			return fmt.Errorf("window %s is shorter than %s", w, bucketSize)
+		}
+		if seen[w] {
+			return fmt.Errorf("window %s is listed more than once", w)
+		}
+		seen[w] = true
+	}
+	if c.Period < bucketSize {
+		return fmt.Errorf("period must be at least %s", bucketSize)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package compliancebudgetconnector
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id       component.ID
+		expected *Config
+	}{
+		{
+			id:       component.NewID(componentType),
+			expected: createDefaultConfig().(*Config),
+		},
+		{
+			id: component.NewIDWithName(componentType, "custom"),
+			expected: &Config{
+				FlushInterval: 30 * time.Second,
+				Objective:     0.995,
+				Windows:       []time.Duration{time.Hour, 6 * time.Hour},
+				Period:        168 * time.Hour,
+				RiskLevels:    []string{"Critical", "High"},
+				GroupBy:       []string{proofwatch.COMPLIANCE_FRAMEWORKS, proofwatch.POLICY_TARGET_ENVIRONMENT},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, sub.Unmarshal(cfg))
+			require.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "non-positive flush interval",
+			mutate:  func(cfg *Config) { cfg.FlushInterval = 0 },
+			wantErr: "flush_interval must be positive",
+		},
+		{
+			name:    "objective of one",
+			mutate:  func(cfg *Config) { cfg.Objective = 1 },
+			wantErr: "objective must be between 0 and 1, got 1",
+		},
+		{
+			name:    "objective as a percentage",
+			mutate:  func(cfg *Config) { cfg.Objective = 99.9 },
+			wantErr: "objective must be between 0 and 1, got 99.9",
+		},
+		{
+			name:    "no windows",
+			mutate:  func(cfg *Config) { cfg.Windows = nil },
+			wantErr: "windows must not be empty",
+		},
+		{
+			name:    "window below the bucket size",
+			mutate:  func(cfg *Config) { cfg.Windows = []time.Duration{30 * time.Second} },
+			wantErr: "window 30s is shorter than 1m0s",
+		},
+		{
+			name:    "duplicate window",
+			mutate:  func(cfg *Config) { cfg.Windows = []time.Duration{time.Hour, 60 * time.Minute} },
+			wantErr: "window 1h0m0s is listed more than once",
+		},
+		{
+			name:    "period below the bucket size",
+			mutate:  func(cfg *Config) { cfg.Period = 0 },
+			wantErr: "period must be at least 1m0s",
+		},
+		{
+			name:   "valid",
+			mutate: func(*Config) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
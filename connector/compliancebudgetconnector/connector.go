@@ -0,0 +1,164 @@
+package compliancebudgetconnector
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+var _ connector.Logs = (*budgetConnector)(nil)
+
+type budgetConnector struct {
+	cfg      *Config
+	settings connector.Settings
+	next     consumer.Metrics
+	now      func() time.Time
+
+	// horizon is how long evaluations are kept: the longest window or the period.
+	horizon time.Duration
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	tracker *tracker
+}
+
+func newBudgetConnector(cfg *Config, set connector.Settings, next consumer.Metrics) *budgetConnector {
+	horizon := cfg.Period
+	for _, w := range cfg.Windows {
+		horizon = max(horizon, w)
+	}
+	return &budgetConnector{
+		cfg:      cfg,
+		settings: set,
+		next:     next,
+		now:      time.Now,
+		horizon:  horizon,
+		tracker:  newTracker(),
+	}
+}
+
+// Capabilities implements consumer.Logs.
+func (c *budgetConnector) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// Start begins emitting budget metrics every flush interval.
+func (c *budgetConnector) Start(context.Context, component.Host) error {
+	runCtx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		ticker := time.NewTicker(c.cfg.FlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-runCtx.Done():
+				return
+			case <-ticker.C:
+				if err := c.flush(runCtx); err != nil {
+					c.settings.Logger.Warn("failed to emit compliance budget metrics", zap.Error(err))
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the flush loop. Budgets are gauges of rolling windows, so no
+// final flush is needed.
+func (c *budgetConnector) Shutdown(context.Context) error {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.wg.Wait()
+	return nil
+}
+
+// ConsumeLogs counts the passed and failed evaluations in logs at their
+// timestamp. Other results, and records outside the selected risk levels,
+// do not count toward the budget.
+func (c *budgetConnector) ConsumeLogs(_ context.Context, logs plog.Logs) error {
+	now := c.now()
+	oldest := now.Add(-c.horizon)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		sls := logs.ResourceLogs().At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				record := lrs.At(k)
+				attrs := record.Attributes()
+
+				result := str(attrs, proofwatch.POLICY_EVALUATION_RESULT)
+				if result != resultPassed && result != resultFailed {
+					continue
+				}
+				if len(c.cfg.RiskLevels) > 0 && !slices.Contains(c.cfg.RiskLevels, str(attrs, proofwatch.COMPLIANCE_RISK_LEVEL)) {
+					continue
+				}
+
+				ts := now
+				switch {
+				case record.Timestamp() != 0:
+					ts = record.Timestamp().AsTime()
+				case record.ObservedTimestamp() != 0:
+					ts = record.ObservedTimestamp().AsTime()
+				}
+				if ts.After(now) {
+					ts = now
+				}
+				if ts.Before(oldest) {
+					continue
+				}
+				for _, group := range groups(attrs, c.cfg.GroupBy) {
+					c.tracker.add(group, result, ts)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// flush drops evaluations older than every window and the period, and emits
+// the current budgets.
+func (c *budgetConnector) flush(ctx context.Context) error {
+	now := c.now()
+
+	c.mu.Lock()
+	c.tracker.expire(now.Add(-c.horizon))
+	if len(c.tracker.budgets) == 0 {
+		c.mu.Unlock()
+		return nil
+	}
+	metrics := buildMetrics(c.tracker, c.cfg, now)
+	c.mu.Unlock()
+
+	if metrics.MetricCount() == 0 {
+		return nil
+	}
+	return c.next.ConsumeMetrics(ctx, metrics)
+}
+
+func str(attrs pcommon.Map, key string) string {
+	if v, ok := attrs.Get(key); ok {
+		return v.AsString()
+	}
+	return ""
+}
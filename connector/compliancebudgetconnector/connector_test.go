@@ -0,0 +1,222 @@
+package compliancebudgetconnector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+var now = time.Date(2026, 6, 2, 12, 0, 0, 0, time.UTC)
+
+// evaluation is a record evaluated age before now.
+type evaluation struct {
+	age   time.Duration
+	attrs map[string]any
+}
+
+func evidenceLogs(evaluations ...evaluation) plog.Logs {
+	logs := plog.NewLogs()
+	lrs := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, e := range evaluations {
+		record := lrs.AppendEmpty()
+		record.SetTimestamp(pcommon.NewTimestampFromTime(now.Add(-e.age)))
+		_ = record.Attributes().FromRaw(e.attrs)
+	}
+	return logs
+}
+
+func evidence(age time.Duration, result, risk string, frameworks ...any) evaluation {
+	return evaluation{age: age, attrs: map[string]any{
+		proofwatch.POLICY_RULE_ID:           "ssh-root-login",
+		proofwatch.POLICY_EVALUATION_RESULT: result,
+		proofwatch.COMPLIANCE_RISK_LEVEL:    risk,
+		proofwatch.COMPLIANCE_FRAMEWORKS:    frameworks,
+	}}
+}
+
+func newTestConnector(next consumer.Metrics) *budgetConnector {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Objective = 0.9
+	cfg.Windows = []time.Duration{6 * time.Hour, time.Hour}
+	cfg.Period = 24 * time.Hour
+	cfg.GroupBy = []string{proofwatch.COMPLIANCE_FRAMEWORKS}
+	c := newBudgetConnector(cfg, connectortest.NewNopSettings(componentType), next)
+	c.now = func() time.Time { return now }
+	return c
+}
+
+// gaugeValues returns the data points of a gauge keyed by their attributes.
+func gaugeValues(t *testing.T, metrics pmetric.Metrics, name string) map[string]pmetric.NumberDataPoint {
+	t.Helper()
+	out := map[string]pmetric.NumberDataPoint{}
+	ms := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		if ms.At(i).Name() != name {
+			continue
+		}
+		dps := ms.At(i).Gauge().DataPoints()
+		for j := 0; j < dps.Len(); j++ {
+			key := ""
+			for _, k := range []string{proofwatch.COMPLIANCE_FRAMEWORKS, windowAttribute, proofwatch.POLICY_EVALUATION_RESULT} {
+				if v, ok := dps.At(j).Attributes().Get(k); ok {
+					key += v.Str() + "/"
+				}
+			}
+			out[key] = dps.At(j)
+		}
+	}
+	return out
+}
+
+func TestFlushEmitsBudgets(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	c := newTestConnector(sink)
+
+	require.NoError(t, c.ConsumeLogs(context.Background(), evidenceLogs(
+		evidence(10*time.Minute, "Passed", "Critical", "NIST-800-53", "FedRAMP"),
+		evidence(10*time.Minute, "Passed", "Critical", "NIST-800-53", "FedRAMP"),
+		evidence(10*time.Minute, "Passed", "Critical", "NIST-800-53", "FedRAMP"),
+		evidence(30*time.Minute, "Failed", "Critical", "NIST-800-53"),
+		evidence(3*time.Hour, "Failed", "Critical", "NIST-800-53"),
+		// Outside the selected risk levels.
+		evidence(5*time.Minute, "Failed", "High", "NIST-800-53"),
+		// Neither passed nor failed.
+		evidence(5*time.Minute, "Not Applicable", "Critical", "NIST-800-53"),
+		// Older than every window and the period.
+		evidence(48*time.Hour, "Failed", "Critical", "NIST-800-53"),
+	)))
+	require.NoError(t, c.flush(context.Background()))
+
+	require.Len(t, sink.AllMetrics(), 1)
+	metrics := sink.AllMetrics()[0]
+	assert.Equal(t, scopeName, metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Scope().Name())
+
+	counts := gaugeValues(t, metrics, proofwatch.METRIC_COMPLIANCE_BUDGET_EVALUATIONS)
+	assert.Len(t, counts, 8)
+	assert.Equal(t, int64(3), counts["NIST-800-53/1h/Passed/"].IntValue())
+	assert.Equal(t, int64(1), counts["NIST-800-53/1h/Failed/"].IntValue())
+	assert.Equal(t, int64(2), counts["NIST-800-53/6h/Failed/"].IntValue())
+	assert.Equal(t, int64(0), counts["FedRAMP/6h/Failed/"].IntValue())
+
+	errorRatio := gaugeValues(t, metrics, proofwatch.METRIC_COMPLIANCE_BUDGET_ERROR_RATIO)
+	assert.InDelta(t, 0.25, errorRatio["NIST-800-53/1h/"].DoubleValue(), 1e-9)
+	assert.InDelta(t, 0.4, errorRatio["NIST-800-53/6h/"].DoubleValue(), 1e-9)
+	assert.InDelta(t, 0.0, errorRatio["FedRAMP/1h/"].DoubleValue(), 1e-9)
+
+	burnRate := gaugeValues(t, metrics, proofwatch.METRIC_COMPLIANCE_BUDGET_BURN_RATE)
+	assert.InDelta(t, 2.5, burnRate["NIST-800-53/1h/"].DoubleValue(), 1e-9)
+	assert.InDelta(t, 4.0, burnRate["NIST-800-53/6h/"].DoubleValue(), 1e-9)
+	assert.Equal(t, pcommon.NewTimestampFromTime(now), burnRate["NIST-800-53/6h/"].Timestamp())
+
+	remaining := gaugeValues(t, metrics, proofwatch.METRIC_COMPLIANCE_BUDGET_REMAINING)
+	assert.Len(t, remaining, 2)
+	assert.InDelta(t, -3.0, remaining["NIST-800-53/"].DoubleValue(), 1e-9)
+	assert.InDelta(t, 1.0, remaining["FedRAMP/"].DoubleValue(), 1e-9)
+}
+
+func TestFlushWithoutGroupBy(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	c := newTestConnector(sink)
+	c.cfg.GroupBy = nil
+	c.cfg.RiskLevels = nil
+
+	require.NoError(t, c.ConsumeLogs(context.Background(), evidenceLogs(
+		evidence(time.Minute, "Passed", "Low", "NIST-800-53", "FedRAMP"),
+		evidence(time.Minute, "Failed", "High"),
+	)))
+	require.NoError(t, c.flush(context.Background()))
+
+	require.Len(t, sink.AllMetrics(), 1)
+	errorRatio := gaugeValues(t, sink.AllMetrics()[0], proofwatch.METRIC_COMPLIANCE_BUDGET_ERROR_RATIO)
+	assert.Len(t, errorRatio, 2)
+	assert.InDelta(t, 0.5, errorRatio["1h/"].DoubleValue(), 1e-9)
+	assert.Equal(t, 1, errorRatio["1h/"].Attributes().Len())
+}
+
+func TestWindowsRollAndExpire(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	c := newTestConnector(sink)
+
+	require.NoError(t, c.ConsumeLogs(context.Background(), evidenceLogs(
+		evidence(0, "Failed", "Critical", "NIST-800-53"),
+	)))
+
+	// Two hours later the failure has left the 1h window but not the 6h window.
+	c.now = func() time.Time { return now.Add(2 * time.Hour) }
+	require.NoError(t, c.ConsumeLogs(context.Background(), evidenceLogs(
+		evidence(-2*time.Hour, "Passed", "Critical", "NIST-800-53"),
+	)))
+	require.NoError(t, c.flush(context.Background()))
+	require.Len(t, sink.AllMetrics(), 1)
+	errorRatio := gaugeValues(t, sink.AllMetrics()[0], proofwatch.METRIC_COMPLIANCE_BUDGET_ERROR_RATIO)
+	assert.InDelta(t, 0.0, errorRatio["NIST-800-53/1h/"].DoubleValue(), 1e-9)
+	assert.InDelta(t, 0.5, errorRatio["NIST-800-53/6h/"].DoubleValue(), 1e-9)
+
+	// After the period nothing is left to report.
+	c.now = func() time.Time { return now.Add(27 * time.Hour) }
+	require.NoError(t, c.flush(context.Background()))
+	assert.Len(t, sink.AllMetrics(), 1)
+	assert.Empty(t, c.tracker.budgets)
+}
+
+func TestConsumeLogsTimestamps(t *testing.T) {
+	c := newTestConnector(consumertest.NewNop())
+	c.cfg.GroupBy = nil
+
+	logs := plog.NewLogs()
+	lrs := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	// Without a timestamp the observed timestamp is used.
+	observed := lrs.AppendEmpty()
+	observed.SetObservedTimestamp(pcommon.NewTimestampFromTime(now.Add(-90 * time.Minute)))
+	// Timestamps in the future count as now.
+	future := lrs.AppendEmpty()
+	future.SetTimestamp(pcommon.NewTimestampFromTime(now.Add(time.Hour)))
+	// Without any timestamp the time of receipt is used.
+	lrs.AppendEmpty()
+	for i := 0; i < lrs.Len(); i++ {
+		lrs.At(i).Attributes().PutStr(proofwatch.POLICY_EVALUATION_RESULT, "Failed")
+		lrs.At(i).Attributes().PutStr(proofwatch.COMPLIANCE_RISK_LEVEL, "Critical")
+	}
+	require.NoError(t, c.ConsumeLogs(context.Background(), logs))
+
+	b := c.tracker.budgets[""]
+	require.NotNil(t, b)
+	assert.Equal(t, counts{failed: 2}, b.sum(now.Add(-time.Hour)))
+	assert.Equal(t, counts{failed: 3}, b.sum(now.Add(-2*time.Hour)))
+}
+
+func TestFormatWindow(t *testing.T) {
+	for window, want := range map[time.Duration]string{
+		5 * time.Minute:  "5m",
+		90 * time.Minute: "1h30m",
+		2 * time.Hour:    "2h",
+		72 * time.Hour:   "3d",
+		30 * time.Hour:   "30h",
+		90 * time.Second: "1m30s",
+	} {
+		assert.Equal(t, want, formatWindow(window))
+	}
+}
+
+func TestConnectorFlushesPeriodically(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	c := newTestConnector(sink)
+	c.cfg.FlushInterval = 10 * time.Millisecond
+
+	require.NoError(t, c.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, c.ConsumeLogs(context.Background(), evidenceLogs(evidence(0, "Passed", "Critical", "NIST-800-53"))))
+	assert.Eventually(t, func() bool { return len(sink.AllMetrics()) > 0 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, c.Shutdown(context.Background()))
+}
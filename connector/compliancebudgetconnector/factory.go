@@ -0,0 +1,31 @@
+package compliancebudgetconnector
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+const stability = component.StabilityLevelAlpha
+
+var componentType = component.MustNewType("compliancebudget")
+
+// NewFactory creates a factory for the compliance budget connector.
+func NewFactory() connector.Factory {
+	return connector.NewFactory(
+		componentType,
+		createDefaultConfig,
+		connector.WithLogsToMetrics(createLogsToMetrics, stability),
+	)
+}
+
+func createLogsToMetrics(
+	_ context.Context,
+	set connector.Settings,
+	cfg component.Config,
+	next consumer.Metrics,
+) (connector.Logs, error) {
+	return newBudgetConnector(cfg.(*Config), set, next), nil
+}
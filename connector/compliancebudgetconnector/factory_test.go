@@ -0,0 +1,24 @@
+package compliancebudgetconnector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestNewFactory(t *testing.T) {
+	factory := NewFactory()
+	assert.Equal(t, componentType, factory.Type())
+
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, componenttest.CheckConfigStruct(cfg))
+
+	conn, err := factory.CreateLogsToMetrics(context.Background(), connectortest.NewNopSettings(componentType), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.NotNil(t, conn)
+}
@@ -0,0 +1,45 @@
+package postureconnector
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	defaultFlushInterval = time.Minute
+	// defaultStaleAfter outlives three missed runs of a daily scan.
+	defaultStaleAfter = 72 * time.Hour
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines the configuration for the posture connector.
+type Config struct {
+	// FlushInterval is how often posture metrics are emitted.
+	FlushInterval time.Duration `mapstructure:"flush_interval"`
+
+	// StaleAfter drops evaluations that have not been reported again within
+	// this duration, e.g. for decommissioned targets. Zero keeps them forever,
+	// so memory then grows with every rule, target and control ever seen.
+	StaleAfter time.Duration `mapstructure:"stale_after"`
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		FlushInterval: defaultFlushInterval,
+		StaleAfter:    defaultStaleAfter,
+	}
+}
+
+// Validate checks the connector configuration is valid.
+func (c *Config) Validate() error {
+	if c.FlushInterval <= 0 {
+		return errors.New("flush_interval must be positive")
+	}
+	if c.StaleAfter < 0 {
+		return errors.New("stale_after must not be negative")
+	}
+	return nil
+}
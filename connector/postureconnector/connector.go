@@ -0,0 +1,157 @@
+package postureconnector
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+var _ connector.Logs = (*postureConnector)(nil)
+
+type postureConnector struct {
+	cfg      *Config
+	settings connector.Settings
+	next     consumer.Metrics
+	now      func() time.Time
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu          sync.Mutex
+	evaluations map[string]evaluation
+}
+
+func newPostureConnector(cfg *Config, set connector.Settings, next consumer.Metrics) *postureConnector {
+	return &postureConnector{
+		cfg:         cfg,
+		settings:    set,
+		next:        next,
+		now:         time.Now,
+		evaluations: make(map[string]evaluation),
+	}
+}
+
+// Capabilities implements consumer.Logs.
+func (c *postureConnector) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// Start begins emitting posture metrics every flush interval.
+func (c *postureConnector) Start(context.Context, component.Host) error {
+	runCtx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		ticker := time.NewTicker(c.cfg.FlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-runCtx.Done():
+				return
+			case <-ticker.C:
+				if err := c.flush(runCtx); err != nil {
+					c.settings.Logger.Warn("failed to emit posture metrics", zap.Error(err))
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the flush loop. Posture is a gauge of current state, so no
+// final flush is needed.
+func (c *postureConnector) Shutdown(context.Context) error {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.wg.Wait()
+	return nil
+}
+
+// ConsumeLogs records the latest result of every evaluation in logs.
+// Records without policy.evaluation.result are ignored.
+func (c *postureConnector) ConsumeLogs(_ context.Context, logs plog.Logs) error {
+	now := c.now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		sls := logs.ResourceLogs().At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				c.record(lrs.At(k).Attributes(), now)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *postureConnector) record(attrs pcommon.Map, now time.Time) {
+	result := str(attrs, proofwatch.POLICY_EVALUATION_RESULT)
+	if result == "" {
+		return
+	}
+	e := evaluation{
+		controlID: str(attrs, proofwatch.COMPLIANCE_CONTROL_ID),
+		catalogID: str(attrs, proofwatch.COMPLIANCE_CONTROL_CATALOG_ID),
+		targetID:  str(attrs, proofwatch.POLICY_TARGET_ID),
+		result:    result,
+		updated:   now,
+	}
+	if frameworks, ok := attrs.Get(proofwatch.COMPLIANCE_FRAMEWORKS); ok {
+		switch frameworks.Type() {
+		case pcommon.ValueTypeSlice:
+			for i := 0; i < frameworks.Slice().Len(); i++ {
+				e.frameworks = append(e.frameworks, frameworks.Slice().At(i).AsString())
+			}
+		default:
+			e.frameworks = []string{frameworks.AsString()}
+		}
+	}
+	c.evaluations[evaluationKey(str(attrs, proofwatch.POLICY_RULE_ID), e.targetID, e.controlID)] = e
+}
+
+// flush drops stale evaluations and emits the current posture.
+func (c *postureConnector) flush(ctx context.Context) error {
+	now := c.now()
+
+	c.mu.Lock()
+	if c.cfg.StaleAfter > 0 {
+		for key, e := range c.evaluations {
+			if now.Sub(e.updated) > c.cfg.StaleAfter {
+				delete(c.evaluations, key)
+			}
+		}
+	}
+	if len(c.evaluations) == 0 {
+		c.mu.Unlock()
+		return nil
+	}
+	metrics := buildMetrics(c.evaluations, now)
+	c.mu.Unlock()
+
+	if metrics.MetricCount() == 0 {
+		return nil
+	}
+	return c.next.ConsumeMetrics(ctx, metrics)
+}
+
+func str(attrs pcommon.Map, key string) string {
+	if v, ok := attrs.Get(key); ok {
+		return v.AsString()
+	}
+	return ""
+}
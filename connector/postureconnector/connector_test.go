@@ -0,0 +1,157 @@
+package postureconnector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+var now = time.Date(2026, 6, 2, 12, 0, 0, 0, time.UTC)
+
+func evidenceLogs(records ...map[string]any) plog.Logs {
+	logs := plog.NewLogs()
+	lrs := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, attrs := range records {
+		_ = lrs.AppendEmpty().Attributes().FromRaw(attrs)
+	}
+	return logs
+}
+
+func evidence(rule, target, control, result string) map[string]any {
+	return map[string]any{
+		proofwatch.POLICY_RULE_ID:                rule,
+		proofwatch.POLICY_TARGET_ID:              target,
+		proofwatch.COMPLIANCE_CONTROL_ID:         control,
+		proofwatch.COMPLIANCE_CONTROL_CATALOG_ID: "nist-800-53",
+		proofwatch.COMPLIANCE_FRAMEWORKS:         []any{"NIST-800-53", "FedRAMP"},
+		proofwatch.POLICY_EVALUATION_RESULT:      result,
+	}
+}
+
+func newTestConnector(next consumer.Metrics) *postureConnector {
+	c := newPostureConnector(createDefaultConfig().(*Config), connectortest.NewNopSettings(componentType), next)
+	c.now = func() time.Time { return now }
+	return c
+}
+
+// gaugeValues returns the data points of a gauge keyed by their attributes.
+func gaugeValues(t *testing.T, metrics pmetric.Metrics, name string) map[string]pmetric.NumberDataPoint {
+	t.Helper()
+	out := map[string]pmetric.NumberDataPoint{}
+	ms := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		if ms.At(i).Name() != name {
+			continue
+		}
+		dps := ms.At(i).Gauge().DataPoints()
+		for j := 0; j < dps.Len(); j++ {
+			key := ""
+			dps.At(j).Attributes().Range(func(k string, v pcommon.Value) bool {
+				if k == proofwatch.POLICY_EVALUATION_RESULT || k == proofwatch.COMPLIANCE_CONTROL_ID ||
+					k == proofwatch.COMPLIANCE_FRAMEWORKS || k == proofwatch.POLICY_TARGET_ID {
+					key += v.Str() + "/"
+				}
+				return true
+			})
+			out[key] = dps.At(j)
+		}
+	}
+	return out
+}
+
+func TestFlushEmitsPosture(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	c := newTestConnector(sink)
+
+	require.NoError(t, c.ConsumeLogs(context.Background(), evidenceLogs(
+		evidence("ssh-root-login", "web-01", "ac-6", "Failed"),
+		evidence("ssh-root-login", "web-02", "ac-6", "Passed"),
+		evidence("sudo-nopasswd", "web-01", "ac-6", "Passed"),
+		evidence("audit-enabled", "web-01", "au-2", "Not Applicable"),
+		map[string]any{proofwatch.POLICY_RULE_ID: "no-result"},
+	)))
+	// A newer result for the same rule, target and control replaces the earlier one.
+	require.NoError(t, c.ConsumeLogs(context.Background(), evidenceLogs(
+		evidence("ssh-root-login", "web-02", "ac-6", "Failed"),
+	)))
+	require.NoError(t, c.flush(context.Background()))
+
+	require.Len(t, sink.AllMetrics(), 1)
+	metrics := sink.AllMetrics()[0]
+	assert.Equal(t, scopeName, metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Scope().Name())
+
+	controlCounts := gaugeValues(t, metrics, proofwatch.METRIC_COMPLIANCE_CONTROL_EVALUATIONS)
+	assert.Len(t, controlCounts, 3)
+	assert.Equal(t, int64(2), controlCounts["ac-6/Failed/"].IntValue())
+	assert.Equal(t, int64(1), controlCounts["ac-6/Passed/"].IntValue())
+	assert.Equal(t, int64(1), controlCounts["au-2/Not Applicable/"].IntValue())
+	catalog, _ := controlCounts["ac-6/Failed/"].Attributes().Get(proofwatch.COMPLIANCE_CONTROL_CATALOG_ID)
+	assert.Equal(t, "nist-800-53", catalog.Str())
+
+	// au-2 has no passed or failed evaluations, so it has no ratio.
+	controlRatio := gaugeValues(t, metrics, proofwatch.METRIC_COMPLIANCE_CONTROL_RATIO)
+	assert.Len(t, controlRatio, 1)
+	assert.InDelta(t, 1.0/3.0, controlRatio["ac-6/"].DoubleValue(), 1e-9)
+	assert.Equal(t, pcommon.NewTimestampFromTime(now), controlRatio["ac-6/"].Timestamp())
+
+	frameworkCounts := gaugeValues(t, metrics, proofwatch.METRIC_COMPLIANCE_FRAMEWORK_EVALUATIONS)
+	assert.Equal(t, int64(2), frameworkCounts["FedRAMP/Failed/"].IntValue())
+	assert.Equal(t, int64(2), frameworkCounts["NIST-800-53/Failed/"].IntValue())
+
+	targetRatio := gaugeValues(t, metrics, proofwatch.METRIC_COMPLIANCE_TARGET_RATIO)
+	assert.InDelta(t, 0.5, targetRatio["web-01/"].DoubleValue(), 1e-9)
+	assert.InDelta(t, 0.0, targetRatio["web-02/"].DoubleValue(), 1e-9)
+}
+
+func TestFlushDropsStaleEvaluations(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	c := newTestConnector(sink)
+	c.cfg.StaleAfter = time.Hour
+
+	require.NoError(t, c.ConsumeLogs(context.Background(), evidenceLogs(evidence("r1", "web-01", "ac-6", "Passed"))))
+	c.now = func() time.Time { return now.Add(2 * time.Hour) }
+	require.NoError(t, c.ConsumeLogs(context.Background(), evidenceLogs(evidence("r2", "web-01", "ac-6", "Failed"))))
+
+	require.NoError(t, c.flush(context.Background()))
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Len(t, c.evaluations, 1)
+	ratio := gaugeValues(t, sink.AllMetrics()[0], proofwatch.METRIC_COMPLIANCE_CONTROL_RATIO)
+	assert.InDelta(t, 0.0, ratio["ac-6/"].DoubleValue(), 1e-9)
+}
+
+func TestFlushWithoutEvaluations(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	c := newTestConnector(sink)
+
+	require.NoError(t, c.flush(context.Background()))
+
+	// Evaluations without a control, framework or target produce no metrics.
+	require.NoError(t, c.ConsumeLogs(context.Background(), evidenceLogs(
+		map[string]any{proofwatch.POLICY_EVALUATION_RESULT: "Passed"},
+	)))
+	require.NoError(t, c.flush(context.Background()))
+	assert.Empty(t, sink.AllMetrics())
+}
+
+func TestConnectorFlushesPeriodically(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	c := newTestConnector(sink)
+	c.cfg.FlushInterval = 10 * time.Millisecond
+
+	require.NoError(t, c.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, c.ConsumeLogs(context.Background(), evidenceLogs(evidence("r1", "web-01", "ac-6", "Passed"))))
+	assert.Eventually(t, func() bool { return len(sink.AllMetrics()) > 0 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, c.Shutdown(context.Background()))
+}
@@ -0,0 +1,31 @@
+package postureconnector
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+const stability = component.StabilityLevelAlpha
+
+var componentType = component.MustNewType("posture")
+
+// NewFactory creates a factory for the posture connector.
+func NewFactory() connector.Factory {
+	return connector.NewFactory(
+		componentType,
+		createDefaultConfig,
+		connector.WithLogsToMetrics(createLogsToMetrics, stability),
+	)
+}
+
+func createLogsToMetrics(
+	_ context.Context,
+	set connector.Settings,
+	cfg component.Config,
+	next consumer.Metrics,
+) (connector.Logs, error) {
+	return newPostureConnector(cfg.(*Config), set, next), nil
+}
@@ -0,0 +1,181 @@
+package postureconnector
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+const (
+	scopeName = "github.com/complytime/complybeacon/connector/postureconnector"
+
+	resultPassed = "Passed"
+	resultFailed = "Failed"
+)
+
+// metricDef describes one emitted metric.
+type metricDef struct {
+	name, description, unit string
+}
+
+// postureMetrics are the evaluation count and pass ratio metrics of one
+// subject, such as a control.
+type postureMetrics struct {
+	evaluations, ratio metricDef
+}
+
+var (
+	controlMetrics = postureMetrics{
+		evaluations: metricDef{proofwatch.METRIC_COMPLIANCE_CONTROL_EVALUATIONS, proofwatch.METRIC_COMPLIANCE_CONTROL_EVALUATIONS_DESCRIPTION, proofwatch.METRIC_COMPLIANCE_CONTROL_EVALUATIONS_UNIT},
+		ratio:       metricDef{proofwatch.METRIC_COMPLIANCE_CONTROL_RATIO, proofwatch.METRIC_COMPLIANCE_CONTROL_RATIO_DESCRIPTION, proofwatch.METRIC_COMPLIANCE_CONTROL_RATIO_UNIT},
+	}
+	frameworkMetrics = postureMetrics{
+		evaluations: metricDef{proofwatch.METRIC_COMPLIANCE_FRAMEWORK_EVALUATIONS, proofwatch.METRIC_COMPLIANCE_FRAMEWORK_EVALUATIONS_DESCRIPTION, proofwatch.METRIC_COMPLIANCE_FRAMEWORK_EVALUATIONS_UNIT},
+		ratio:       metricDef{proofwatch.METRIC_COMPLIANCE_FRAMEWORK_RATIO, proofwatch.METRIC_COMPLIANCE_FRAMEWORK_RATIO_DESCRIPTION, proofwatch.METRIC_COMPLIANCE_FRAMEWORK_RATIO_UNIT},
+	}
+	targetMetrics = postureMetrics{
+		evaluations: metricDef{proofwatch.METRIC_COMPLIANCE_TARGET_EVALUATIONS, proofwatch.METRIC_COMPLIANCE_TARGET_EVALUATIONS_DESCRIPTION, proofwatch.METRIC_COMPLIANCE_TARGET_EVALUATIONS_UNIT},
+		ratio:       metricDef{proofwatch.METRIC_COMPLIANCE_TARGET_RATIO, proofwatch.METRIC_COMPLIANCE_TARGET_RATIO_DESCRIPTION, proofwatch.METRIC_COMPLIANCE_TARGET_RATIO_UNIT},
+	}
+)
+
+// evaluation is the latest outcome of one rule on one target for one control.
+type evaluation struct {
+	controlID  string
+	catalogID  string
+	targetID   string
+	frameworks []string
+	result     string
+	updated    time.Time
+}
+
+// evaluationKey identifies an evaluation, so a newer result for the same rule,
+// target and control replaces the previous one.
+func evaluationKey(ruleID, targetID, controlID string) string {
+	return strings.Join([]string{ruleID, targetID, controlID}, "|")
+}
+
+// tally counts evaluation results for one set of dimension attributes.
+type tally struct {
+	attrs   map[string]string
+	results map[string]int64
+}
+
+// tallies groups counts by the dimension attributes they are reported for.
+type tallies map[string]*tally
+
+func (t tallies) add(attrs map[string]string, result string) {
+	keys := make([]string, 0, len(attrs))
+	for k, v := range attrs {
+		keys = append(keys, k+"="+v)
+	}
+	sort.Strings(keys)
+	key := strings.Join(keys, ",")
+
+	entry, ok := t[key]
+	if !ok {
+		entry = &tally{attrs: attrs, results: make(map[string]int64)}
+		t[key] = entry
+	}
+	entry.results[result]++
+}
+
+func (t tallies) sortedKeys() []string {
+	keys := make([]string, 0, len(t))
+	for k := range t {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// buildMetrics summarizes evaluations as evaluation counts by result and as a
+// compliance ratio, per control, per framework and per target.
+func buildMetrics(evaluations map[string]evaluation, now time.Time) pmetric.Metrics {
+	byControl, byFramework, byTarget := tallies{}, tallies{}, tallies{}
+	for _, e := range evaluations {
+		if e.controlID != "" {
+			attrs := map[string]string{proofwatch.COMPLIANCE_CONTROL_ID: e.controlID}
+			if e.catalogID != "" {
+				attrs[proofwatch.COMPLIANCE_CONTROL_CATALOG_ID] = e.catalogID
+			}
+			byControl.add(attrs, e.result)
+		}
+		for _, framework := range e.frameworks {
+			byFramework.add(map[string]string{proofwatch.COMPLIANCE_FRAMEWORKS: framework}, e.result)
+		}
+		if e.targetID != "" {
+			byTarget.add(map[string]string{proofwatch.POLICY_TARGET_ID: e.targetID}, e.result)
+		}
+	}
+
+	metrics := pmetric.NewMetrics()
+	sm := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName(scopeName)
+
+	ts := pcommon.NewTimestampFromTime(now)
+	appendPosture(sm.Metrics(), controlMetrics, byControl, ts)
+	appendPosture(sm.Metrics(), frameworkMetrics, byFramework, ts)
+	appendPosture(sm.Metrics(), targetMetrics, byTarget, ts)
+	return metrics
+}
+
+func appendPosture(metrics pmetric.MetricSlice, defs postureMetrics, t tallies, ts pcommon.Timestamp) {
+	if len(t) == 0 {
+		return
+	}
+
+	count := metrics.AppendEmpty()
+	count.SetName(defs.evaluations.name)
+	count.SetDescription(defs.evaluations.description)
+	count.SetUnit(defs.evaluations.unit)
+	countPoints := count.SetEmptyGauge().DataPoints()
+
+	ratio := metrics.AppendEmpty()
+	ratio.SetName(defs.ratio.name)
+	ratio.SetDescription(defs.ratio.description)
+	ratio.SetUnit(defs.ratio.unit)
+	ratioPoints := ratio.SetEmptyGauge().DataPoints()
+
+	for _, key := range t.sortedKeys() {
+		entry := t[key]
+
+		results := make([]string, 0, len(entry.results))
+		for result := range entry.results {
+			results = append(results, result)
+		}
+		sort.Strings(results)
+		for _, result := range results {
+			dp := countPoints.AppendEmpty()
+			dp.SetTimestamp(ts)
+			putAttrs(dp.Attributes(), entry.attrs)
+			dp.Attributes().PutStr(proofwatch.POLICY_EVALUATION_RESULT, result)
+			dp.SetIntValue(entry.results[result])
+		}
+
+		decided := entry.results[resultPassed] + entry.results[resultFailed]
+		if decided == 0 {
+			// Not Applicable, Not Run and similar results do not contribute to the ratio.
+			continue
+		}
+		dp := ratioPoints.AppendEmpty()
+		dp.SetTimestamp(ts)
+		putAttrs(dp.Attributes(), entry.attrs)
+		dp.SetDoubleValue(float64(entry.results[resultPassed]) / float64(decided))
+	}
+
+	if ratioPoints.Len() == 0 {
+		metrics.RemoveIf(func(m pmetric.Metric) bool { return m.Name() == ratio.Name() })
+	}
+}
+
+func putAttrs(dest pcommon.Map, attrs map[string]string) {
+	for k, v := range attrs {
+		dest.PutStr(k, v)
+	}
+}
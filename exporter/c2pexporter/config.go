@@ -0,0 +1,91 @@
+package c2pexporter
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const (
+	defaultWindow      = time.Hour
+	defaultMaxSubjects = 10000
+
+	fileKey = "file"
+	httpKey = "http"
+)
+
+var (
+	_ component.Config    = (*Config)(nil)
+	_ confmap.Unmarshaler = (*Config)(nil)
+)
+
+// Config defines the configuration for the C2P exporter.
+// At least one of File or HTTP must be set.
+type Config struct {
+	// Window is how often the aggregated evidence is written as a PVP result.
+	// Zero writes a result on shutdown, and whenever MaxSubjects is reached.
+	Window time.Duration `mapstructure:"window"`
+
+	// MaxSubjects closes a window early once its checks hold this many
+	// subjects, so memory stays bounded however long the window is.
+	MaxSubjects int `mapstructure:"max_subjects"`
+
+	// File writes each result to a directory.
+	File *FileConfig `mapstructure:"file"`
+
+	// HTTP POSTs each result to an endpoint.
+	HTTP *confighttp.ClientConfig `mapstructure:"http"`
+}
+
+// FileConfig configures file output.
+type FileConfig struct {
+	// Directory receives one pvp-result-<uuid>.json file per result.
+	Directory string `mapstructure:"directory"`
+}
+
+func createDefaultConfig() component.Config {
+	httpCfg := confighttp.NewDefaultClientConfig()
+	return &Config{
+		Window:      defaultWindow,
+		MaxSubjects: defaultMaxSubjects,
+		File:        &FileConfig{},
+		HTTP:        &httpCfg,
+	}
+}
+
+// Unmarshal keeps only the outputs that are present in the user configuration.
+func (c *Config) Unmarshal(conf *confmap.Conf) error {
+	if err := conf.Unmarshal(c); err != nil {
+		return err
+	}
+	if !conf.IsSet(fileKey) {
+		c.File = nil
+	}
+	if !conf.IsSet(httpKey) {
+		c.HTTP = nil
+	}
+	return nil
+}
+
+// Validate checks the exporter configuration is valid.
+func (c *Config) Validate() error {
+	if c.File == nil && c.HTTP == nil {
+		return errors.New("at least one of file or http must be configured")
+	}
+	if c.Window < 0 {
+		return errors.New("window must not be negative")
+	}
+	if c.MaxSubjects <= 0 {
+		return errors.New("max_subjects must be positive")
+	}
+	if c.File != nil && c.File.Directory == "" {
+		return errors.New("file.directory must not be empty")
+	}
+	if c.HTTP != nil && c.HTTP.Endpoint == "" {
+		return errors.New("http.endpoint must not be empty")
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package c2pexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id       component.ID
+		wantFile bool
+		wantHTTP bool
+		check    func(t *testing.T, cfg *Config)
+	}{
+		{
+			id:       component.NewID(componentType),
+			wantFile: true,
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "/var/lib/complybeacon/c2p", cfg.File.Directory)
+				assert.Equal(t, defaultWindow, cfg.Window)
+				assert.Equal(t, defaultMaxSubjects, cfg.MaxSubjects)
+			},
+		},
+		{
+			id:       component.NewIDWithName(componentType, "http"),
+			wantHTTP: true,
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, 15*time.Minute, cfg.Window)
+				assert.Equal(t, 500, cfg.MaxSubjects)
+				assert.Equal(t, "https://complytime.example.com/api/pvp-results", cfg.HTTP.Endpoint)
+			},
+		},
+		{
+			id:       component.NewIDWithName(componentType, "shutdown"),
+			wantFile: true,
+			wantHTTP: true,
+			check: func(t *testing.T, cfg *Config) {
+				assert.Zero(t, cfg.Window)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, cfg.Unmarshal(sub))
+			require.NoError(t, cfg.Validate())
+
+			assert.Equal(t, tt.wantFile, cfg.File != nil)
+			assert.Equal(t, tt.wantHTTP, cfg.HTTP != nil)
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name: "no output",
+			mutate: func(cfg *Config) {
+				cfg.File = nil
+				cfg.HTTP = nil
+			},
+			wantErr: "at least one of file or http must be configured",
+		},
+		{
+			name:    "negative window",
+			mutate:  func(cfg *Config) { cfg.Window = -time.Second },
+			wantErr: "window must not be negative",
+		},
+		{
+			name:    "zero max subjects",
+			mutate:  func(cfg *Config) { cfg.MaxSubjects = 0 },
+			wantErr: "max_subjects must be positive",
+		},
+		{
+			name:    "empty file directory",
+			mutate:  func(cfg *Config) { cfg.File.Directory = "" },
+			wantErr: "file.directory must not be empty",
+		},
+		{
+			name:    "empty http endpoint",
+			mutate:  func(cfg *Config) { cfg.HTTP.Endpoint = "" },
+			wantErr: "http.endpoint must not be empty",
+		},
+		{
+			name:   "valid",
+			mutate: func(*Config) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.File.Directory = t.TempDir()
+			cfg.HTTP.Endpoint = "http://localhost:8080/results"
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
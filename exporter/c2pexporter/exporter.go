@@ -0,0 +1,203 @@
+package c2pexporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+// maxPendingResults bounds the results kept for retry while outputs are failing.
+const maxPendingResults = 16
+
+// pendingResult is a result awaiting delivery. The ID names its file, so a
+// retried result overwrites its earlier copy instead of duplicating it.
+type pendingResult struct {
+	id     string
+	result pvpResult
+}
+
+type c2pExporter struct {
+	cfg      *Config
+	settings exporter.Settings
+	client   *http.Client
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	// full signals that the current window reached max_subjects.
+	full chan struct{}
+
+	mu      sync.Mutex
+	current *aggregator
+
+	// flushMu serializes flushes so pending results are delivered in order.
+	flushMu sync.Mutex
+	pending []pendingResult
+}
+
+func newC2PExporter(cfg *Config, set exporter.Settings) *c2pExporter {
+	return &c2pExporter{
+		cfg:      cfg,
+		settings: set,
+		full:     make(chan struct{}, 1),
+		current:  newAggregator(),
+	}
+}
+
+func (e *c2pExporter) start(ctx context.Context, host component.Host) error {
+	if e.cfg.HTTP != nil {
+		client, err := e.cfg.HTTP.ToClient(ctx, host.GetExtensions(), e.settings.TelemetrySettings)
+		if err != nil {
+			return fmt.Errorf("failed to create HTTP client: %w", err)
+		}
+		e.client = client
+	}
+	if e.cfg.File != nil {
+		if err := os.MkdirAll(e.cfg.File.Directory, 0o750); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	e.cancel = cancel
+	e.wg.Add(1)
+	go e.flushEvery(runCtx, e.cfg.Window)
+	return nil
+}
+
+// shutdown writes the evidence aggregated since the last window.
+func (e *c2pExporter) shutdown(ctx context.Context) error {
+	if e.cancel != nil {
+		e.cancel()
+	}
+	e.wg.Wait()
+	return e.flush(ctx)
+}
+
+func (e *c2pExporter) consumeLogs(_ context.Context, logs plog.Logs) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.current.add(logs)
+	if e.current.size() >= e.cfg.MaxSubjects {
+		select {
+		case e.full <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// flushEvery closes the current window every window, and early once it is
+// full. A zero window is only closed early and on shutdown.
+func (e *c2pExporter) flushEvery(ctx context.Context, window time.Duration) {
+	defer e.wg.Done()
+	var tick <-chan time.Time
+	if window > 0 {
+		ticker := time.NewTicker(window)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick:
+		case <-e.full:
+		}
+		if err := e.flush(ctx); err != nil {
+			e.settings.Logger.Warn("failed to deliver PVP results; will retry on the next window", zap.Error(err))
+		}
+	}
+}
+
+// flush closes the current window and delivers it together with any results
+// that failed to deliver earlier. Results that still fail are kept for the
+// next flush, up to maxPendingResults.
+func (e *c2pExporter) flush(ctx context.Context) error {
+	e.flushMu.Lock()
+	defer e.flushMu.Unlock()
+
+	e.mu.Lock()
+	if !e.current.empty() {
+		e.pending = append(e.pending, pendingResult{id: uuid.NewString(), result: e.current.build()})
+		e.current = newAggregator()
+	}
+	e.mu.Unlock()
+
+	if dropped := len(e.pending) - maxPendingResults; dropped > 0 {
+		e.settings.Logger.Error("dropping undelivered PVP results", zap.Int("results", dropped))
+		e.pending = e.pending[dropped:]
+	}
+
+	var errs error
+	remaining := e.pending[:0]
+	for _, p := range e.pending {
+		if err := e.deliver(ctx, p); err != nil {
+			errs = errors.Join(errs, err)
+			remaining = append(remaining, p)
+		}
+	}
+	e.pending = remaining
+	return errs
+}
+
+func (e *c2pExporter) deliver(ctx context.Context, p pendingResult) error {
+	data, err := json.MarshalIndent(p.result, "", "  ")
+	if err != nil {
+		return err
+	}
+	var errs error
+	if e.cfg.File != nil {
+		errs = errors.Join(errs, e.writeFile(p.id, data))
+	}
+	if e.client != nil {
+		errs = errors.Join(errs, e.post(ctx, data))
+	}
+	return errs
+}
+
+func (e *c2pExporter) writeFile(id string, data []byte) error {
+	path := filepath.Join(e.cfg.File.Directory, "pvp-result-"+id+".json")
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+func (e *c2pExporter) post(ctx context.Context, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.cfg.HTTP.Endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post PVP result: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("failed to post PVP result: %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package c2pexporter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+func newTestExporter(t *testing.T, cfg *Config) *c2pExporter {
+	t.Helper()
+	e := newC2PExporter(cfg, exportertest.NewNopSettings(componentType))
+	require.NoError(t, e.start(context.Background(), componenttest.NewNopHost()))
+	return e
+}
+
+func readResults(t *testing.T, dir string) []pvpResult {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join(dir, "pvp-result-*.json"))
+	require.NoError(t, err)
+
+	results := make([]pvpResult, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		var res pvpResult
+		require.NoError(t, json.Unmarshal(data, &res))
+		results = append(results, res)
+	}
+	return results
+}
+
+func TestFlushWritesFile(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTP = nil
+	cfg.Window = 0
+	cfg.File.Directory = filepath.Join(t.TempDir(), "c2p")
+	e := newTestExporter(t, cfg)
+
+	// Nothing is written for an empty window.
+	require.NoError(t, e.flush(context.Background()))
+	assert.Empty(t, readResults(t, cfg.File.Directory))
+
+	require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs(map[string]any{
+		proofwatch.POLICY_RULE_ID:           "r1",
+		proofwatch.POLICY_EVALUATION_RESULT: "Failed",
+		proofwatch.POLICY_TARGET_ID:         "t1",
+	})))
+	require.NoError(t, e.shutdown(context.Background()))
+
+	results := readResults(t, cfg.File.Directory)
+	require.Len(t, results, 1)
+	obs := results[0].ObservationsByCheck[0]
+	assert.Equal(t, "r1", obs.CheckID)
+	assert.Equal(t, resultFail, obs.Subjects[0].Result)
+	assert.Equal(t, evaluatedOn, obs.Subjects[0].EvaluatedOn)
+}
+
+func TestFullWindowIsWrittenEarly(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTP = nil
+	cfg.Window = 0
+	cfg.MaxSubjects = 2
+	cfg.File.Directory = filepath.Join(t.TempDir(), "c2p")
+	e := newTestExporter(t, cfg)
+	defer func() { require.NoError(t, e.shutdown(context.Background())) }()
+
+	evaluation := func(targetID string) map[string]any {
+		return map[string]any{
+			proofwatch.POLICY_RULE_ID:           "r1",
+			proofwatch.POLICY_EVALUATION_RESULT: "Passed",
+			proofwatch.POLICY_TARGET_ID:         targetID,
+		}
+	}
+	// A repeated evaluation of the same target replaces its subject.
+	require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs(evaluation("t1"), evaluation("t1"))))
+	assert.Never(t, func() bool { return len(readResults(t, cfg.File.Directory)) > 0 }, 50*time.Millisecond, 10*time.Millisecond)
+
+	require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs(evaluation("t2"))))
+	assert.Eventually(t, func() bool { return len(readResults(t, cfg.File.Directory)) == 1 }, time.Second, 10*time.Millisecond)
+}
+
+func TestFlushRetriesFailedPosts(t *testing.T) {
+	var fail atomic.Bool
+	var received atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		if fail.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var res pvpResult
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&res))
+		received.Add(1)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.File = nil
+	cfg.HTTP.Endpoint = server.URL
+	e := newTestExporter(t, cfg)
+
+	fail.Store(true)
+	require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs(map[string]any{proofwatch.POLICY_RULE_ID: "r1"})))
+	assert.ErrorContains(t, e.flush(context.Background()), "503 Service Unavailable")
+	assert.Len(t, e.pending, 1)
+
+	fail.Store(false)
+	require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs(map[string]any{proofwatch.POLICY_RULE_ID: "r2"})))
+	require.NoError(t, e.shutdown(context.Background()))
+	assert.Equal(t, int32(2), received.Load())
+	assert.Empty(t, e.pending)
+}
+
+func TestFlushDropsOldestPendingResults(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTP = nil
+	// A regular file in place of the directory makes every write fail.
+	cfg.File.Directory = filepath.Join(t.TempDir(), "blocked")
+	e := newC2PExporter(cfg, exportertest.NewNopSettings(componentType))
+	require.NoError(t, os.WriteFile(cfg.File.Directory, nil, 0o600))
+
+	for range maxPendingResults + 2 {
+		require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs(map[string]any{proofwatch.POLICY_RULE_ID: "r1"})))
+		assert.Error(t, e.flush(context.Background()))
+	}
+	assert.Len(t, e.pending, maxPendingResults)
+}
@@ -0,0 +1,36 @@
+package c2pexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+func TestNewFactory(t *testing.T) {
+	factory := NewFactory()
+	assert.Equal(t, componentType, factory.Type())
+
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, componenttest.CheckConfigStruct(cfg))
+}
+
+func TestExporterLifecycle(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTP = nil
+	cfg.File.Directory = t.TempDir()
+
+	exp, err := NewFactory().CreateLogs(context.Background(), exportertest.NewNopSettings(componentType), cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, exp.ConsumeLogs(context.Background(), evidenceLogs(map[string]any{proofwatch.POLICY_RULE_ID: "r1"})))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	assert.Len(t, readResults(t, cfg.File.Directory), 1)
+}
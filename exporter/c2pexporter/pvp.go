@@ -0,0 +1,224 @@
+package c2pexporter
+
+import (
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+// Subject results defined by compliance-to-policy.
+const (
+	resultPass    = "pass"
+	resultFail    = "fail"
+	resultWarning = "warning"
+	resultError   = "error"
+)
+
+// pvpResult mirrors the PVPResult that compliance-to-policy plugins return to
+// the ComplyTime tooling, one observation per policy check.
+type pvpResult struct {
+	ObservationsByCheck []observationByCheck `json:"observationsByCheck,omitempty"`
+	Links               []link               `json:"links,omitempty"`
+}
+
+type observationByCheck struct {
+	Title       string     `json:"title,omitempty"`
+	Description string     `json:"description,omitempty"`
+	CheckID     string     `json:"checkId,omitempty"`
+	Methods     []string   `json:"methods"`
+	Subjects    []subject  `json:"subjects,omitempty"`
+	Collected   time.Time  `json:"collected"`
+	Props       []property `json:"props,omitempty"`
+}
+
+type subject struct {
+	Title       string     `json:"title,omitempty"`
+	Type        string     `json:"type,omitempty"`
+	ResourceID  string     `json:"resourceId,omitempty"`
+	Result      string     `json:"result,omitempty"`
+	EvaluatedOn time.Time  `json:"evaluatedOn"`
+	Reason      string     `json:"reason,omitempty"`
+	Props       []property `json:"props,omitempty"`
+}
+
+type property struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type link struct {
+	Description string `json:"description,omitempty"`
+	Href        string `json:"href"`
+}
+
+// subjectAttributes lists the record attributes copied onto subjects as props.
+var subjectAttributes = []struct {
+	attribute string
+	name      string
+}{
+	{proofwatch.COMPLIANCE_CONTROL_ID, "compliance-control-id"},
+	{proofwatch.COMPLIANCE_CONTROL_CATALOG_ID, "compliance-control-catalog-id"},
+	{proofwatch.COMPLIANCE_STATUS, "compliance-status"},
+	{proofwatch.COMPLIANCE_RISK_LEVEL, "compliance-risk-level"},
+}
+
+// check collects the subjects evaluated by one policy rule.
+type check struct {
+	obs observationByCheck
+	// subjects maps a target ID to its latest evaluation.
+	subjects map[string]subject
+}
+
+// aggregator collects the evaluations of one window, grouped by policy rule.
+type aggregator struct {
+	checks map[string]*check
+	// subjects counts the subjects of all checks.
+	subjects int
+}
+
+func newAggregator() *aggregator {
+	return &aggregator{checks: make(map[string]*check)}
+}
+
+func (a *aggregator) empty() bool {
+	return len(a.checks) == 0
+}
+
+func (a *aggregator) size() int {
+	return a.subjects
+}
+
+// add records every log record that names a policy rule.
+func (a *aggregator) add(logs plog.Logs) {
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		sls := logs.ResourceLogs().At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				a.addRecord(lrs.At(k))
+			}
+		}
+	}
+}
+
+func (a *aggregator) addRecord(record plog.LogRecord) {
+	attrs := record.Attributes()
+	checkID := str(attrs, proofwatch.POLICY_RULE_ID)
+	if checkID == "" {
+		return
+	}
+	evaluatedOn := recordTime(record)
+
+	c, ok := a.checks[checkID]
+	if !ok {
+		c = &check{
+			obs: observationByCheck{
+				Title:   str(attrs, proofwatch.POLICY_RULE_NAME),
+				CheckID: checkID,
+				Methods: []string{"AUTOMATED"},
+			},
+			subjects: make(map[string]subject),
+		}
+		if c.obs.Title == "" {
+			c.obs.Title = checkID
+		}
+		if engine := str(attrs, proofwatch.POLICY_ENGINE_NAME); engine != "" {
+			c.obs.Description = "Evaluated by " + engine + "."
+			c.obs.Props = []property{{Name: "policy-engine-name", Value: engine}}
+		}
+		a.checks[checkID] = c
+	}
+	if evaluatedOn.After(c.obs.Collected) {
+		c.obs.Collected = evaluatedOn
+	}
+
+	result, ok := subjectResult(str(attrs, proofwatch.POLICY_EVALUATION_RESULT))
+	if !ok {
+		return
+	}
+	targetID := str(attrs, proofwatch.POLICY_TARGET_ID)
+	if prev, ok := c.subjects[targetID]; ok && prev.EvaluatedOn.After(evaluatedOn) {
+		return
+	}
+
+	s := subject{
+		Title:       str(attrs, proofwatch.POLICY_TARGET_NAME),
+		Type:        str(attrs, proofwatch.POLICY_TARGET_TYPE),
+		ResourceID:  targetID,
+		Result:      result,
+		EvaluatedOn: evaluatedOn,
+		Reason:      str(attrs, proofwatch.POLICY_EVALUATION_MESSAGE),
+	}
+	if s.Title == "" {
+		s.Title = targetID
+	}
+	if s.Type == "" {
+		s.Type = "resource"
+	}
+	for _, p := range subjectAttributes {
+		if v := str(attrs, p.attribute); v != "" {
+			s.Props = append(s.Props, property{Name: p.name, Value: v})
+		}
+	}
+	if _, ok := c.subjects[targetID]; !ok {
+		a.subjects++
+	}
+	c.subjects[targetID] = s
+}
+
+// build returns the aggregated checks, ordered by check ID and then subject
+// resource ID.
+func (a *aggregator) build() pvpResult {
+	ids := make([]string, 0, len(a.checks))
+	for id := range a.checks {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var res pvpResult
+	for _, id := range ids {
+		c := a.checks[id]
+		obs := c.obs
+		for _, s := range c.subjects {
+			obs.Subjects = append(obs.Subjects, s)
+		}
+		sort.Slice(obs.Subjects, func(i, j int) bool { return obs.Subjects[i].ResourceID < obs.Subjects[j].ResourceID })
+		res.ObservationsByCheck = append(res.ObservationsByCheck, obs)
+	}
+	return res
+}
+
+// subjectResult maps policy.evaluation.result to a subject result. Not
+// Applicable and Not Run evaluations have no subject result and are skipped.
+func subjectResult(result string) (string, bool) {
+	switch result {
+	case "Passed":
+		return resultPass, true
+	case "Failed":
+		return resultFail, true
+	case "Needs Review":
+		return resultWarning, true
+	case "Not Applicable", "Not Run":
+		return "", false
+	default:
+		return resultError, true
+	}
+}
+
+func recordTime(record plog.LogRecord) time.Time {
+	if record.Timestamp() != 0 {
+		return record.Timestamp().AsTime().UTC()
+	}
+	return record.ObservedTimestamp().AsTime().UTC()
+}
+
+func str(attrs pcommon.Map, key string) string {
+	if v, ok := attrs.Get(key); ok {
+		return v.AsString()
+	}
+	return ""
+}
@@ -0,0 +1,157 @@
+package c2pexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+var evaluatedOn = time.Date(2026, 6, 2, 10, 1, 0, 0, time.UTC)
+
+// evidenceLogs builds one record per attribute map, a minute apart.
+func evidenceLogs(records ...map[string]any) plog.Logs {
+	logs := plog.NewLogs()
+	lrs := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for i, attrs := range records {
+		record := lrs.AppendEmpty()
+		record.SetTimestamp(pcommon.NewTimestampFromTime(evaluatedOn.Add(time.Duration(i) * time.Minute)))
+		_ = record.Attributes().FromRaw(attrs)
+	}
+	return logs
+}
+
+func TestAggregatorBuild(t *testing.T) {
+	agg := newAggregator()
+	assert.True(t, agg.empty())
+
+	agg.add(evidenceLogs(
+		map[string]any{
+			proofwatch.POLICY_RULE_ID:            "ssh-root-login",
+			proofwatch.POLICY_RULE_NAME:          "Disable SSH root login",
+			proofwatch.POLICY_ENGINE_NAME:        "OpenSCAP",
+			proofwatch.POLICY_EVALUATION_RESULT:  "Failed",
+			proofwatch.POLICY_EVALUATION_MESSAGE: "PermitRootLogin is yes",
+			proofwatch.POLICY_TARGET_ID:          "web-01",
+			proofwatch.COMPLIANCE_CONTROL_ID:     "ac-6",
+		},
+		map[string]any{
+			proofwatch.POLICY_RULE_ID:           "ssh-root-login",
+			proofwatch.POLICY_EVALUATION_RESULT: "Passed",
+			proofwatch.POLICY_TARGET_ID:         "web-02",
+			proofwatch.POLICY_TARGET_NAME:       "web-02.example.com",
+			proofwatch.POLICY_TARGET_TYPE:       "host",
+		},
+		// A later evaluation of the same target replaces the earlier one.
+		map[string]any{
+			proofwatch.POLICY_RULE_ID:           "ssh-root-login",
+			proofwatch.POLICY_EVALUATION_RESULT: "Passed",
+			proofwatch.POLICY_TARGET_ID:         "web-01",
+		},
+		map[string]any{
+			proofwatch.POLICY_RULE_ID:           "audit-enabled",
+			proofwatch.POLICY_EVALUATION_RESULT: "Not Applicable",
+			proofwatch.POLICY_TARGET_ID:         "web-01",
+		},
+		map[string]any{
+			proofwatch.POLICY_EVALUATION_RESULT: "Failed",
+		},
+	))
+	require.False(t, agg.empty())
+
+	res := agg.build()
+	require.Len(t, res.ObservationsByCheck, 2)
+
+	audit := res.ObservationsByCheck[0]
+	assert.Equal(t, "audit-enabled", audit.CheckID)
+	assert.Equal(t, "audit-enabled", audit.Title)
+	assert.Empty(t, audit.Subjects)
+
+	ssh := res.ObservationsByCheck[1]
+	assert.Equal(t, "ssh-root-login", ssh.CheckID)
+	assert.Equal(t, "Disable SSH root login", ssh.Title)
+	assert.Equal(t, "Evaluated by OpenSCAP.", ssh.Description)
+	assert.Equal(t, []string{"AUTOMATED"}, ssh.Methods)
+	assert.Equal(t, evaluatedOn.Add(2*time.Minute), ssh.Collected)
+	assert.Equal(t, []property{{Name: "policy-engine-name", Value: "OpenSCAP"}}, ssh.Props)
+	assert.Equal(t, []subject{
+		{
+			Title:       "web-01",
+			Type:        "resource",
+			ResourceID:  "web-01",
+			Result:      resultPass,
+			EvaluatedOn: evaluatedOn.Add(2 * time.Minute),
+		},
+		{
+			Title:       "web-02.example.com",
+			Type:        "host",
+			ResourceID:  "web-02",
+			Result:      resultPass,
+			EvaluatedOn: evaluatedOn.Add(time.Minute),
+		},
+	}, ssh.Subjects)
+}
+
+func TestAggregatorKeepsLatestEvaluation(t *testing.T) {
+	agg := newAggregator()
+	agg.add(evidenceLogs(
+		map[string]any{
+			proofwatch.POLICY_RULE_ID:           "r1",
+			proofwatch.POLICY_EVALUATION_RESULT: "Passed",
+			proofwatch.POLICY_TARGET_ID:         "t1",
+		},
+		map[string]any{
+			proofwatch.POLICY_RULE_ID:            "r1",
+			proofwatch.POLICY_EVALUATION_RESULT:  "Failed",
+			proofwatch.POLICY_EVALUATION_MESSAGE: "drift detected",
+			proofwatch.POLICY_TARGET_ID:          "t1",
+			proofwatch.COMPLIANCE_CONTROL_ID:     "cm-2",
+			proofwatch.COMPLIANCE_RISK_LEVEL:     "High",
+		},
+	))
+	// Evidence that arrives late does not replace a newer evaluation.
+	late := evidenceLogs(map[string]any{
+		proofwatch.POLICY_RULE_ID:           "r1",
+		proofwatch.POLICY_EVALUATION_RESULT: "Passed",
+		proofwatch.POLICY_TARGET_ID:         "t1",
+	})
+	agg.add(late)
+
+	subjects := agg.build().ObservationsByCheck[0].Subjects
+	require.Len(t, subjects, 1)
+	assert.Equal(t, resultFail, subjects[0].Result)
+	assert.Equal(t, "drift detected", subjects[0].Reason)
+	assert.Equal(t, []property{
+		{Name: "compliance-control-id", Value: "cm-2"},
+		{Name: "compliance-risk-level", Value: "High"},
+	}, subjects[0].Props)
+}
+
+func TestSubjectResult(t *testing.T) {
+	tests := []struct {
+		result string
+		want   string
+		wantOK bool
+	}{
+		{result: "Passed", want: resultPass, wantOK: true},
+		{result: "Failed", want: resultFail, wantOK: true},
+		{result: "Needs Review", want: resultWarning, wantOK: true},
+		{result: "Unknown", want: resultError, wantOK: true},
+		{result: "", want: resultError, wantOK: true},
+		{result: "Not Applicable"},
+		{result: "Not Run"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.result, func(t *testing.T) {
+			got, ok := subjectResult(tt.result)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package evidencebundleexporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/url"
+	"path"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+const unknownPartition = "unknown"
+
+// bundle holds the records of one partition until it is uploaded.
+type bundle struct {
+	partition string
+	logs      plog.Logs
+	records   int
+}
+
+// bundler groups incoming records into bundles by partition path.
+type bundler struct {
+	cfg     *Config
+	bundles map[string]*bundle
+}
+
+func newBundler(cfg *Config) *bundler {
+	return &bundler{cfg: cfg, bundles: make(map[string]*bundle)}
+}
+
+// add copies every record of logs into the bundle of its partition, keeping
+// its resource and scope. It reports whether any bundle reached MaxRecords.
+func (b *bundler) add(logs plog.Logs, now time.Time) bool {
+	full := false
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		rl := logs.ResourceLogs().At(i)
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			// Destination scope per partition, so records sharing a resource and
+			// scope stay grouped inside each bundle.
+			scopes := map[string]plog.ScopeLogs{}
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				record := sl.LogRecords().At(k)
+				partition := partitionPath(b.cfg, rl.Resource(), record, now)
+
+				dest, ok := scopes[partition]
+				if !ok {
+					bd := b.bundle(partition)
+					destRL := bd.logs.ResourceLogs().AppendEmpty()
+					rl.Resource().CopyTo(destRL.Resource())
+					destRL.SetSchemaUrl(rl.SchemaUrl())
+					dest = destRL.ScopeLogs().AppendEmpty()
+					sl.Scope().CopyTo(dest.Scope())
+					dest.SetSchemaUrl(sl.SchemaUrl())
+					scopes[partition] = dest
+				}
+				record.CopyTo(dest.LogRecords().AppendEmpty())
+
+				bd := b.bundles[partition]
+				bd.records++
+				if bd.records >= b.cfg.MaxRecords {
+					full = true
+				}
+			}
+		}
+	}
+	return full
+}
+
+func (b *bundler) bundle(partition string) *bundle {
+	bd, ok := b.bundles[partition]
+	if !ok {
+		bd = &bundle{partition: partition, logs: plog.NewLogs()}
+		b.bundles[partition] = bd
+	}
+	return bd
+}
+
+// take returns all open bundles and starts new ones.
+func (b *bundler) take() []*bundle {
+	out := make([]*bundle, 0, len(b.bundles))
+	for _, bd := range b.bundles {
+		out = append(out, bd)
+	}
+	b.bundles = make(map[string]*bundle)
+	return out
+}
+
+// partitionPath builds hive-style key=value path segments for a record.
+func partitionPath(cfg *Config, resource pcommon.Resource, record plog.LogRecord, now time.Time) string {
+	segments := make([]string, 0, len(cfg.Partition))
+	for _, p := range cfg.Partition {
+		var value string
+		switch p {
+		case PartitionDate:
+			value = recordTime(record, now).Format(time.DateOnly)
+		case PartitionFramework:
+			value = framework(record.Attributes())
+		case PartitionTenant:
+			if v, ok := resource.Attributes().Get(cfg.TenantAttribute); ok {
+				value = v.AsString()
+			}
+		}
+		if value == "" {
+			value = unknownPartition
+		}
+		segments = append(segments, p+"="+url.PathEscape(value))
+	}
+	return path.Join(segments...)
+}
+
+// framework returns the first entry of compliance.frameworks, falling back to
+// compliance.control.catalog.id.
+func framework(attrs pcommon.Map) string {
+	if v, ok := attrs.Get(proofwatch.COMPLIANCE_FRAMEWORKS); ok {
+		if v.Type() == pcommon.ValueTypeSlice {
+			if v.Slice().Len() > 0 {
+				return v.Slice().At(0).AsString()
+			}
+		} else if s := v.AsString(); s != "" {
+			return s
+		}
+	}
+	if v, ok := attrs.Get(proofwatch.COMPLIANCE_CONTROL_CATALOG_ID); ok {
+		return v.AsString()
+	}
+	return ""
+}
+
+func recordTime(record plog.LogRecord, now time.Time) time.Time {
+	if record.Timestamp() != 0 {
+		return record.Timestamp().AsTime().UTC()
+	}
+	if record.ObservedTimestamp() != 0 {
+		return record.ObservedTimestamp().AsTime().UTC()
+	}
+	return now.UTC()
+}
+
+// encode serializes a bundle as gzip-compressed OTLP JSON.
+func encode(bd *bundle) ([]byte, error) {
+	data, err := (&plog.JSONMarshaler{}).MarshalLogs(bd.logs)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
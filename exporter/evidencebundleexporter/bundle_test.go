@@ -0,0 +1,131 @@
+package evidencebundleexporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+var recordTimestamp = time.Date(2026, 6, 2, 23, 30, 0, 0, time.UTC)
+
+func evidenceLogs(records ...map[string]any) plog.Logs {
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.namespace", "acme")
+	lrs := rl.ScopeLogs().AppendEmpty().LogRecords()
+	for _, attrs := range records {
+		record := lrs.AppendEmpty()
+		record.SetTimestamp(pcommon.NewTimestampFromTime(recordTimestamp))
+		_ = record.Attributes().FromRaw(attrs)
+	}
+	return logs
+}
+
+func TestPartitionPath(t *testing.T) {
+	cfg := &Config{
+		Partition:       []string{PartitionTenant, PartitionFramework, PartitionDate},
+		TenantAttribute: "service.namespace",
+	}
+	now := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		attrs  map[string]any
+		noTime bool
+		want   string
+	}{
+		{
+			name:  "framework list",
+			attrs: map[string]any{proofwatch.COMPLIANCE_FRAMEWORKS: []any{"NIST-800-53", "PCI-DSS"}},
+			want:  "tenant=acme/framework=NIST-800-53/date=2026-06-02",
+		},
+		{
+			name:  "catalog fallback",
+			attrs: map[string]any{proofwatch.COMPLIANCE_CONTROL_CATALOG_ID: "CIS Benchmarks/RHEL 9"},
+			want:  "tenant=acme/framework=CIS%20Benchmarks%2FRHEL%209/date=2026-06-02",
+		},
+		{
+			name:   "unknown framework and receive time",
+			attrs:  map[string]any{},
+			noTime: true,
+			want:   "tenant=acme/framework=unknown/date=2026-07-01",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs := evidenceLogs(tt.attrs)
+			rl := logs.ResourceLogs().At(0)
+			record := rl.ScopeLogs().At(0).LogRecords().At(0)
+			if tt.noTime {
+				record.SetTimestamp(0)
+			}
+			assert.Equal(t, tt.want, partitionPath(cfg, rl.Resource(), record, now))
+		})
+	}
+}
+
+func TestBundlerAdd(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Partition = []string{PartitionFramework}
+	cfg.MaxRecords = 2
+	b := newBundler(cfg)
+
+	full := b.add(evidenceLogs(
+		map[string]any{proofwatch.POLICY_RULE_ID: "r1", proofwatch.COMPLIANCE_FRAMEWORKS: []any{"NIST-800-53"}},
+		map[string]any{proofwatch.POLICY_RULE_ID: "r2", proofwatch.COMPLIANCE_FRAMEWORKS: []any{"PCI-DSS"}},
+	), recordTimestamp)
+	assert.False(t, full)
+
+	full = b.add(evidenceLogs(
+		map[string]any{proofwatch.POLICY_RULE_ID: "r3", proofwatch.COMPLIANCE_FRAMEWORKS: []any{"NIST-800-53"}},
+	), recordTimestamp)
+	assert.True(t, full)
+
+	bundles := b.take()
+	require.Len(t, bundles, 2)
+	assert.Empty(t, b.bundles)
+
+	byPartition := map[string]*bundle{}
+	for _, bd := range bundles {
+		byPartition[bd.partition] = bd
+	}
+	nist := byPartition["framework=NIST-800-53"]
+	require.NotNil(t, nist)
+	assert.Equal(t, 2, nist.records)
+	assert.Equal(t, 2, nist.logs.LogRecordCount())
+	// Each call keeps its own resource, with the resource attributes copied.
+	require.Equal(t, 2, nist.logs.ResourceLogs().Len())
+	tenant, ok := nist.logs.ResourceLogs().At(1).Resource().Attributes().Get("service.namespace")
+	require.True(t, ok)
+	assert.Equal(t, "acme", tenant.Str())
+	assert.Equal(t, 1, byPartition["framework=PCI-DSS"].records)
+}
+
+func TestEncode(t *testing.T) {
+	b := newBundler(createDefaultConfig().(*Config))
+	b.add(evidenceLogs(map[string]any{proofwatch.POLICY_RULE_ID: "r1"}), recordTimestamp)
+	bundles := b.take()
+	require.Len(t, bundles, 1)
+
+	data, err := encode(bundles[0])
+	require.NoError(t, err)
+
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	raw, err := io.ReadAll(zr)
+	require.NoError(t, err)
+
+	logs, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(raw)
+	require.NoError(t, err)
+	assert.Equal(t, 1, logs.LogRecordCount())
+}
@@ -0,0 +1,86 @@
+package evidencebundleexporter
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	// PartitionDate partitions bundles by the UTC date of the record timestamp.
+	PartitionDate = "date"
+	// PartitionFramework partitions bundles by the first compliance framework of a record.
+	PartitionFramework = "framework"
+	// PartitionTenant partitions bundles by the resource attribute named in TenantAttribute.
+	PartitionTenant = "tenant"
+
+	defaultFlushInterval = 5 * time.Minute
+	defaultMaxRecords    = 10000
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines the configuration for the evidence bundle exporter.
+type Config struct {
+	// BucketURL opens the destination bucket, e.g. s3://bucket?region=us-east-1,
+	// gs://bucket, azblob://container or file:///var/lib/evidence.
+	BucketURL string `mapstructure:"bucket_url"`
+
+	// Prefix is prepended to every object key.
+	Prefix string `mapstructure:"prefix"`
+
+	// Partition lists the path partitions, in order, from "date", "framework" and "tenant".
+	Partition []string `mapstructure:"partition"`
+
+	// TenantAttribute is the resource attribute used by the "tenant" partition.
+	TenantAttribute string `mapstructure:"tenant_attribute"`
+
+	// FlushInterval is how often open bundles are uploaded.
+	FlushInterval time.Duration `mapstructure:"flush_interval"`
+
+	// MaxRecords uploads a bundle early once it holds this many records.
+	MaxRecords int `mapstructure:"max_records"`
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Partition:     []string{PartitionDate},
+		FlushInterval: defaultFlushInterval,
+		MaxRecords:    defaultMaxRecords,
+	}
+}
+
+// Validate checks the exporter configuration is valid.
+func (c *Config) Validate() error {
+	if c.BucketURL == "" {
+		return errors.New("bucket_url must not be empty")
+	}
+	if _, err := url.Parse(c.BucketURL); err != nil {
+		return fmt.Errorf("bucket_url is not a valid URL: %w", err)
+	}
+	seen := make(map[string]bool, len(c.Partition))
+	for _, p := range c.Partition {
+		switch p {
+		case PartitionDate, PartitionFramework, PartitionTenant:
+		default:
+			return fmt.Errorf("partition %q is not one of %q, %q or %q", p, PartitionDate, PartitionFramework, PartitionTenant)
+		}
+		if seen[p] {
+			return fmt.Errorf("partition %q is listed more than once", p)
+		}
+		seen[p] = true
+	}
+	if seen[PartitionTenant] && c.TenantAttribute == "" {
+		return errors.New("tenant_attribute must be set when partitioning by tenant")
+	}
+	if c.FlushInterval <= 0 {
+		return errors.New("flush_interval must be positive")
+	}
+	if c.MaxRecords <= 0 {
+		return errors.New("max_records must be positive")
+	}
+	return nil
+}
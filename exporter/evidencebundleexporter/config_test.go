@@ -0,0 +1,122 @@
+package evidencebundleexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id       component.ID
+		expected *Config
+	}{
+		{
+			id: component.NewID(componentType),
+			expected: &Config{
+				BucketURL:     "s3://audit-evidence?region=us-east-1",
+				Partition:     []string{PartitionDate},
+				FlushInterval: defaultFlushInterval,
+				MaxRecords:    defaultMaxRecords,
+			},
+		},
+		{
+			id: component.NewIDWithName(componentType, "partitioned"),
+			expected: &Config{
+				BucketURL:       "gs://audit-evidence",
+				Prefix:          "complybeacon",
+				Partition:       []string{PartitionTenant, PartitionFramework, PartitionDate},
+				TenantAttribute: "service.namespace",
+				FlushInterval:   time.Hour,
+				MaxRecords:      500,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, sub.Unmarshal(cfg))
+			require.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "empty bucket url",
+			mutate:  func(cfg *Config) { cfg.BucketURL = "" },
+			wantErr: "bucket_url must not be empty",
+		},
+		{
+			name:    "invalid bucket url",
+			mutate:  func(cfg *Config) { cfg.BucketURL = "s3://bucket\x7f" },
+			wantErr: "bucket_url is not a valid URL: parse \"s3://bucket\\x7f\": net/url: invalid control character in URL",
+		},
+		{
+			name:    "unknown partition",
+			mutate:  func(cfg *Config) { cfg.Partition = []string{"region"} },
+			wantErr: `partition "region" is not one of "date", "framework" or "tenant"`,
+		},
+		{
+			name:    "duplicate partition",
+			mutate:  func(cfg *Config) { cfg.Partition = []string{PartitionDate, PartitionDate} },
+			wantErr: `partition "date" is listed more than once`,
+		},
+		{
+			name:    "tenant without attribute",
+			mutate:  func(cfg *Config) { cfg.Partition = []string{PartitionTenant} },
+			wantErr: "tenant_attribute must be set when partitioning by tenant",
+		},
+		{
+			name:    "zero flush interval",
+			mutate:  func(cfg *Config) { cfg.FlushInterval = 0 },
+			wantErr: "flush_interval must be positive",
+		},
+		{
+			name:    "zero max records",
+			mutate:  func(cfg *Config) { cfg.MaxRecords = 0 },
+			wantErr: "max_records must be positive",
+		},
+		{
+			name:   "no partitions",
+			mutate: func(cfg *Config) { cfg.Partition = nil },
+		},
+		{
+			name:   "valid",
+			mutate: func(*Config) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.BucketURL = "file:///var/lib/evidence"
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
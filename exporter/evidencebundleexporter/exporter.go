@@ -0,0 +1,182 @@
+package evidencebundleexporter
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+	"gocloud.dev/blob"
+
+	// Bucket drivers register themselves for their URL schemes.
+	_ "gocloud.dev/blob/azureblob"
+	_ "gocloud.dev/blob/fileblob"
+	_ "gocloud.dev/blob/gcsblob"
+	_ "gocloud.dev/blob/s3blob"
+)
+
+// maxPendingBundles bounds the bundles kept for retry while uploads are failing.
+const maxPendingBundles = 64
+
+// pendingBundle is a closed bundle with the object key it is uploaded to.
+// The key is fixed on close, so a retried upload overwrites an earlier partial attempt.
+type pendingBundle struct {
+	key    string
+	bundle *bundle
+}
+
+type bundleExporter struct {
+	cfg      *Config
+	settings exporter.Settings
+	bucket   *blob.Bucket
+	now      func() time.Time
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	// full is signalled when a bundle reaches MaxRecords before the flush interval.
+	full chan struct{}
+
+	mu      sync.Mutex
+	bundler *bundler
+
+	// flushMu serializes flushes so pending bundles are uploaded in order.
+	flushMu sync.Mutex
+	pending []pendingBundle
+}
+
+func newBundleExporter(cfg *Config, set exporter.Settings) *bundleExporter {
+	return &bundleExporter{
+		cfg:      cfg,
+		settings: set,
+		now:      time.Now,
+		full:     make(chan struct{}, 1),
+		bundler:  newBundler(cfg),
+	}
+}
+
+func (e *bundleExporter) start(ctx context.Context, _ component.Host) error {
+	bucket, err := blob.OpenBucket(ctx, e.cfg.BucketURL)
+	if err != nil {
+		return fmt.Errorf("failed to open bucket: %w", err)
+	}
+	e.bucket = bucket
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	e.cancel = cancel
+	e.wg.Add(1)
+	go e.flushLoop(runCtx)
+	return nil
+}
+
+// shutdown uploads the open bundles and closes the bucket.
+func (e *bundleExporter) shutdown(ctx context.Context) error {
+	if e.cancel != nil {
+		e.cancel()
+	}
+	e.wg.Wait()
+	if e.bucket == nil {
+		return nil
+	}
+	return errors.Join(e.flush(ctx), e.bucket.Close())
+}
+
+func (e *bundleExporter) consumeLogs(_ context.Context, logs plog.Logs) error {
+	e.mu.Lock()
+	full := e.bundler.add(logs, e.now())
+	e.mu.Unlock()
+
+	if full {
+		select {
+		case e.full <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+func (e *bundleExporter) flushLoop(ctx context.Context) {
+	defer e.wg.Done()
+	ticker := time.NewTicker(e.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-e.full:
+		}
+		if err := e.flush(ctx); err != nil {
+			e.settings.Logger.Warn("failed to upload evidence bundles; will retry on the next flush", zap.Error(err))
+		}
+	}
+}
+
+// flush closes the open bundles and uploads them together with bundles that
+// failed earlier. Bundles that still fail are kept, up to maxPendingBundles.
+func (e *bundleExporter) flush(ctx context.Context) error {
+	e.flushMu.Lock()
+	defer e.flushMu.Unlock()
+
+	e.mu.Lock()
+	closed := e.bundler.take()
+	e.mu.Unlock()
+
+	for _, bd := range closed {
+		e.pending = append(e.pending, pendingBundle{key: e.objectKey(bd), bundle: bd})
+	}
+	if dropped := len(e.pending) - maxPendingBundles; dropped > 0 {
+		e.settings.Logger.Error("dropping undelivered evidence bundles", zap.Int("bundles", dropped))
+		e.pending = e.pending[dropped:]
+	}
+
+	var errs error
+	remaining := e.pending[:0]
+	for _, p := range e.pending {
+		if err := e.upload(ctx, p); err != nil {
+			errs = errors.Join(errs, err)
+			remaining = append(remaining, p)
+		}
+	}
+	e.pending = remaining
+	return errs
+}
+
+// objectKey names a bundle <prefix>/<partition>/evidence-<uuidv7>.json.gz. UUIDv7
+// keys sort by creation time within a partition.
+func (e *bundleExporter) objectKey(bd *bundle) string {
+	id, err := uuid.NewV7()
+	if err != nil {
+		id = uuid.New()
+	}
+	return path.Join(e.cfg.Prefix, bd.partition, "evidence-"+id.String()+".json.gz")
+}
+
+func (e *bundleExporter) upload(ctx context.Context, p pendingBundle) error {
+	data, err := encode(p.bundle)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+	opts := &blob.WriterOptions{
+		ContentType: "application/gzip",
+		Metadata: map[string]string{
+			"record-count": strconv.Itoa(p.bundle.records),
+			"sha256":       hex.EncodeToString(sum[:]),
+		},
+	}
+	if err := e.bucket.WriteAll(ctx, p.key, data, opts); err != nil {
+		return fmt.Errorf("failed to upload %s: %w", p.key, err)
+	}
+	return nil
+}
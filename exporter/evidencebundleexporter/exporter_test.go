@@ -0,0 +1,154 @@
+package evidencebundleexporter
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"gocloud.dev/blob"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+func bucketURL(dir string) string {
+	return "file://" + filepath.ToSlash(dir)
+}
+
+// readBundles returns the object keys of all uploaded bundles.
+func readBundles(t *testing.T, dir string) []string {
+	t.Helper()
+	bucket, err := blob.OpenBucket(context.Background(), bucketURL(dir))
+	require.NoError(t, err)
+	defer bucket.Close()
+
+	var keys []string
+	iter := bucket.List(nil)
+	for {
+		obj, err := iter.Next(context.Background())
+		if err != nil {
+			break
+		}
+		keys = append(keys, obj.Key)
+	}
+	return keys
+}
+
+func newTestExporter(t *testing.T, cfg *Config) *bundleExporter {
+	t.Helper()
+	e := newBundleExporter(cfg, exportertest.NewNopSettings(componentType))
+	e.now = func() time.Time { return recordTimestamp }
+	require.NoError(t, e.start(context.Background(), componenttest.NewNopHost()))
+	return e
+}
+
+func TestFlushUploadsBundles(t *testing.T) {
+	dir := t.TempDir()
+	cfg := createDefaultConfig().(*Config)
+	cfg.BucketURL = bucketURL(dir)
+	cfg.Prefix = "complybeacon"
+	cfg.Partition = []string{PartitionDate, PartitionFramework}
+	e := newTestExporter(t, cfg)
+
+	// Nothing is uploaded when no records were received.
+	require.NoError(t, e.flush(context.Background()))
+	assert.Empty(t, readBundles(t, dir))
+
+	require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs(
+		map[string]any{proofwatch.POLICY_RULE_ID: "r1", proofwatch.COMPLIANCE_FRAMEWORKS: []any{"NIST-800-53"}},
+		map[string]any{proofwatch.POLICY_RULE_ID: "r2", proofwatch.COMPLIANCE_FRAMEWORKS: []any{"NIST-800-53"}},
+		map[string]any{proofwatch.POLICY_RULE_ID: "r3", proofwatch.COMPLIANCE_FRAMEWORKS: []any{"PCI-DSS"}},
+	)))
+	require.NoError(t, e.flush(context.Background()))
+
+	keys := readBundles(t, dir)
+	require.Len(t, keys, 2)
+	for _, key := range keys {
+		assert.True(t, strings.HasPrefix(key, "complybeacon/date=2026-06-02/framework="), key)
+		assert.True(t, strings.HasSuffix(key, ".json.gz"), key)
+	}
+
+	bucket, err := blob.OpenBucket(context.Background(), bucketURL(dir))
+	require.NoError(t, err)
+	defer bucket.Close()
+	for _, key := range keys {
+		attrs, err := bucket.Attributes(context.Background(), key)
+		require.NoError(t, err)
+		data, err := bucket.ReadAll(context.Background(), key)
+		require.NoError(t, err)
+
+		sum := sha256.Sum256(data)
+		assert.Equal(t, hex.EncodeToString(sum[:]), attrs.Metadata["sha256"])
+		if strings.Contains(key, "NIST-800-53") {
+			assert.Equal(t, "2", attrs.Metadata["record-count"])
+		} else {
+			assert.Equal(t, "1", attrs.Metadata["record-count"])
+		}
+	}
+
+	require.NoError(t, e.shutdown(context.Background()))
+	assert.Len(t, readBundles(t, dir), 2)
+}
+
+func TestMaxRecordsTriggersFlush(t *testing.T) {
+	dir := t.TempDir()
+	cfg := createDefaultConfig().(*Config)
+	cfg.BucketURL = bucketURL(dir)
+	cfg.FlushInterval = time.Hour
+	cfg.MaxRecords = 2
+	e := newTestExporter(t, cfg)
+	defer func() { require.NoError(t, e.shutdown(context.Background())) }()
+
+	require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs(
+		map[string]any{proofwatch.POLICY_RULE_ID: "r1"},
+		map[string]any{proofwatch.POLICY_RULE_ID: "r2"},
+	)))
+	assert.Eventually(t, func() bool { return len(readBundles(t, dir)) == 1 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestFlushRetriesFailedUploads(t *testing.T) {
+	dir := t.TempDir()
+	cfg := createDefaultConfig().(*Config)
+	cfg.BucketURL = bucketURL(dir)
+	cfg.Prefix = "evidence"
+	e := newTestExporter(t, cfg)
+
+	// A regular file in place of the prefix directory makes every upload fail.
+	blocker := filepath.Join(dir, "evidence")
+	require.NoError(t, os.WriteFile(blocker, nil, 0o600))
+
+	require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs(map[string]any{proofwatch.POLICY_RULE_ID: "r1"})))
+	assert.Error(t, e.flush(context.Background()))
+	require.Len(t, e.pending, 1)
+	key := e.pending[0].key
+
+	require.NoError(t, os.Remove(blocker))
+	require.NoError(t, e.shutdown(context.Background()))
+	assert.Empty(t, e.pending)
+	assert.Equal(t, []string{key}, readBundles(t, dir))
+}
+
+func TestFlushDropsOldestPendingBundles(t *testing.T) {
+	dir := t.TempDir()
+	cfg := createDefaultConfig().(*Config)
+	cfg.BucketURL = bucketURL(dir)
+	cfg.Prefix = "evidence"
+	e := newTestExporter(t, cfg)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "evidence"), nil, 0o600))
+
+	for range maxPendingBundles + 2 {
+		require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs(map[string]any{proofwatch.POLICY_RULE_ID: "r1"})))
+		assert.Error(t, e.flush(context.Background()))
+	}
+	assert.Len(t, e.pending, maxPendingBundles)
+	e.cancel()
+	e.wg.Wait()
+}
@@ -0,0 +1,37 @@
+package evidencebundleexporter
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const stability = component.StabilityLevelAlpha
+
+var componentType = component.MustNewType("evidencebundle")
+
+// NewFactory creates a factory for the evidence bundle exporter.
+func NewFactory() exporter.Factory {
+	return exporter.NewFactory(
+		componentType,
+		createDefaultConfig,
+		exporter.WithLogs(createLogsExporter, stability),
+	)
+}
+
+func createLogsExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Logs, error) {
+	exp := newBundleExporter(cfg.(*Config), set)
+	return exporterhelper.NewLogs(ctx, set, cfg,
+		exp.consumeLogs,
+		exporterhelper.WithStart(exp.start),
+		exporterhelper.WithShutdown(exp.shutdown),
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+	)
+}
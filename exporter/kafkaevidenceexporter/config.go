@@ -0,0 +1,134 @@
+package kafkaevidenceexporter
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/config/configtls"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+const (
+	// FormatAvro encodes records with the Avro schema.
+	FormatAvro = "avro"
+	// FormatProtobuf encodes records with the Protobuf schema.
+	FormatProtobuf = "protobuf"
+
+	// MechanismPlain authenticates with SASL/PLAIN.
+	MechanismPlain = "PLAIN"
+	// MechanismSCRAMSHA256 authenticates with SASL/SCRAM-SHA-256.
+	MechanismSCRAMSHA256 = "SCRAM-SHA-256"
+	// MechanismSCRAMSHA512 authenticates with SASL/SCRAM-SHA-512.
+	MechanismSCRAMSHA512 = "SCRAM-SHA-512"
+
+	defaultTopic    = "complybeacon-evidence"
+	defaultClientID = "complybeacon"
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines the configuration for the Kafka evidence exporter.
+type Config struct {
+	configretry.BackOffConfig `mapstructure:"retry_on_failure"`
+
+	// Brokers are the seed brokers of the cluster.
+	Brokers []string `mapstructure:"brokers"`
+
+	// ClientID identifies the exporter to the brokers.
+	ClientID string `mapstructure:"client_id"`
+
+	// TLS enables TLS to the brokers. Nil connects in plaintext.
+	TLS *configtls.ClientConfig `mapstructure:"tls"`
+
+	// SASL authenticates to the brokers. Nil disables authentication.
+	SASL *SASLConfig `mapstructure:"sasl"`
+
+	// Topic receives the records no route matches.
+	Topic string `mapstructure:"topic"`
+
+	// Routing sends records to topics by an attribute value.
+	Routing RoutingConfig `mapstructure:"routing"`
+
+	// KeyAttribute names the attribute whose value keys the records, so
+	// evidence for one target stays in one partition and in order. Records
+	// without it are unkeyed.
+	KeyAttribute string `mapstructure:"key_attribute"`
+
+	// Format is "avro" or "protobuf".
+	Format string `mapstructure:"format"`
+
+	// SchemaRegistry is the schema registry the record schema is registered
+	// in, under the subject "<topic>-value" of every topic written to.
+	SchemaRegistry confighttp.ClientConfig `mapstructure:"schema_registry"`
+}
+
+// SASLConfig configures SASL authentication.
+type SASLConfig struct {
+	// Mechanism is "PLAIN", "SCRAM-SHA-256" or "SCRAM-SHA-512".
+	Mechanism string `mapstructure:"mechanism"`
+
+	Username string              `mapstructure:"username"`
+	Password configopaque.String `mapstructure:"password"`
+}
+
+// RoutingConfig routes records to topics by an attribute value.
+type RoutingConfig struct {
+	// Attribute is the record attribute, or failing that the resource
+	// attribute, routed on. A record whose attribute holds several values,
+	// such as compliance.frameworks, is written to the topic of each.
+	Attribute string `mapstructure:"attribute"`
+
+	// Topics maps attribute values to topics.
+	Topics map[string]string `mapstructure:"topics"`
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		BackOffConfig:  configretry.NewDefaultBackOffConfig(),
+		ClientID:       defaultClientID,
+		Topic:          defaultTopic,
+		KeyAttribute:   proofwatch.POLICY_TARGET_ID,
+		Format:         FormatAvro,
+		SchemaRegistry: confighttp.NewDefaultClientConfig(),
+	}
+}
+
+// Validate checks the exporter configuration is valid.
+func (c *Config) Validate() error {
+	if len(c.Brokers) == 0 {
+		return errors.New("brokers must not be empty")
+	}
+	if c.Topic == "" {
+		return errors.New("topic must not be empty")
+	}
+	if c.Routing.Attribute == "" && len(c.Routing.Topics) > 0 {
+		return errors.New("routing.attribute must be set when routing.topics is")
+	}
+	for value, topic := range c.Routing.Topics {
+		if topic == "" {
+			return fmt.Errorf("routing.topics[%q] must not be empty", value)
+		}
+	}
+	if c.Format != FormatAvro && c.Format != FormatProtobuf {
+		return fmt.Errorf("format %q is not one of %q or %q", c.Format, FormatAvro, FormatProtobuf)
+	}
+	if c.SchemaRegistry.Endpoint == "" {
+		return errors.New("schema_registry.endpoint must not be empty")
+	}
+	if c.SASL != nil {
+		switch c.SASL.Mechanism {
+		case MechanismPlain, MechanismSCRAMSHA256, MechanismSCRAMSHA512:
+		default:
+			return fmt.Errorf("sasl.mechanism %q is not one of %q, %q or %q", c.SASL.Mechanism, MechanismPlain, MechanismSCRAMSHA256, MechanismSCRAMSHA512)
+		}
+		if c.SASL.Username == "" || c.SASL.Password == "" {
+			return errors.New("sasl.username and sasl.password must not be empty")
+		}
+	}
+	return nil
+}
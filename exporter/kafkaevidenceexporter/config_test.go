@@ -0,0 +1,139 @@
+package kafkaevidenceexporter
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id    component.ID
+		check func(t *testing.T, cfg *Config)
+	}{
+		{
+			id: component.NewID(componentType),
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, []string{"kafka-0.example.com:9092"}, cfg.Brokers)
+				assert.Equal(t, defaultClientID, cfg.ClientID)
+				assert.Nil(t, cfg.TLS)
+				assert.Nil(t, cfg.SASL)
+				assert.Equal(t, defaultTopic, cfg.Topic)
+				assert.Equal(t, proofwatch.POLICY_TARGET_ID, cfg.KeyAttribute)
+				assert.Equal(t, FormatAvro, cfg.Format)
+				assert.Equal(t, "http://schema-registry.example.com:8081", cfg.SchemaRegistry.Endpoint)
+				assert.True(t, cfg.BackOffConfig.Enabled)
+			},
+		},
+		{
+			id: component.NewIDWithName(componentType, "routed"),
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, []string{"kafka-0.example.com:9093", "kafka-1.example.com:9093"}, cfg.Brokers)
+				assert.Equal(t, "grc-evidence", cfg.ClientID)
+				require.NotNil(t, cfg.TLS)
+				assert.Equal(t, "/etc/kafka/ca.crt", cfg.TLS.CAFile)
+				assert.Equal(t, &SASLConfig{Mechanism: MechanismSCRAMSHA512, Username: "complybeacon", Password: "secret"}, cfg.SASL)
+				assert.Equal(t, "evidence-unrouted", cfg.Topic)
+				assert.Equal(t, RoutingConfig{
+					Attribute: proofwatch.COMPLIANCE_FRAMEWORKS,
+					Topics:    map[string]string{"NIST-800-53": "evidence-nist", "PCI-DSS": "evidence-pci"},
+				}, cfg.Routing)
+				assert.Equal(t, proofwatch.COMPLIANCE_CONTROL_ID, cfg.KeyAttribute)
+				assert.Equal(t, FormatProtobuf, cfg.Format)
+				assert.False(t, cfg.BackOffConfig.Enabled)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, sub.Unmarshal(cfg))
+			require.NoError(t, cfg.Validate())
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "no brokers",
+			mutate:  func(cfg *Config) { cfg.Brokers = nil },
+			wantErr: "brokers must not be empty",
+		},
+		{
+			name:    "empty topic",
+			mutate:  func(cfg *Config) { cfg.Topic = "" },
+			wantErr: "topic must not be empty",
+		},
+		{
+			name:    "routes without attribute",
+			mutate:  func(cfg *Config) { cfg.Routing.Topics = map[string]string{"PCI-DSS": "evidence-pci"} },
+			wantErr: "routing.attribute must be set when routing.topics is",
+		},
+		{
+			name: "empty route topic",
+			mutate: func(cfg *Config) {
+				cfg.Routing = RoutingConfig{Attribute: "tenant.id", Topics: map[string]string{"acme": ""}}
+			},
+			wantErr: `routing.topics["acme"] must not be empty`,
+		},
+		{
+			name:    "unknown format",
+			mutate:  func(cfg *Config) { cfg.Format = "json" },
+			wantErr: `format "json" is not one of "avro" or "protobuf"`,
+		},
+		{
+			name:    "no schema registry",
+			mutate:  func(cfg *Config) { cfg.SchemaRegistry.Endpoint = "" },
+			wantErr: "schema_registry.endpoint must not be empty",
+		},
+		{
+			name:    "unknown SASL mechanism",
+			mutate:  func(cfg *Config) { cfg.SASL = &SASLConfig{Mechanism: "GSSAPI", Username: "u", Password: "p"} },
+			wantErr: `sasl.mechanism "GSSAPI" is not one of "PLAIN", "SCRAM-SHA-256" or "SCRAM-SHA-512"`,
+		},
+		{
+			name:    "SASL without password",
+			mutate:  func(cfg *Config) { cfg.SASL = &SASLConfig{Mechanism: MechanismPlain, Username: "u"} },
+			wantErr: "sasl.username and sasl.password must not be empty",
+		},
+		{
+			name:   "valid",
+			mutate: func(*Config) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Brokers = []string{"localhost:9092"}
+			cfg.SchemaRegistry.Endpoint = "http://localhost:8081"
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package kafkaevidenceexporter
+
+import (
+	"encoding/binary"
+	"maps"
+	"slices"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// magicByte starts every message in the schema registry wire format.
+const magicByte = 0
+
+// evidence holds the schema fields of one record.
+type evidence struct {
+	timestamp          int64
+	observedTimestamp  int64
+	severityText       string
+	body               *string
+	attributes         map[string]string
+	resourceAttributes map[string]string
+	// values holds the values of attributeFields by position. A nil entry
+	// marks an absent attribute.
+	values [][]string
+}
+
+func newEvidence(resource pcommon.Resource, lr plog.LogRecord) evidence {
+	ts := lr.Timestamp()
+	if ts == 0 {
+		ts = lr.ObservedTimestamp()
+	}
+	e := evidence{
+		timestamp:          ts.AsTime().UnixMicro(),
+		observedTimestamp:  lr.ObservedTimestamp().AsTime().UnixMicro(),
+		severityText:       lr.SeverityText(),
+		attributes:         stringMap(lr.Attributes()),
+		resourceAttributes: stringMap(resource.Attributes()),
+		values:             make([][]string, len(attributeFields)),
+	}
+	if lr.Body().Type() != pcommon.ValueTypeEmpty {
+		body := lr.Body().AsString()
+		e.body = &body
+	}
+	for i, f := range attributeFields {
+		v, ok := lr.Attributes().Get(f.attribute)
+		switch {
+		case !ok:
+		case f.repeated:
+			e.values[i] = values(v)
+		default:
+			e.values[i] = []string{v.AsString()}
+		}
+	}
+	return e
+}
+
+// avro encodes the evidence in the Avro binary encoding of avroSchema.
+func (e evidence) avro() []byte {
+	var b []byte
+	b = binary.AppendVarint(b, e.timestamp)
+	b = binary.AppendVarint(b, e.observedTimestamp)
+	b = appendAvroString(b, e.severityText)
+	b = appendAvroOptional(b, e.body)
+	b = appendAvroMap(b, e.attributes)
+	b = appendAvroMap(b, e.resourceAttributes)
+	for i, f := range attributeFields {
+		values := e.values[i]
+		if f.repeated {
+			if len(values) > 0 {
+				b = binary.AppendVarint(b, int64(len(values)))
+				for _, v := range values {
+					b = appendAvroString(b, v)
+				}
+			}
+			b = binary.AppendVarint(b, 0)
+			continue
+		}
+		var value *string
+		if values != nil {
+			value = &values[0]
+		}
+		b = appendAvroOptional(b, value)
+	}
+	return b
+}
+
+func appendAvroString(b []byte, s string) []byte {
+	b = binary.AppendVarint(b, int64(len(s)))
+	return append(b, s...)
+}
+
+// appendAvroOptional encodes a ["null", "string"] union.
+func appendAvroOptional(b []byte, s *string) []byte {
+	if s == nil {
+		return binary.AppendVarint(b, 0)
+	}
+	b = binary.AppendVarint(b, 1)
+	return appendAvroString(b, *s)
+}
+
+// appendAvroMap encodes a map as a single block, with sorted keys so equal
+// maps encode equally.
+func appendAvroMap(b []byte, m map[string]string) []byte {
+	if len(m) > 0 {
+		b = binary.AppendVarint(b, int64(len(m)))
+		for _, k := range sortedKeys(m) {
+			b = appendAvroString(b, k)
+			b = appendAvroString(b, m[k])
+		}
+	}
+	return binary.AppendVarint(b, 0)
+}
+
+// protobuf encodes the evidence as the message of protoSchema.
+func (e evidence) protobuf() []byte {
+	var b []byte
+	b = protowire.AppendTag(b, 1, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(e.timestamp))
+	b = protowire.AppendTag(b, 2, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(e.observedTimestamp))
+	if e.severityText != "" {
+		b = appendProtoString(b, 3, e.severityText)
+	}
+	if e.body != nil {
+		b = appendProtoString(b, 4, *e.body)
+	}
+	b = appendProtoMap(b, 5, e.attributes)
+	b = appendProtoMap(b, 6, e.resourceAttributes)
+	for i := range attributeFields {
+		// A non-repeated field holds exactly one value when present.
+		for _, v := range e.values[i] {
+			b = appendProtoString(b, protowire.Number(firstFieldNumber+i), v)
+		}
+	}
+	return b
+}
+
+func appendProtoString(b []byte, num protowire.Number, s string) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+// appendProtoMap encodes a map field as its entry messages, in key order.
+func appendProtoMap(b []byte, num protowire.Number, m map[string]string) []byte {
+	for _, k := range sortedKeys(m) {
+		var entry []byte
+		entry = appendProtoString(entry, 1, k)
+		entry = appendProtoString(entry, 2, m[k])
+		b = protowire.AppendTag(b, num, protowire.BytesType)
+		b = protowire.AppendBytes(b, entry)
+	}
+	return b
+}
+
+// frame prefixes a payload with the schema registry wire format header: the
+// magic byte and the schema ID, followed for Protobuf by the index of the
+// message in the schema, which is the first.
+func frame(format string, schemaID int, payload []byte) []byte {
+	b := make([]byte, 0, 6+len(payload))
+	b = append(b, magicByte)
+	b = binary.BigEndian.AppendUint32(b, uint32(schemaID))
+	if format == FormatProtobuf {
+		b = append(b, 0)
+	}
+	return append(b, payload...)
+}
+
+func stringMap(attrs pcommon.Map) map[string]string {
+	m := make(map[string]string, attrs.Len())
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		m[k] = v.AsString()
+		return true
+	})
+	return m
+}
+
+func sortedKeys(m map[string]string) []string {
+	return slices.Sorted(maps.Keys(m))
+}
@@ -0,0 +1,207 @@
+package kafkaevidenceexporter
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+func testRecord() (pcommon.Resource, plog.LogRecord) {
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("service.name", "scanner")
+
+	lr := plog.NewLogRecord()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(1700000000, 0)))
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Unix(1700000001, 0)))
+	lr.Body().SetStr("scan finished")
+	lr.Attributes().PutStr(proofwatch.POLICY_RULE_ID, "deny-root")
+	lr.Attributes().PutStr(proofwatch.POLICY_EVALUATION_RESULT, "Failed")
+	frameworks := lr.Attributes().PutEmptySlice(proofwatch.COMPLIANCE_FRAMEWORKS)
+	frameworks.AppendEmpty().SetStr("NIST-800-53")
+	frameworks.AppendEmpty().SetStr("PCI-DSS")
+	return resource, lr
+}
+
+func fieldIndex(t *testing.T, attribute string) int {
+	t.Helper()
+	for i, f := range attributeFields {
+		if f.attribute == attribute {
+			return i
+		}
+	}
+	t.Fatalf("no field for %s", attribute)
+	return 0
+}
+
+func TestNewEvidence(t *testing.T) {
+	resource, lr := testRecord()
+	ev := newEvidence(resource, lr)
+
+	assert.Equal(t, int64(1700000000000000), ev.timestamp)
+	assert.Equal(t, int64(1700000001000000), ev.observedTimestamp)
+	require.NotNil(t, ev.body)
+	assert.Equal(t, "scan finished", *ev.body)
+	assert.Equal(t, map[string]string{"service.name": "scanner"}, ev.resourceAttributes)
+	assert.Equal(t, []string{"deny-root"}, ev.values[fieldIndex(t, proofwatch.POLICY_RULE_ID)])
+	assert.Equal(t, []string{"NIST-800-53", "PCI-DSS"}, ev.values[fieldIndex(t, proofwatch.COMPLIANCE_FRAMEWORKS)])
+	assert.Nil(t, ev.values[fieldIndex(t, proofwatch.POLICY_TARGET_ID)])
+
+	lr.SetTimestamp(0)
+	plog.NewLogRecord().Body().CopyTo(lr.Body())
+	ev = newEvidence(resource, lr)
+	assert.Equal(t, ev.observedTimestamp, ev.timestamp)
+	assert.Nil(t, ev.body)
+}
+
+// avroReader decodes the Avro binary encoding the tests need.
+type avroReader struct {
+	t *testing.T
+	b []byte
+}
+
+func (r *avroReader) long() int64 {
+	v, n := binary.Varint(r.b)
+	require.Positive(r.t, n)
+	r.b = r.b[n:]
+	return v
+}
+
+func (r *avroReader) string() string {
+	n := r.long()
+	s := string(r.b[:n])
+	r.b = r.b[n:]
+	return s
+}
+
+func (r *avroReader) optional() *string {
+	if r.long() == 0 {
+		return nil
+	}
+	s := r.string()
+	return &s
+}
+
+func (r *avroReader) strings() []string {
+	var out []string
+	for n := r.long(); n != 0; n = r.long() {
+		for range n {
+			out = append(out, r.string())
+		}
+	}
+	return out
+}
+
+func (r *avroReader) stringMap() map[string]string {
+	out := map[string]string{}
+	for n := r.long(); n != 0; n = r.long() {
+		for range n {
+			k := r.string()
+			out[k] = r.string()
+		}
+	}
+	return out
+}
+
+func TestEvidenceAvro(t *testing.T) {
+	resource, lr := testRecord()
+	r := &avroReader{t: t, b: newEvidence(resource, lr).avro()}
+
+	assert.Equal(t, int64(1700000000000000), r.long())
+	assert.Equal(t, int64(1700000001000000), r.long())
+	assert.Empty(t, r.string())
+	assert.Equal(t, "scan finished", *r.optional())
+	assert.Equal(t, map[string]string{
+		proofwatch.POLICY_RULE_ID:           "deny-root",
+		proofwatch.POLICY_EVALUATION_RESULT: "Failed",
+		proofwatch.COMPLIANCE_FRAMEWORKS:    `["NIST-800-53","PCI-DSS"]`,
+	}, r.stringMap())
+	assert.Equal(t, map[string]string{"service.name": "scanner"}, r.stringMap())
+
+	got := map[string]any{}
+	for _, f := range attributeFields {
+		if f.repeated {
+			if values := r.strings(); values != nil {
+				got[f.name] = values
+			}
+			continue
+		}
+		if v := r.optional(); v != nil {
+			got[f.name] = *v
+		}
+	}
+	assert.Empty(t, r.b)
+	assert.Equal(t, map[string]any{
+		"policy_rule_id":           "deny-root",
+		"policy_evaluation_result": "Failed",
+		"compliance_frameworks":    []string{"NIST-800-53", "PCI-DSS"},
+	}, got)
+}
+
+func TestEvidenceProtobuf(t *testing.T) {
+	resource, lr := testRecord()
+	b := newEvidence(resource, lr).protobuf()
+
+	strs := map[protowire.Number][]string{}
+	varints := map[protowire.Number]uint64{}
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		require.Positive(t, n)
+		b = b[n:]
+		switch typ {
+		case protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			require.Positive(t, n)
+			varints[num] = v
+			b = b[n:]
+		case protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			require.Positive(t, n)
+			if num != 5 && num != 6 {
+				strs[num] = append(strs[num], string(v))
+			}
+			b = b[n:]
+		default:
+			t.Fatalf("unexpected wire type %d", typ)
+		}
+	}
+
+	assert.Equal(t, uint64(1700000000000000), varints[1])
+	assert.Equal(t, uint64(1700000001000000), varints[2])
+	assert.Equal(t, map[protowire.Number][]string{
+		4: {"scan finished"},
+		protowire.Number(firstFieldNumber + fieldIndex(t, proofwatch.POLICY_RULE_ID)):           {"deny-root"},
+		protowire.Number(firstFieldNumber + fieldIndex(t, proofwatch.POLICY_EVALUATION_RESULT)): {"Failed"},
+		protowire.Number(firstFieldNumber + fieldIndex(t, proofwatch.COMPLIANCE_FRAMEWORKS)):    {"NIST-800-53", "PCI-DSS"},
+	}, strs)
+}
+
+func TestFrame(t *testing.T) {
+	assert.Equal(t, []byte{0, 0, 0, 1, 2, 'x'}, frame(FormatAvro, 258, []byte("x")))
+	assert.Equal(t, []byte{0, 0, 0, 0, 7, 0, 'x'}, frame(FormatProtobuf, 7, []byte("x")))
+}
+
+func TestAvroSchema(t *testing.T) {
+	var schema struct {
+		Type      string `json:"type"`
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+		Fields    []struct {
+			Name string `json:"name"`
+		} `json:"fields"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(avroSchema()), &schema))
+	assert.Equal(t, "record", schema.Type)
+	assert.Equal(t, schemaName, schema.Name)
+	assert.Equal(t, schemaNamespace, schema.Namespace)
+	require.Len(t, schema.Fields, 6+len(attributeFields))
+	assert.Equal(t, "policy_engine_name", schema.Fields[6].Name)
+}
@@ -0,0 +1,176 @@
+package kafkaevidenceexporter
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// producer is the part of the Kafka client the exporter uses.
+type producer interface {
+	ProduceSync(ctx context.Context, rs ...*kgo.Record) kgo.ProduceResults
+	Close()
+}
+
+type kafkaEvidenceExporter struct {
+	cfg      *Config
+	settings exporter.Settings
+	producer producer
+	registry *schemaRegistry
+}
+
+func newKafkaEvidenceExporter(cfg *Config, set exporter.Settings) *kafkaEvidenceExporter {
+	return &kafkaEvidenceExporter{cfg: cfg, settings: set}
+}
+
+func (e *kafkaEvidenceExporter) start(ctx context.Context, host component.Host) error {
+	client, err := e.cfg.SchemaRegistry.ToClient(ctx, host.GetExtensions(), e.settings.TelemetrySettings)
+	if err != nil {
+		return fmt.Errorf("failed to create schema registry client: %w", err)
+	}
+	e.registry = newSchemaRegistry(client, e.cfg.SchemaRegistry.Endpoint)
+
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(e.cfg.Brokers...),
+		kgo.ClientID(e.cfg.ClientID),
+	}
+	if e.cfg.TLS != nil {
+		tlsCfg, err := e.cfg.TLS.LoadTLSConfig(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load TLS configuration: %w", err)
+		}
+		if tlsCfg != nil {
+			opts = append(opts, kgo.DialTLSConfig(tlsCfg))
+		}
+	}
+	if e.cfg.SASL != nil {
+		opts = append(opts, kgo.SASL(saslMechanism(e.cfg.SASL)))
+	}
+	kafka, err := kgo.NewClient(opts...)
+	if err != nil {
+		return fmt.Errorf("failed to create Kafka client: %w", err)
+	}
+	e.producer = kafka
+	return nil
+}
+
+func (e *kafkaEvidenceExporter) shutdown(context.Context) error {
+	if e.producer != nil {
+		e.producer.Close()
+	}
+	return nil
+}
+
+// consumeLogs writes every record to its topics and waits until the brokers
+// have acknowledged all of them. A failed batch is retried whole, so records
+// already written may be written again.
+func (e *kafkaEvidenceExporter) consumeLogs(ctx context.Context, logs plog.Logs) error {
+	schemaType, schema := "AVRO", avroSchema()
+	if e.cfg.Format == FormatProtobuf {
+		schemaType, schema = "PROTOBUF", protoSchema()
+	}
+
+	var records []*kgo.Record
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		rl := logs.ResourceLogs().At(i)
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			lrs := rl.ScopeLogs().At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				lr := lrs.At(k)
+				ev := newEvidence(rl.Resource(), lr)
+				payload := ev.avro()
+				if e.cfg.Format == FormatProtobuf {
+					payload = ev.protobuf()
+				}
+				key := e.key(lr)
+				for _, topic := range e.topics(rl.Resource(), lr) {
+					id, err := e.registry.register(ctx, topic+"-value", schemaType, schema)
+					if err != nil {
+						return err
+					}
+					records = append(records, &kgo.Record{
+						Topic: topic,
+						Key:   key,
+						Value: frame(e.cfg.Format, id, payload),
+					})
+				}
+			}
+		}
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	if err := e.producer.ProduceSync(ctx, records...).FirstErr(); err != nil {
+		return fmt.Errorf("failed to produce evidence: %w", err)
+	}
+	return nil
+}
+
+// topics returns the topics of the routes the record matches, or the default
+// topic when it matches none.
+func (e *kafkaEvidenceExporter) topics(resource pcommon.Resource, lr plog.LogRecord) []string {
+	var topics []string
+	if e.cfg.Routing.Attribute != "" {
+		v, ok := lr.Attributes().Get(e.cfg.Routing.Attribute)
+		if !ok {
+			v, ok = resource.Attributes().Get(e.cfg.Routing.Attribute)
+		}
+		if ok {
+			for _, value := range values(v) {
+				topic, routed := e.cfg.Routing.Topics[value]
+				if routed && !slices.Contains(topics, topic) {
+					topics = append(topics, topic)
+				}
+			}
+		}
+	}
+	if len(topics) == 0 {
+		return []string{e.cfg.Topic}
+	}
+	return topics
+}
+
+func (e *kafkaEvidenceExporter) key(lr plog.LogRecord) []byte {
+	if e.cfg.KeyAttribute == "" {
+		return nil
+	}
+	v, ok := lr.Attributes().Get(e.cfg.KeyAttribute)
+	if !ok {
+		return nil
+	}
+	return []byte(v.AsString())
+}
+
+// values returns the elements of a list value in their string form, or the
+// string form of any other value.
+func values(v pcommon.Value) []string {
+	if v.Type() != pcommon.ValueTypeSlice {
+		return []string{v.AsString()}
+	}
+	out := make([]string, 0, v.Slice().Len())
+	for i := 0; i < v.Slice().Len(); i++ {
+		out = append(out, v.Slice().At(i).AsString())
+	}
+	return out
+}
+
+func saslMechanism(cfg *SASLConfig) sasl.Mechanism {
+	password := string(cfg.Password)
+	switch cfg.Mechanism {
+	case MechanismSCRAMSHA256:
+		return scram.Auth{User: cfg.Username, Pass: password}.AsSha256Mechanism()
+	case MechanismSCRAMSHA512:
+		return scram.Auth{User: cfg.Username, Pass: password}.AsSha512Mechanism()
+	default:
+		return plain.Auth{User: cfg.Username, Pass: password}.AsMechanism()
+	}
+}
@@ -0,0 +1,217 @@
+package kafkaevidenceexporter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+type fakeProducer struct {
+	records []*kgo.Record
+	err     error
+}
+
+func (p *fakeProducer) ProduceSync(_ context.Context, rs ...*kgo.Record) kgo.ProduceResults {
+	results := make(kgo.ProduceResults, 0, len(rs))
+	for _, r := range rs {
+		if p.err == nil {
+			p.records = append(p.records, r)
+		}
+		results = append(results, kgo.ProduceResult{Record: r, Err: p.err})
+	}
+	return results
+}
+
+func (p *fakeProducer) Close() {}
+
+// fakeRegistry answers schema registrations with an ID per subject and counts
+// the requests of each.
+type fakeRegistry struct {
+	mu       sync.Mutex
+	requests map[string]int
+	status   int
+}
+
+func newFakeRegistry(t *testing.T, status int) (*fakeRegistry, *httptest.Server) {
+	r := &fakeRegistry{requests: map[string]int{}, status: status}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /subjects/{subject}/versions", func(w http.ResponseWriter, req *http.Request) {
+		var body struct {
+			Schema     string `json:"schema"`
+			SchemaType string `json:"schemaType"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil || body.Schema == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		r.mu.Lock()
+		r.requests[req.PathValue("subject")]++
+		id := len(r.requests)
+		r.mu.Unlock()
+		if r.status != http.StatusOK {
+			http.Error(w, `{"error_code":409,"message":"incompatible schema"}`, r.status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+		_ = json.NewEncoder(w).Encode(map[string]int{"id": id})
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return r, srv
+}
+
+func newTestExporter(t *testing.T, endpoint string, mutate func(cfg *Config)) (*kafkaEvidenceExporter, *fakeProducer) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Brokers = []string{"localhost:9092"}
+	cfg.SchemaRegistry.Endpoint = endpoint
+	if mutate != nil {
+		mutate(cfg)
+	}
+	require.NoError(t, cfg.Validate())
+
+	exp := newKafkaEvidenceExporter(cfg, exportertest.NewNopSettings(componentType))
+	p := &fakeProducer{}
+	exp.producer = p
+	exp.registry = newSchemaRegistry(http.DefaultClient, endpoint)
+	return exp, p
+}
+
+func testLogs(frameworks ...string) plog.Logs {
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "scanner")
+	lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Attributes().PutStr(proofwatch.POLICY_TARGET_ID, "deployments.apps/payments/api")
+	lr.Attributes().PutStr(proofwatch.COMPLIANCE_CONTROL_ID, "AC-6")
+	if len(frameworks) > 0 {
+		s := lr.Attributes().PutEmptySlice(proofwatch.COMPLIANCE_FRAMEWORKS)
+		for _, f := range frameworks {
+			s.AppendEmpty().SetStr(f)
+		}
+	}
+	return logs
+}
+
+func TestConsumeLogs(t *testing.T) {
+	registry, srv := newFakeRegistry(t, http.StatusOK)
+	exp, p := newTestExporter(t, srv.URL, nil)
+
+	require.NoError(t, exp.consumeLogs(context.Background(), testLogs()))
+	require.NoError(t, exp.consumeLogs(context.Background(), testLogs()))
+
+	require.Len(t, p.records, 2)
+	for _, r := range p.records {
+		assert.Equal(t, defaultTopic, r.Topic)
+		assert.Equal(t, []byte("deployments.apps/payments/api"), r.Key)
+		assert.Equal(t, []byte{magicByte, 0, 0, 0, 1}, r.Value[:5])
+	}
+	// The schema ID is cached after the first registration.
+	assert.Equal(t, map[string]int{defaultTopic + "-value": 1}, registry.requests)
+}
+
+func TestConsumeLogsRouting(t *testing.T) {
+	tests := []struct {
+		name       string
+		frameworks []string
+		resource   string
+		want       []string
+	}{
+		{
+			name:       "single route",
+			frameworks: []string{"PCI-DSS"},
+			want:       []string{"evidence-pci"},
+		},
+		{
+			name:       "every matching route once",
+			frameworks: []string{"NIST-800-53", "PCI-DSS", "NIST-800-53"},
+			want:       []string{"evidence-nist", "evidence-pci"},
+		},
+		{
+			name:       "unrouted value",
+			frameworks: []string{"SOC2"},
+			want:       []string{"evidence-unrouted"},
+		},
+		{
+			name: "missing attribute",
+			want: []string{"evidence-unrouted"},
+		},
+		{
+			name:     "resource attribute",
+			resource: "NIST-800-53",
+			want:     []string{"evidence-nist"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, srv := newFakeRegistry(t, http.StatusOK)
+			exp, p := newTestExporter(t, srv.URL, func(cfg *Config) {
+				cfg.Topic = "evidence-unrouted"
+				cfg.Routing = RoutingConfig{
+					Attribute: proofwatch.COMPLIANCE_FRAMEWORKS,
+					Topics:    map[string]string{"NIST-800-53": "evidence-nist", "PCI-DSS": "evidence-pci"},
+				}
+				cfg.KeyAttribute = proofwatch.COMPLIANCE_CONTROL_ID
+				cfg.Format = FormatProtobuf
+			})
+
+			logs := testLogs(tt.frameworks...)
+			if tt.resource != "" {
+				logs.ResourceLogs().At(0).Resource().Attributes().PutStr(proofwatch.COMPLIANCE_FRAMEWORKS, tt.resource)
+			}
+			require.NoError(t, exp.consumeLogs(context.Background(), logs))
+
+			var topics []string
+			for _, r := range p.records {
+				topics = append(topics, r.Topic)
+				assert.Equal(t, []byte("AC-6"), r.Key)
+				// Protobuf messages carry the message index after the ID.
+				assert.Equal(t, byte(0), r.Value[5])
+			}
+			assert.Equal(t, tt.want, topics)
+		})
+	}
+}
+
+func TestConsumeLogsErrors(t *testing.T) {
+	t.Run("rejected schema", func(t *testing.T) {
+		_, srv := newFakeRegistry(t, http.StatusConflict)
+		exp, p := newTestExporter(t, srv.URL, nil)
+
+		err := exp.consumeLogs(context.Background(), testLogs())
+		require.Error(t, err)
+		assert.True(t, consumererror.IsPermanent(err))
+		assert.Empty(t, p.records)
+	})
+
+	t.Run("registry unavailable", func(t *testing.T) {
+		_, srv := newFakeRegistry(t, http.StatusInternalServerError)
+		exp, _ := newTestExporter(t, srv.URL, nil)
+
+		err := exp.consumeLogs(context.Background(), testLogs())
+		require.Error(t, err)
+		assert.False(t, consumererror.IsPermanent(err))
+	})
+
+	t.Run("produce failure", func(t *testing.T) {
+		_, srv := newFakeRegistry(t, http.StatusOK)
+		exp, p := newTestExporter(t, srv.URL, nil)
+		p.err = errors.New("broker unavailable")
+
+		err := exp.consumeLogs(context.Background(), testLogs())
+		assert.ErrorContains(t, err, "failed to produce evidence: broker unavailable")
+	})
+}
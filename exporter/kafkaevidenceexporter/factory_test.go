@@ -0,0 +1,33 @@
+package kafkaevidenceexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+)
+
+func TestNewFactory(t *testing.T) {
+	factory := NewFactory()
+	assert.Equal(t, componentType, factory.Type())
+
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, componenttest.CheckConfigStruct(cfg))
+}
+
+func TestExporterLifecycle(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	// The client connects lazily, so no broker needs to be reachable.
+	cfg.Brokers = []string{"localhost:9092"}
+	cfg.SchemaRegistry.Endpoint = "http://localhost:8081"
+	cfg.SASL = &SASLConfig{Mechanism: MechanismSCRAMSHA256, Username: "complybeacon", Password: "secret"}
+
+	exp, err := NewFactory().CreateLogs(context.Background(), exportertest.NewNopSettings(componentType), cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, exp.Shutdown(context.Background()))
+}
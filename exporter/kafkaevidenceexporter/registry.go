@@ -0,0 +1,91 @@
+package kafkaevidenceexporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+// schemaRegistry registers the record schema in a Confluent-compatible
+// schema registry and caches the schema ID of every subject.
+type schemaRegistry struct {
+	client   *http.Client
+	endpoint string
+
+	mu  sync.Mutex
+	ids map[string]int
+}
+
+func newSchemaRegistry(client *http.Client, endpoint string) *schemaRegistry {
+	return &schemaRegistry{
+		client:   client,
+		endpoint: strings.TrimRight(endpoint, "/"),
+		ids:      make(map[string]int),
+	}
+}
+
+// register returns the ID of the schema under the subject, registering it
+// first. Registering a schema the subject already holds returns its ID, so
+// this is safe to repeat after a restart.
+func (r *schemaRegistry) register(ctx context.Context, subject, schemaType, schema string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if id, ok := r.ids[subject]; ok {
+		return id, nil
+	}
+
+	data, err := json.Marshal(map[string]string{"schema": schema, "schemaType": schemaType})
+	if err != nil {
+		return 0, consumererror.NewPermanent(err)
+	}
+	endpoint := r.endpoint + "/subjects/" + url.PathEscape(subject) + "/versions"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return 0, consumererror.NewPermanent(err)
+	}
+	req.Header.Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to register schema of %s: %w", subject, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read schema registry response: %w", err)
+	}
+	if err := statusError("failed to register schema of "+subject, resp.StatusCode, body); err != nil {
+		return 0, err
+	}
+
+	var registered struct {
+		ID int `json:"id"`
+	}
+	if err := json.Unmarshal(body, &registered); err != nil {
+		return 0, fmt.Errorf("failed to decode schema registry response: %w", err)
+	}
+	r.ids[subject] = registered.ID
+	return registered.ID, nil
+}
+
+// statusError reports a failed response. Client errors other than throttling
+// are permanent, such as a schema the subject's compatibility rules reject.
+func statusError(msg string, status int, body []byte) error {
+	if status >= 200 && status <= 299 {
+		return nil
+	}
+	err := fmt.Errorf("%s: %d %s: %s", msg, status, http.StatusText(status), bytes.TrimSpace(body))
+	if status >= 400 && status <= 499 &&
+		status != http.StatusRequestTimeout && status != http.StatusTooManyRequests {
+		return consumererror.NewPermanent(err)
+	}
+	return err
+}
@@ -0,0 +1,113 @@
+package kafkaevidenceexporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+const (
+	schemaName      = "Evidence"
+	schemaNamespace = "complybeacon.evidence.v1"
+
+	// firstFieldNumber is the Protobuf field number of the first typed
+	// attribute field. Lower numbers hold the generic fields.
+	firstFieldNumber = 16
+)
+
+// attributeField is a compliance attribute promoted to a typed schema field.
+type attributeField struct {
+	name      string
+	attribute string
+	repeated  bool
+}
+
+// attributeFields are the typed attribute fields of the schema, in schema
+// order. The Protobuf field number of each follows from its position, so new
+// fields must only be appended, which keeps the schema backward compatible.
+var attributeFields = []attributeField{
+	{name: "policy_engine_name", attribute: proofwatch.POLICY_ENGINE_NAME},
+	{name: "policy_engine_version", attribute: proofwatch.POLICY_ENGINE_VERSION},
+	{name: "policy_rule_id", attribute: proofwatch.POLICY_RULE_ID},
+	{name: "policy_rule_name", attribute: proofwatch.POLICY_RULE_NAME},
+	{name: "policy_rule_uri", attribute: proofwatch.POLICY_RULE_URI},
+	{name: "policy_evaluation_result", attribute: proofwatch.POLICY_EVALUATION_RESULT},
+	{name: "policy_evaluation_message", attribute: proofwatch.POLICY_EVALUATION_MESSAGE},
+	{name: "policy_target_id", attribute: proofwatch.POLICY_TARGET_ID},
+	{name: "policy_target_name", attribute: proofwatch.POLICY_TARGET_NAME},
+	{name: "policy_target_type", attribute: proofwatch.POLICY_TARGET_TYPE},
+	{name: "policy_target_environment", attribute: proofwatch.POLICY_TARGET_ENVIRONMENT},
+	{name: "compliance_assessment_id", attribute: proofwatch.COMPLIANCE_ASSESSMENT_ID},
+	{name: "compliance_control_id", attribute: proofwatch.COMPLIANCE_CONTROL_ID},
+	{name: "compliance_control_catalog_id", attribute: proofwatch.COMPLIANCE_CONTROL_CATALOG_ID},
+	{name: "compliance_control_category", attribute: proofwatch.COMPLIANCE_CONTROL_CATEGORY},
+	{name: "compliance_control_applicability", attribute: proofwatch.COMPLIANCE_CONTROL_APPLICABILITY, repeated: true},
+	{name: "compliance_frameworks", attribute: proofwatch.COMPLIANCE_FRAMEWORKS, repeated: true},
+	{name: "compliance_requirements", attribute: proofwatch.COMPLIANCE_REQUIREMENTS, repeated: true},
+	{name: "compliance_status", attribute: proofwatch.COMPLIANCE_STATUS},
+	{name: "compliance_risk_level", attribute: proofwatch.COMPLIANCE_RISK_LEVEL},
+	{name: "compliance_remediation_status", attribute: proofwatch.COMPLIANCE_REMEDIATION_STATUS},
+	{name: "compliance_remediation_action", attribute: proofwatch.COMPLIANCE_REMEDIATION_ACTION},
+	{name: "compliance_remediation_description", attribute: proofwatch.COMPLIANCE_REMEDIATION_DESCRIPTION},
+	{name: "compliance_remediation_exception_id", attribute: proofwatch.COMPLIANCE_REMEDIATION_EXCEPTION_ID},
+}
+
+// avroSchema returns the Avro schema of evidence records. Optional fields
+// default to null and lists to empty, so readers of older versions can read
+// newer records.
+func avroSchema() string {
+	fields := []map[string]any{
+		{"name": "timestamp", "type": map[string]any{"type": "long", "logicalType": "timestamp-micros"}},
+		{"name": "observed_timestamp", "type": map[string]any{"type": "long", "logicalType": "timestamp-micros"}},
+		{"name": "severity_text", "type": "string"},
+		{"name": "body", "type": []any{"null", "string"}, "default": nil, "doc": "Record body. Strings are kept as is, other values are encoded as JSON."},
+		{"name": "attributes", "type": map[string]any{"type": "map", "values": "string"}, "doc": "All record attributes, in their string form."},
+		{"name": "resource_attributes", "type": map[string]any{"type": "map", "values": "string"}},
+	}
+	for _, f := range attributeFields {
+		if f.repeated {
+			fields = append(fields, map[string]any{
+				"name": f.name, "type": map[string]any{"type": "array", "items": "string"}, "default": []any{}, "doc": f.attribute,
+			})
+			continue
+		}
+		fields = append(fields, map[string]any{
+			"name": f.name, "type": []any{"null", "string"}, "default": nil, "doc": f.attribute,
+		})
+	}
+	schema, _ := json.Marshal(map[string]any{
+		"type":      "record",
+		"name":      schemaName,
+		"namespace": schemaNamespace,
+		"fields":    fields,
+	})
+	return string(schema)
+}
+
+// protoSchema returns the Protobuf schema of evidence records.
+func protoSchema() string {
+	var b strings.Builder
+	b.WriteString("syntax = \"proto3\";\n\n")
+	fmt.Fprintf(&b, "package %s;\n\n", schemaNamespace)
+	fmt.Fprintf(&b, "message %s {\n", schemaName)
+	b.WriteString("  int64 timestamp_unix_micros = 1;\n")
+	b.WriteString("  int64 observed_timestamp_unix_micros = 2;\n")
+	b.WriteString("  string severity_text = 3;\n")
+	b.WriteString("  // Record body. Strings are kept as is, other values are encoded as JSON.\n")
+	b.WriteString("  optional string body = 4;\n")
+	b.WriteString("  // All record attributes, in their string form.\n")
+	b.WriteString("  map<string, string> attributes = 5;\n")
+	b.WriteString("  map<string, string> resource_attributes = 6;\n")
+	for i, f := range attributeFields {
+		label := "optional"
+		if f.repeated {
+			label = "repeated"
+		}
+		fmt.Fprintf(&b, "  // %s\n", f.attribute)
+		fmt.Fprintf(&b, "  %s string %s = %d;\n", label, f.name, firstFieldNumber+i)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
@@ -0,0 +1,62 @@
+package ocsfexporter
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configretry"
+)
+
+const (
+	// EncodingNDJSON posts events as newline-delimited JSON.
+	EncodingNDJSON = "ndjson"
+	// EncodingJSON posts events as a JSON array.
+	EncodingJSON = "json"
+	// EncodingFirehose sends events with the Amazon Data Firehose PutRecordBatch API.
+	EncodingFirehose = "firehose"
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines the configuration for the OCSF exporter.
+type Config struct {
+	confighttp.ClientConfig   `mapstructure:",squash"`
+	configretry.BackOffConfig `mapstructure:"retry_on_failure"`
+
+	// Encoding selects how events are sent: "ndjson", "json" or "firehose".
+	Encoding string `mapstructure:"encoding"`
+
+	// DeliveryStream is the Firehose delivery stream events are put to.
+	// Required when Encoding is "firehose".
+	DeliveryStream string `mapstructure:"delivery_stream"`
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		ClientConfig:  confighttp.NewDefaultClientConfig(),
+		BackOffConfig: configretry.NewDefaultBackOffConfig(),
+		Encoding:      EncodingNDJSON,
+	}
+}
+
+// Validate checks the exporter configuration is valid.
+func (c *Config) Validate() error {
+	if c.Endpoint == "" {
+		return errors.New("endpoint must not be empty")
+	}
+	switch c.Encoding {
+	case EncodingNDJSON, EncodingJSON:
+		if c.DeliveryStream != "" {
+			return fmt.Errorf("delivery_stream is only supported with encoding %q", EncodingFirehose)
+		}
+	case EncodingFirehose:
+		if c.DeliveryStream == "" {
+			return fmt.Errorf("delivery_stream must be set when encoding is %q", EncodingFirehose)
+		}
+	default:
+		return fmt.Errorf("encoding %q is not one of %q, %q or %q", c.Encoding, EncodingNDJSON, EncodingJSON, EncodingFirehose)
+	}
+	return nil
+}
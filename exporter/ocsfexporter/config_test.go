@@ -0,0 +1,112 @@
+package ocsfexporter
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id    component.ID
+		check func(t *testing.T, cfg *Config)
+	}{
+		{
+			id: component.NewID(componentType),
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "https://siem.example.com/api/ocsf", cfg.Endpoint)
+				assert.Equal(t, EncodingNDJSON, cfg.Encoding)
+				assert.True(t, cfg.BackOffConfig.Enabled)
+			},
+		},
+		{
+			id: component.NewIDWithName(componentType, "json"),
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, EncodingJSON, cfg.Encoding)
+				assert.False(t, cfg.BackOffConfig.Enabled)
+			},
+		},
+		{
+			id: component.NewIDWithName(componentType, "firehose"),
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "https://firehose.us-east-1.amazonaws.com", cfg.Endpoint)
+				assert.Equal(t, EncodingFirehose, cfg.Encoding)
+				assert.Equal(t, "complybeacon-security-lake", cfg.DeliveryStream)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, sub.Unmarshal(cfg))
+			require.NoError(t, cfg.Validate())
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "empty endpoint",
+			mutate:  func(cfg *Config) { cfg.Endpoint = "" },
+			wantErr: "endpoint must not be empty",
+		},
+		{
+			name:    "unknown encoding",
+			mutate:  func(cfg *Config) { cfg.Encoding = "parquet" },
+			wantErr: `encoding "parquet" is not one of "ndjson", "json" or "firehose"`,
+		},
+		{
+			name:    "firehose without delivery stream",
+			mutate:  func(cfg *Config) { cfg.Encoding = EncodingFirehose },
+			wantErr: `delivery_stream must be set when encoding is "firehose"`,
+		},
+		{
+			name:    "delivery stream without firehose",
+			mutate:  func(cfg *Config) { cfg.DeliveryStream = "evidence" },
+			wantErr: `delivery_stream is only supported with encoding "firehose"`,
+		},
+		{
+			name: "firehose",
+			mutate: func(cfg *Config) {
+				cfg.Encoding = EncodingFirehose
+				cfg.DeliveryStream = "evidence"
+			},
+		},
+		{
+			name:   "valid",
+			mutate: func(*Config) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = "https://siem.example.com/api/ocsf"
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
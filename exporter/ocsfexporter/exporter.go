@@ -0,0 +1,210 @@
+package ocsfexporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	ocsf "github.com/telophasehq/go-ocsf/ocsf/v1_5_0"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+const (
+	// firehoseMaxRecords is the PutRecordBatch limit on records per request.
+	firehoseMaxRecords = 500
+	firehoseTarget     = "Firehose_20150804.PutRecordBatch"
+)
+
+type ocsfExporter struct {
+	cfg      *Config
+	settings exporter.Settings
+	client   *http.Client
+}
+
+func newOCSFExporter(cfg *Config, set exporter.Settings) *ocsfExporter {
+	return &ocsfExporter{cfg: cfg, settings: set}
+}
+
+func (e *ocsfExporter) start(ctx context.Context, host component.Host) error {
+	client, err := e.cfg.ToClient(ctx, host.GetExtensions(), e.settings.TelemetrySettings)
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+	e.client = client
+	return nil
+}
+
+// consumeLogs sends the findings of logs. Records that fail schema validation
+// are dropped and logged, since retrying them cannot succeed.
+func (e *ocsfExporter) consumeLogs(ctx context.Context, logs plog.Logs) error {
+	findings, refs, err := convert(logs)
+	if err != nil {
+		e.settings.Logger.Warn("dropping records that are not valid OCSF compliance findings", zap.Error(err))
+	}
+	if len(findings) == 0 {
+		return nil
+	}
+
+	switch e.cfg.Encoding {
+	case EncodingFirehose:
+		return e.putFindings(ctx, logs, findings, refs)
+	case EncodingJSON:
+		data, err := json.Marshal(findings)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		_, err = e.post(ctx, data, "application/json", nil)
+		return err
+	default:
+		data, err := ndjson(findings)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		_, err = e.post(ctx, data, "application/x-ndjson", nil)
+		return err
+	}
+}
+
+func ndjson(findings []ocsf.ComplianceFinding) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, f := range findings {
+		if err := enc.Encode(f); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+type putRecordBatchRequest struct {
+	DeliveryStreamName string           `json:"DeliveryStreamName"`
+	Records            []firehoseRecord `json:"Records"`
+}
+
+type firehoseRecord struct {
+	// Data is base64-encoded by encoding/json, as the API expects.
+	Data []byte `json:"Data"`
+}
+
+type putRecordBatchResponse struct {
+	FailedPutCount   int                    `json:"FailedPutCount"`
+	RequestResponses []putRecordBatchResult `json:"RequestResponses"`
+}
+
+// putRecordBatchResult is the outcome of one record, in request order. A
+// record was rejected when ErrorCode is set.
+type putRecordBatchResult struct {
+	RecordID     string `json:"RecordId,omitempty"`
+	ErrorCode    string `json:"ErrorCode,omitempty"`
+	ErrorMessage string `json:"ErrorMessage,omitempty"`
+}
+
+// putFindings puts the findings in batches of firehoseMaxRecords. When only
+// some records are delivered, the returned error carries the records of logs
+// that were rejected or not sent, so only those are retried.
+func (e *ocsfExporter) putFindings(ctx context.Context, logs plog.Logs, findings []ocsf.ComplianceFinding, refs []recordRef) error {
+	var failed []recordRef
+	var errs error
+	for start := 0; start < len(findings); start += firehoseMaxRecords {
+		end := min(start+firehoseMaxRecords, len(findings))
+		rejected, err := e.putRecordBatch(ctx, findings[start:end])
+		if err != nil {
+			if start == 0 || consumererror.IsPermanent(err) {
+				return err
+			}
+			// The request failed as a whole, so resend it and every later batch.
+			failed = append(failed, refs[start:]...)
+			errs = errors.Join(errs, err)
+			break
+		}
+		if len(rejected) > 0 {
+			for _, i := range rejected {
+				failed = append(failed, refs[start+i])
+			}
+			errs = errors.Join(errs, fmt.Errorf("firehose rejected %d of %d records", len(rejected), end-start))
+		}
+	}
+	if errs == nil {
+		return nil
+	}
+	return consumererror.NewLogs(errs, subset(logs, failed))
+}
+
+// putRecordBatch puts one newline-terminated JSON record per finding, so a
+// delivery stream with record format conversion can write them as Parquet for
+// Amazon Security Lake, and returns the indexes of the rejected findings.
+// Requests are expected to be signed by a SigV4 authenticator extension.
+func (e *ocsfExporter) putRecordBatch(ctx context.Context, findings []ocsf.ComplianceFinding) ([]int, error) {
+	req := putRecordBatchRequest{DeliveryStreamName: e.cfg.DeliveryStream}
+	for _, f := range findings {
+		data, err := json.Marshal(f)
+		if err != nil {
+			return nil, consumererror.NewPermanent(err)
+		}
+		req.Records = append(req.Records, firehoseRecord{Data: append(data, '\n')})
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, consumererror.NewPermanent(err)
+	}
+
+	respBody, err := e.post(ctx, body, "application/x-amz-json-1.1", map[string]string{"X-Amz-Target": firehoseTarget})
+	if err != nil {
+		return nil, err
+	}
+	var resp putRecordBatchResponse
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode PutRecordBatch response: %w", err)
+	}
+	if resp.FailedPutCount == 0 {
+		return nil, nil
+	}
+	if len(resp.RequestResponses) != len(findings) {
+		return nil, fmt.Errorf("firehose rejected %d of %d records", resp.FailedPutCount, len(findings))
+	}
+	var rejected []int
+	for i, r := range resp.RequestResponses {
+		if r.ErrorCode != "" {
+			rejected = append(rejected, i)
+		}
+	}
+	return rejected, nil
+}
+
+// post sends data to the endpoint and returns the response body. Client errors
+// other than throttling are permanent, so the exporter does not retry them.
+func (e *ocsfExporter) post(ctx context.Context, data []byte, contentType string, headers map[string]string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.cfg.Endpoint, bytes.NewReader(data))
+	if err != nil {
+		return nil, consumererror.NewPermanent(err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send OCSF findings: %w", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return body, nil
+	}
+	err = fmt.Errorf("failed to send OCSF findings: %s", resp.Status)
+	if resp.StatusCode >= 400 && resp.StatusCode <= 499 &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+		return nil, consumererror.NewPermanent(err)
+	}
+	return nil, err
+}
@@ -0,0 +1,208 @@
+package ocsfexporter
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ocsf "github.com/telophasehq/go-ocsf/ocsf/v1_5_0"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+func newTestExporter(t *testing.T, cfg *Config) *ocsfExporter {
+	t.Helper()
+	e := newOCSFExporter(cfg, exportertest.NewNopSettings(componentType))
+	require.NoError(t, e.start(context.Background(), componenttest.NewNopHost()))
+	return e
+}
+
+func TestConsumeLogsNDJSON(t *testing.T) {
+	var events []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "application/x-ndjson", req.Header.Get("Content-Type"))
+		scanner := bufio.NewScanner(req.Body)
+		for scanner.Scan() {
+			var event map[string]any
+			assert.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+			events = append(events, event)
+		}
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	e := newTestExporter(t, cfg)
+
+	// The record without a rule ID is dropped rather than failing the batch.
+	require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs(
+		validRecord(),
+		validRecord(),
+		map[string]any{proofwatch.COMPLIANCE_FRAMEWORKS: []any{"NIST-800-53"}},
+	)))
+	require.Len(t, events, 2)
+	assert.InDelta(t, 200301, events[0]["type_uid"], 0)
+}
+
+func TestConsumeLogsJSON(t *testing.T) {
+	var events []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&events))
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	cfg.Encoding = EncodingJSON
+	e := newTestExporter(t, cfg)
+
+	require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs(validRecord(), validRecord())))
+	assert.Len(t, events, 2)
+}
+
+func TestConsumeLogsFirehose(t *testing.T) {
+	var requests []putRecordBatchRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "application/x-amz-json-1.1", req.Header.Get("Content-Type"))
+		assert.Equal(t, firehoseTarget, req.Header.Get("X-Amz-Target"))
+		var body putRecordBatchRequest
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		requests = append(requests, body)
+		_ = json.NewEncoder(w).Encode(putRecordBatchResponse{})
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	cfg.Encoding = EncodingFirehose
+	cfg.DeliveryStream = "evidence"
+	e := newTestExporter(t, cfg)
+
+	records := make([]map[string]any, firehoseMaxRecords+1)
+	for i := range records {
+		records[i] = validRecord()
+	}
+	require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs(records...)))
+	require.Len(t, requests, 2)
+	assert.Equal(t, "evidence", requests[0].DeliveryStreamName)
+	assert.Len(t, requests[0].Records, firehoseMaxRecords)
+	assert.Len(t, requests[1].Records, 1)
+
+	data := requests[1].Records[0].Data
+	assert.Equal(t, byte('\n'), data[len(data)-1])
+	var event ocsf.ComplianceFinding
+	require.NoError(t, json.Unmarshal(data, &event))
+	assert.Equal(t, classUID, event.ClassUid)
+}
+
+func TestConsumeLogsFirehosePartialFailure(t *testing.T) {
+	tests := []struct {
+		name      string
+		records   int
+		respond   func(w http.ResponseWriter, call int, records int)
+		wantErr   string
+		wantRules []string
+	}{
+		{
+			name:    "rejected records",
+			records: 3,
+			respond: func(w http.ResponseWriter, _ int, records int) {
+				resp := putRecordBatchResponse{FailedPutCount: 1, RequestResponses: make([]putRecordBatchResult, records)}
+				resp.RequestResponses[1] = putRecordBatchResult{ErrorCode: "ServiceUnavailableException", ErrorMessage: "Slow down."}
+				_ = json.NewEncoder(w).Encode(resp)
+			},
+			wantErr:   "firehose rejected 1 of 3 records",
+			wantRules: []string{"rule-1"},
+		},
+		{
+			name:    "later batch fails",
+			records: firehoseMaxRecords + 2,
+			respond: func(w http.ResponseWriter, call int, _ int) {
+				if call > 0 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				_ = json.NewEncoder(w).Encode(putRecordBatchResponse{})
+			},
+			wantErr:   "failed to send OCSF findings: 503 Service Unavailable",
+			wantRules: []string{fmt.Sprintf("rule-%d", firehoseMaxRecords), fmt.Sprintf("rule-%d", firehoseMaxRecords+1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				var body putRecordBatchRequest
+				assert.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+				tt.respond(w, calls, len(body.Records))
+				calls++
+			}))
+			defer server.Close()
+
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = server.URL
+			cfg.Encoding = EncodingFirehose
+			cfg.DeliveryStream = "evidence"
+			e := newTestExporter(t, cfg)
+
+			records := make([]map[string]any, tt.records)
+			for i := range records {
+				records[i] = validRecord()
+				records[i][proofwatch.POLICY_RULE_ID] = fmt.Sprintf("rule-%d", i)
+			}
+			err := e.consumeLogs(context.Background(), evidenceLogs(records...))
+			require.ErrorContains(t, err, tt.wantErr)
+			assert.False(t, consumererror.IsPermanent(err))
+
+			var failed consumererror.Logs
+			require.ErrorAs(t, err, &failed)
+			var rules []string
+			lrs := failed.Data().ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+			for i := 0; i < lrs.Len(); i++ {
+				rule, _ := lrs.At(i).Attributes().Get(proofwatch.POLICY_RULE_ID)
+				rules = append(rules, rule.Str())
+			}
+			assert.Equal(t, tt.wantRules, rules)
+		})
+	}
+}
+
+func TestConsumeLogsErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		wantPermanent bool
+	}{
+		{name: "bad request", status: http.StatusBadRequest, wantPermanent: true},
+		{name: "throttled", status: http.StatusTooManyRequests},
+		{name: "unavailable", status: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = server.URL
+			e := newTestExporter(t, cfg)
+
+			err := e.consumeLogs(context.Background(), evidenceLogs(validRecord()))
+			require.Error(t, err)
+			assert.Equal(t, tt.wantPermanent, consumererror.IsPermanent(err))
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package ocsfexporter
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const stability = component.StabilityLevelAlpha
+
+var componentType = component.MustNewType("ocsf")
+
+// NewFactory creates a factory for the OCSF exporter.
+func NewFactory() exporter.Factory {
+	return exporter.NewFactory(
+		componentType,
+		createDefaultConfig,
+		exporter.WithLogs(createLogsExporter, stability),
+	)
+}
+
+func createLogsExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Logs, error) {
+	oCfg := cfg.(*Config)
+	exp := newOCSFExporter(oCfg, set)
+	return exporterhelper.NewLogs(ctx, set, cfg,
+		exp.consumeLogs,
+		exporterhelper.WithStart(exp.start),
+		exporterhelper.WithRetry(oCfg.BackOffConfig),
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+	)
+}
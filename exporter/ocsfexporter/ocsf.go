@@ -0,0 +1,349 @@
+package ocsfexporter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	ocsf "github.com/telophasehq/go-ocsf/ocsf/v1_5_0"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+// OCSF 1.5.0 Compliance Finding, reported with the Create activity.
+const (
+	schemaVersion = "1.5.0"
+
+	categoryUID  int32 = 2
+	categoryName       = "Findings"
+	classUID     int32 = 2003
+	className          = "Compliance Finding"
+	activityID   int32 = 1
+	activityName       = "Create"
+	typeUID            = int64(classUID)*100 + int64(activityID)
+	typeName           = className + ": " + activityName
+
+	defaultProductName = "ComplyBeacon"
+)
+
+// findingNamespace seeds the name-based finding UIDs, so repeated evaluations
+// of a rule on a target for a control update the same finding.
+var findingNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://github.com/complytime/complybeacon/ocsf"))
+
+// severities maps compliance.risk.level to the OCSF severity_id.
+var severities = map[string]int32{
+	"Informational": 1,
+	"Low":           2,
+	"Medium":        3,
+	"High":          4,
+	"Critical":      5,
+}
+
+// complianceStatuses maps policy.evaluation.result to the OCSF compliance
+// status. Other results are reported as Other with the result as caption.
+var complianceStatuses = map[string]struct {
+	id      int32
+	caption string
+}{
+	"":             {0, "Unknown"},
+	"Unknown":      {0, "Unknown"},
+	"Passed":       {1, "Pass"},
+	"Needs Review": {2, "Warning"},
+	"Failed":       {3, "Fail"},
+}
+
+const complianceStatusOther int32 = 99
+
+// Finding status_id values.
+const (
+	findingStatusNew        int32 = 1
+	findingStatusSuppressed int32 = 3
+	findingStatusResolved   int32 = 4
+)
+
+var findingStatusNames = map[int32]string{
+	findingStatusNew:        "New",
+	findingStatusSuppressed: "Suppressed",
+	findingStatusResolved:   "Resolved",
+}
+
+// The activity_id, severity_id and status_id values defined by OCSF 1.5.0
+// for the Compliance Finding class.
+var (
+	activityIDs = map[int32]bool{0: true, 1: true, 2: true, 3: true, 99: true}
+	severityIDs = map[int32]bool{0: true, 1: true, 2: true, 3: true, 4: true, 5: true, 6: true, 99: true}
+	statusIDs   = map[int32]bool{0: true, 1: true, 2: true, 3: true, 4: true, 5: true, 6: true, 99: true}
+)
+
+// mappedAttributes are the record attributes carried by OCSF fields. All other
+// attributes are kept under unmapped.
+var mappedAttributes = map[string]bool{
+	proofwatch.POLICY_RULE_ID:                          true,
+	proofwatch.POLICY_RULE_NAME:                        true,
+	proofwatch.POLICY_RULE_URI:                         true,
+	proofwatch.POLICY_ENGINE_NAME:                      true,
+	proofwatch.POLICY_ENGINE_VERSION:                   true,
+	proofwatch.POLICY_EVALUATION_RESULT:                true,
+	proofwatch.POLICY_EVALUATION_MESSAGE:               true,
+	proofwatch.POLICY_TARGET_ID:                        true,
+	proofwatch.POLICY_TARGET_NAME:                      true,
+	proofwatch.POLICY_TARGET_TYPE:                      true,
+	proofwatch.COMPLIANCE_CONTROL_ID:                   true,
+	proofwatch.COMPLIANCE_CONTROL_CATALOG_ID:           true,
+	proofwatch.COMPLIANCE_FRAMEWORKS:                   true,
+	proofwatch.COMPLIANCE_REQUIREMENTS:                 true,
+	proofwatch.COMPLIANCE_RISK_LEVEL:                   true,
+	proofwatch.COMPLIANCE_REMEDIATION_DESCRIPTION:      true,
+	proofwatch.COMPLIANCE_REMEDIATION_EXCEPTION_ACTIVE: true,
+}
+
+// recordRef locates the log record a finding was built from.
+type recordRef struct {
+	resource, scope, record int
+}
+
+// convert maps every log record to a Compliance Finding, and returns the
+// source of each finding alongside it. Records that do not produce a valid
+// finding are returned as errors instead.
+func convert(logs plog.Logs) ([]ocsf.ComplianceFinding, []recordRef, error) {
+	var findings []ocsf.ComplianceFinding
+	var refs []recordRef
+	var errs error
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		sls := logs.ResourceLogs().At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				finding := newFinding(lrs.At(k))
+				if err := validate(&finding); err != nil {
+					errs = errors.Join(errs, err)
+					continue
+				}
+				findings = append(findings, finding)
+				refs = append(refs, recordRef{resource: i, scope: j, record: k})
+			}
+		}
+	}
+	return findings, refs, errs
+}
+
+// subset copies the records at refs, which must be in order, into new logs
+// that keep their resource and scope.
+func subset(logs plog.Logs, refs []recordRef) plog.Logs {
+	out := plog.NewLogs()
+	var rl plog.ResourceLogs
+	var sl plog.ScopeLogs
+	last := recordRef{resource: -1, scope: -1}
+	for _, ref := range refs {
+		srcRL := logs.ResourceLogs().At(ref.resource)
+		if ref.resource != last.resource {
+			rl = out.ResourceLogs().AppendEmpty()
+			srcRL.Resource().CopyTo(rl.Resource())
+			rl.SetSchemaUrl(srcRL.SchemaUrl())
+			last.scope = -1
+		}
+		srcSL := srcRL.ScopeLogs().At(ref.scope)
+		if ref.scope != last.scope {
+			sl = rl.ScopeLogs().AppendEmpty()
+			srcSL.Scope().CopyTo(sl.Scope())
+			sl.SetSchemaUrl(srcSL.SchemaUrl())
+		}
+		srcSL.LogRecords().At(ref.record).CopyTo(sl.LogRecords().AppendEmpty())
+		last = ref
+	}
+	return out
+}
+
+func newFinding(record plog.LogRecord) ocsf.ComplianceFinding {
+	attrs := record.Attributes()
+	ruleID := str(attrs, proofwatch.POLICY_RULE_ID)
+	targetID := str(attrs, proofwatch.POLICY_TARGET_ID)
+	controlID := str(attrs, proofwatch.COMPLIANCE_CONTROL_ID)
+	result := str(attrs, proofwatch.POLICY_EVALUATION_RESULT)
+
+	f := ocsf.ComplianceFinding{
+		ActivityId:   activityID,
+		ActivityName: ptr(activityName),
+		CategoryUid:  categoryUID,
+		CategoryName: ptr(categoryName),
+		ClassUid:     classUID,
+		ClassName:    ptr(className),
+		TypeUid:      typeUID,
+		TypeName:     ptr(typeName),
+		Time:         recordTime(record).UnixMilli(),
+		Message:      optional(str(attrs, proofwatch.POLICY_EVALUATION_MESSAGE)),
+		Metadata: ocsf.Metadata{
+			Uid:     ptr(uuid.NewString()),
+			Version: schemaVersion,
+			Product: ocsf.Product{
+				Name:    optional(str(attrs, proofwatch.POLICY_ENGINE_NAME)),
+				Version: optional(str(attrs, proofwatch.POLICY_ENGINE_VERSION)),
+			},
+		},
+		FindingInfo: ocsf.FindingInformation{
+			Title:  optional(str(attrs, proofwatch.POLICY_RULE_NAME)),
+			Desc:   optional(str(attrs, proofwatch.POLICY_EVALUATION_MESSAGE)),
+			SrcUrl: optional(str(attrs, proofwatch.POLICY_RULE_URI)),
+		},
+		Compliance: ocsf.Compliance{
+			Control:      optional(controlID),
+			Standards:    strs(attrs, proofwatch.COMPLIANCE_FRAMEWORKS),
+			Requirements: strs(attrs, proofwatch.COMPLIANCE_REQUIREMENTS),
+		},
+	}
+	if f.Metadata.Product.Name == nil {
+		f.Metadata.Product.Name = ptr(defaultProductName)
+	}
+	if ruleID != "" {
+		f.FindingInfo.Uid = uuid.NewSHA1(findingNamespace, []byte(ruleID+"|"+targetID+"|"+controlID)).String()
+	}
+	if f.FindingInfo.Title == nil {
+		f.FindingInfo.Title = optional(ruleID)
+	}
+	if len(f.Compliance.Standards) == 0 {
+		if catalog := str(attrs, proofwatch.COMPLIANCE_CONTROL_CATALOG_ID); catalog != "" {
+			f.Compliance.Standards = []string{catalog}
+		}
+	}
+
+	risk := str(attrs, proofwatch.COMPLIANCE_RISK_LEVEL)
+	f.SeverityId = severities[risk]
+	f.Severity = ptr(risk)
+	if f.SeverityId == 0 {
+		f.Severity = ptr("Unknown")
+	}
+
+	if status, ok := complianceStatuses[result]; ok {
+		f.Compliance.StatusId, f.Compliance.Status = ptr(status.id), ptr(status.caption)
+	} else {
+		f.Compliance.StatusId, f.Compliance.Status = ptr(complianceStatusOther), ptr(result)
+	}
+
+	var statusID int32
+	switch {
+	case boolean(attrs, proofwatch.COMPLIANCE_REMEDIATION_EXCEPTION_ACTIVE):
+		statusID = findingStatusSuppressed
+	case result == "Passed":
+		statusID = findingStatusResolved
+	default:
+		statusID = findingStatusNew
+	}
+	f.StatusId, f.Status = ptr(statusID), ptr(findingStatusNames[statusID])
+
+	if targetID != "" {
+		f.Resources = []ocsf.ResourceDetails{{
+			Uid:  ptr(targetID),
+			Name: optional(str(attrs, proofwatch.POLICY_TARGET_NAME)),
+			Type: optional(str(attrs, proofwatch.POLICY_TARGET_TYPE)),
+		}}
+	}
+	if desc := str(attrs, proofwatch.COMPLIANCE_REMEDIATION_DESCRIPTION); desc != "" {
+		f.Remediation = &ocsf.Remediation{Desc: desc}
+	}
+
+	unmapped := make(map[string]any)
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		if !mappedAttributes[k] {
+			unmapped[k] = v.AsRaw()
+		}
+		return true
+	})
+	if len(unmapped) > 0 {
+		if data, err := json.Marshal(unmapped); err == nil {
+			f.Unmapped = ptr(string(data))
+		}
+	}
+	return f
+}
+
+// validate checks the finding against the requirements of the OCSF schema.
+func validate(f *ocsf.ComplianceFinding) error {
+	switch {
+	case f.FindingInfo.Uid == "":
+		return fmt.Errorf("record has no %s for finding_info.uid", proofwatch.POLICY_RULE_ID)
+	case f.ClassUid != classUID || f.CategoryUid != categoryUID:
+		return fmt.Errorf("finding %s has class_uid %d and category_uid %d, want %d and %d",
+			f.FindingInfo.Uid, f.ClassUid, f.CategoryUid, classUID, categoryUID)
+	case !activityIDs[f.ActivityId]:
+		return fmt.Errorf("finding %s has unknown activity_id %d", f.FindingInfo.Uid, f.ActivityId)
+	case f.TypeUid != int64(f.ClassUid)*100+int64(f.ActivityId):
+		return fmt.Errorf("finding %s has type_uid %d, want class_uid * 100 + activity_id", f.FindingInfo.Uid, f.TypeUid)
+	case !severityIDs[f.SeverityId]:
+		return fmt.Errorf("finding %s has unknown severity_id %d", f.FindingInfo.Uid, f.SeverityId)
+	case f.StatusId == nil || !statusIDs[*f.StatusId]:
+		return fmt.Errorf("finding %s has no known status_id", f.FindingInfo.Uid)
+	case f.Metadata.Product.Name == nil || *f.Metadata.Product.Name == "":
+		return fmt.Errorf("finding %s has no metadata.product.name", f.FindingInfo.Uid)
+	case f.Metadata.Version == "":
+		return fmt.Errorf("finding %s has no metadata.version", f.FindingInfo.Uid)
+	case len(f.Compliance.Standards) == 0:
+		return fmt.Errorf("finding %s has no %s or %s for compliance.standards",
+			f.FindingInfo.Uid, proofwatch.COMPLIANCE_FRAMEWORKS, proofwatch.COMPLIANCE_CONTROL_CATALOG_ID)
+	case f.Time <= 0:
+		return fmt.Errorf("finding %s has no timestamp", f.FindingInfo.Uid)
+	}
+	if err := f.ValidateObservables(); err != nil {
+		return fmt.Errorf("finding %s: %w", f.FindingInfo.Uid, err)
+	}
+	return nil
+}
+
+func recordTime(record plog.LogRecord) time.Time {
+	if record.Timestamp() != 0 {
+		return record.Timestamp().AsTime()
+	}
+	return record.ObservedTimestamp().AsTime()
+}
+
+func str(attrs pcommon.Map, key string) string {
+	if v, ok := attrs.Get(key); ok {
+		return v.AsString()
+	}
+	return ""
+}
+
+// strs reads a string or string slice attribute.
+func strs(attrs pcommon.Map, key string) []string {
+	v, ok := attrs.Get(key)
+	if !ok {
+		return nil
+	}
+	if v.Type() != pcommon.ValueTypeSlice {
+		if s := v.AsString(); s != "" {
+			return []string{s}
+		}
+		return nil
+	}
+	out := make([]string, 0, v.Slice().Len())
+	for i := 0; i < v.Slice().Len(); i++ {
+		out = append(out, v.Slice().At(i).AsString())
+	}
+	return out
+}
+
+func boolean(attrs pcommon.Map, key string) bool {
+	v, ok := attrs.Get(key)
+	if !ok {
+		return false
+	}
+	if v.Type() == pcommon.ValueTypeBool {
+		return v.Bool()
+	}
+	return v.AsString() == "true"
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// optional returns nil for an empty string, so the field is omitted.
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
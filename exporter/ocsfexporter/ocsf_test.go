@@ -0,0 +1,233 @@
+package ocsfexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ocsf "github.com/telophasehq/go-ocsf/ocsf/v1_5_0"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+var recordTimestamp = time.Date(2026, 6, 2, 12, 0, 0, 0, time.UTC)
+
+func evidenceLogs(records ...map[string]any) plog.Logs {
+	logs := plog.NewLogs()
+	lrs := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, attrs := range records {
+		record := lrs.AppendEmpty()
+		record.SetTimestamp(pcommon.NewTimestampFromTime(recordTimestamp))
+		_ = record.Attributes().FromRaw(attrs)
+	}
+	return logs
+}
+
+func validRecord() map[string]any {
+	return map[string]any{
+		proofwatch.POLICY_RULE_ID:           "sshd-disable-root-login",
+		proofwatch.POLICY_EVALUATION_RESULT: "Failed",
+		proofwatch.COMPLIANCE_FRAMEWORKS:    []any{"NIST-800-53"},
+	}
+}
+
+func TestConvert(t *testing.T) {
+	findings, _, err := convert(evidenceLogs(map[string]any{
+		proofwatch.POLICY_RULE_ID:                     "sshd-disable-root-login",
+		proofwatch.POLICY_RULE_NAME:                   "Disable SSH root login",
+		proofwatch.POLICY_RULE_URI:                    "https://example.com/rules/sshd",
+		proofwatch.POLICY_ENGINE_NAME:                 "OpenSCAP",
+		proofwatch.POLICY_ENGINE_VERSION:              "1.4.0",
+		proofwatch.POLICY_EVALUATION_RESULT:           "Failed",
+		proofwatch.POLICY_EVALUATION_MESSAGE:          "PermitRootLogin is yes",
+		proofwatch.POLICY_TARGET_ID:                   "web-01",
+		proofwatch.POLICY_TARGET_NAME:                 "web-01.example.com",
+		proofwatch.POLICY_TARGET_TYPE:                 "host",
+		proofwatch.COMPLIANCE_CONTROL_ID:              "AC-6",
+		proofwatch.COMPLIANCE_FRAMEWORKS:              []any{"NIST-800-53", "PCI-DSS"},
+		proofwatch.COMPLIANCE_REQUIREMENTS:            []any{"AC-6(2)"},
+		proofwatch.COMPLIANCE_RISK_LEVEL:              "High",
+		proofwatch.COMPLIANCE_REMEDIATION_DESCRIPTION: "Set PermitRootLogin no",
+		proofwatch.COMPLIANCE_STATUS:                  "Non-Compliant",
+	}))
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	f := findings[0]
+
+	assert.Equal(t, int64(200301), f.TypeUid)
+	assert.Equal(t, int32(2003), f.ClassUid)
+	assert.Equal(t, int32(2), f.CategoryUid)
+	assert.Equal(t, recordTimestamp.UnixMilli(), f.Time)
+	assert.Equal(t, int32(4), f.SeverityId)
+	assert.Equal(t, ptr("High"), f.Severity)
+	assert.Equal(t, ptr(findingStatusNew), f.StatusId)
+	assert.Equal(t, ptr("New"), f.Status)
+	assert.Equal(t, schemaVersion, f.Metadata.Version)
+	assert.Equal(t, ocsf.Product{Name: ptr("OpenSCAP"), Version: ptr("1.4.0")}, f.Metadata.Product)
+	assert.Equal(t, ptr("Disable SSH root login"), f.FindingInfo.Title)
+	assert.Equal(t, ptr("https://example.com/rules/sshd"), f.FindingInfo.SrcUrl)
+	assert.Equal(t, ocsf.Compliance{
+		Control:      ptr("AC-6"),
+		Standards:    []string{"NIST-800-53", "PCI-DSS"},
+		Requirements: []string{"AC-6(2)"},
+		StatusId:     ptr(int32(3)),
+		Status:       ptr("Fail"),
+	}, f.Compliance)
+	assert.Equal(t, []ocsf.ResourceDetails{{Uid: ptr("web-01"), Name: ptr("web-01.example.com"), Type: ptr("host")}}, f.Resources)
+	assert.Equal(t, &ocsf.Remediation{Desc: "Set PermitRootLogin no"}, f.Remediation)
+	require.NotNil(t, f.Unmapped)
+	assert.JSONEq(t, `{"compliance.status":"Non-Compliant"}`, *f.Unmapped)
+
+	// The same rule, target and control always map to the same finding.
+	again, _, err := convert(evidenceLogs(map[string]any{
+		proofwatch.POLICY_RULE_ID:        "sshd-disable-root-login",
+		proofwatch.POLICY_TARGET_ID:      "web-01",
+		proofwatch.COMPLIANCE_CONTROL_ID: "AC-6",
+		proofwatch.COMPLIANCE_FRAMEWORKS: []any{"NIST-800-53"},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, f.FindingInfo.Uid, again[0].FindingInfo.Uid)
+	assert.NotEqual(t, f.Metadata.Uid, again[0].Metadata.Uid)
+}
+
+func TestConvertStatus(t *testing.T) {
+	tests := []struct {
+		name             string
+		attrs            map[string]any
+		wantStatusID     int32
+		wantCompliance   int32
+		wantComplianceAs string
+	}{
+		{
+			name:             "passed",
+			attrs:            map[string]any{proofwatch.POLICY_EVALUATION_RESULT: "Passed"},
+			wantStatusID:     findingStatusResolved,
+			wantCompliance:   1,
+			wantComplianceAs: "Pass",
+		},
+		{
+			name:             "needs review",
+			attrs:            map[string]any{proofwatch.POLICY_EVALUATION_RESULT: "Needs Review"},
+			wantStatusID:     findingStatusNew,
+			wantCompliance:   2,
+			wantComplianceAs: "Warning",
+		},
+		{
+			name:             "not applicable",
+			attrs:            map[string]any{proofwatch.POLICY_EVALUATION_RESULT: "Not Applicable"},
+			wantStatusID:     findingStatusNew,
+			wantCompliance:   complianceStatusOther,
+			wantComplianceAs: "Not Applicable",
+		},
+		{
+			name: "exempt",
+			attrs: map[string]any{
+				proofwatch.POLICY_EVALUATION_RESULT:                "Failed",
+				proofwatch.COMPLIANCE_REMEDIATION_EXCEPTION_ACTIVE: true,
+			},
+			wantStatusID:     findingStatusSuppressed,
+			wantCompliance:   3,
+			wantComplianceAs: "Fail",
+		},
+		{
+			name:             "no result",
+			attrs:            map[string]any{},
+			wantStatusID:     findingStatusNew,
+			wantCompliance:   0,
+			wantComplianceAs: "Unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.attrs[proofwatch.POLICY_RULE_ID] = "r1"
+			tt.attrs[proofwatch.COMPLIANCE_CONTROL_CATALOG_ID] = "NIST-800-53"
+
+			findings, _, err := convert(evidenceLogs(tt.attrs))
+			require.NoError(t, err)
+			require.Len(t, findings, 1)
+			assert.Equal(t, ptr(tt.wantStatusID), findings[0].StatusId)
+			assert.Equal(t, ptr(tt.wantCompliance), findings[0].Compliance.StatusId)
+			assert.Equal(t, ptr(tt.wantComplianceAs), findings[0].Compliance.Status)
+			assert.Equal(t, []string{"NIST-800-53"}, findings[0].Compliance.Standards)
+			assert.Equal(t, ptr("Unknown"), findings[0].Severity)
+		})
+	}
+}
+
+func TestConvertValidation(t *testing.T) {
+	logs := evidenceLogs(
+		validRecord(),
+		map[string]any{proofwatch.COMPLIANCE_FRAMEWORKS: []any{"NIST-800-53"}},
+		map[string]any{proofwatch.POLICY_RULE_ID: "no-standard"},
+	)
+	noTime := validRecord()
+	_ = logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().AppendEmpty().Attributes().FromRaw(noTime)
+
+	findings, _, err := convert(logs)
+	assert.Len(t, findings, 1)
+	assert.ErrorContains(t, err, "record has no policy.rule.id for finding_info.uid")
+	assert.ErrorContains(t, err, "has no compliance.frameworks or compliance.control.catalog.id for compliance.standards")
+	assert.ErrorContains(t, err, "has no timestamp")
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(f *ocsf.ComplianceFinding)
+		wantErr string
+	}{
+		{name: "valid", mutate: func(*ocsf.ComplianceFinding) {}},
+		{
+			name:    "class",
+			mutate:  func(f *ocsf.ComplianceFinding) { f.ClassUid = 2004 },
+			wantErr: "has class_uid 2004 and category_uid 2, want 2003 and 2",
+		},
+		{
+			name:    "activity",
+			mutate:  func(f *ocsf.ComplianceFinding) { f.ActivityId = 7 },
+			wantErr: "has unknown activity_id 7",
+		},
+		{
+			name:    "type",
+			mutate:  func(f *ocsf.ComplianceFinding) { f.TypeUid = 200302 },
+			wantErr: "has type_uid 200302, want class_uid * 100 + activity_id",
+		},
+		{
+			name:    "severity",
+			mutate:  func(f *ocsf.ComplianceFinding) { f.SeverityId = 7 },
+			wantErr: "has unknown severity_id 7",
+		},
+		{
+			name:    "status",
+			mutate:  func(f *ocsf.ComplianceFinding) { f.StatusId = nil },
+			wantErr: "has no known status_id",
+		},
+		{
+			name:    "product",
+			mutate:  func(f *ocsf.ComplianceFinding) { f.Metadata.Product.Name = nil },
+			wantErr: "has no metadata.product.name",
+		},
+		{
+			name:    "version",
+			mutate:  func(f *ocsf.ComplianceFinding) { f.Metadata.Version = "" },
+			wantErr: "has no metadata.version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFinding(evidenceLogs(validRecord()).ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0))
+			tt.mutate(&f)
+			err := validate(&f)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
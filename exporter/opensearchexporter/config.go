@@ -0,0 +1,107 @@
+package opensearchexporter
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configretry"
+)
+
+const (
+	// FlavorOpenSearch manages the lifecycle with an Index State Management policy.
+	FlavorOpenSearch = "opensearch"
+	// FlavorElasticsearch manages the lifecycle with an Index Lifecycle Management policy.
+	FlavorElasticsearch = "elasticsearch"
+
+	defaultDataStream   = "complybeacon-evidence"
+	defaultRolloverAge  = 24 * time.Hour
+	defaultRolloverSize = "50gb"
+	defaultRetention    = 365 * 24 * time.Hour
+)
+
+var (
+	_ component.Config = (*Config)(nil)
+
+	// byteSizePattern matches the byte sizes both search engines accept, such as "50gb".
+	byteSizePattern = regexp.MustCompile(`^[1-9][0-9]*(b|kb|mb|gb|tb|pb)$`)
+	// dataStreamPattern matches the data stream names both search engines accept.
+	dataStreamPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+)
+
+// Config defines the configuration for the OpenSearch exporter.
+type Config struct {
+	confighttp.ClientConfig   `mapstructure:",squash"`
+	configretry.BackOffConfig `mapstructure:"retry_on_failure"`
+
+	// Flavor selects the search engine: "opensearch" or "elasticsearch".
+	Flavor string `mapstructure:"flavor"`
+
+	// DataStream is the data stream evidence is written to. The index template
+	// and lifecycle policy are named after it.
+	DataStream string `mapstructure:"data_stream"`
+
+	// ManageTemplate installs the index template and lifecycle policy before
+	// the first write, and updates them to match the configuration.
+	ManageTemplate bool `mapstructure:"manage_template"`
+
+	// Lifecycle configures the rollover and retention of backing indices.
+	Lifecycle LifecycleConfig `mapstructure:"lifecycle"`
+}
+
+// LifecycleConfig configures the lifecycle policy.
+type LifecycleConfig struct {
+	// RolloverAge rolls the write index over once it is this old.
+	RolloverAge time.Duration `mapstructure:"rollover_age"`
+
+	// RolloverSize rolls the write index over once its primary shards hold
+	// this much data, such as "50gb".
+	RolloverSize string `mapstructure:"rollover_size"`
+
+	// Retention deletes backing indices once they are this old. Zero keeps them.
+	Retention time.Duration `mapstructure:"retention"`
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		ClientConfig:   confighttp.NewDefaultClientConfig(),
+		BackOffConfig:  configretry.NewDefaultBackOffConfig(),
+		Flavor:         FlavorOpenSearch,
+		DataStream:     defaultDataStream,
+		ManageTemplate: true,
+		Lifecycle: LifecycleConfig{
+			RolloverAge:  defaultRolloverAge,
+			RolloverSize: defaultRolloverSize,
+			Retention:    defaultRetention,
+		},
+	}
+}
+
+// Validate checks the exporter configuration is valid.
+func (c *Config) Validate() error {
+	if c.Endpoint == "" {
+		return errors.New("endpoint must not be empty")
+	}
+	if c.Flavor != FlavorOpenSearch && c.Flavor != FlavorElasticsearch {
+		return fmt.Errorf("flavor %q is not one of %q or %q", c.Flavor, FlavorOpenSearch, FlavorElasticsearch)
+	}
+	if !dataStreamPattern.MatchString(c.DataStream) {
+		return fmt.Errorf("data_stream %q is not a valid data stream name", c.DataStream)
+	}
+	if c.Lifecycle.RolloverAge < 0 {
+		return errors.New("lifecycle.rollover_age must not be negative")
+	}
+	if c.Lifecycle.RolloverSize != "" && !byteSizePattern.MatchString(c.Lifecycle.RolloverSize) {
+		return fmt.Errorf("lifecycle.rollover_size %q is not a byte size such as 50gb", c.Lifecycle.RolloverSize)
+	}
+	if c.Lifecycle.RolloverAge == 0 && c.Lifecycle.RolloverSize == "" {
+		return errors.New("at least one of lifecycle.rollover_age or lifecycle.rollover_size must be set")
+	}
+	if c.Lifecycle.Retention < 0 {
+		return errors.New("lifecycle.retention must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package opensearchexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id    component.ID
+		check func(t *testing.T, cfg *Config)
+	}{
+		{
+			id: component.NewID(componentType),
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "https://opensearch.example.com:9200", cfg.Endpoint)
+				assert.Equal(t, FlavorOpenSearch, cfg.Flavor)
+				assert.Equal(t, defaultDataStream, cfg.DataStream)
+				assert.True(t, cfg.ManageTemplate)
+				assert.Equal(t, LifecycleConfig{
+					RolloverAge:  defaultRolloverAge,
+					RolloverSize: defaultRolloverSize,
+					Retention:    defaultRetention,
+				}, cfg.Lifecycle)
+			},
+		},
+		{
+			id: component.NewIDWithName(componentType, "elasticsearch"),
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, FlavorElasticsearch, cfg.Flavor)
+				assert.Equal(t, "compliance-evidence", cfg.DataStream)
+				assert.Equal(t, LifecycleConfig{RolloverAge: 168 * time.Hour}, cfg.Lifecycle)
+			},
+		},
+		{
+			id: component.NewIDWithName(componentType, "unmanaged"),
+			check: func(t *testing.T, cfg *Config) {
+				assert.False(t, cfg.ManageTemplate)
+				assert.False(t, cfg.BackOffConfig.Enabled)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, sub.Unmarshal(cfg))
+			require.NoError(t, cfg.Validate())
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "empty endpoint",
+			mutate:  func(cfg *Config) { cfg.Endpoint = "" },
+			wantErr: "endpoint must not be empty",
+		},
+		{
+			name:    "unknown flavor",
+			mutate:  func(cfg *Config) { cfg.Flavor = "solr" },
+			wantErr: `flavor "solr" is not one of "opensearch" or "elasticsearch"`,
+		},
+		{
+			name:    "invalid data stream",
+			mutate:  func(cfg *Config) { cfg.DataStream = "Evidence" },
+			wantErr: `data_stream "Evidence" is not a valid data stream name`,
+		},
+		{
+			name:    "negative rollover age",
+			mutate:  func(cfg *Config) { cfg.Lifecycle.RolloverAge = -time.Hour },
+			wantErr: "lifecycle.rollover_age must not be negative",
+		},
+		{
+			name:    "invalid rollover size",
+			mutate:  func(cfg *Config) { cfg.Lifecycle.RolloverSize = "50 GB" },
+			wantErr: `lifecycle.rollover_size "50 GB" is not a byte size such as 50gb`,
+		},
+		{
+			name: "no rollover condition",
+			mutate: func(cfg *Config) {
+				cfg.Lifecycle.RolloverAge = 0
+				cfg.Lifecycle.RolloverSize = ""
+			},
+			wantErr: "at least one of lifecycle.rollover_age or lifecycle.rollover_size must be set",
+		},
+		{
+			name:    "negative retention",
+			mutate:  func(cfg *Config) { cfg.Lifecycle.Retention = -time.Hour },
+			wantErr: "lifecycle.retention must not be negative",
+		},
+		{
+			name:   "valid",
+			mutate: func(*Config) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = "https://opensearch.example.com:9200"
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package opensearchexporter
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// bulkDocument is one document of a bulk request and its create action.
+type bulkDocument struct {
+	id     string
+	source []byte
+}
+
+// encodeLogs converts every log record into a document. Documents are named
+// after a digest of their content, so a retried bulk request does not index
+// a record twice.
+func encodeLogs(logs plog.Logs) ([]bulkDocument, error) {
+	var docs []bulkDocument
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		rl := logs.ResourceLogs().At(i)
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				source, err := json.Marshal(encodeRecord(rl.Resource(), sl.Scope(), sl.LogRecords().At(k)))
+				if err != nil {
+					return nil, err
+				}
+				digest := sha256.Sum256(source)
+				docs = append(docs, bulkDocument{id: hex.EncodeToString(digest[:]), source: source})
+			}
+		}
+	}
+	return docs, nil
+}
+
+func encodeRecord(resource pcommon.Resource, scope pcommon.InstrumentationScope, record plog.LogRecord) map[string]any {
+	timestamp := record.Timestamp()
+	if timestamp == 0 {
+		timestamp = record.ObservedTimestamp()
+	}
+	doc := map[string]any{
+		"@timestamp":         formatTimestamp(timestamp),
+		"observed_timestamp": formatTimestamp(record.ObservedTimestamp()),
+		"severity_number":    int32(record.SeverityNumber()),
+		"attributes":         record.Attributes().AsRaw(),
+		"resource":           map[string]any{"attributes": resource.Attributes().AsRaw()},
+	}
+	if text := record.SeverityText(); text != "" {
+		doc["severity_text"] = text
+	}
+	if record.Body().Type() != pcommon.ValueTypeEmpty {
+		doc["body"] = record.Body().AsRaw()
+	}
+	if scope.Name() != "" {
+		doc["scope"] = map[string]any{"name": scope.Name(), "version": scope.Version()}
+	}
+	if traceID := record.TraceID(); !traceID.IsEmpty() {
+		doc["trace_id"] = hex.EncodeToString(traceID[:])
+	}
+	if spanID := record.SpanID(); !spanID.IsEmpty() {
+		doc["span_id"] = hex.EncodeToString(spanID[:])
+	}
+	return doc
+}
+
+// bulkBody encodes documents as a newline-delimited bulk request body.
+func bulkBody(index string, docs []bulkDocument) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, doc := range docs {
+		action := map[string]any{"create": map[string]string{"_index": index, "_id": doc.id}}
+		if err := enc.Encode(action); err != nil {
+			return nil, err
+		}
+		buf.Write(doc.source)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+func formatTimestamp(ts pcommon.Timestamp) string {
+	return ts.AsTime().UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,237 @@
+package opensearchexporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+type opensearchExporter struct {
+	cfg      *Config
+	settings exporter.Settings
+	client   *http.Client
+
+	// setupMu guards ready, which is set once the template and policy are installed.
+	setupMu sync.Mutex
+	ready   bool
+}
+
+type bulkResponse struct {
+	Errors bool                  `json:"errors"`
+	Items  []map[string]bulkItem `json:"items"`
+}
+
+type bulkItem struct {
+	Status int `json:"status"`
+	Error  *struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	} `json:"error"`
+}
+
+// ismPolicyVersion identifies the stored revision of an ISM policy, which
+// OpenSearch requires to update it.
+type ismPolicyVersion struct {
+	SeqNo       int64 `json:"_seq_no"`
+	PrimaryTerm int64 `json:"_primary_term"`
+}
+
+func newOpenSearchExporter(cfg *Config, set exporter.Settings) *opensearchExporter {
+	return &opensearchExporter{cfg: cfg, settings: set}
+}
+
+func (e *opensearchExporter) start(ctx context.Context, host component.Host) error {
+	client, err := e.cfg.ToClient(ctx, host.GetExtensions(), e.settings.TelemetrySettings)
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+	e.client = client
+	return nil
+}
+
+// consumeLogs indexes every record into the data stream. Documents the search
+// engine rejects, such as on a mapping conflict, are dropped and logged, since
+// retrying them cannot succeed.
+func (e *opensearchExporter) consumeLogs(ctx context.Context, logs plog.Logs) error {
+	if err := e.ensureSetup(ctx); err != nil {
+		return err
+	}
+
+	docs, err := encodeLogs(logs)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+	body, err := bulkBody(e.cfg.DataStream, docs)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+
+	status, respBody, err := e.do(ctx, http.MethodPost, "/_bulk", body, "application/x-ndjson")
+	if err != nil {
+		return err
+	}
+	if err := statusError("failed to index evidence", status); err != nil {
+		return err
+	}
+	var resp bulkResponse
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return fmt.Errorf("failed to decode bulk response: %w", err)
+	}
+	if !resp.Errors {
+		return nil
+	}
+	return e.checkItems(resp.Items, len(docs))
+}
+
+// checkItems fails the request when documents were throttled or hit a server
+// error, so the batch is retried. Documents that already exist were indexed
+// by an earlier attempt of the same batch.
+func (e *opensearchExporter) checkItems(items []map[string]bulkItem, total int) error {
+	retryable, rejected := 0, 0
+	reason := ""
+	for _, actions := range items {
+		for _, item := range actions {
+			switch {
+			case item.Status < 300, item.Status == http.StatusConflict:
+			case item.Status == http.StatusTooManyRequests, item.Status >= 500:
+				retryable++
+			default:
+				rejected++
+				if reason == "" && item.Error != nil {
+					reason = item.Error.Type + ": " + item.Error.Reason
+				}
+			}
+		}
+	}
+	if rejected > 0 {
+		e.settings.Logger.Warn("dropping documents rejected by the data stream",
+			zap.String("data_stream", e.cfg.DataStream),
+			zap.Int("documents", rejected),
+			zap.String("reason", reason))
+	}
+	if retryable > 0 {
+		return fmt.Errorf("failed to index %d of %d documents", retryable, total)
+	}
+	return nil
+}
+
+// ensureSetup installs the lifecycle policy and the index template before
+// the first write. A failed installation is repeated on the next batch.
+func (e *opensearchExporter) ensureSetup(ctx context.Context) error {
+	if !e.cfg.ManageTemplate {
+		return nil
+	}
+	e.setupMu.Lock()
+	defer e.setupMu.Unlock()
+	if e.ready {
+		return nil
+	}
+
+	var err error
+	if e.cfg.Flavor == FlavorElasticsearch {
+		err = e.putJSON(ctx, "/_ilm/policy/"+url.PathEscape(e.cfg.DataStream), ilmPolicy(e.cfg))
+	} else {
+		err = e.putISMPolicy(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to install lifecycle policy: %w", err)
+	}
+	if err := e.putJSON(ctx, "/_index_template/"+url.PathEscape(e.cfg.DataStream), indexTemplate(e.cfg)); err != nil {
+		return fmt.Errorf("failed to install index template: %w", err)
+	}
+	e.ready = true
+	return nil
+}
+
+// putISMPolicy creates the ISM policy, or updates the stored revision.
+func (e *opensearchExporter) putISMPolicy(ctx context.Context) error {
+	path := "/_plugins/_ism/policies/" + url.PathEscape(e.cfg.DataStream)
+	status, body, err := e.do(ctx, http.MethodGet, path, nil, "")
+	if err != nil {
+		return err
+	}
+	switch {
+	case status == http.StatusNotFound:
+		return e.putJSON(ctx, path, ismPolicy(e.cfg))
+	case status < 200 || status > 299:
+		return fmt.Errorf("GET %s: %s", path, http.StatusText(status))
+	}
+	var version ismPolicyVersion
+	if err := json.Unmarshal(body, &version); err != nil {
+		return fmt.Errorf("failed to decode ISM policy: %w", err)
+	}
+	query := url.Values{}
+	query.Set("if_seq_no", fmt.Sprint(version.SeqNo))
+	query.Set("if_primary_term", fmt.Sprint(version.PrimaryTerm))
+	return e.putJSON(ctx, path+"?"+query.Encode(), ismPolicy(e.cfg))
+}
+
+func (e *opensearchExporter) putJSON(ctx context.Context, path string, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	status, body, err := e.do(ctx, http.MethodPut, path, data, "application/json")
+	if err != nil {
+		return err
+	}
+	if status < 200 || status > 299 {
+		return fmt.Errorf("PUT %s: %s: %s", strings.SplitN(path, "?", 2)[0], http.StatusText(status), bytes.TrimSpace(body))
+	}
+	return nil
+}
+
+// do sends a request to the endpoint and returns the status and body of the response.
+func (e *opensearchExporter) do(ctx context.Context, method, path string, data []byte, contentType string) (int, []byte, error) {
+	var body io.Reader
+	if data != nil {
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(e.cfg.Endpoint, "/")+path, body)
+	if err != nil {
+		return 0, nil, consumererror.NewPermanent(err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 64<<20))
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return resp.StatusCode, respBody, nil
+}
+
+// statusError reports a failed response. Client errors other than throttling
+// are permanent, so the exporter does not retry them.
+func statusError(msg string, status int) error {
+	if status >= 200 && status <= 299 {
+		return nil
+	}
+	err := fmt.Errorf("%s: %d %s", msg, status, http.StatusText(status))
+	if status >= 400 && status <= 499 &&
+		status != http.StatusRequestTimeout && status != http.StatusTooManyRequests {
+		return consumererror.NewPermanent(err)
+	}
+	return err
+}
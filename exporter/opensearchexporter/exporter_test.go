@@ -0,0 +1,309 @@
+package opensearchexporter
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+var recordTimestamp = time.Date(2026, 6, 1, 10, 0, 0, 0, time.UTC)
+
+func evidenceLogs(records ...map[string]any) plog.Logs {
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "complybeacon")
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("github.com/complytime/complybeacon/receiver/xccdfreceiver")
+	for _, attrs := range records {
+		record := sl.LogRecords().AppendEmpty()
+		record.SetTimestamp(pcommon.NewTimestampFromTime(recordTimestamp))
+		record.SetSeverityNumber(plog.SeverityNumberInfo)
+		record.SetSeverityText("INFO")
+		record.Body().SetStr("sshd-disable-root-login: fail")
+		_ = record.Attributes().FromRaw(attrs)
+	}
+	return logs
+}
+
+func validRecord(ruleID string) map[string]any {
+	return map[string]any{
+		proofwatch.POLICY_RULE_ID:           ruleID,
+		proofwatch.POLICY_EVALUATION_RESULT: "Failed",
+		proofwatch.COMPLIANCE_FRAMEWORKS:    []any{"NIST-800-53"},
+	}
+}
+
+// fakeCluster answers the template, policy and bulk APIs of a search engine.
+type fakeCluster struct {
+	t *testing.T
+
+	mu        sync.Mutex
+	requests  []string
+	bodies    map[string]map[string]any
+	ismExists bool
+	// failStatus, when set, is returned for every request.
+	failStatus int
+	// itemStatus returns the status of the n-th document of a bulk request.
+	itemStatus func(n int) int
+	docs       []map[string]any
+	ids        []string
+}
+
+func newFakeCluster(t *testing.T) (*fakeCluster, *httptest.Server) {
+	c := &fakeCluster{
+		t:          t,
+		bodies:     make(map[string]map[string]any),
+		itemStatus: func(int) int { return http.StatusCreated },
+	}
+	server := httptest.NewServer(http.HandlerFunc(c.serve))
+	t.Cleanup(server.Close)
+	return c, server
+}
+
+func (c *fakeCluster) serve(w http.ResponseWriter, req *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests = append(c.requests, req.Method+" "+req.URL.RequestURI())
+	if c.failStatus != 0 {
+		w.WriteHeader(c.failStatus)
+		return
+	}
+
+	switch {
+	case req.Method == http.MethodGet:
+		if !c.ismExists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"_id":"complybeacon-evidence","_seq_no":7,"_primary_term":2,"policy":{}}`))
+	case req.Method == http.MethodPut:
+		var body map[string]any
+		assert.NoError(c.t, json.NewDecoder(req.Body).Decode(&body))
+		c.bodies[req.URL.Path] = body
+		_, _ = w.Write([]byte(`{"acknowledged":true}`))
+	case req.URL.Path == "/_bulk":
+		assert.Equal(c.t, "application/x-ndjson", req.Header.Get("Content-Type"))
+		resp := bulkResponse{}
+		scanner := bufio.NewScanner(req.Body)
+		scanner.Buffer(make([]byte, 0, 1<<20), 1<<20)
+		for n := 0; scanner.Scan(); n++ {
+			var action map[string]map[string]string
+			assert.NoError(c.t, json.Unmarshal(scanner.Bytes(), &action))
+			if !assert.True(c.t, scanner.Scan(), "action without a document") {
+				break
+			}
+			var doc map[string]any
+			assert.NoError(c.t, json.Unmarshal(scanner.Bytes(), &doc))
+
+			status := c.itemStatus(n)
+			item := bulkItem{Status: status}
+			if status >= 300 {
+				resp.Errors = true
+				item.Error = &struct {
+					Type   string `json:"type"`
+					Reason string `json:"reason"`
+				}{Type: "mapper_parsing_exception", Reason: "failed to parse"}
+			} else {
+				c.docs = append(c.docs, doc)
+				c.ids = append(c.ids, action["create"]["_id"])
+			}
+			assert.Equal(c.t, "complybeacon-evidence", action["create"]["_index"])
+			resp.Items = append(resp.Items, map[string]bulkItem{"create": item})
+		}
+		assert.NoError(c.t, json.NewEncoder(w).Encode(resp))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestExporter(t *testing.T, cfg *Config) *opensearchExporter {
+	t.Helper()
+	e := newOpenSearchExporter(cfg, exportertest.NewNopSettings(componentType))
+	require.NoError(t, e.start(context.Background(), componenttest.NewNopHost()))
+	return e
+}
+
+func TestConsumeLogsOpenSearch(t *testing.T) {
+	cluster, server := newFakeCluster(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL + "/"
+	e := newTestExporter(t, cfg)
+
+	require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs(validRecord("r1"), validRecord("r2"))))
+	require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs(validRecord("r3"))))
+
+	// The template and policy are installed once, before the first write.
+	assert.Equal(t, []string{
+		"GET /_plugins/_ism/policies/complybeacon-evidence",
+		"PUT /_plugins/_ism/policies/complybeacon-evidence",
+		"PUT /_index_template/complybeacon-evidence",
+		"POST /_bulk",
+		"POST /_bulk",
+	}, cluster.requests)
+	assert.Contains(t, cluster.bodies, "/_plugins/_ism/policies/complybeacon-evidence")
+
+	require.Len(t, cluster.docs, 3)
+	doc := cluster.docs[0]
+	assert.Equal(t, "2026-06-01T10:00:00Z", doc["@timestamp"])
+	assert.Equal(t, "INFO", doc["severity_text"])
+	assert.Equal(t, "sshd-disable-root-login: fail", doc["body"])
+	assert.Equal(t, map[string]any{
+		proofwatch.POLICY_RULE_ID:           "r1",
+		proofwatch.POLICY_EVALUATION_RESULT: "Failed",
+		proofwatch.COMPLIANCE_FRAMEWORKS:    []any{"NIST-800-53"},
+	}, doc["attributes"])
+	assert.Equal(t, map[string]any{"attributes": map[string]any{"service.name": "complybeacon"}}, doc["resource"])
+	assert.Equal(t, "github.com/complytime/complybeacon/receiver/xccdfreceiver", doc["scope"].(map[string]any)["name"])
+	assert.Len(t, cluster.ids[0], 64)
+	assert.NotEqual(t, cluster.ids[0], cluster.ids[1])
+}
+
+func TestConsumeLogsUpdatesExistingISMPolicy(t *testing.T) {
+	cluster, server := newFakeCluster(t)
+	cluster.ismExists = true
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	e := newTestExporter(t, cfg)
+
+	require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs(validRecord("r1"))))
+	assert.Contains(t, cluster.requests, "PUT /_plugins/_ism/policies/complybeacon-evidence?if_primary_term=2&if_seq_no=7")
+}
+
+func TestConsumeLogsElasticsearch(t *testing.T) {
+	cluster, server := newFakeCluster(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	cfg.Flavor = FlavorElasticsearch
+	e := newTestExporter(t, cfg)
+
+	require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs(validRecord("r1"))))
+	assert.Equal(t, []string{
+		"PUT /_ilm/policy/complybeacon-evidence",
+		"PUT /_index_template/complybeacon-evidence",
+		"POST /_bulk",
+	}, cluster.requests)
+	settings := cluster.bodies["/_index_template/complybeacon-evidence"]["template"].(map[string]any)["settings"]
+	assert.Equal(t, map[string]any{"index.lifecycle.name": "complybeacon-evidence"}, settings)
+}
+
+func TestConsumeLogsUnmanagedTemplate(t *testing.T) {
+	cluster, server := newFakeCluster(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	cfg.ManageTemplate = false
+	e := newTestExporter(t, cfg)
+
+	require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs(validRecord("r1"))))
+	assert.Equal(t, []string{"POST /_bulk"}, cluster.requests)
+}
+
+func TestConsumeLogsItemFailures(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		wantErr  bool
+		wantDocs int
+	}{
+		{name: "rejected documents are dropped", status: http.StatusBadRequest, wantDocs: 1},
+		{name: "existing documents succeed", status: http.StatusConflict, wantDocs: 1},
+		{name: "throttled documents are retried", status: http.StatusTooManyRequests, wantErr: true, wantDocs: 1},
+		{name: "server errors are retried", status: http.StatusServiceUnavailable, wantErr: true, wantDocs: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster, server := newFakeCluster(t)
+			cluster.itemStatus = func(n int) int {
+				if n == 1 {
+					return tt.status
+				}
+				return http.StatusCreated
+			}
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = server.URL
+			cfg.ManageTemplate = false
+			e := newTestExporter(t, cfg)
+
+			err := e.consumeLogs(context.Background(), evidenceLogs(validRecord("r1"), validRecord("r2")))
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.False(t, consumererror.IsPermanent(err))
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Len(t, cluster.docs, tt.wantDocs)
+		})
+	}
+}
+
+func TestConsumeLogsSetupFailureIsRetried(t *testing.T) {
+	cluster, server := newFakeCluster(t)
+	cluster.failStatus = http.StatusForbidden
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	cfg.Flavor = FlavorElasticsearch
+	e := newTestExporter(t, cfg)
+
+	err := e.consumeLogs(context.Background(), evidenceLogs(validRecord("r1")))
+	require.ErrorContains(t, err, "failed to install lifecycle policy")
+	assert.Empty(t, cluster.docs)
+
+	cluster.mu.Lock()
+	cluster.failStatus = 0
+	cluster.mu.Unlock()
+	require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs(validRecord("r1"))))
+	assert.Len(t, cluster.docs, 1)
+}
+
+func TestConsumeLogsBulkStatus(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		wantPermanent bool
+	}{
+		{name: "bad request", status: http.StatusBadRequest, wantPermanent: true},
+		{name: "throttled", status: http.StatusTooManyRequests},
+		{name: "unavailable", status: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = server.URL
+			cfg.ManageTemplate = false
+			e := newTestExporter(t, cfg)
+
+			err := e.consumeLogs(context.Background(), evidenceLogs(validRecord("r1")))
+			require.Error(t, err)
+			assert.Equal(t, tt.wantPermanent, consumererror.IsPermanent(err))
+		})
+	}
+}
+
+func TestEncodeLogsIsDeterministic(t *testing.T) {
+	first, err := encodeLogs(evidenceLogs(validRecord("r1")))
+	require.NoError(t, err)
+	second, err := encodeLogs(evidenceLogs(validRecord("r1")))
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+}
@@ -0,0 +1,34 @@
+package opensearchexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+)
+
+func TestNewFactory(t *testing.T) {
+	factory := NewFactory()
+	assert.Equal(t, componentType, factory.Type())
+
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, componenttest.CheckConfigStruct(cfg))
+}
+
+func TestExporterLifecycle(t *testing.T) {
+	cluster, server := newFakeCluster(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+
+	exp, err := NewFactory().CreateLogs(context.Background(), exportertest.NewNopSettings(componentType), cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, exp.ConsumeLogs(context.Background(), evidenceLogs(validRecord("r1"))))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	assert.Len(t, cluster.docs, 1)
+}
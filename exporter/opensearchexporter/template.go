@@ -0,0 +1,210 @@
+package opensearchexporter
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+const (
+	// templatePriority outranks the built-in templates of both search engines.
+	templatePriority = 200
+
+	// managedBy marks the template and policy as owned by this exporter.
+	managedBy = "complybeacon"
+)
+
+// attributeTypes maps the compliance attributes to their field types. String
+// attributes not listed here are mapped as keywords by a dynamic template.
+var attributeTypes = map[string]string{
+	proofwatch.COMPLIANCE_ASSESSMENT_ID:                "keyword",
+	proofwatch.COMPLIANCE_CONTROL_APPLICABILITY:        "keyword",
+	proofwatch.COMPLIANCE_CONTROL_CATALOG_ID:           "keyword",
+	proofwatch.COMPLIANCE_CONTROL_CATEGORY:             "keyword",
+	proofwatch.COMPLIANCE_CONTROL_ID:                   "keyword",
+	proofwatch.COMPLIANCE_FRAMEWORKS:                   "keyword",
+	proofwatch.COMPLIANCE_REMEDIATION_ACTION:           "keyword",
+	proofwatch.COMPLIANCE_REMEDIATION_DESCRIPTION:      "text",
+	proofwatch.COMPLIANCE_REMEDIATION_EXCEPTION_ACTIVE: "boolean",
+	proofwatch.COMPLIANCE_REMEDIATION_EXCEPTION_ID:     "keyword",
+	proofwatch.COMPLIANCE_REMEDIATION_STATUS:           "keyword",
+	proofwatch.COMPLIANCE_REQUIREMENTS:                 "keyword",
+	proofwatch.COMPLIANCE_RISK_LEVEL:                   "keyword",
+	proofwatch.COMPLIANCE_STATUS:                       "keyword",
+	proofwatch.EVIDENCE_CORRELATION_COUNT:              "long",
+	proofwatch.EVIDENCE_CORRELATION_FIRST_SEEN:         "date",
+	proofwatch.EVIDENCE_CORRELATION_LAST_SEEN:          "date",
+	proofwatch.EVIDENCE_REDACTION_FIELDS:               "keyword",
+	proofwatch.EVIDENCE_SIGNATURE_BATCH_DIGEST:         "keyword",
+	proofwatch.EVIDENCE_SIGNATURE_DIGEST:               "keyword",
+	proofwatch.EVIDENCE_SIGNATURE_KEY_ID:               "keyword",
+	proofwatch.EVIDENCE_SIGNATURE_VALUE:                "keyword",
+	proofwatch.POLICY_ENGINE_NAME:                      "keyword",
+	proofwatch.POLICY_ENGINE_VERSION:                   "keyword",
+	proofwatch.POLICY_EVALUATION_MESSAGE:               "text",
+	proofwatch.POLICY_EVALUATION_RESULT:                "keyword",
+	proofwatch.POLICY_RULE_ID:                          "keyword",
+	proofwatch.POLICY_RULE_NAME:                        "keyword",
+	proofwatch.POLICY_RULE_URI:                         "keyword",
+	proofwatch.POLICY_TARGET_ENVIRONMENT:               "keyword",
+	proofwatch.POLICY_TARGET_ID:                        "keyword",
+	proofwatch.POLICY_TARGET_NAME:                      "keyword",
+	proofwatch.POLICY_TARGET_TYPE:                      "keyword",
+}
+
+// indexTemplate builds the composable index template of the data stream.
+func indexTemplate(cfg *Config) map[string]any {
+	settings := map[string]any{}
+	if cfg.Flavor == FlavorElasticsearch {
+		settings["index.lifecycle.name"] = cfg.DataStream
+	}
+	return map[string]any{
+		"index_patterns": []string{cfg.DataStream},
+		"data_stream":    map[string]any{},
+		"priority":       templatePriority,
+		"_meta":          map[string]any{"managed_by": managedBy},
+		"template": map[string]any{
+			"settings": settings,
+			"mappings": mappings(),
+		},
+	}
+}
+
+func mappings() map[string]any {
+	return map[string]any{
+		"dynamic_templates": []map[string]any{
+			keywordTemplate("attribute_strings", "attributes.*"),
+			keywordTemplate("resource_attribute_strings", "resource.attributes.*"),
+		},
+		"properties": map[string]any{
+			"@timestamp":         map[string]any{"type": "date_nanos"},
+			"observed_timestamp": map[string]any{"type": "date_nanos"},
+			"severity_text":      map[string]any{"type": "keyword"},
+			"severity_number":    map[string]any{"type": "byte"},
+			"trace_id":           map[string]any{"type": "keyword"},
+			"span_id":            map[string]any{"type": "keyword"},
+			// The body is kept in _source but not indexed, since its shape
+			// differs from one receiver to the next.
+			"body":       map[string]any{"type": "object", "enabled": false},
+			"attributes": map[string]any{"properties": attributeProperties()},
+			"scope": map[string]any{"properties": map[string]any{
+				"name":    map[string]any{"type": "keyword"},
+				"version": map[string]any{"type": "keyword"},
+			}},
+		},
+	}
+}
+
+func keywordTemplate(name, pathMatch string) map[string]any {
+	return map[string]any{name: map[string]any{
+		"path_match":         pathMatch,
+		"match_mapping_type": "string",
+		"mapping":            map[string]any{"type": "keyword", "ignore_above": 1024},
+	}}
+}
+
+// attributeProperties nests the dotted attribute names into object
+// properties, the way documents with dotted field names are indexed.
+func attributeProperties() map[string]any {
+	root := map[string]any{}
+	for name, fieldType := range attributeTypes {
+		parts := strings.Split(name, ".")
+		properties := root
+		for _, part := range parts[:len(parts)-1] {
+			object, ok := properties[part].(map[string]any)
+			if !ok {
+				object = map[string]any{"properties": map[string]any{}}
+				properties[part] = object
+			}
+			properties = object["properties"].(map[string]any)
+		}
+		properties[parts[len(parts)-1]] = map[string]any{"type": fieldType}
+	}
+	return root
+}
+
+// ilmPolicy builds the Elasticsearch Index Lifecycle Management policy.
+func ilmPolicy(cfg *Config) map[string]any {
+	rollover := map[string]any{}
+	if cfg.Lifecycle.RolloverAge > 0 {
+		rollover["max_age"] = formatDuration(cfg.Lifecycle.RolloverAge)
+	}
+	if cfg.Lifecycle.RolloverSize != "" {
+		rollover["max_primary_shard_size"] = cfg.Lifecycle.RolloverSize
+	}
+	phases := map[string]any{
+		"hot": map[string]any{
+			"min_age": "0ms",
+			"actions": map[string]any{"rollover": rollover},
+		},
+	}
+	if cfg.Lifecycle.Retention > 0 {
+		phases["delete"] = map[string]any{
+			"min_age": formatDuration(cfg.Lifecycle.Retention),
+			"actions": map[string]any{"delete": map[string]any{}},
+		}
+	}
+	return map[string]any{"policy": map[string]any{
+		"_meta":  map[string]any{"managed_by": managedBy},
+		"phases": phases,
+	}}
+}
+
+// ismPolicy builds the OpenSearch Index State Management policy. Its ISM
+// template attaches it to every backing index of the data stream.
+func ismPolicy(cfg *Config) map[string]any {
+	rollover := map[string]any{}
+	if cfg.Lifecycle.RolloverAge > 0 {
+		rollover["min_index_age"] = formatDuration(cfg.Lifecycle.RolloverAge)
+	}
+	if cfg.Lifecycle.RolloverSize != "" {
+		rollover["min_primary_shard_size"] = cfg.Lifecycle.RolloverSize
+	}
+	hot := map[string]any{
+		"name":        "hot",
+		"actions":     []any{map[string]any{"rollover": rollover}},
+		"transitions": []any{},
+	}
+	states := []any{hot}
+	if cfg.Lifecycle.Retention > 0 {
+		hot["transitions"] = []any{map[string]any{
+			"state_name": "delete",
+			"conditions": map[string]any{"min_index_age": formatDuration(cfg.Lifecycle.Retention)},
+		}}
+		states = append(states, map[string]any{
+			"name":        "delete",
+			"actions":     []any{map[string]any{"delete": map[string]any{}}},
+			"transitions": []any{},
+		})
+	}
+	return map[string]any{"policy": map[string]any{
+		"description":   "Rollover and retention of " + cfg.DataStream + ", managed by " + managedBy,
+		"default_state": "hot",
+		"states":        states,
+		"ism_template": []any{map[string]any{
+			"index_patterns": []string{".ds-" + cfg.DataStream + "-*"},
+			"priority":       templatePriority,
+		}},
+	}}
+}
+
+// formatDuration formats a duration in the largest time unit both search
+// engines accept that represents it exactly.
+func formatDuration(d time.Duration) string {
+	for _, unit := range []struct {
+		suffix string
+		size   time.Duration
+	}{
+		{"d", 24 * time.Hour},
+		{"h", time.Hour},
+		{"m", time.Minute},
+		{"s", time.Second},
+	} {
+		if d%unit.size == 0 {
+			return strconv.FormatInt(int64(d/unit.size), 10) + unit.suffix
+		}
+	}
+	return strconv.FormatInt(d.Milliseconds(), 10) + "ms"
+}
@@ -0,0 +1,101 @@
+package opensearchexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttributeProperties(t *testing.T) {
+	properties := attributeProperties()
+
+	policy := properties["policy"].(map[string]any)["properties"].(map[string]any)
+	rule := policy["rule"].(map[string]any)["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "keyword"}, rule["id"])
+
+	compliance := properties["compliance"].(map[string]any)["properties"].(map[string]any)
+	control := compliance["control"].(map[string]any)["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "keyword"}, control["id"])
+	catalog := control["catalog"].(map[string]any)["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "keyword"}, catalog["id"])
+
+	evidence := properties["evidence"].(map[string]any)["properties"].(map[string]any)
+	correlation := evidence["correlation"].(map[string]any)["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "long"}, correlation["count"])
+}
+
+func TestIndexTemplate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	template := indexTemplate(cfg)
+	assert.Equal(t, []string{defaultDataStream}, template["index_patterns"])
+	assert.Equal(t, map[string]any{}, template["data_stream"])
+	assert.Empty(t, template["template"].(map[string]any)["settings"])
+
+	cfg.Flavor = FlavorElasticsearch
+	settings := indexTemplate(cfg)["template"].(map[string]any)["settings"].(map[string]any)
+	assert.Equal(t, defaultDataStream, settings["index.lifecycle.name"])
+}
+
+func TestILMPolicy(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.Equal(t, map[string]any{"policy": map[string]any{
+		"_meta": map[string]any{"managed_by": managedBy},
+		"phases": map[string]any{
+			"hot": map[string]any{
+				"min_age": "0ms",
+				"actions": map[string]any{"rollover": map[string]any{
+					"max_age":                "1d",
+					"max_primary_shard_size": "50gb",
+				}},
+			},
+			"delete": map[string]any{
+				"min_age": "365d",
+				"actions": map[string]any{"delete": map[string]any{}},
+			},
+		},
+	}}, ilmPolicy(cfg))
+
+	cfg.Lifecycle = LifecycleConfig{RolloverSize: "10gb"}
+	phases := ilmPolicy(cfg)["policy"].(map[string]any)["phases"].(map[string]any)
+	assert.NotContains(t, phases, "delete")
+}
+
+func TestISMPolicy(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	policy := ismPolicy(cfg)["policy"].(map[string]any)
+
+	states := policy["states"].([]any)
+	assert.Len(t, states, 2)
+	hot := states[0].(map[string]any)
+	assert.Equal(t, []any{map[string]any{"rollover": map[string]any{
+		"min_index_age":          "1d",
+		"min_primary_shard_size": "50gb",
+	}}}, hot["actions"])
+	assert.Equal(t, []any{map[string]any{
+		"state_name": "delete",
+		"conditions": map[string]any{"min_index_age": "365d"},
+	}}, hot["transitions"])
+	assert.Equal(t, []any{map[string]any{
+		"index_patterns": []string{".ds-" + defaultDataStream + "-*"},
+		"priority":       templatePriority,
+	}}, policy["ism_template"])
+
+	cfg.Lifecycle.Retention = 0
+	states = ismPolicy(cfg)["policy"].(map[string]any)["states"].([]any)
+	assert.Len(t, states, 1)
+	assert.Equal(t, []any{}, states[0].(map[string]any)["transitions"])
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		48 * time.Hour:          "2d",
+		36 * time.Hour:          "36h",
+		90 * time.Minute:        "90m",
+		45 * time.Second:        "45s",
+		1500 * time.Millisecond: "1500ms",
+	}
+	for d, want := range tests {
+		assert.Equal(t, want, formatDuration(d), d.String())
+	}
+}
@@ -0,0 +1,108 @@
+package oscalexporter
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const (
+	defaultWindow          = time.Hour
+	defaultMaxObservations = 10000
+	defaultTitle           = "ComplyBeacon Assessment Results"
+	defaultImportHref      = "#"
+
+	fileKey = "file"
+	httpKey = "http"
+)
+
+var (
+	_ component.Config    = (*Config)(nil)
+	_ confmap.Unmarshaler = (*Config)(nil)
+)
+
+// Config defines the configuration for the OSCAL exporter.
+// At least one of File or HTTP must be set.
+type Config struct {
+	// Window is how often the aggregated evidence is written as an
+	// assessment-results document. Zero writes a document on shutdown, and
+	// whenever MaxObservations is reached.
+	Window time.Duration `mapstructure:"window"`
+
+	// MaxObservations closes a window early once it holds this many
+	// observations, so memory stays bounded however long the window is.
+	MaxObservations int `mapstructure:"max_observations"`
+
+	// Title is written to metadata.title of every document.
+	Title string `mapstructure:"title"`
+
+	// ImportAPHref references the assessment plan the results belong to.
+	ImportAPHref string `mapstructure:"import_ap_href"`
+
+	// File writes each document to a directory.
+	File *FileConfig `mapstructure:"file"`
+
+	// HTTP POSTs each document to an endpoint.
+	HTTP *confighttp.ClientConfig `mapstructure:"http"`
+}
+
+// FileConfig configures file output.
+type FileConfig struct {
+	// Directory receives one assessment-results-<uuid>.json file per document.
+	Directory string `mapstructure:"directory"`
+}
+
+func createDefaultConfig() component.Config {
+	httpCfg := confighttp.NewDefaultClientConfig()
+	return &Config{
+		Window:          defaultWindow,
+		MaxObservations: defaultMaxObservations,
+		Title:           defaultTitle,
+		ImportAPHref:    defaultImportHref,
+		File:            &FileConfig{},
+		HTTP:            &httpCfg,
+	}
+}
+
+// Unmarshal keeps only the outputs that are present in the user configuration.
+func (c *Config) Unmarshal(conf *confmap.Conf) error {
+	if err := conf.Unmarshal(c); err != nil {
+		return err
+	}
+	if !conf.IsSet(fileKey) {
+		c.File = nil
+	}
+	if !conf.IsSet(httpKey) {
+		c.HTTP = nil
+	}
+	return nil
+}
+
+// Validate checks the exporter configuration is valid.
+func (c *Config) Validate() error {
+	if c.File == nil && c.HTTP == nil {
+		return errors.New("at least one of file or http must be configured")
+	}
+	if c.Window < 0 {
+		return errors.New("window must not be negative")
+	}
+	if c.MaxObservations <= 0 {
+		return errors.New("max_observations must be positive")
+	}
+	if c.Title == "" {
+		return errors.New("title must not be empty")
+	}
+	if c.ImportAPHref == "" {
+		return errors.New("import_ap_href must not be empty")
+	}
+	if c.File != nil && c.File.Directory == "" {
+		return errors.New("file.directory must not be empty")
+	}
+	if c.HTTP != nil && c.HTTP.Endpoint == "" {
+		return errors.New("http.endpoint must not be empty")
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package oscalexporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+// maxPendingDocuments bounds the documents kept for retry while outputs are failing.
+const maxPendingDocuments = 16
+
+type oscalExporter struct {
+	cfg      *Config
+	settings exporter.Settings
+	client   *http.Client
+	now      func() time.Time
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	// full signals that the current window reached max_observations.
+	full chan struct{}
+
+	mu      sync.Mutex
+	current *aggregator
+
+	// flushMu serializes flushes so pending documents are delivered in order.
+	flushMu sync.Mutex
+	pending []document
+}
+
+func newOSCALExporter(cfg *Config, set exporter.Settings) *oscalExporter {
+	return &oscalExporter{
+		cfg:      cfg,
+		settings: set,
+		now:      time.Now,
+		full:     make(chan struct{}, 1),
+		current:  newAggregator(time.Now()),
+	}
+}
+
+func (e *oscalExporter) start(ctx context.Context, host component.Host) error {
+	if e.cfg.HTTP != nil {
+		client, err := e.cfg.HTTP.ToClient(ctx, host.GetExtensions(), e.settings.TelemetrySettings)
+		if err != nil {
+			return fmt.Errorf("failed to create HTTP client: %w", err)
+		}
+		e.client = client
+	}
+	if e.cfg.File != nil {
+		if err := os.MkdirAll(e.cfg.File.Directory, 0o750); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	e.cancel = cancel
+	e.wg.Add(1)
+	go e.flushEvery(runCtx, e.cfg.Window)
+	return nil
+}
+
+// shutdown writes the evidence aggregated since the last window.
+func (e *oscalExporter) shutdown(ctx context.Context) error {
+	if e.cancel != nil {
+		e.cancel()
+	}
+	e.wg.Wait()
+	return e.flush(ctx)
+}
+
+func (e *oscalExporter) consumeLogs(_ context.Context, logs plog.Logs) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.current.add(logs)
+	if e.current.size() >= e.cfg.MaxObservations {
+		select {
+		case e.full <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// flushEvery closes the current window every window, and early once it is
+// full. A zero window is only closed early and on shutdown.
+func (e *oscalExporter) flushEvery(ctx context.Context, window time.Duration) {
+	defer e.wg.Done()
+	var tick <-chan time.Time
+	if window > 0 {
+		ticker := time.NewTicker(window)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick:
+		case <-e.full:
+		}
+		if err := e.flush(ctx); err != nil {
+			e.settings.Logger.Warn("failed to deliver assessment results; will retry on the next window", zap.Error(err))
+		}
+	}
+}
+
+// flush closes the current window and delivers it together with any documents
+// that failed to deliver earlier. Documents that still fail are kept for the
+// next flush, up to maxPendingDocuments.
+func (e *oscalExporter) flush(ctx context.Context) error {
+	e.flushMu.Lock()
+	defer e.flushMu.Unlock()
+
+	e.mu.Lock()
+	if !e.current.empty() {
+		end := e.now()
+		e.pending = append(e.pending, e.current.build(e.cfg, end))
+		e.current = newAggregator(end)
+	}
+	e.mu.Unlock()
+
+	if dropped := len(e.pending) - maxPendingDocuments; dropped > 0 {
+		e.settings.Logger.Error("dropping undelivered assessment results", zap.Int("documents", dropped))
+		e.pending = e.pending[dropped:]
+	}
+
+	var errs error
+	remaining := e.pending[:0]
+	for _, doc := range e.pending {
+		if err := e.deliver(ctx, doc); err != nil {
+			errs = errors.Join(errs, err)
+			remaining = append(remaining, doc)
+		}
+	}
+	e.pending = remaining
+	return errs
+}
+
+func (e *oscalExporter) deliver(ctx context.Context, doc document) error {
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	var errs error
+	if e.cfg.File != nil {
+		errs = errors.Join(errs, e.writeFile(doc.AssessmentResults.UUID, data))
+	}
+	if e.client != nil {
+		errs = errors.Join(errs, e.post(ctx, data))
+	}
+	return errs
+}
+
+// writeFile names the file after the document UUID, so a retried document
+// overwrites its earlier copy instead of duplicating it.
+func (e *oscalExporter) writeFile(id string, data []byte) error {
+	path := filepath.Join(e.cfg.File.Directory, "assessment-results-"+id+".json")
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+func (e *oscalExporter) post(ctx context.Context, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.cfg.HTTP.Endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post assessment results: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("failed to post assessment results: %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package oscalexporter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+func newTestExporter(t *testing.T, cfg *Config) *oscalExporter {
+	t.Helper()
+	e := newOSCALExporter(cfg, exportertest.NewNopSettings(componentType))
+	e.now = func() time.Time { return windowEnd }
+	require.NoError(t, e.start(context.Background(), componenttest.NewNopHost()))
+	return e
+}
+
+func readDocuments(t *testing.T, dir string) []document {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join(dir, "assessment-results-*.json"))
+	require.NoError(t, err)
+
+	docs := make([]document, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		var doc document
+		require.NoError(t, json.Unmarshal(data, &doc))
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+func TestFlushWritesFile(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTP = nil
+	cfg.Window = 0
+	cfg.File.Directory = filepath.Join(t.TempDir(), "oscal")
+	e := newTestExporter(t, cfg)
+
+	// Nothing is written for an empty window.
+	require.NoError(t, e.flush(context.Background()))
+	assert.Empty(t, readDocuments(t, cfg.File.Directory))
+
+	require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs(
+		map[string]any{proofwatch.POLICY_RULE_ID: "r1", proofwatch.COMPLIANCE_CONTROL_ID: "ac-2"},
+	)))
+	require.NoError(t, e.shutdown(context.Background()))
+
+	docs := readDocuments(t, cfg.File.Directory)
+	require.Len(t, docs, 1)
+	res := docs[0].AssessmentResults.Results[0]
+	assert.Len(t, res.Observations, 1)
+	assert.Equal(t, "ac-2_smt", res.Findings[0].Target.TargetID)
+	assert.Equal(t, windowEnd, res.End)
+}
+
+func TestFullWindowIsWrittenEarly(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTP = nil
+	cfg.Window = 0
+	cfg.MaxObservations = 2
+	cfg.File.Directory = filepath.Join(t.TempDir(), "oscal")
+	e := newTestExporter(t, cfg)
+	defer func() { require.NoError(t, e.shutdown(context.Background())) }()
+
+	require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs(map[string]any{proofwatch.POLICY_RULE_ID: "r1"})))
+	assert.Never(t, func() bool { return len(readDocuments(t, cfg.File.Directory)) > 0 }, 50*time.Millisecond, 10*time.Millisecond)
+
+	require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs(map[string]any{proofwatch.POLICY_RULE_ID: "r2"})))
+	assert.Eventually(t, func() bool { return len(readDocuments(t, cfg.File.Directory)) == 1 }, time.Second, 10*time.Millisecond)
+}
+
+func TestFlushRetriesFailedPosts(t *testing.T) {
+	var fail atomic.Bool
+	var received atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		if fail.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var doc document
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&doc))
+		received.Add(1)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.File = nil
+	cfg.HTTP.Endpoint = server.URL
+	e := newTestExporter(t, cfg)
+
+	fail.Store(true)
+	require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs(map[string]any{proofwatch.POLICY_RULE_ID: "r1"})))
+	assert.ErrorContains(t, e.flush(context.Background()), "503 Service Unavailable")
+	assert.Len(t, e.pending, 1)
+
+	fail.Store(false)
+	require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs(map[string]any{proofwatch.POLICY_RULE_ID: "r2"})))
+	require.NoError(t, e.shutdown(context.Background()))
+	assert.Equal(t, int32(2), received.Load())
+	assert.Empty(t, e.pending)
+}
+
+func TestFlushDropsOldestPendingDocuments(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTP = nil
+	// A regular file in place of the directory makes every write fail.
+	cfg.File.Directory = filepath.Join(t.TempDir(), "blocked")
+	e := newOSCALExporter(cfg, exportertest.NewNopSettings(componentType))
+	require.NoError(t, os.WriteFile(cfg.File.Directory, nil, 0o600))
+
+	for range maxPendingDocuments + 2 {
+		require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs(map[string]any{proofwatch.POLICY_RULE_ID: "r1"})))
+		assert.Error(t, e.flush(context.Background()))
+	}
+	assert.Len(t, e.pending, maxPendingDocuments)
+}
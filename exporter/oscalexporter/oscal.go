@@ -0,0 +1,294 @@
+package oscalexporter
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+const (
+	oscalVersion = "1.1.2"
+
+	// propNamespace qualifies the ComplyBeacon-specific props on observations.
+	propNamespace = "https://github.com/complytime/complybeacon"
+
+	stateSatisfied    = "satisfied"
+	stateNotSatisfied = "not-satisfied"
+)
+
+// subjectNamespace seeds the name-based UUIDs of observation subjects, so a
+// target keeps the same subject-uuid across documents.
+var subjectNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte(propNamespace))
+
+// document is the root of an OSCAL assessment-results JSON document.
+type document struct {
+	AssessmentResults assessmentResults `json:"assessment-results"`
+}
+
+type assessmentResults struct {
+	UUID     string   `json:"uuid"`
+	Metadata metadata `json:"metadata"`
+	ImportAP importAP `json:"import-ap"`
+	Results  []result `json:"results"`
+}
+
+type metadata struct {
+	Title        string    `json:"title"`
+	LastModified time.Time `json:"last-modified"`
+	Version      string    `json:"version"`
+	OSCALVersion string    `json:"oscal-version"`
+}
+
+type importAP struct {
+	Href string `json:"href"`
+}
+
+type result struct {
+	UUID             string           `json:"uuid"`
+	Title            string           `json:"title"`
+	Description      string           `json:"description"`
+	Start            time.Time        `json:"start"`
+	End              time.Time        `json:"end"`
+	ReviewedControls reviewedControls `json:"reviewed-controls"`
+	Observations     []observation    `json:"observations,omitempty"`
+	Findings         []finding        `json:"findings,omitempty"`
+}
+
+type reviewedControls struct {
+	ControlSelections []controlSelection `json:"control-selections"`
+}
+
+type controlSelection struct {
+	IncludeAll      *struct{}    `json:"include-all,omitempty"`
+	IncludeControls []controlRef `json:"include-controls,omitempty"`
+}
+
+type controlRef struct {
+	ControlID string `json:"control-id"`
+}
+
+type observation struct {
+	UUID        string    `json:"uuid"`
+	Title       string    `json:"title,omitempty"`
+	Description string    `json:"description"`
+	Props       []prop    `json:"props,omitempty"`
+	Methods     []string  `json:"methods"`
+	Subjects    []subject `json:"subjects,omitempty"`
+	Collected   time.Time `json:"collected"`
+}
+
+type subject struct {
+	SubjectUUID string `json:"subject-uuid"`
+	Type        string `json:"type"`
+	Title       string `json:"title,omitempty"`
+}
+
+type finding struct {
+	UUID                string               `json:"uuid"`
+	Title               string               `json:"title"`
+	Description         string               `json:"description"`
+	Target              findingTarget        `json:"target"`
+	RelatedObservations []relatedObservation `json:"related-observations"`
+}
+
+type findingTarget struct {
+	Type     string          `json:"type"`
+	TargetID string          `json:"target-id"`
+	Status   objectiveStatus `json:"status"`
+}
+
+type objectiveStatus struct {
+	State string `json:"state"`
+}
+
+type relatedObservation struct {
+	ObservationUUID string `json:"observation-uuid"`
+}
+
+type prop struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	NS    string `json:"ns,omitempty"`
+}
+
+// propAttributes lists the record attributes copied onto observations as props.
+var propAttributes = []struct {
+	attribute string
+	name      string
+}{
+	{proofwatch.POLICY_ENGINE_NAME, "policy-engine-name"},
+	{proofwatch.POLICY_RULE_ID, "policy-rule-id"},
+	{proofwatch.POLICY_EVALUATION_RESULT, "policy-evaluation-result"},
+	{proofwatch.COMPLIANCE_CONTROL_CATALOG_ID, "compliance-control-catalog-id"},
+	{proofwatch.COMPLIANCE_STATUS, "compliance-status"},
+	{proofwatch.COMPLIANCE_RISK_LEVEL, "compliance-risk-level"},
+}
+
+// aggregator collects observations for one window and groups them by control.
+type aggregator struct {
+	start        time.Time
+	observations []observation
+	// controls maps a control ID to the observations that assess it.
+	controls map[string][]string
+	// failing records controls with at least one failed observation.
+	failing map[string]bool
+}
+
+func newAggregator(start time.Time) *aggregator {
+	return &aggregator{
+		start:    start,
+		controls: make(map[string][]string),
+		failing:  make(map[string]bool),
+	}
+}
+
+func (a *aggregator) empty() bool {
+	return len(a.observations) == 0
+}
+
+func (a *aggregator) size() int {
+	return len(a.observations)
+}
+
+// add converts every log record into an observation.
+func (a *aggregator) add(logs plog.Logs) {
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		sls := logs.ResourceLogs().At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				a.addRecord(lrs.At(k))
+			}
+		}
+	}
+}
+
+func (a *aggregator) addRecord(record plog.LogRecord) {
+	attrs := record.Attributes()
+	obs := observation{
+		UUID:        uuid.NewString(),
+		Title:       str(attrs, proofwatch.POLICY_RULE_ID),
+		Description: str(attrs, proofwatch.POLICY_EVALUATION_MESSAGE),
+		Methods:     []string{"TEST"},
+		Collected:   recordTime(record),
+	}
+	if obs.Description == "" {
+		obs.Description = record.Body().AsString()
+	}
+	if obs.Description == "" {
+		// description is required by the OSCAL schema.
+		obs.Description = "Automated policy evaluation"
+	}
+	for _, p := range propAttributes {
+		if v := str(attrs, p.attribute); v != "" {
+			obs.Props = append(obs.Props, prop{Name: p.name, Value: v, NS: propNamespace})
+		}
+	}
+	if targetID := str(attrs, proofwatch.POLICY_TARGET_ID); targetID != "" {
+		title := str(attrs, proofwatch.POLICY_TARGET_NAME)
+		if title == "" {
+			title = targetID
+		}
+		obs.Subjects = []subject{{
+			SubjectUUID: uuid.NewSHA1(subjectNamespace, []byte(targetID)).String(),
+			Type:        "inventory-item",
+			Title:       title,
+		}}
+	}
+	a.observations = append(a.observations, obs)
+
+	controlID := str(attrs, proofwatch.COMPLIANCE_CONTROL_ID)
+	if controlID == "" {
+		return
+	}
+	a.controls[controlID] = append(a.controls[controlID], obs.UUID)
+	if failed(attrs) {
+		a.failing[controlID] = true
+	}
+}
+
+// build assembles the aggregated observations into a single-result document.
+func (a *aggregator) build(cfg *Config, end time.Time) document {
+	controlIDs := make([]string, 0, len(a.controls))
+	for id := range a.controls {
+		controlIDs = append(controlIDs, id)
+	}
+	sort.Strings(controlIDs)
+
+	res := result{
+		UUID:         uuid.NewString(),
+		Title:        cfg.Title,
+		Description:  "Automated assessment results aggregated from compliance evidence logs.",
+		Start:        a.start.UTC(),
+		End:          end.UTC(),
+		Observations: a.observations,
+	}
+
+	if len(controlIDs) == 0 {
+		res.ReviewedControls.ControlSelections = []controlSelection{{IncludeAll: &struct{}{}}}
+	} else {
+		selection := controlSelection{}
+		for _, id := range controlIDs {
+			selection.IncludeControls = append(selection.IncludeControls, controlRef{ControlID: id})
+			res.Findings = append(res.Findings, a.finding(id))
+		}
+		res.ReviewedControls.ControlSelections = []controlSelection{selection}
+	}
+
+	return document{AssessmentResults: assessmentResults{
+		UUID: uuid.NewString(),
+		Metadata: metadata{
+			Title:        cfg.Title,
+			LastModified: end.UTC(),
+			Version:      end.UTC().Format(time.RFC3339),
+			OSCALVersion: oscalVersion,
+		},
+		ImportAP: importAP{Href: cfg.ImportAPHref},
+		Results:  []result{res},
+	}}
+}
+
+func (a *aggregator) finding(controlID string) finding {
+	state := stateSatisfied
+	if a.failing[controlID] {
+		state = stateNotSatisfied
+	}
+	f := finding{
+		UUID:        uuid.NewString(),
+		Title:       controlID,
+		Description: "Control " + controlID + " is " + state + " based on the related observations.",
+		Target: findingTarget{
+			Type:     "statement-id",
+			TargetID: controlID + "_smt",
+			Status:   objectiveStatus{State: state},
+		},
+	}
+	for _, id := range a.controls[controlID] {
+		f.RelatedObservations = append(f.RelatedObservations, relatedObservation{ObservationUUID: id})
+	}
+	return f
+}
+
+func failed(attrs pcommon.Map) bool {
+	return str(attrs, proofwatch.POLICY_EVALUATION_RESULT) == "Failed" ||
+		str(attrs, proofwatch.COMPLIANCE_STATUS) == "Non-Compliant"
+}
+
+func recordTime(record plog.LogRecord) time.Time {
+	if record.Timestamp() != 0 {
+		return record.Timestamp().AsTime().UTC()
+	}
+	return record.ObservedTimestamp().AsTime().UTC()
+}
+
+func str(attrs pcommon.Map, key string) string {
+	if v, ok := attrs.Get(key); ok {
+		return v.AsString()
+	}
+	return ""
+}
@@ -0,0 +1,124 @@
+package oscalexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+var (
+	windowStart = time.Date(2026, 6, 2, 10, 0, 0, 0, time.UTC)
+	windowEnd   = time.Date(2026, 6, 2, 11, 0, 0, 0, time.UTC)
+)
+
+// evidenceLogs builds one record per attribute map.
+func evidenceLogs(records ...map[string]any) plog.Logs {
+	logs := plog.NewLogs()
+	lrs := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, attrs := range records {
+		record := lrs.AppendEmpty()
+		record.SetTimestamp(pcommon.NewTimestampFromTime(windowStart.Add(time.Minute)))
+		_ = record.Attributes().FromRaw(attrs)
+	}
+	return logs
+}
+
+func TestAggregatorBuild(t *testing.T) {
+	agg := newAggregator(windowStart)
+	agg.add(evidenceLogs(
+		map[string]any{
+			proofwatch.POLICY_RULE_ID:            "ssh-root-login",
+			proofwatch.POLICY_ENGINE_NAME:        "OpenSCAP",
+			proofwatch.POLICY_EVALUATION_RESULT:  "Passed",
+			proofwatch.POLICY_EVALUATION_MESSAGE: "PermitRootLogin is no",
+			proofwatch.POLICY_TARGET_ID:          "web-01",
+			proofwatch.COMPLIANCE_CONTROL_ID:     "ac-6",
+		},
+		map[string]any{
+			proofwatch.POLICY_RULE_ID:           "sudo-nopasswd",
+			proofwatch.POLICY_EVALUATION_RESULT: "Failed",
+			proofwatch.POLICY_TARGET_ID:         "web-02",
+			proofwatch.POLICY_TARGET_NAME:       "web-02.example.com",
+			proofwatch.COMPLIANCE_CONTROL_ID:    "ac-6",
+		},
+		map[string]any{
+			proofwatch.POLICY_RULE_ID:        "audit-enabled",
+			proofwatch.COMPLIANCE_STATUS:     "Compliant",
+			proofwatch.COMPLIANCE_CONTROL_ID: "au-2",
+		},
+	))
+
+	cfg := createDefaultConfig().(*Config)
+	doc := agg.build(cfg, windowEnd)
+
+	ar := doc.AssessmentResults
+	assert.NotEmpty(t, ar.UUID)
+	assert.Equal(t, defaultTitle, ar.Metadata.Title)
+	assert.Equal(t, oscalVersion, ar.Metadata.OSCALVersion)
+	assert.Equal(t, windowEnd, ar.Metadata.LastModified)
+	assert.Equal(t, defaultImportHref, ar.ImportAP.Href)
+	require.Len(t, ar.Results, 1)
+
+	res := ar.Results[0]
+	assert.Equal(t, windowStart, res.Start)
+	assert.Equal(t, windowEnd, res.End)
+	assert.Equal(t, []controlSelection{{IncludeControls: []controlRef{{ControlID: "ac-6"}, {ControlID: "au-2"}}}},
+		res.ReviewedControls.ControlSelections)
+
+	require.Len(t, res.Observations, 3)
+	obs := res.Observations[0]
+	assert.Equal(t, "ssh-root-login", obs.Title)
+	assert.Equal(t, "PermitRootLogin is no", obs.Description)
+	assert.Equal(t, []string{"TEST"}, obs.Methods)
+	assert.Equal(t, windowStart.Add(time.Minute), obs.Collected)
+	assert.Contains(t, obs.Props, prop{Name: "policy-engine-name", Value: "OpenSCAP", NS: propNamespace})
+	require.Len(t, obs.Subjects, 1)
+	assert.Equal(t, "web-01", obs.Subjects[0].Title)
+	assert.Equal(t, "inventory-item", obs.Subjects[0].Type)
+	assert.Equal(t, "web-02.example.com", res.Observations[1].Subjects[0].Title)
+	assert.Empty(t, res.Observations[2].Subjects)
+
+	require.Len(t, res.Findings, 2)
+	ac6 := res.Findings[0]
+	assert.Equal(t, "ac-6_smt", ac6.Target.TargetID)
+	assert.Equal(t, "statement-id", ac6.Target.Type)
+	assert.Equal(t, stateNotSatisfied, ac6.Target.Status.State)
+	assert.Equal(t, []relatedObservation{
+		{ObservationUUID: res.Observations[0].UUID},
+		{ObservationUUID: res.Observations[1].UUID},
+	}, ac6.RelatedObservations)
+	assert.Equal(t, stateSatisfied, res.Findings[1].Target.Status.State)
+}
+
+func TestAggregatorWithoutControls(t *testing.T) {
+	agg := newAggregator(windowStart)
+	assert.True(t, agg.empty())
+
+	logs := evidenceLogs(map[string]any{proofwatch.POLICY_TARGET_ID: "web-01"})
+	logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().SetStr("rule: pass")
+	agg.add(logs)
+	agg.add(evidenceLogs(map[string]any{proofwatch.POLICY_TARGET_ID: "web-01"}))
+	assert.False(t, agg.empty())
+
+	res := agg.build(createDefaultConfig().(*Config), windowEnd).AssessmentResults.Results[0]
+	assert.Equal(t, []controlSelection{{IncludeAll: &struct{}{}}}, res.ReviewedControls.ControlSelections)
+	assert.Empty(t, res.Findings)
+
+	require.Len(t, res.Observations, 2)
+	assert.Equal(t, "rule: pass", res.Observations[0].Description)
+	assert.Equal(t, "Automated policy evaluation", res.Observations[1].Description)
+	// The same target always maps to the same subject UUID.
+	assert.Equal(t, res.Observations[0].Subjects[0].SubjectUUID, res.Observations[1].Subjects[0].SubjectUUID)
+}
+
+func TestRecordTimeFallsBackToObserved(t *testing.T) {
+	record := plog.NewLogRecord()
+	record.SetObservedTimestamp(pcommon.NewTimestampFromTime(windowEnd))
+	assert.Equal(t, windowEnd, recordTime(record))
+}
@@ -0,0 +1,74 @@
+package poamexporter
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	defaultInterval          = time.Hour
+	defaultSustainedFor      = 24 * time.Hour
+	defaultRemediationWindow = 30 * 24 * time.Hour
+	defaultTitle             = "ComplyBeacon Plan of Action and Milestones"
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines the configuration for the POA&M exporter.
+type Config struct {
+	// Path is the POA&M document. An existing document is updated in place,
+	// keeping its other entries; otherwise a new document is generated.
+	Path string `mapstructure:"path"`
+
+	// Interval is how often the document is updated. Zero updates it only
+	// on shutdown.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// SustainedFor is how long a control must fail continuously before it
+	// becomes a POA&M item. Zero adds every failing control.
+	SustainedFor time.Duration `mapstructure:"sustained_for"`
+
+	// RemediationWindow sets the risk deadline relative to when the control
+	// started failing. Zero sets no deadline.
+	RemediationWindow time.Duration `mapstructure:"remediation_window"`
+
+	// Title is written to metadata.title of a generated document.
+	Title string `mapstructure:"title"`
+
+	// ImportSSPHref references the system security plan of a generated document.
+	ImportSSPHref string `mapstructure:"import_ssp_href"`
+
+	// SystemID identifies the system of a generated document.
+	SystemID string `mapstructure:"system_id"`
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Interval:          defaultInterval,
+		SustainedFor:      defaultSustainedFor,
+		RemediationWindow: defaultRemediationWindow,
+		Title:             defaultTitle,
+	}
+}
+
+// Validate checks the exporter configuration is valid.
+func (c *Config) Validate() error {
+	if c.Path == "" {
+		return errors.New("path must not be empty")
+	}
+	if c.Interval < 0 {
+		return errors.New("interval must not be negative")
+	}
+	if c.SustainedFor < 0 {
+		return errors.New("sustained_for must not be negative")
+	}
+	if c.RemediationWindow < 0 {
+		return errors.New("remediation_window must not be negative")
+	}
+	if c.Title == "" {
+		return errors.New("title must not be empty")
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package poamexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id       component.ID
+		expected *Config
+	}{
+		{
+			id: component.NewID(componentType),
+			expected: &Config{
+				Path:              "/var/lib/complybeacon/poam.json",
+				Interval:          defaultInterval,
+				SustainedFor:      defaultSustainedFor,
+				RemediationWindow: defaultRemediationWindow,
+				Title:             defaultTitle,
+			},
+		},
+		{
+			id: component.NewIDWithName(componentType, "custom"),
+			expected: &Config{
+				Path:          "./poam.json",
+				Interval:      15 * time.Minute,
+				SustainedFor:  72 * time.Hour,
+				Title:         "Platform POA&M",
+				ImportSSPHref: "https://grc.example.com/ssp/platform.json",
+				SystemID:      "platform-prod",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, sub.Unmarshal(cfg))
+			require.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "empty path",
+			mutate:  func(cfg *Config) { cfg.Path = "" },
+			wantErr: "path must not be empty",
+		},
+		{
+			name:    "negative interval",
+			mutate:  func(cfg *Config) { cfg.Interval = -time.Second },
+			wantErr: "interval must not be negative",
+		},
+		{
+			name:    "negative sustained_for",
+			mutate:  func(cfg *Config) { cfg.SustainedFor = -time.Second },
+			wantErr: "sustained_for must not be negative",
+		},
+		{
+			name:    "negative remediation_window",
+			mutate:  func(cfg *Config) { cfg.RemediationWindow = -time.Second },
+			wantErr: "remediation_window must not be negative",
+		},
+		{
+			name:    "empty title",
+			mutate:  func(cfg *Config) { cfg.Title = "" },
+			wantErr: "title must not be empty",
+		},
+		{
+			name:   "valid",
+			mutate: func(*Config) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Path = filepath.Join(t.TempDir(), "poam.json")
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
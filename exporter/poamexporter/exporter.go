@@ -0,0 +1,128 @@
+package poamexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+type poamExporter struct {
+	cfg      *Config
+	settings exporter.Settings
+	now      func() time.Time
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	tracker *tracker
+
+	// flushMu serializes updates of the document.
+	flushMu sync.Mutex
+}
+
+func newPOAMExporter(cfg *Config, set exporter.Settings) *poamExporter {
+	return &poamExporter{
+		cfg:      cfg,
+		settings: set,
+		now:      time.Now,
+		tracker:  newTracker(),
+	}
+}
+
+func (e *poamExporter) start(_ context.Context, _ component.Host) error {
+	if err := os.MkdirAll(filepath.Dir(e.cfg.Path), 0o750); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	e.cancel = cancel
+	if e.cfg.Interval > 0 {
+		e.wg.Add(1)
+		go e.flushEvery(runCtx, e.cfg.Interval)
+	}
+	return nil
+}
+
+// shutdown updates the document with the evidence received since the last update.
+func (e *poamExporter) shutdown(_ context.Context) error {
+	if e.cancel != nil {
+		e.cancel()
+	}
+	e.wg.Wait()
+	return e.flush()
+}
+
+func (e *poamExporter) consumeLogs(_ context.Context, logs plog.Logs) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.tracker.add(logs)
+	return nil
+}
+
+func (e *poamExporter) flushEvery(ctx context.Context, interval time.Duration) {
+	defer e.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := e.flush(); err != nil {
+				e.settings.Logger.Warn("failed to update POA&M document; will retry on the next interval", zap.Error(err))
+			}
+		}
+	}
+}
+
+// flush merges the current control states into the document. The states are
+// kept in memory, so a failed update is repeated in full by the next flush.
+func (e *poamExporter) flush() error {
+	e.flushMu.Lock()
+	defer e.flushMu.Unlock()
+
+	now := e.now()
+	e.mu.Lock()
+	controls := e.tracker.snapshot(now, e.cfg.SustainedFor)
+	e.mu.Unlock()
+
+	existing, err := os.ReadFile(e.cfg.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		existing = nil
+	} else if err != nil {
+		return fmt.Errorf("failed to read %s: %w", e.cfg.Path, err)
+	}
+
+	data, err := merge(existing, e.cfg, controls, now)
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %w", e.cfg.Path, err)
+	}
+	if data == nil {
+		return nil
+	}
+	return e.writeFile(data)
+}
+
+// writeFile replaces the document atomically, so readers never see a partial write.
+func (e *poamExporter) writeFile(data []byte) error {
+	tmp := e.cfg.Path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", e.cfg.Path, err)
+	}
+	if err := os.Rename(tmp, e.cfg.Path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", e.cfg.Path, err)
+	}
+	return nil
+}
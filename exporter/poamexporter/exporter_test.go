@@ -0,0 +1,83 @@
+package poamexporter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+)
+
+func newTestExporter(t *testing.T, cfg *Config, now *time.Time) *poamExporter {
+	t.Helper()
+	e := newPOAMExporter(cfg, exportertest.NewNopSettings(componentType))
+	e.now = func() time.Time { return *now }
+	require.NoError(t, e.start(context.Background(), componenttest.NewNopHost()))
+	return e
+}
+
+func TestFlushWritesDocument(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Interval = 0
+	cfg.Path = filepath.Join(t.TempDir(), "poam", "poam.json")
+	now := failureStart.Add(time.Hour)
+	e := newTestExporter(t, cfg, &now)
+
+	require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs(failure("ac-2", "r1", "web-01"))))
+
+	// Nothing is written while the failure is not sustained.
+	require.NoError(t, e.flush())
+	_, err := os.Stat(cfg.Path)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	now = failureStart.Add(cfg.SustainedFor)
+	require.NoError(t, e.shutdown(context.Background()))
+
+	doc := readDocument(t, cfg.Path)
+	require.Len(t, doc.Risks, 1)
+	assert.Equal(t, riskOpen, doc.Risks[0].Status)
+	assert.Len(t, doc.POAMItems, 1)
+	assert.Equal(t, now, doc.Metadata.LastModified)
+}
+
+func TestFlushLeavesUnchangedDocument(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Interval = 0
+	cfg.SustainedFor = 0
+	cfg.Path = filepath.Join(t.TempDir(), "poam.json")
+	now := failureStart
+	e := newTestExporter(t, cfg, &now)
+
+	require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs(failure("ac-2", "r1", "web-01"))))
+	require.NoError(t, e.flush())
+	first, err := os.ReadFile(cfg.Path)
+	require.NoError(t, err)
+
+	now = now.Add(time.Hour)
+	require.NoError(t, e.flush())
+	second, err := os.ReadFile(cfg.Path)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+}
+
+func TestFlushKeepsInvalidDocument(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Interval = 0
+	cfg.SustainedFor = 0
+	cfg.Path = filepath.Join(t.TempDir(), "poam.json")
+	require.NoError(t, os.WriteFile(cfg.Path, []byte("not json"), 0o600))
+	now := failureStart
+	e := newTestExporter(t, cfg, &now)
+
+	require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs(failure("ac-2", "r1", "web-01"))))
+	assert.ErrorContains(t, e.flush(), "failed to update")
+
+	data, err := os.ReadFile(cfg.Path)
+	require.NoError(t, err)
+	assert.Equal(t, "not json", string(data))
+}
@@ -0,0 +1,458 @@
+package poamexporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	oscalVersion = "1.1.2"
+
+	// propNamespace qualifies the ComplyBeacon-specific props on POA&M entries.
+	propNamespace = "https://github.com/complytime/complybeacon"
+
+	rootKey = "plan-of-action-and-milestones"
+
+	riskOpen   = "open"
+	riskClosed = "closed"
+)
+
+// entryNamespace seeds the name-based UUIDs of the entries generated for a
+// control, so every update of the document finds the entries it wrote before.
+var entryNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte(propNamespace+"/poam"))
+
+type metadata struct {
+	Title        string    `json:"title"`
+	LastModified time.Time `json:"last-modified"`
+	Version      string    `json:"version"`
+	OSCALVersion string    `json:"oscal-version"`
+}
+
+type importSSP struct {
+	Href string `json:"href"`
+}
+
+type systemID struct {
+	ID string `json:"id"`
+}
+
+type observation struct {
+	UUID        string    `json:"uuid"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Props       []prop    `json:"props,omitempty"`
+	Methods     []string  `json:"methods"`
+	Subjects    []subject `json:"subjects,omitempty"`
+	Collected   time.Time `json:"collected"`
+}
+
+type subject struct {
+	SubjectUUID string `json:"subject-uuid"`
+	Type        string `json:"type"`
+	Title       string `json:"title,omitempty"`
+}
+
+type risk struct {
+	UUID                string               `json:"uuid"`
+	Title               string               `json:"title"`
+	Description         string               `json:"description"`
+	Statement           string               `json:"statement"`
+	Props               []prop               `json:"props,omitempty"`
+	Status              string               `json:"status"`
+	Deadline            *time.Time           `json:"deadline,omitempty"`
+	RelatedObservations []relatedObservation `json:"related-observations,omitempty"`
+}
+
+type poamItem struct {
+	UUID                string               `json:"uuid"`
+	Title               string               `json:"title"`
+	Description         string               `json:"description"`
+	Props               []prop               `json:"props,omitempty"`
+	RelatedObservations []relatedObservation `json:"related-observations,omitempty"`
+	RelatedRisks        []relatedRisk        `json:"related-risks,omitempty"`
+}
+
+type relatedObservation struct {
+	ObservationUUID string `json:"observation-uuid"`
+}
+
+type relatedRisk struct {
+	RiskUUID string `json:"risk-uuid"`
+}
+
+type prop struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	NS    string `json:"ns,omitempty"`
+}
+
+// entryList is a list of POA&M entries indexed by UUID. Entries are kept as
+// raw JSON, so fields added by other tools or by people survive updates.
+type entryList struct {
+	items []json.RawMessage
+	index map[string]int
+}
+
+func decodeEntryList(data json.RawMessage) (*entryList, error) {
+	l := &entryList{index: make(map[string]int)}
+	if len(data) == 0 {
+		return l, nil
+	}
+	if err := json.Unmarshal(data, &l.items); err != nil {
+		return nil, err
+	}
+	for i, item := range l.items {
+		var entry struct {
+			UUID string `json:"uuid"`
+		}
+		if err := json.Unmarshal(item, &entry); err != nil {
+			return nil, err
+		}
+		l.index[entry.UUID] = i
+	}
+	return l, nil
+}
+
+// field returns a field of the entry with the given UUID.
+func (l *entryList) field(id, name string) (json.RawMessage, bool) {
+	i, ok := l.index[id]
+	if !ok {
+		return nil, false
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(l.items[i], &fields); err != nil {
+		return nil, false
+	}
+	value, ok := fields[name]
+	return value, ok
+}
+
+// upsert adds the entry, or overwrites the fields it sets on the existing
+// entry with the same UUID. Fields named in keep are only written when the
+// existing entry lacks them. upsert reports whether the list changed.
+func (l *entryList) upsert(id string, entry any, keep ...string) (bool, error) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return false, err
+	}
+	i, ok := l.index[id]
+	if !ok {
+		l.index[id] = len(l.items)
+		l.items = append(l.items, data)
+		return true, nil
+	}
+
+	var fields, current map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(l.items[i], &current); err != nil {
+		return false, err
+	}
+	changed := false
+	for name, value := range fields {
+		old, exists := current[name]
+		if exists && (slices.Contains(keep, name) || equalJSON(old, value)) {
+			continue
+		}
+		current[name] = value
+		changed = true
+	}
+	if !changed {
+		return false, nil
+	}
+	if l.items[i], err = json.Marshal(current); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (l *entryList) raw() json.RawMessage {
+	data, _ := json.Marshal(l.items)
+	return data
+}
+
+// merge applies the control snapshots to a POA&M document. data is the
+// existing document, or nil to generate a new one. Sustained failures get an
+// open risk and a POA&M item; risks of controls that pass again are closed.
+// merge returns nil when there is nothing to write.
+func merge(data []byte, cfg *Config, controls []controlSnapshot, now time.Time) ([]byte, error) {
+	root := make(map[string]json.RawMessage)
+	if data != nil {
+		var doc map[string]json.RawMessage
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		body, ok := doc[rootKey]
+		if !ok {
+			return nil, errors.New("not an OSCAL plan-of-action-and-milestones document")
+		}
+		if err := json.Unmarshal(body, &root); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := newDocument(root, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	lists := make(map[string]*entryList)
+	for _, name := range []string{"observations", "risks", "poam-items"} {
+		l, err := decodeEntryList(root[name])
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", name, err)
+		}
+		lists[name] = l
+	}
+
+	changed := false
+	for _, c := range controls {
+		updated, err := applyControl(lists, cfg, c)
+		if err != nil {
+			return nil, err
+		}
+		changed = changed || updated
+	}
+	if !changed {
+		return nil, nil
+	}
+
+	for name, l := range lists {
+		if len(l.items) > 0 {
+			root[name] = l.raw()
+		}
+	}
+	if err := touchMetadata(root, cfg, now); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(map[string]any{rootKey: root}, "", "  ")
+}
+
+func newDocument(root map[string]json.RawMessage, cfg *Config) error {
+	fields := map[string]any{"uuid": uuid.NewString()}
+	if cfg.ImportSSPHref != "" {
+		fields["import-ssp"] = importSSP{Href: cfg.ImportSSPHref}
+	}
+	if cfg.SystemID != "" {
+		fields["system-id"] = systemID{ID: cfg.SystemID}
+	}
+	for name, value := range fields {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		root[name] = data
+	}
+	return nil
+}
+
+// touchMetadata records the update in the document metadata, keeping the
+// title and any other metadata of an existing document.
+func touchMetadata(root map[string]json.RawMessage, cfg *Config, now time.Time) error {
+	fields := make(map[string]json.RawMessage)
+	if existing, ok := root["metadata"]; ok {
+		if err := json.Unmarshal(existing, &fields); err != nil {
+			return fmt.Errorf("failed to decode metadata: %w", err)
+		}
+	}
+	data, err := json.Marshal(metadata{
+		Title:        cfg.Title,
+		LastModified: now.UTC(),
+		Version:      now.UTC().Format(time.RFC3339),
+		OSCALVersion: oscalVersion,
+	})
+	if err != nil {
+		return err
+	}
+	var updates map[string]json.RawMessage
+	if err := json.Unmarshal(data, &updates); err != nil {
+		return err
+	}
+	for name, value := range updates {
+		if _, ok := fields[name]; ok && (name == "title" || name == "oscal-version") {
+			continue
+		}
+		fields[name] = value
+	}
+	root["metadata"], err = json.Marshal(fields)
+	return err
+}
+
+// applyControl writes the entries of one control and reports whether any changed.
+func applyControl(lists map[string]*entryList, cfg *Config, c controlSnapshot) (bool, error) {
+	obsID := entryUUID("observation", c.key)
+	riskID := entryUUID("risk", c.key)
+	itemID := entryUUID("poam-item", c.key)
+	risks := lists["risks"]
+
+	status, exists := risks.field(riskID, "status")
+	open := exists && equalJSON(status, json.RawMessage(`"`+riskOpen+`"`))
+
+	switch {
+	case c.sustained:
+		var keep []string
+		if open {
+			// The deadline of a risk that is still open stays as first set.
+			keep = append(keep, "deadline")
+		}
+		changed := false
+		for _, u := range []struct {
+			list  *entryList
+			id    string
+			entry any
+			keep  []string
+		}{
+			{lists["observations"], obsID, buildObservation(obsID, c), nil},
+			{risks, riskID, buildRisk(riskID, obsID, cfg, c), keep},
+			{lists["poam-items"], itemID, buildItem(itemID, obsID, riskID, c), nil},
+		} {
+			updated, err := u.list.upsert(u.id, u.entry, u.keep...)
+			if err != nil {
+				return false, err
+			}
+			changed = changed || updated
+		}
+		return changed, nil
+	case !c.failing && open:
+		return risks.upsert(riskID, map[string]string{"status": riskClosed})
+	default:
+		return false, nil
+	}
+}
+
+func buildObservation(id string, c controlSnapshot) observation {
+	obs := observation{
+		UUID:        id,
+		Title:       "Failing evidence for " + controlTitle(c.key),
+		Description: describeFailures(c.failures),
+		Props:       controlProps(c.key),
+		Methods:     []string{"TEST"},
+	}
+	seen := make(map[string]bool)
+	for _, f := range c.failures {
+		if f.at.After(obs.Collected) {
+			obs.Collected = f.at
+		}
+		if f.targetID == "" || seen[f.targetID] {
+			continue
+		}
+		seen[f.targetID] = true
+		title := f.targetName
+		if title == "" {
+			title = f.targetID
+		}
+		obs.Subjects = append(obs.Subjects, subject{
+			SubjectUUID: uuid.NewSHA1(entryNamespace, []byte("subject/"+f.targetID)).String(),
+			Type:        "inventory-item",
+			Title:       title,
+		})
+	}
+	return obs
+}
+
+func buildRisk(id, obsID string, cfg *Config, c controlSnapshot) risk {
+	r := risk{
+		UUID:        id,
+		Title:       "Sustained failure of " + controlTitle(c.key),
+		Description: fmt.Sprintf("Control %s has failed continuously since %s.", controlTitle(c.key), c.since.UTC().Format(time.RFC3339)),
+		Statement:   fmt.Sprintf("%d rule evaluation(s) report the control as failing.", len(c.failures)),
+		Props:       controlProps(c.key),
+		Status:      riskOpen,
+		RelatedObservations: []relatedObservation{
+			{ObservationUUID: obsID},
+		},
+	}
+	if level := highestRiskLevel(c.failures); level != "" {
+		r.Props = append(r.Props, prop{Name: "compliance-risk-level", Value: level, NS: propNamespace})
+	}
+	if cfg.RemediationWindow > 0 {
+		deadline := c.since.Add(cfg.RemediationWindow).UTC()
+		r.Deadline = &deadline
+	}
+	return r
+}
+
+func buildItem(id, obsID, riskID string, c controlSnapshot) poamItem {
+	description := "Remediate the failing rules of " + controlTitle(c.key) + "."
+	var remediations []string
+	for _, f := range c.failures {
+		if f.remediation != "" && !slices.Contains(remediations, f.remediation) {
+			remediations = append(remediations, f.remediation)
+		}
+	}
+	if len(remediations) > 0 {
+		description += "\n\n" + strings.Join(remediations, "\n\n")
+	}
+	return poamItem{
+		UUID:                id,
+		Title:               "Remediate " + controlTitle(c.key),
+		Description:         description,
+		Props:               controlProps(c.key),
+		RelatedObservations: []relatedObservation{{ObservationUUID: obsID}},
+		RelatedRisks:        []relatedRisk{{RiskUUID: riskID}},
+	}
+}
+
+// describeFailures lists the failing rule evaluations, one per line.
+func describeFailures(failures []ruleResult) string {
+	lines := make([]string, 0, len(failures))
+	for _, f := range failures {
+		rule := f.ruleID
+		if f.ruleName != "" {
+			rule += " (" + f.ruleName + ")"
+		}
+		line := "Rule " + rule + " failed"
+		if f.targetID != "" {
+			line += " on " + f.targetID
+		}
+		if f.message != "" {
+			line += ": " + f.message
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func highestRiskLevel(failures []ruleResult) string {
+	highest := ""
+	for _, f := range failures {
+		if riskLevels[f.riskLevel] > riskLevels[highest] {
+			highest = f.riskLevel
+		}
+	}
+	return highest
+}
+
+func controlProps(key controlKey) []prop {
+	props := []prop{{Name: "compliance-control-id", Value: key.controlID, NS: propNamespace}}
+	if key.catalogID != "" {
+		props = append(props, prop{Name: "compliance-control-catalog-id", Value: key.catalogID, NS: propNamespace})
+	}
+	return props
+}
+
+func controlTitle(key controlKey) string {
+	if key.catalogID == "" {
+		return key.controlID
+	}
+	return key.catalogID + " " + key.controlID
+}
+
+func entryUUID(kind string, key controlKey) string {
+	return uuid.NewSHA1(entryNamespace, []byte(kind+"/"+key.catalogID+"/"+key.controlID)).String()
+}
+
+func equalJSON(a, b json.RawMessage) bool {
+	var ca, cb bytes.Buffer
+	if json.Compact(&ca, a) != nil || json.Compact(&cb, b) != nil {
+		return false
+	}
+	return bytes.Equal(ca.Bytes(), cb.Bytes())
+}
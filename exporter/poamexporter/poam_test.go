@@ -0,0 +1,194 @@
+package poamexporter
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+var failureStart = time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+
+// testPOAM is the body of a POA&M document as written by the exporter.
+type testPOAM struct {
+	UUID         string         `json:"uuid"`
+	Metadata     metadata       `json:"metadata"`
+	ImportSSP    *importSSP     `json:"import-ssp"`
+	SystemID     *systemID      `json:"system-id"`
+	Observations []observation  `json:"observations"`
+	Risks        []risk         `json:"risks"`
+	POAMItems    []poamItem     `json:"poam-items"`
+	BackMatter   map[string]any `json:"back-matter"`
+}
+
+func decodeDocument(t *testing.T, data []byte) testPOAM {
+	t.Helper()
+	var doc struct {
+		POAM testPOAM `json:"plan-of-action-and-milestones"`
+	}
+	require.NoError(t, json.Unmarshal(data, &doc))
+	return doc.POAM
+}
+
+func readDocument(t *testing.T, path string) testPOAM {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return decodeDocument(t, data)
+}
+
+func evidenceLogs(records ...map[string]any) plog.Logs {
+	return evidenceLogsAt(failureStart, records...)
+}
+
+func evidenceLogsAt(at time.Time, records ...map[string]any) plog.Logs {
+	logs := plog.NewLogs()
+	lrs := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, attrs := range records {
+		record := lrs.AppendEmpty()
+		record.SetTimestamp(pcommon.NewTimestampFromTime(at))
+		_ = record.Attributes().FromRaw(attrs)
+	}
+	return logs
+}
+
+func TestMergeGeneratesDocument(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.SystemID = "platform-prod"
+	tr := newTracker()
+	high := failure("ac-2", "r1", "web-01")
+	high[proofwatch.COMPLIANCE_RISK_LEVEL] = "High"
+	high[proofwatch.COMPLIANCE_REMEDIATION_DESCRIPTION] = "Disable inactive accounts."
+	low := failure("ac-2", "r2", "web-02")
+	low[proofwatch.COMPLIANCE_RISK_LEVEL] = "Low"
+	tr.add(evidenceLogs(high, low))
+	now := failureStart.Add(48 * time.Hour)
+
+	data, err := merge(nil, cfg, tr.snapshot(now, cfg.SustainedFor), now)
+	require.NoError(t, err)
+	doc := decodeDocument(t, data)
+
+	assert.NotEmpty(t, doc.UUID)
+	assert.Equal(t, defaultTitle, doc.Metadata.Title)
+	assert.Equal(t, now, doc.Metadata.LastModified)
+	assert.Equal(t, oscalVersion, doc.Metadata.OSCALVersion)
+	assert.Equal(t, &systemID{ID: "platform-prod"}, doc.SystemID)
+	assert.Nil(t, doc.ImportSSP)
+
+	require.Len(t, doc.Observations, 1)
+	obs := doc.Observations[0]
+	assert.Len(t, obs.Subjects, 2)
+	assert.Contains(t, obs.Description, "Rule r1 failed on web-01")
+
+	require.Len(t, doc.Risks, 1)
+	r := doc.Risks[0]
+	assert.Equal(t, riskOpen, r.Status)
+	require.NotNil(t, r.Deadline)
+	assert.Equal(t, failureStart.Add(defaultRemediationWindow), *r.Deadline)
+	assert.Contains(t, r.Props, prop{Name: "compliance-risk-level", Value: "High", NS: propNamespace})
+	assert.Equal(t, []relatedObservation{{ObservationUUID: obs.UUID}}, r.RelatedObservations)
+
+	require.Len(t, doc.POAMItems, 1)
+	item := doc.POAMItems[0]
+	assert.Contains(t, item.Description, "Disable inactive accounts.")
+	assert.Equal(t, []relatedRisk{{RiskUUID: r.UUID}}, item.RelatedRisks)
+	assert.Contains(t, item.Props, prop{Name: "compliance-control-id", Value: "ac-2", NS: propNamespace})
+}
+
+func TestMergeSkipsFailuresNotSustained(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	tr := newTracker()
+	tr.add(evidenceLogs(failure("ac-2", "r1", "web-01")))
+	now := failureStart.Add(time.Hour)
+
+	data, err := merge(nil, cfg, tr.snapshot(now, cfg.SustainedFor), now)
+	require.NoError(t, err)
+	assert.Nil(t, data)
+}
+
+func TestMergeUpdatesExistingDocument(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	existing := []byte(`{
+  "plan-of-action-and-milestones": {
+    "uuid": "3f1b8f9e-2f7c-4d3e-9a51-6c0b0b8f4a11",
+    "metadata": {"title": "Agency POA&M", "last-modified": "2026-01-01T00:00:00Z", "version": "1", "oscal-version": "1.1.2"},
+    "import-ssp": {"href": "ssp.json"},
+    "risks": [{"uuid": "0e5b1d4e-7d7c-4f51-8c8e-0f3c6c2a9b10", "title": "Manual finding", "description": "d", "statement": "s", "status": "open"}],
+    "poam-items": [{"uuid": "9d1a7c3b-1e8e-4c55-b2b5-1b3c7e4f6a22", "title": "Manual item", "description": "d"}],
+    "back-matter": {"resources": []}
+  }
+}`)
+	tr := newTracker()
+	tr.add(evidenceLogs(failure("ac-2", "r1", "web-01")))
+	now := failureStart.Add(48 * time.Hour)
+
+	data, err := merge(existing, cfg, tr.snapshot(now, cfg.SustainedFor), now)
+	require.NoError(t, err)
+	doc := decodeDocument(t, data)
+
+	assert.Equal(t, "3f1b8f9e-2f7c-4d3e-9a51-6c0b0b8f4a11", doc.UUID)
+	assert.Equal(t, "Agency POA&M", doc.Metadata.Title)
+	assert.Equal(t, now, doc.Metadata.LastModified)
+	assert.Equal(t, &importSSP{Href: "ssp.json"}, doc.ImportSSP)
+	assert.NotNil(t, doc.BackMatter)
+	require.Len(t, doc.Risks, 2)
+	assert.Equal(t, "Manual finding", doc.Risks[0].Title)
+	require.Len(t, doc.POAMItems, 2)
+	assert.Equal(t, "Manual item", doc.POAMItems[0].Title)
+
+	// Merging the same state again leaves the document alone.
+	again, err := merge(data, cfg, tr.snapshot(now.Add(time.Hour), cfg.SustainedFor), now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Nil(t, again)
+}
+
+func TestMergeClosesAndReopensRisks(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	tr := newTracker()
+	tr.add(evidenceLogs(failure("ac-2", "r1", "web-01")))
+	now := failureStart.Add(48 * time.Hour)
+	data, err := merge(nil, cfg, tr.snapshot(now, cfg.SustainedFor), now)
+	require.NoError(t, err)
+
+	// A later failure of another rule keeps the original deadline.
+	tr.add(evidenceLogsAt(now, failure("ac-2", "r2", "web-01")))
+	data, err = merge(data, cfg, tr.snapshot(now, cfg.SustainedFor), now)
+	require.NoError(t, err)
+	doc := decodeDocument(t, data)
+	require.Len(t, doc.Risks, 1)
+	assert.Equal(t, failureStart.Add(defaultRemediationWindow), *doc.Risks[0].Deadline)
+
+	// The risk is closed once every rule passes.
+	passed := now.Add(time.Hour)
+	tr.add(evidenceLogsAt(passed, pass("ac-2", "r1", "web-01"), pass("ac-2", "r2", "web-01")))
+	data, err = merge(data, cfg, tr.snapshot(passed, cfg.SustainedFor), passed)
+	require.NoError(t, err)
+	doc = decodeDocument(t, data)
+	require.Len(t, doc.Risks, 1)
+	assert.Equal(t, riskClosed, doc.Risks[0].Status)
+	assert.Len(t, doc.POAMItems, 1)
+
+	// A new sustained failure reopens it with a new deadline.
+	failed := passed.Add(time.Hour)
+	tr.add(evidenceLogsAt(failed, failure("ac-2", "r1", "web-01")))
+	later := failed.Add(cfg.SustainedFor)
+	data, err = merge(data, cfg, tr.snapshot(later, cfg.SustainedFor), later)
+	require.NoError(t, err)
+	doc = decodeDocument(t, data)
+	require.Len(t, doc.Risks, 1)
+	assert.Equal(t, riskOpen, doc.Risks[0].Status)
+	assert.Equal(t, failed.Add(defaultRemediationWindow), *doc.Risks[0].Deadline)
+}
+
+func TestMergeRejectsOtherDocuments(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	_, err := merge([]byte(`{"assessment-results": {}}`), cfg, nil, failureStart)
+	assert.EqualError(t, err, "not an OSCAL plan-of-action-and-milestones document")
+}
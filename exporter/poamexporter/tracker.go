@@ -0,0 +1,200 @@
+package poamexporter
+
+import (
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+// riskLevels orders compliance.risk.level values from lowest to highest.
+var riskLevels = map[string]int{
+	"Informational": 1,
+	"Low":           2,
+	"Medium":        3,
+	"High":          4,
+	"Critical":      5,
+}
+
+// controlKey identifies a control across catalogs.
+type controlKey struct {
+	catalogID string
+	controlID string
+}
+
+// resultKey identifies the evaluation of one rule on one target.
+type resultKey struct {
+	ruleID   string
+	targetID string
+}
+
+// ruleResult is the latest evaluation of a rule on a target.
+type ruleResult struct {
+	resultKey
+	ruleName    string
+	targetName  string
+	message     string
+	riskLevel   string
+	remediation string
+	failed      bool
+	at          time.Time
+}
+
+// controlState holds the latest results of the rules assessing a control.
+type controlState struct {
+	results map[resultKey]ruleResult
+	// failingSince is when the control started failing, zero while it passes.
+	failingSince time.Time
+}
+
+func (s *controlState) failing() bool {
+	for _, r := range s.results {
+		if r.failed {
+			return true
+		}
+	}
+	return false
+}
+
+// controlSnapshot is the state of a control at the time the document is updated.
+type controlSnapshot struct {
+	key controlKey
+	// failing is set while any rule assessing the control fails.
+	failing bool
+	// sustained is set once the control has failed for at least sustained_for.
+	sustained bool
+	since     time.Time
+	// failures lists the failing results, ordered by rule and target.
+	failures []ruleResult
+}
+
+// tracker follows the pass/fail state of every control seen in the evidence.
+type tracker struct {
+	controls map[controlKey]*controlState
+}
+
+func newTracker() *tracker {
+	return &tracker{controls: make(map[controlKey]*controlState)}
+}
+
+// add records the outcome of every log record that assesses a control.
+func (t *tracker) add(logs plog.Logs) {
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		sls := logs.ResourceLogs().At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				t.addRecord(lrs.At(k))
+			}
+		}
+	}
+}
+
+func (t *tracker) addRecord(record plog.LogRecord) {
+	attrs := record.Attributes()
+	controlID := str(attrs, proofwatch.COMPLIANCE_CONTROL_ID)
+	if controlID == "" {
+		return
+	}
+	failed, ok := outcome(attrs)
+	if !ok {
+		return
+	}
+
+	key := controlKey{catalogID: str(attrs, proofwatch.COMPLIANCE_CONTROL_CATALOG_ID), controlID: controlID}
+	state, ok := t.controls[key]
+	if !ok {
+		state = &controlState{results: make(map[resultKey]ruleResult)}
+		t.controls[key] = state
+	}
+
+	res := ruleResult{
+		resultKey: resultKey{
+			ruleID:   str(attrs, proofwatch.POLICY_RULE_ID),
+			targetID: str(attrs, proofwatch.POLICY_TARGET_ID),
+		},
+		ruleName:    str(attrs, proofwatch.POLICY_RULE_NAME),
+		targetName:  str(attrs, proofwatch.POLICY_TARGET_NAME),
+		message:     str(attrs, proofwatch.POLICY_EVALUATION_MESSAGE),
+		riskLevel:   str(attrs, proofwatch.COMPLIANCE_RISK_LEVEL),
+		remediation: str(attrs, proofwatch.COMPLIANCE_REMEDIATION_DESCRIPTION),
+		failed:      failed,
+		at:          recordTime(record),
+	}
+	// Evidence delivered out of order must not overwrite a newer result.
+	if prev, ok := state.results[res.resultKey]; ok && res.at.Before(prev.at) {
+		return
+	}
+	state.results[res.resultKey] = res
+
+	switch {
+	case !state.failing():
+		state.failingSince = time.Time{}
+	case state.failingSince.IsZero():
+		state.failingSince = res.at
+	}
+}
+
+// snapshot returns the state of every control, ordered by catalog and control.
+func (t *tracker) snapshot(now time.Time, sustainedFor time.Duration) []controlSnapshot {
+	snapshots := make([]controlSnapshot, 0, len(t.controls))
+	for key, state := range t.controls {
+		s := controlSnapshot{key: key, since: state.failingSince}
+		for _, r := range state.results {
+			if r.failed {
+				s.failures = append(s.failures, r)
+			}
+		}
+		sort.Slice(s.failures, func(i, j int) bool {
+			a, b := s.failures[i], s.failures[j]
+			if a.ruleID != b.ruleID {
+				return a.ruleID < b.ruleID
+			}
+			return a.targetID < b.targetID
+		})
+		s.failing = len(s.failures) > 0
+		s.sustained = s.failing && now.Sub(state.failingSince) >= sustainedFor
+		snapshots = append(snapshots, s)
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		a, b := snapshots[i].key, snapshots[j].key
+		if a.catalogID != b.catalogID {
+			return a.catalogID < b.catalogID
+		}
+		return a.controlID < b.controlID
+	})
+	return snapshots
+}
+
+// outcome reports whether a record is a failure, and whether it is a pass or
+// a failure at all. Results such as Needs Review do not change the state of a
+// control.
+func outcome(attrs pcommon.Map) (failed bool, ok bool) {
+	switch {
+	case str(attrs, proofwatch.POLICY_EVALUATION_RESULT) == "Failed",
+		str(attrs, proofwatch.COMPLIANCE_STATUS) == "Non-Compliant":
+		return true, true
+	case str(attrs, proofwatch.POLICY_EVALUATION_RESULT) == "Passed",
+		str(attrs, proofwatch.COMPLIANCE_STATUS) == "Compliant":
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+func recordTime(record plog.LogRecord) time.Time {
+	if record.Timestamp() != 0 {
+		return record.Timestamp().AsTime().UTC()
+	}
+	return record.ObservedTimestamp().AsTime().UTC()
+}
+
+func str(attrs pcommon.Map, key string) string {
+	if v, ok := attrs.Get(key); ok {
+		return v.AsString()
+	}
+	return ""
+}
@@ -0,0 +1,193 @@
+package poamexporter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+func failure(controlID, ruleID, targetID string) map[string]any {
+	return map[string]any{
+		proofwatch.COMPLIANCE_CONTROL_CATALOG_ID: "nist_800_53",
+		proofwatch.COMPLIANCE_CONTROL_ID:         controlID,
+		proofwatch.POLICY_RULE_ID:                ruleID,
+		proofwatch.POLICY_TARGET_ID:              targetID,
+		proofwatch.POLICY_EVALUATION_RESULT:      "Failed",
+	}
+}
+
+func pass(controlID, ruleID, targetID string) map[string]any {
+	attrs := failure(controlID, ruleID, targetID)
+	attrs[proofwatch.POLICY_EVALUATION_RESULT] = "Passed"
+	return attrs
+}
+
+func TestTrackerSnapshot(t *testing.T) {
+	tr := newTracker()
+	tr.add(evidenceLogs(
+		failure("ac-2", "r1", "web-01"),
+		pass("ac-2", "r2", "web-01"),
+		failure("cm-6", "r3", "web-02"),
+		// Records without a control or a pass/fail result are ignored.
+		failure("", "r4", "web-01"),
+		map[string]any{
+			proofwatch.COMPLIANCE_CONTROL_ID:    "si-4",
+			proofwatch.POLICY_EVALUATION_RESULT: "Needs Review",
+		},
+	))
+	tr.add(evidenceLogsAt(failureStart.Add(2*time.Hour), pass("cm-6", "r3", "web-02")))
+	// An older result does not overwrite a newer one.
+	tr.add(evidenceLogsAt(failureStart.Add(time.Hour), failure("cm-6", "r3", "web-02")))
+
+	snapshots := tr.snapshot(failureStart.Add(24*time.Hour), 24*time.Hour)
+	require.Len(t, snapshots, 2)
+
+	ac2 := snapshots[0]
+	assert.Equal(t, controlKey{catalogID: "nist_800_53", controlID: "ac-2"}, ac2.key)
+	assert.True(t, ac2.failing)
+	assert.True(t, ac2.sustained)
+	assert.Equal(t, failureStart, ac2.since)
+	require.Len(t, ac2.failures, 1)
+	assert.Equal(t, "r1", ac2.failures[0].ruleID)
+
+	cm6 := snapshots[1]
+	assert.False(t, cm6.failing)
+	assert.False(t, cm6.sustained)
+	assert.True(t, cm6.since.IsZero())
+}
+
+func TestTrackerFailingSinceResetsOnPass(t *testing.T) {
+	tr := newTracker()
+	tr.add(evidenceLogs(failure("ac-2", "r1", "web-01")))
+	tr.add(evidenceLogsAt(failureStart.Add(time.Hour), pass("ac-2", "r1", "web-01")))
+	tr.add(evidenceLogsAt(failureStart.Add(2*time.Hour), failure("ac-2", "r1", "web-01")))
+
+	snapshots := tr.snapshot(failureStart.Add(24*time.Hour), 24*time.Hour)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, failureStart.Add(2*time.Hour), snapshots[0].since)
+	assert.False(t, snapshots[0].sustained)
+}
+
+// trackerStep delivers evidence at an offset from failureStart and then
+// updates the document.
+type trackerStep struct {
+	at      time.Duration
+	records []map[string]any
+	// restart replaces the exporter, so only the document on disk remains.
+	restart bool
+	// wantRisk is the status of the control's risk after the update, or empty
+	// when there is no document yet.
+	wantRisk string
+}
+
+func TestTrackerRiskLifecycle(t *testing.T) {
+	fail := failure("ac-2", "r1", "web-01")
+	ok := pass("ac-2", "r1", "web-01")
+	sustained := defaultSustainedFor
+
+	tests := []struct {
+		name  string
+		steps []trackerStep
+	}{
+		{
+			name: "failure not yet sustained",
+			steps: []trackerStep{
+				{records: []map[string]any{fail}},
+				{at: sustained - time.Minute},
+			},
+		},
+		{
+			name: "open on sustained failure",
+			steps: []trackerStep{
+				{records: []map[string]any{fail}},
+				{at: sustained, wantRisk: riskOpen},
+			},
+		},
+		{
+			name: "close on later pass",
+			steps: []trackerStep{
+				{records: []map[string]any{fail}},
+				{at: sustained, wantRisk: riskOpen},
+				{at: sustained + time.Hour, records: []map[string]any{ok}, wantRisk: riskClosed},
+			},
+		},
+		{
+			name: "reopen on new sustained failure",
+			steps: []trackerStep{
+				{records: []map[string]any{fail}},
+				{at: sustained, wantRisk: riskOpen},
+				{at: sustained + time.Hour, records: []map[string]any{ok}, wantRisk: riskClosed},
+				{at: sustained + 2*time.Hour, records: []map[string]any{fail}, wantRisk: riskClosed},
+				{at: 2*sustained + 2*time.Hour, wantRisk: riskOpen},
+			},
+		},
+		{
+			name: "restore open risk from disk and close it",
+			steps: []trackerStep{
+				{records: []map[string]any{fail}},
+				{at: sustained, wantRisk: riskOpen},
+				{at: sustained + time.Hour, restart: true, records: []map[string]any{ok}, wantRisk: riskClosed},
+			},
+		},
+		{
+			name: "restore open risk from disk while failing",
+			steps: []trackerStep{
+				{records: []map[string]any{fail}},
+				{at: sustained, wantRisk: riskOpen},
+				// The failure is not sustained for the new exporter yet, so
+				// the open risk is left as it is.
+				{at: sustained + time.Hour, restart: true, records: []map[string]any{fail}, wantRisk: riskOpen},
+				{at: 2*sustained + time.Hour, wantRisk: riskOpen},
+			},
+		},
+		{
+			name: "restore closed risk from disk and reopen it",
+			steps: []trackerStep{
+				{records: []map[string]any{fail}},
+				{at: sustained, wantRisk: riskOpen},
+				{at: sustained + time.Hour, records: []map[string]any{ok}, wantRisk: riskClosed},
+				{at: sustained + 2*time.Hour, restart: true, records: []map[string]any{fail}, wantRisk: riskClosed},
+				{at: 2*sustained + 2*time.Hour, wantRisk: riskOpen},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Interval = 0
+			cfg.Path = filepath.Join(t.TempDir(), "poam.json")
+			now := failureStart
+			e := newTestExporter(t, cfg, &now)
+
+			for i, step := range tt.steps {
+				now = failureStart.Add(step.at)
+				if step.restart {
+					e = newTestExporter(t, cfg, &now)
+				}
+				require.NoError(t, e.consumeLogs(context.Background(), evidenceLogsAt(now, step.records...)))
+				require.NoError(t, e.flush())
+				assert.Equal(t, step.wantRisk, riskStatus(t, cfg.Path), "step %d", i)
+			}
+		})
+	}
+}
+
+// riskStatus returns the status of the only risk of the document at path, or
+// empty when there is no document.
+func riskStatus(t *testing.T, path string) string {
+	t.Helper()
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return ""
+	}
+	doc := readDocument(t, path)
+	require.Len(t, doc.Risks, 1)
+	return doc.Risks[0].Status
+}
@@ -0,0 +1,56 @@
+package transparencylogexporter
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configretry"
+)
+
+// defaultEndpoint is the public Rekor instance operated by Sigstore.
+const defaultEndpoint = "https://rekor.sigstore.dev"
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines the configuration for the transparency log exporter.
+type Config struct {
+	confighttp.ClientConfig   `mapstructure:",squash"`
+	configretry.BackOffConfig `mapstructure:"retry_on_failure"`
+
+	// Key is the path to a PEM private key, optionally cosign-encrypted, or a
+	// KMS key reference such as awskms:///alias/name, gcpkms://..., azurekms://...
+	// or hashivault://name. Batch digests are signed with it before they are logged.
+	Key string `mapstructure:"key"`
+
+	// Password decrypts a cosign-encrypted private key.
+	Password configopaque.String `mapstructure:"password"`
+
+	// ReceiptsPath is the file the log entry of every batch is appended to,
+	// one JSON object per line.
+	ReceiptsPath string `mapstructure:"receipts_path"`
+}
+
+func createDefaultConfig() component.Config {
+	clientConfig := confighttp.NewDefaultClientConfig()
+	clientConfig.Endpoint = defaultEndpoint
+	return &Config{
+		ClientConfig:  clientConfig,
+		BackOffConfig: configretry.NewDefaultBackOffConfig(),
+	}
+}
+
+// Validate checks the exporter configuration is valid.
+func (c *Config) Validate() error {
+	if c.Endpoint == "" {
+		return errors.New("endpoint must not be empty")
+	}
+	if c.Key == "" {
+		return errors.New("key must not be empty")
+	}
+	if c.ReceiptsPath == "" {
+		return errors.New("receipts_path must not be empty")
+	}
+	return nil
+}
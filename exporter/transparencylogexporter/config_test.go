@@ -0,0 +1,97 @@
+package transparencylogexporter
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id    component.ID
+		check func(t *testing.T, cfg *Config)
+	}{
+		{
+			id: component.NewID(componentType),
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, defaultEndpoint, cfg.Endpoint)
+				assert.Equal(t, "/etc/complybeacon/cosign.key", cfg.Key)
+				assert.Equal(t, configopaque.String("s3cret"), cfg.Password)
+				assert.Equal(t, "/var/lib/complybeacon/rekor-receipts.jsonl", cfg.ReceiptsPath)
+				assert.True(t, cfg.BackOffConfig.Enabled)
+			},
+		},
+		{
+			id: component.NewIDWithName(componentType, "private"),
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "https://rekor.internal.example.com", cfg.Endpoint)
+				assert.Equal(t, "awskms:///alias/evidence-signing", cfg.Key)
+				assert.False(t, cfg.BackOffConfig.Enabled)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, sub.Unmarshal(cfg))
+			require.NoError(t, cfg.Validate())
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "empty endpoint",
+			mutate:  func(cfg *Config) { cfg.Endpoint = "" },
+			wantErr: "endpoint must not be empty",
+		},
+		{
+			name:    "empty key",
+			mutate:  func(cfg *Config) { cfg.Key = "" },
+			wantErr: "key must not be empty",
+		},
+		{
+			name:    "empty receipts path",
+			mutate:  func(cfg *Config) { cfg.ReceiptsPath = "" },
+			wantErr: "receipts_path must not be empty",
+		},
+		{
+			name:   "valid",
+			mutate: func(*Config) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Key = "/etc/complybeacon/cosign.key"
+			cfg.ReceiptsPath = "/var/lib/complybeacon/receipts.jsonl"
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package transparencylogexporter
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+// signatureAttributes are excluded from the canonical encoding, as they are by
+// the signing processor, so record digests match evidence.signature.digest.
+var signatureAttributes = []string{
+	proofwatch.EVIDENCE_SIGNATURE_VALUE,
+	proofwatch.EVIDENCE_SIGNATURE_DIGEST,
+	proofwatch.EVIDENCE_SIGNATURE_BATCH_DIGEST,
+	proofwatch.EVIDENCE_SIGNATURE_KEY_ID,
+}
+
+// batchDigests returns the digest of every record in logs, sorted, and the
+// message they form: the sorted digests joined by newlines. The message is
+// what gets logged, so a batch can be verified from any ordering of its records.
+func batchDigests(logs plog.Logs) ([]string, []byte, error) {
+	var digests []string
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		rl := logs.ResourceLogs().At(i)
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				canonical, err := canonicalize(rl.Resource(), sl.Scope(), sl.LogRecords().At(k))
+				if err != nil {
+					return nil, nil, err
+				}
+				digests = append(digests, digest(canonical))
+			}
+		}
+	}
+	sort.Strings(digests)
+	return digests, []byte(strings.Join(digests, "\n")), nil
+}
+
+// canonicalRecord is the digested representation of a log record, identical
+// to the one the signing processor signs. encoding/json sorts map keys, which
+// makes the encoding deterministic.
+type canonicalRecord struct {
+	Resource         map[string]any `json:"resource"`
+	Scope            string         `json:"scope"`
+	ScopeVersion     string         `json:"scope_version"`
+	TimeUnixNano     uint64         `json:"time_unix_nano"`
+	ObservedUnixNano uint64         `json:"observed_time_unix_nano"`
+	SeverityNumber   int32          `json:"severity_number"`
+	SeverityText     string         `json:"severity_text"`
+	Body             any            `json:"body"`
+	Attributes       map[string]any `json:"attributes"`
+	TraceID          string         `json:"trace_id"`
+	SpanID           string         `json:"span_id"`
+}
+
+func canonicalize(resource pcommon.Resource, scope pcommon.InstrumentationScope, record plog.LogRecord) ([]byte, error) {
+	attrs := record.Attributes().AsRaw()
+	for _, key := range signatureAttributes {
+		delete(attrs, key)
+	}
+	return json.Marshal(canonicalRecord{
+		Resource:         resource.Attributes().AsRaw(),
+		Scope:            scope.Name(),
+		ScopeVersion:     scope.Version(),
+		TimeUnixNano:     uint64(record.Timestamp()),
+		ObservedUnixNano: uint64(record.ObservedTimestamp()),
+		SeverityNumber:   int32(record.SeverityNumber()),
+		SeverityText:     record.SeverityText(),
+		Body:             record.Body().AsRaw(),
+		Attributes:       attrs,
+		TraceID:          record.TraceID().String(),
+		SpanID:           record.SpanID().String(),
+	})
+}
@@ -0,0 +1,69 @@
+package transparencylogexporter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+func evidenceLogs(results ...string) plog.Logs {
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "oscap")
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("proofwatch")
+	for _, result := range results {
+		record := sl.LogRecords().AppendEmpty()
+		record.Body().SetStr("rule: " + result)
+		record.Attributes().PutStr(proofwatch.POLICY_RULE_ID, "rule")
+		record.Attributes().PutStr(proofwatch.POLICY_EVALUATION_RESULT, result)
+	}
+	return logs
+}
+
+func TestBatchDigests(t *testing.T) {
+	logs := evidenceLogs("Passed", "Failed")
+	digests, message, err := batchDigests(logs)
+	require.NoError(t, err)
+
+	require.Len(t, digests, 2)
+	assert.True(t, strings.Compare(digests[0], digests[1]) < 0, "digests are sorted")
+	assert.Equal(t, digests[0]+"\n"+digests[1], string(message))
+
+	sl := logs.ResourceLogs().At(0).ScopeLogs().At(0)
+	canonical, err := canonicalize(logs.ResourceLogs().At(0).Resource(), sl.Scope(), sl.LogRecords().At(0))
+	require.NoError(t, err)
+	assert.Contains(t, digests, digest(canonical))
+}
+
+func TestBatchDigestsIgnoresOrderAndSignatures(t *testing.T) {
+	_, want, err := batchDigests(evidenceLogs("Passed", "Failed"))
+	require.NoError(t, err)
+
+	// The same records in another order, signed by the signing processor.
+	logs := evidenceLogs("Failed", "Passed")
+	records := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	for i := 0; i < records.Len(); i++ {
+		records.At(i).Attributes().PutStr(proofwatch.EVIDENCE_SIGNATURE_VALUE, "c2lnbmF0dXJl")
+		records.At(i).Attributes().PutStr(proofwatch.EVIDENCE_SIGNATURE_KEY_ID, "sha256:abc")
+	}
+	_, got, err := batchDigests(logs)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	_, other, err := batchDigests(evidenceLogs("Failed", "Failed"))
+	require.NoError(t, err)
+	assert.NotEqual(t, want, other)
+}
+
+func TestBatchDigestsEmpty(t *testing.T) {
+	digests, message, err := batchDigests(plog.NewLogs())
+	require.NoError(t, err)
+	assert.Empty(t, digests)
+	assert.Empty(t, message)
+}
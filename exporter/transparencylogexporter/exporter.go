@@ -0,0 +1,231 @@
+package transparencylogexporter
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+const entriesPath = "/api/v1/log/entries"
+
+type transparencyLogExporter struct {
+	cfg      *Config
+	settings exporter.Settings
+	client   *http.Client
+	signer   *evidenceSigner
+
+	// receiptsMu serializes appends to the receipts file.
+	receiptsMu sync.Mutex
+}
+
+// receipt records where the digest of a batch was logged, so an auditor can
+// recompute it from the archived records and look it up in the log.
+type receipt struct {
+	BatchDigest   string   `json:"batch_digest"`
+	RecordDigests []string `json:"record_digests"`
+	KeyID         string   `json:"key_id"`
+	Signature     string   `json:"signature"`
+
+	LogURL               string          `json:"log_url"`
+	EntryUUID            string          `json:"entry_uuid"`
+	LogID                string          `json:"log_id"`
+	LogIndex             int64           `json:"log_index"`
+	IntegratedTime       time.Time       `json:"integrated_time"`
+	InclusionProof       *inclusionProof `json:"inclusion_proof,omitempty"`
+	SignedEntryTimestamp string          `json:"signed_entry_timestamp,omitempty"`
+}
+
+func newTransparencyLogExporter(cfg *Config, set exporter.Settings) *transparencyLogExporter {
+	return &transparencyLogExporter{cfg: cfg, settings: set}
+}
+
+func (e *transparencyLogExporter) start(ctx context.Context, host component.Host) error {
+	signer, err := loadSigner(ctx, e.cfg)
+	if err != nil {
+		return err
+	}
+	e.signer = signer
+
+	if err := os.MkdirAll(filepath.Dir(e.cfg.ReceiptsPath), 0o750); err != nil {
+		return fmt.Errorf("failed to create receipts directory: %w", err)
+	}
+	client, err := e.cfg.ToClient(ctx, host.GetExtensions(), e.settings.TelemetrySettings)
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+	e.client = client
+	return nil
+}
+
+// consumeLogs signs the digest of the batch, records it in the log and appends
+// the receipt. A retried batch may be logged again; every entry verifies.
+func (e *transparencyLogExporter) consumeLogs(ctx context.Context, logs plog.Logs) error {
+	digests, message, err := batchDigests(logs)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	if len(digests) == 0 {
+		return nil
+	}
+
+	sig, err := e.signer.signer.SignMessage(bytes.NewReader(message))
+	if err != nil {
+		return fmt.Errorf("failed to sign batch digest: %w", err)
+	}
+	sum := sha256.Sum256(message)
+	digestHex := hex.EncodeToString(sum[:])
+
+	uuid, entry, err := e.createEntry(ctx, newHashedRekord(digestHex, sig, e.signer.publicKey))
+	if err != nil {
+		return err
+	}
+	if err := verifyEntry(uuid, entry, digestHex); err != nil {
+		return fmt.Errorf("transparency log returned an invalid entry: %w", err)
+	}
+
+	r := receipt{
+		BatchDigest:    "sha256:" + digestHex,
+		RecordDigests:  digests,
+		KeyID:          e.signer.keyID,
+		Signature:      base64.StdEncoding.EncodeToString(sig),
+		LogURL:         e.cfg.Endpoint,
+		EntryUUID:      uuid,
+		LogID:          entry.LogID,
+		LogIndex:       entry.LogIndex,
+		IntegratedTime: time.Unix(entry.IntegratedTime, 0).UTC(),
+	}
+	if entry.Verification != nil {
+		r.InclusionProof = entry.Verification.InclusionProof
+		r.SignedEntryTimestamp = entry.Verification.SignedEntryTimestamp
+	}
+	if err := e.appendReceipt(r); err != nil {
+		return err
+	}
+	e.settings.Logger.Debug("recorded evidence batch in the transparency log",
+		zap.String("batch_digest", r.BatchDigest),
+		zap.Int64("log_index", r.LogIndex))
+	return nil
+}
+
+// createEntry adds entry to the log. When the log already holds it, the
+// existing entry is fetched instead.
+func (e *transparencyLogExporter) createEntry(ctx context.Context, entry hashedRekord) (string, logEntry, error) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return "", logEntry{}, consumererror.NewPermanent(err)
+	}
+	resp, err := e.do(ctx, http.MethodPost, entriesPath, data)
+	if err != nil {
+		return "", logEntry{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusConflict {
+		// Location names the existing entry, as a path or an absolute URL.
+		location, err := url.Parse(resp.Header.Get("Location"))
+		if err != nil || location.Path == "" {
+			return "", logEntry{}, statusError("failed to create log entry", resp.StatusCode)
+		}
+		existing, err := e.do(ctx, http.MethodGet, location.RequestURI(), nil)
+		if err != nil {
+			return "", logEntry{}, err
+		}
+		defer existing.Body.Close()
+		return decodeEntry(existing, "failed to fetch log entry")
+	}
+	return decodeEntry(resp, "failed to create log entry")
+}
+
+// decodeEntry reads the single entry of a Rekor response, keyed by its UUID.
+func decodeEntry(resp *http.Response, msg string) (string, logEntry, error) {
+	if err := statusError(msg, resp.StatusCode); err != nil {
+		return "", logEntry{}, err
+	}
+	var entries map[string]logEntry
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&entries); err != nil {
+		return "", logEntry{}, fmt.Errorf("%s: failed to decode response: %w", msg, err)
+	}
+	if len(entries) != 1 {
+		return "", logEntry{}, fmt.Errorf("%s: expected one entry, got %d", msg, len(entries))
+	}
+	for uuid, entry := range entries {
+		return uuid, entry, nil
+	}
+	return "", logEntry{}, nil
+}
+
+func (e *transparencyLogExporter) do(ctx context.Context, method, path string, data []byte) (*http.Response, error) {
+	var body io.Reader
+	if data != nil {
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(e.cfg.Endpoint, "/")+path, body)
+	if err != nil {
+		return nil, consumererror.NewPermanent(err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if data != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	return resp, nil
+}
+
+// appendReceipt writes r as one line of the receipts file.
+func (e *transparencyLogExporter) appendReceipt(r receipt) error {
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	e.receiptsMu.Lock()
+	defer e.receiptsMu.Unlock()
+	f, err := os.OpenFile(e.cfg.ReceiptsPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open receipts file: %w", err)
+	}
+	if _, err := f.Write(line); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write receipt: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write receipt: %w", err)
+	}
+	return nil
+}
+
+// statusError reports a failed response. Client errors other than throttling
+// are permanent, so the exporter does not retry them.
+func statusError(msg string, status int) error {
+	if status >= 200 && status <= 299 {
+		return nil
+	}
+	err := fmt.Errorf("%s: %d %s", msg, status, http.StatusText(status))
+	if status >= 400 && status <= 499 &&
+		status != http.StatusRequestTimeout && status != http.StatusTooManyRequests {
+		return consumererror.NewPermanent(err)
+	}
+	return err
+}
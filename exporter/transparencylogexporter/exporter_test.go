@@ -0,0 +1,258 @@
+package transparencylogexporter
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+const testTreeID = "24296fb24b8ad77a"
+
+// fakeRekor is a transparency log that verifies and stores hashedrekord entries.
+type fakeRekor struct {
+	t *testing.T
+
+	mu      sync.Mutex
+	leaves  [][]byte
+	bodies  map[string][]byte
+	byHash  map[string]string
+	entries []hashedRekord
+	// status, when set, is returned for every request.
+	status int
+	// tamper makes the log return a different body than the one it stored.
+	tamper bool
+}
+
+func newFakeRekor(t *testing.T) (*fakeRekor, *httptest.Server) {
+	r := &fakeRekor{
+		t: t,
+		// The log already holds an entry of someone else.
+		leaves: [][]byte{leafHash([]byte("unrelated entry"))},
+		bodies: make(map[string][]byte),
+		byHash: make(map[string]string),
+	}
+	server := httptest.NewServer(http.HandlerFunc(r.serve))
+	t.Cleanup(server.Close)
+	return r, server
+}
+
+func (r *fakeRekor) serve(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.status != 0 {
+		w.WriteHeader(r.status)
+		return
+	}
+
+	switch {
+	case req.Method == http.MethodPost && req.URL.Path == entriesPath:
+		var entry hashedRekord
+		if !assert.NoError(r.t, json.NewDecoder(req.Body).Decode(&entry)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if !r.verifySignature(entry) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if uuid, ok := r.byHash[entry.Spec.Data.Hash.Value]; ok {
+			w.Header().Set("Location", entriesPath+"/"+uuid)
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		body, _ := json.Marshal(entry)
+		uuid := testTreeID + hex.EncodeToString(leafHash(body))
+		r.leaves = append(r.leaves, leafHash(body))
+		r.bodies[uuid] = body
+		r.byHash[entry.Spec.Data.Hash.Value] = uuid
+		r.entries = append(r.entries, entry)
+		w.WriteHeader(http.StatusCreated)
+		r.writeEntry(w, uuid)
+	case req.Method == http.MethodGet && strings.HasPrefix(req.URL.Path, entriesPath+"/"):
+		uuid := strings.TrimPrefix(req.URL.Path, entriesPath+"/")
+		if _, ok := r.bodies[uuid]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		r.writeEntry(w, uuid)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// verifySignature checks the signature of entry over its digest, as Rekor does.
+func (r *fakeRekor) verifySignature(entry hashedRekord) bool {
+	pemKey, _ := base64.StdEncoding.DecodeString(entry.Spec.Signature.PublicKey.Content)
+	pub, err := cryptoutils.UnmarshalPEMToPublicKey(pemKey)
+	if !assert.NoError(r.t, err) {
+		return false
+	}
+	sig, _ := base64.StdEncoding.DecodeString(entry.Spec.Signature.Content)
+	sum, _ := hex.DecodeString(entry.Spec.Data.Hash.Value)
+	return assert.True(r.t, ecdsa.VerifyASN1(pub.(*ecdsa.PublicKey), sum, sig), "signature does not verify")
+}
+
+func (r *fakeRekor) writeEntry(w http.ResponseWriter, uuid string) {
+	body := r.bodies[uuid]
+	index := 0
+	for i, leaf := range r.leaves {
+		if hex.EncodeToString(leaf) == strings.TrimPrefix(uuid, testTreeID) {
+			index = i
+		}
+	}
+	if r.tamper {
+		var entry hashedRekord
+		_ = json.Unmarshal(body, &entry)
+		entry.Spec.Data.Hash.Value = strings.Repeat("0", 64)
+		body, _ = json.Marshal(entry)
+	}
+	resp := map[string]logEntry{uuid: {
+		Body:           base64.StdEncoding.EncodeToString(body),
+		IntegratedTime: 1780000000 + int64(index),
+		LogID:          "c0d23d6ad406973f9559f3ba2d1ca01f84147d8ffc5b8445c224f98b9591801d",
+		LogIndex:       int64(100 + index),
+		Verification: &verification{
+			InclusionProof:       newProof(index, r.leaves),
+			SignedEntryTimestamp: "TUVVQ0lRQ1NFVA==",
+		},
+	}}
+	assert.NoError(r.t, json.NewEncoder(w).Encode(resp))
+}
+
+func newTestExporter(t *testing.T, endpoint string) *transparencyLogExporter {
+	t.Helper()
+	_, der := newECKey(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.Key = writeKey(t, "PRIVATE KEY", der)
+	cfg.ReceiptsPath = filepath.Join(t.TempDir(), "receipts", "rekor.jsonl")
+
+	e := newTransparencyLogExporter(cfg, exportertest.NewNopSettings(componentType))
+	require.NoError(t, e.start(context.Background(), componenttest.NewNopHost()))
+	return e
+}
+
+func readReceipts(t *testing.T, path string) []receipt {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var receipts []receipt
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r receipt
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &r))
+		receipts = append(receipts, r)
+	}
+	require.NoError(t, scanner.Err())
+	return receipts
+}
+
+func TestConsumeLogsRecordsBatch(t *testing.T) {
+	rekor, server := newFakeRekor(t)
+	e := newTestExporter(t, server.URL)
+
+	logs := evidenceLogs("Passed", "Failed")
+	require.NoError(t, e.consumeLogs(context.Background(), logs))
+	require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs("Failed")))
+
+	digests, message, err := batchDigests(logs)
+	require.NoError(t, err)
+
+	require.Len(t, rekor.entries, 2)
+	assert.Equal(t, strings.TrimPrefix(digest(message), "sha256:"), rekor.entries[0].Spec.Data.Hash.Value)
+
+	receipts := readReceipts(t, e.cfg.ReceiptsPath)
+	require.Len(t, receipts, 2)
+	r := receipts[0]
+	assert.Equal(t, digest(message), r.BatchDigest)
+	assert.Equal(t, digests, r.RecordDigests)
+	assert.Equal(t, e.signer.keyID, r.KeyID)
+	assert.Equal(t, rekor.entries[0].Spec.Signature.Content, r.Signature)
+	assert.Equal(t, server.URL, r.LogURL)
+	assert.Equal(t, int64(101), r.LogIndex)
+	assert.Equal(t, int64(1780000001), r.IntegratedTime.Unix())
+	assert.Regexp(t, "^"+testTreeID+"[0-9a-f]{64}$", r.EntryUUID)
+	require.NotNil(t, r.InclusionProof)
+	assert.Equal(t, int64(1), r.InclusionProof.LogIndex)
+	assert.Equal(t, int64(2), r.InclusionProof.TreeSize)
+	assert.NotEmpty(t, r.SignedEntryTimestamp)
+	assert.Equal(t, int64(102), receipts[1].LogIndex)
+}
+
+func TestConsumeLogsExistingEntry(t *testing.T) {
+	rekor, server := newFakeRekor(t)
+	e := newTestExporter(t, server.URL)
+
+	require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs("Passed")))
+	require.NoError(t, e.consumeLogs(context.Background(), evidenceLogs("Passed")))
+
+	assert.Len(t, rekor.entries, 1)
+	receipts := readReceipts(t, e.cfg.ReceiptsPath)
+	require.Len(t, receipts, 2)
+	assert.Equal(t, receipts[0].EntryUUID, receipts[1].EntryUUID)
+}
+
+func TestConsumeLogsEmpty(t *testing.T) {
+	rekor, server := newFakeRekor(t)
+	e := newTestExporter(t, server.URL)
+
+	require.NoError(t, e.consumeLogs(context.Background(), plog.NewLogs()))
+	assert.Empty(t, rekor.entries)
+	assert.NoFileExists(t, e.cfg.ReceiptsPath)
+}
+
+func TestConsumeLogsRejectsTamperedEntry(t *testing.T) {
+	rekor, server := newFakeRekor(t)
+	rekor.tamper = true
+	e := newTestExporter(t, server.URL)
+
+	err := e.consumeLogs(context.Background(), evidenceLogs("Passed"))
+	require.ErrorContains(t, err, "transparency log returned an invalid entry")
+	assert.NoFileExists(t, e.cfg.ReceiptsPath)
+}
+
+func TestConsumeLogsStatus(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		wantPermanent bool
+	}{
+		{name: "bad request", status: http.StatusBadRequest, wantPermanent: true},
+		{name: "throttled", status: http.StatusTooManyRequests},
+		{name: "unavailable", status: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rekor, server := newFakeRekor(t)
+			rekor.status = tt.status
+			e := newTestExporter(t, server.URL)
+
+			err := e.consumeLogs(context.Background(), evidenceLogs("Passed"))
+			require.Error(t, err)
+			assert.Equal(t, tt.wantPermanent, consumererror.IsPermanent(err))
+			assert.NoFileExists(t, e.cfg.ReceiptsPath)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package transparencylogexporter
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+)
+
+func TestNewFactory(t *testing.T) {
+	factory := NewFactory()
+	assert.Equal(t, componentType, factory.Type())
+
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, componenttest.CheckConfigStruct(cfg))
+}
+
+func TestExporterLifecycle(t *testing.T) {
+	rekor, server := newFakeRekor(t)
+	_, der := newECKey(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	cfg.Key = writeKey(t, "PRIVATE KEY", der)
+	cfg.ReceiptsPath = filepath.Join(t.TempDir(), "receipts.jsonl")
+
+	exp, err := NewFactory().CreateLogs(context.Background(), exportertest.NewNopSettings(componentType), cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, exp.ConsumeLogs(context.Background(), evidenceLogs("Passed")))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	assert.Len(t, rekor.entries, 1)
+	assert.FileExists(t, cfg.ReceiptsPath)
+}
+
+func TestExporterStartFailsWithoutKey(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Key = filepath.Join(t.TempDir(), "missing.key")
+	cfg.ReceiptsPath = filepath.Join(t.TempDir(), "receipts.jsonl")
+
+	exp, err := NewFactory().CreateLogs(context.Background(), exportertest.NewNopSettings(componentType), cfg)
+	require.NoError(t, err)
+	assert.ErrorContains(t, exp.Start(context.Background(), componenttest.NewNopHost()), "failed to read key")
+}
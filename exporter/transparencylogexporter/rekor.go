@@ -0,0 +1,153 @@
+package transparencylogexporter
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// hashedRekord is a proposed Rekor entry of kind hashedrekord: a SHA-256
+// digest, a signature over it and the public key that verifies the signature.
+type hashedRekord struct {
+	APIVersion string           `json:"apiVersion"`
+	Kind       string           `json:"kind"`
+	Spec       hashedRekordSpec `json:"spec"`
+}
+
+type hashedRekordSpec struct {
+	Data struct {
+		Hash struct {
+			Algorithm string `json:"algorithm"`
+			Value     string `json:"value"`
+		} `json:"hash"`
+	} `json:"data"`
+	Signature struct {
+		Content   string `json:"content"`
+		PublicKey struct {
+			Content string `json:"content"`
+		} `json:"publicKey"`
+	} `json:"signature"`
+}
+
+func newHashedRekord(digestHex string, sig, publicKey []byte) hashedRekord {
+	entry := hashedRekord{APIVersion: "0.0.1", Kind: "hashedrekord"}
+	entry.Spec.Data.Hash.Algorithm = "sha256"
+	entry.Spec.Data.Hash.Value = digestHex
+	entry.Spec.Signature.Content = base64.StdEncoding.EncodeToString(sig)
+	entry.Spec.Signature.PublicKey.Content = base64.StdEncoding.EncodeToString(publicKey)
+	return entry
+}
+
+// logEntry is an entry as returned by the Rekor API.
+type logEntry struct {
+	Body           string        `json:"body"`
+	IntegratedTime int64         `json:"integratedTime"`
+	LogID          string        `json:"logID"`
+	LogIndex       int64         `json:"logIndex"`
+	Verification   *verification `json:"verification,omitempty"`
+}
+
+// verification holds the proofs the log returns with an entry.
+type verification struct {
+	InclusionProof       *inclusionProof `json:"inclusionProof,omitempty"`
+	SignedEntryTimestamp string          `json:"signedEntryTimestamp,omitempty"`
+}
+
+// inclusionProof proves that an entry is a leaf of the log's Merkle tree.
+// LogIndex is the position of the entry in the tree of its shard.
+type inclusionProof struct {
+	Checkpoint string   `json:"checkpoint,omitempty"`
+	Hashes     []string `json:"hashes"`
+	LogIndex   int64    `json:"logIndex"`
+	RootHash   string   `json:"rootHash"`
+	TreeSize   int64    `json:"treeSize"`
+}
+
+// verifyEntry checks that the log recorded digestHex under uuid and, when the
+// log returned an inclusion proof, that the entry is included in its tree.
+func verifyEntry(uuid string, entry logEntry, digestHex string) error {
+	body, err := base64.StdEncoding.DecodeString(entry.Body)
+	if err != nil {
+		return fmt.Errorf("failed to decode entry body: %w", err)
+	}
+	var logged hashedRekord
+	if err := json.Unmarshal(body, &logged); err != nil {
+		return fmt.Errorf("failed to decode entry body: %w", err)
+	}
+	if logged.Kind != "hashedrekord" || logged.Spec.Data.Hash.Value != digestHex {
+		return fmt.Errorf("entry %s does not record digest %s", uuid, digestHex)
+	}
+
+	// The UUID ends with the leaf hash, after the tree ID on sharded logs.
+	leaf := leafHash(body)
+	if !strings.HasSuffix(uuid, hex.EncodeToString(leaf)) {
+		return fmt.Errorf("entry %s does not match its body", uuid)
+	}
+	if entry.Verification == nil || entry.Verification.InclusionProof == nil {
+		return nil
+	}
+	if err := verifyInclusion(leaf, entry.Verification.InclusionProof); err != nil {
+		return fmt.Errorf("entry %s: %w", uuid, err)
+	}
+	return nil
+}
+
+// verifyInclusion checks an RFC 9162 inclusion proof of leaf against the
+// root hash of the tree.
+func verifyInclusion(leaf []byte, proof *inclusionProof) error {
+	if proof.LogIndex < 0 || proof.LogIndex >= proof.TreeSize {
+		return fmt.Errorf("inclusion proof index %d is outside a tree of size %d", proof.LogIndex, proof.TreeSize)
+	}
+	root, err := hex.DecodeString(proof.RootHash)
+	if err != nil {
+		return fmt.Errorf("failed to decode root hash: %w", err)
+	}
+
+	fn, sn := uint64(proof.LogIndex), uint64(proof.TreeSize-1)
+	r := leaf
+	for _, h := range proof.Hashes {
+		p, err := hex.DecodeString(h)
+		if err != nil {
+			return fmt.Errorf("failed to decode inclusion proof hash: %w", err)
+		}
+		if sn == 0 {
+			return errors.New("inclusion proof is longer than the tree")
+		}
+		if fn&1 == 1 || fn == sn {
+			r = nodeHash(p, r)
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			r = nodeHash(r, p)
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+	if sn != 0 {
+		return errors.New("inclusion proof is shorter than the tree")
+	}
+	if !bytes.Equal(r, root) {
+		return errors.New("inclusion proof does not match the root hash")
+	}
+	return nil
+}
+
+func leafHash(data []byte) []byte {
+	sum := sha256.Sum256(append([]byte{0x00}, data...))
+	return sum[:]
+}
+
+func nodeHash(left, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{0x01})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
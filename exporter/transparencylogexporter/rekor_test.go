@@ -0,0 +1,142 @@
+package transparencylogexporter
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// merkleRoot computes the RFC 9162 Merkle tree hash of the leaf hashes.
+func merkleRoot(leaves [][]byte) []byte {
+	if len(leaves) == 1 {
+		return leaves[0]
+	}
+	k := splitPoint(len(leaves))
+	return nodeHash(merkleRoot(leaves[:k]), merkleRoot(leaves[k:]))
+}
+
+// merklePath computes the RFC 9162 inclusion proof of leaf m.
+func merklePath(m int, leaves [][]byte) [][]byte {
+	if len(leaves) == 1 {
+		return nil
+	}
+	k := splitPoint(len(leaves))
+	if m < k {
+		return append(merklePath(m, leaves[:k]), merkleRoot(leaves[k:]))
+	}
+	return append(merklePath(m-k, leaves[k:]), merkleRoot(leaves[:k]))
+}
+
+// splitPoint is the largest power of two smaller than n.
+func splitPoint(n int) int {
+	k := 1
+	for k<<1 < n {
+		k <<= 1
+	}
+	return k
+}
+
+func newProof(m int, leaves [][]byte) *inclusionProof {
+	proof := &inclusionProof{
+		LogIndex: int64(m),
+		TreeSize: int64(len(leaves)),
+		RootHash: hex.EncodeToString(merkleRoot(leaves)),
+		Hashes:   []string{},
+	}
+	for _, h := range merklePath(m, leaves) {
+		proof.Hashes = append(proof.Hashes, hex.EncodeToString(h))
+	}
+	return proof
+}
+
+func testLeaves(n int) [][]byte {
+	leaves := make([][]byte, n)
+	for i := range leaves {
+		leaves[i] = leafHash([]byte("entry " + strconv.Itoa(i)))
+	}
+	return leaves
+}
+
+func TestVerifyInclusion(t *testing.T) {
+	for size := 1; size <= 9; size++ {
+		leaves := testLeaves(size)
+		for m := range leaves {
+			assert.NoError(t, verifyInclusion(leaves[m], newProof(m, leaves)), "leaf %d of %d", m, size)
+		}
+	}
+}
+
+func TestVerifyInclusionRejects(t *testing.T) {
+	leaves := testLeaves(5)
+
+	tests := []struct {
+		name    string
+		leaf    []byte
+		mutate  func(p *inclusionProof)
+		wantErr string
+	}{
+		{
+			name:    "other leaf",
+			leaf:    leaves[3],
+			mutate:  func(*inclusionProof) {},
+			wantErr: "inclusion proof does not match the root hash",
+		},
+		{
+			name:    "other root",
+			leaf:    leaves[2],
+			mutate:  func(p *inclusionProof) { p.RootHash = hex.EncodeToString(leaves[0]) },
+			wantErr: "inclusion proof does not match the root hash",
+		},
+		{
+			name:    "missing hash",
+			leaf:    leaves[2],
+			mutate:  func(p *inclusionProof) { p.Hashes = p.Hashes[1:] },
+			wantErr: "inclusion proof is shorter than the tree",
+		},
+		{
+			name:    "extra hash",
+			leaf:    leaves[2],
+			mutate:  func(p *inclusionProof) { p.Hashes = append(p.Hashes, p.Hashes[0]) },
+			wantErr: "inclusion proof is longer than the tree",
+		},
+		{
+			name:    "index outside the tree",
+			leaf:    leaves[2],
+			mutate:  func(p *inclusionProof) { p.LogIndex = 5 },
+			wantErr: "inclusion proof index 5 is outside a tree of size 5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proof := newProof(2, leaves)
+			tt.mutate(proof)
+			assert.EqualError(t, verifyInclusion(tt.leaf, proof), tt.wantErr)
+		})
+	}
+}
+
+func TestVerifyEntry(t *testing.T) {
+	digestHex := hex.EncodeToString(leafHash([]byte("batch")))
+	body, err := json.Marshal(newHashedRekord(digestHex, []byte("sig"), []byte("key")))
+	require.NoError(t, err)
+	leaves := append(testLeaves(2), leafHash(body))
+	uuid := "24296fb24b8ad77a" + hex.EncodeToString(leafHash(body))
+
+	entry := logEntry{Body: base64.StdEncoding.EncodeToString(body), LogIndex: 2}
+	require.NoError(t, verifyEntry(uuid, entry, digestHex), "entry without a proof")
+
+	entry.Verification = &verification{InclusionProof: newProof(2, leaves)}
+	require.NoError(t, verifyEntry(uuid, entry, digestHex))
+
+	assert.ErrorContains(t, verifyEntry(uuid, entry, hex.EncodeToString(leafHash([]byte("other")))), "does not record digest")
+	assert.ErrorContains(t, verifyEntry("24296fb24b8ad77a"+digestHex, entry, digestHex), "does not match its body")
+
+	entry.Verification.InclusionProof = newProof(1, leaves)
+	assert.ErrorContains(t, verifyEntry(uuid, entry, digestHex), "inclusion proof does not match the root hash")
+}
@@ -0,0 +1,123 @@
+package transparencylogexporter
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/secure-systems-lab/go-securesystemslib/encrypted"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/kms"
+
+	// KMS providers register themselves for their key reference schemes.
+	_ "github.com/sigstore/sigstore/pkg/signature/kms/aws"
+	_ "github.com/sigstore/sigstore/pkg/signature/kms/azure"
+	_ "github.com/sigstore/sigstore/pkg/signature/kms/gcp"
+	_ "github.com/sigstore/sigstore/pkg/signature/kms/hashivault"
+)
+
+// encryptedKeyTypes are the PEM block types `cosign generate-key-pair` writes for password-protected keys.
+var encryptedKeyTypes = map[string]bool{
+	"ENCRYPTED SIGSTORE PRIVATE KEY": true,
+	"ENCRYPTED COSIGN PRIVATE KEY":   true,
+}
+
+// evidenceSigner signs messages with SHA-256 and identifies the signing key.
+type evidenceSigner struct {
+	signer signature.Signer
+	keyID  string
+	// publicKey is the PEM-encoded public key the log verifies signatures with.
+	publicKey []byte
+}
+
+// loadSigner resolves cfg.Key to a KMS signer or a local private key.
+func loadSigner(ctx context.Context, cfg *Config) (*evidenceSigner, error) {
+	if strings.Contains(cfg.Key, "://") {
+		sv, err := kms.Get(ctx, cfg.Key, crypto.SHA256)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load KMS key %q: %w", cfg.Key, err)
+		}
+		pub, err := sv.PublicKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load KMS key %q: %w", cfg.Key, err)
+		}
+		pubPEM, err := cryptoutils.MarshalPublicKeyToPEM(pub)
+		if err != nil {
+			return nil, err
+		}
+		return &evidenceSigner{signer: sv, keyID: cfg.Key, publicKey: pubPEM}, nil
+	}
+
+	data, err := os.ReadFile(cfg.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key: %w", err)
+	}
+	priv, err := parsePrivateKey(data, []byte(cfg.Password))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load key %s: %w", cfg.Key, err)
+	}
+	sv, err := signature.LoadSignerVerifier(priv, crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load key %s: %w", cfg.Key, err)
+	}
+	pub, err := sv.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	keyID, err := publicKeyID(pub)
+	if err != nil {
+		return nil, err
+	}
+	pubPEM, err := cryptoutils.MarshalPublicKeyToPEM(pub)
+	if err != nil {
+		return nil, err
+	}
+	return &evidenceSigner{signer: sv, keyID: keyID, publicKey: pubPEM}, nil
+}
+
+// parsePrivateKey decodes a PKCS#8, SEC 1 or PKCS#1 PEM key, or a cosign key
+// encrypted with password.
+func parsePrivateKey(data, password []byte) (crypto.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	switch {
+	case encryptedKeyTypes[block.Type]:
+		der, err := encrypted.Decrypt(block.Bytes, password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt key: %w", err)
+		}
+		return x509.ParsePKCS8PrivateKey(der)
+	case block.Type == "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	case block.Type == "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case block.Type == "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+}
+
+// publicKeyID is the SHA-256 digest of the DER-encoded public key.
+func publicKeyID(pub crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return "", err
+	}
+	return digest(der), nil
+}
+
+func digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
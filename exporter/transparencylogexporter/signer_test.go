@@ -0,0 +1,68 @@
+package transparencylogexporter
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/secure-systems-lab/go-securesystemslib/encrypted"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+// writeKey writes key as a PEM block of the given type and returns its path.
+func writeKey(t *testing.T, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "signing.key")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+	return path
+}
+
+func newECKey(t *testing.T) (*ecdsa.PrivateKey, []byte) {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	return priv, der
+}
+
+func TestLoadSigner(t *testing.T) {
+	priv, der := newECKey(t)
+	encryptedKey, err := encrypted.Encrypt(der, []byte("s3cret"))
+	require.NoError(t, err)
+
+	cfg := &Config{Key: writeKey(t, "ENCRYPTED SIGSTORE PRIVATE KEY", encryptedKey), Password: configopaque.String("s3cret")}
+	signer, err := loadSigner(context.Background(), cfg)
+	require.NoError(t, err)
+
+	wantID, err := publicKeyID(priv.Public())
+	require.NoError(t, err)
+	assert.Equal(t, wantID, signer.keyID)
+
+	pub, err := cryptoutils.UnmarshalPEMToPublicKey(signer.publicKey)
+	require.NoError(t, err)
+	assert.True(t, priv.PublicKey.Equal(pub))
+}
+
+func TestLoadSignerErrors(t *testing.T) {
+	_, err := loadSigner(context.Background(), &Config{Key: filepath.Join(t.TempDir(), "missing.key")})
+	assert.ErrorContains(t, err, "failed to read key")
+
+	_, err = loadSigner(context.Background(), &Config{Key: writeKey(t, "PRIVATE KEY", []byte("garbage"))})
+	assert.ErrorContains(t, err, "failed to load key")
+
+	_, err = loadSigner(context.Background(), &Config{Key: writeKey(t, "CERTIFICATE", []byte{0})})
+	assert.ErrorContains(t, err, `unsupported PEM block type "CERTIFICATE"`)
+
+	_, err = loadSigner(context.Background(), &Config{Key: "unknownkms://key"})
+	assert.ErrorContains(t, err, `failed to load KMS key "unknownkms://key"`)
+}
@@ -0,0 +1,56 @@
+package correlationprocessor
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+const (
+	defaultWindow    = 5 * time.Minute
+	defaultMaxGroups = 10000
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines the configuration for the correlation processor.
+type Config struct {
+	// Window is how long evidence for a control and target is collected into
+	// one record, counted from the first record.
+	Window time.Duration `mapstructure:"window"`
+
+	// KeyAttributes are the attributes, besides the control and target IDs,
+	// whose values must also match for records to be collapsed.
+	KeyAttributes []string `mapstructure:"key_attributes"`
+
+	// MaxGroups bounds the windows held open at once. When reached, the
+	// oldest window is closed early.
+	MaxGroups int `mapstructure:"max_groups"`
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Window:        defaultWindow,
+		KeyAttributes: []string{proofwatch.POLICY_RULE_ID, proofwatch.POLICY_EVALUATION_RESULT},
+		MaxGroups:     defaultMaxGroups,
+	}
+}
+
+// Validate checks the processor configuration is valid.
+func (c *Config) Validate() error {
+	if c.Window <= 0 {
+		return errors.New("window must be positive")
+	}
+	if c.MaxGroups <= 0 {
+		return errors.New("max_groups must be positive")
+	}
+	for _, attr := range c.KeyAttributes {
+		if attr == "" {
+			return errors.New("key_attributes must not contain empty names")
+		}
+	}
+	return nil
+}
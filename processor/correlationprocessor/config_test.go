@@ -0,0 +1,95 @@
+package correlationprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id       component.ID
+		expected *Config
+	}{
+		{
+			id:       component.NewID(componentType),
+			expected: createDefaultConfig().(*Config),
+		},
+		{
+			id: component.NewIDWithName(componentType, "custom"),
+			expected: &Config{
+				Window:        time.Hour,
+				KeyAttributes: []string{proofwatch.POLICY_RULE_ID},
+				MaxGroups:     500,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, sub.Unmarshal(cfg))
+			require.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "zero window",
+			mutate:  func(cfg *Config) { cfg.Window = 0 },
+			wantErr: "window must be positive",
+		},
+		{
+			name:    "zero max groups",
+			mutate:  func(cfg *Config) { cfg.MaxGroups = 0 },
+			wantErr: "max_groups must be positive",
+		},
+		{
+			name:    "empty key attribute",
+			mutate:  func(cfg *Config) { cfg.KeyAttributes = []string{proofwatch.POLICY_RULE_ID, ""} },
+			wantErr: "key_attributes must not contain empty names",
+		},
+		{
+			name:   "no key attributes",
+			mutate: func(cfg *Config) { cfg.KeyAttributes = nil },
+		},
+		{
+			name:   "valid",
+			mutate: func(*Config) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
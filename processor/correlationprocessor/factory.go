@@ -0,0 +1,33 @@
+package correlationprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor"
+)
+
+const stability = component.StabilityLevelAlpha
+
+var componentType = component.MustNewType("correlation")
+
+// NewFactory creates a factory for the correlation processor.
+func NewFactory() processor.Factory {
+	return processor.NewFactory(
+		componentType,
+		createDefaultConfig,
+		processor.WithLogs(createLogsProcessor, stability),
+	)
+}
+
+// createLogsProcessor does not use processorhelper: correlated records are
+// held back and emitted when their window closes, not returned per batch.
+func createLogsProcessor(
+	_ context.Context,
+	set processor.Settings,
+	cfg component.Config,
+	next consumer.Logs,
+) (processor.Logs, error) {
+	return newCorrelationProcessor(cfg.(*Config), set, next), nil
+}
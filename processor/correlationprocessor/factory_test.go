@@ -0,0 +1,35 @@
+package correlationprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+func TestNewFactory(t *testing.T) {
+	factory := NewFactory()
+	assert.Equal(t, componentType, factory.Type())
+
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, componenttest.CheckConfigStruct(cfg))
+}
+
+func TestProcessorLifecycle(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+
+	sink := new(consumertest.LogsSink)
+	proc, err := NewFactory().CreateLogs(context.Background(), processortest.NewNopSettings(componentType), cfg, sink)
+	require.NoError(t, err)
+
+	require.NoError(t, proc.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, proc.ConsumeLogs(context.Background(), evidenceLogs(evidence("ac-2", "web-01", "Passed"), evidence("ac-2", "web-01", "Passed"))))
+	assert.Zero(t, sink.LogRecordCount())
+	require.NoError(t, proc.Shutdown(context.Background()))
+
+	assert.Equal(t, 1, sink.LogRecordCount())
+}
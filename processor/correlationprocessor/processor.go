@@ -0,0 +1,262 @@
+package correlationprocessor
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/processor"
+	"go.uber.org/zap"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+var _ processor.Logs = (*correlationProcessor)(nil)
+
+// group holds the latest record for one control and target, and when records
+// for it were seen during its window.
+type group struct {
+	key string
+	// opened is when the first record arrived; the window is counted from it.
+	opened time.Time
+	// firstSeen and lastSeen are the earliest and latest record timestamps.
+	firstSeen, lastSeen time.Time
+	count               int64
+
+	resource pcommon.Resource
+	scope    pcommon.InstrumentationScope
+	record   plog.LogRecord
+}
+
+func newGroup(key string, opened time.Time) *group {
+	return &group{
+		key:      key,
+		opened:   opened,
+		resource: pcommon.NewResource(),
+		scope:    pcommon.NewInstrumentationScope(),
+		record:   plog.NewLogRecord(),
+	}
+}
+
+// add collapses a record into the group. The latest record by timestamp is
+// the one that is emitted.
+func (g *group) add(resource pcommon.Resource, scope pcommon.InstrumentationScope, lr plog.LogRecord, ts time.Time) {
+	g.count++
+	if g.firstSeen.IsZero() || ts.Before(g.firstSeen) {
+		g.firstSeen = ts
+	}
+	if ts.Before(g.lastSeen) {
+		return
+	}
+	g.lastSeen = ts
+	resource.CopyTo(g.resource)
+	scope.CopyTo(g.scope)
+	lr.CopyTo(g.record)
+}
+
+type correlationProcessor struct {
+	cfg      *Config
+	settings processor.Settings
+	next     consumer.Logs
+	now      func() time.Time
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu     sync.Mutex
+	groups map[string]*group
+	// ended holds groups closed early because MaxGroups was reached.
+	ended []*group
+}
+
+func newCorrelationProcessor(cfg *Config, set processor.Settings, next consumer.Logs) *correlationProcessor {
+	return &correlationProcessor{
+		cfg:      cfg,
+		settings: set,
+		next:     next,
+		now:      time.Now,
+		groups:   make(map[string]*group),
+	}
+}
+
+// Capabilities implements consumer.Logs. Correlated records are removed from
+// the batch before the rest is passed on.
+func (p *correlationProcessor) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+// Start begins closing windows, checked twice per window.
+func (p *correlationProcessor) Start(context.Context, component.Host) error {
+	runCtx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(p.cfg.Window / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-runCtx.Done():
+				return
+			case <-ticker.C:
+				if err := p.flush(runCtx, false); err != nil {
+					p.settings.Logger.Warn("failed to emit correlated evidence", zap.Error(err))
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the window check and emits every open group.
+func (p *correlationProcessor) Shutdown(ctx context.Context) error {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.wg.Wait()
+	return p.flush(ctx, true)
+}
+
+// ConsumeLogs holds back every record with a control and target ID, and
+// passes the remaining records on unchanged.
+func (p *correlationProcessor) ConsumeLogs(ctx context.Context, logs plog.Logs) error {
+	now := p.now()
+
+	p.mu.Lock()
+	logs.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+			sl.LogRecords().RemoveIf(func(lr plog.LogRecord) bool {
+				return p.correlate(rl.Resource(), sl.Scope(), lr, now)
+			})
+			return sl.LogRecords().Len() == 0
+		})
+		return rl.ScopeLogs().Len() == 0
+	})
+	p.mu.Unlock()
+
+	if logs.LogRecordCount() == 0 {
+		return nil
+	}
+	return p.next.ConsumeLogs(ctx, logs)
+}
+
+// correlate adds the record to its group and reports whether it was held back.
+func (p *correlationProcessor) correlate(resource pcommon.Resource, scope pcommon.InstrumentationScope, lr plog.LogRecord, now time.Time) bool {
+	key, ok := p.key(lr.Attributes())
+	if !ok {
+		return false
+	}
+	g, ok := p.groups[key]
+	if !ok {
+		if len(p.groups) >= p.cfg.MaxGroups {
+			p.endOldest()
+		}
+		g = newGroup(key, now)
+		p.groups[key] = g
+	}
+	g.add(resource, scope, lr, recordTime(lr, now))
+	return true
+}
+
+// key joins the control ID, target ID and key attribute values of a record.
+// Records without a control or target ID are not correlated.
+func (p *correlationProcessor) key(attrs pcommon.Map) (string, bool) {
+	controlID := str(attrs, proofwatch.COMPLIANCE_CONTROL_ID)
+	targetID := str(attrs, proofwatch.POLICY_TARGET_ID)
+	if controlID == "" || targetID == "" {
+		return "", false
+	}
+	parts := make([]string, 0, 2+len(p.cfg.KeyAttributes))
+	parts = append(parts, controlID, targetID)
+	for _, attr := range p.cfg.KeyAttributes {
+		parts = append(parts, str(attrs, attr))
+	}
+	return strings.Join(parts, "\x00"), true
+}
+
+// endOldest closes the group that was opened first.
+func (p *correlationProcessor) endOldest() {
+	var oldest *group
+	for _, g := range p.groups {
+		if oldest == nil || g.opened.Before(oldest.opened) {
+			oldest = g
+		}
+	}
+	if oldest != nil {
+		p.settings.Logger.Warn("max_groups reached; closing the oldest correlation window early")
+		delete(p.groups, oldest.key)
+		p.ended = append(p.ended, oldest)
+	}
+}
+
+// flush emits the groups whose window has elapsed, or every group when all is
+// set. Groups that fail to emit are dropped, like a failed batch upstream.
+func (p *correlationProcessor) flush(ctx context.Context, all bool) error {
+	now := p.now()
+
+	p.mu.Lock()
+	ended := p.ended
+	p.ended = nil
+	for key, g := range p.groups {
+		if all || now.Sub(g.opened) >= p.cfg.Window {
+			ended = append(ended, g)
+			delete(p.groups, key)
+		}
+	}
+	p.mu.Unlock()
+
+	if len(ended) == 0 {
+		return nil
+	}
+	sort.Slice(ended, func(i, j int) bool {
+		if !ended[i].opened.Equal(ended[j].opened) {
+			return ended[i].opened.Before(ended[j].opened)
+		}
+		return ended[i].firstSeen.Before(ended[j].firstSeen)
+	})
+	return p.next.ConsumeLogs(ctx, buildLogs(ended))
+}
+
+// buildLogs emits the latest record of every group with its correlation
+// attributes, under the resource and scope it arrived with.
+func buildLogs(groups []*group) plog.Logs {
+	logs := plog.NewLogs()
+	for _, g := range groups {
+		rl := logs.ResourceLogs().AppendEmpty()
+		g.resource.CopyTo(rl.Resource())
+		sl := rl.ScopeLogs().AppendEmpty()
+		g.scope.CopyTo(sl.Scope())
+
+		lr := sl.LogRecords().AppendEmpty()
+		g.record.CopyTo(lr)
+		attrs := lr.Attributes()
+		attrs.PutStr(proofwatch.EVIDENCE_CORRELATION_FIRST_SEEN, g.firstSeen.UTC().Format(time.RFC3339Nano))
+		attrs.PutStr(proofwatch.EVIDENCE_CORRELATION_LAST_SEEN, g.lastSeen.UTC().Format(time.RFC3339Nano))
+		attrs.PutInt(proofwatch.EVIDENCE_CORRELATION_COUNT, g.count)
+	}
+	return logs
+}
+
+func recordTime(lr plog.LogRecord, now time.Time) time.Time {
+	if lr.Timestamp() != 0 {
+		return lr.Timestamp().AsTime()
+	}
+	if lr.ObservedTimestamp() != 0 {
+		return lr.ObservedTimestamp().AsTime()
+	}
+	return now
+}
+
+func str(attrs pcommon.Map, key string) string {
+	if v, ok := attrs.Get(key); ok {
+		return v.AsString()
+	}
+	return ""
+}
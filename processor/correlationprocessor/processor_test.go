@@ -0,0 +1,216 @@
+package correlationprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+var evaluatedAt = time.Date(2026, 6, 2, 10, 0, 0, 0, time.UTC)
+
+func evidence(controlID, targetID, result string) map[string]any {
+	attrs := map[string]any{
+		proofwatch.POLICY_RULE_ID:           "rule-" + controlID,
+		proofwatch.POLICY_EVALUATION_RESULT: result,
+	}
+	if controlID != "" {
+		attrs[proofwatch.COMPLIANCE_CONTROL_ID] = controlID
+	}
+	if targetID != "" {
+		attrs[proofwatch.POLICY_TARGET_ID] = targetID
+	}
+	return attrs
+}
+
+// evidenceLogs builds one record per attribute map, a minute apart.
+func evidenceLogs(records ...map[string]any) plog.Logs {
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "openscap")
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("test")
+	for i, attrs := range records {
+		record := sl.LogRecords().AppendEmpty()
+		record.SetTimestamp(pcommon.NewTimestampFromTime(evaluatedAt.Add(time.Duration(i) * time.Minute)))
+		record.Body().SetStr(attrs[proofwatch.POLICY_EVALUATION_RESULT].(string))
+		_ = record.Attributes().FromRaw(attrs)
+	}
+	return logs
+}
+
+func newTestProcessor(t *testing.T, cfg *Config, now *time.Time) (*correlationProcessor, *consumertest.LogsSink) {
+	t.Helper()
+	sink := new(consumertest.LogsSink)
+	p := newCorrelationProcessor(cfg, processortest.NewNopSettings(componentType), sink)
+	p.now = func() time.Time { return *now }
+	return p, sink
+}
+
+func allRecords(sink *consumertest.LogsSink) []plog.LogRecord {
+	var records []plog.LogRecord
+	for _, logs := range sink.AllLogs() {
+		for i := 0; i < logs.ResourceLogs().Len(); i++ {
+			sls := logs.ResourceLogs().At(i).ScopeLogs()
+			for j := 0; j < sls.Len(); j++ {
+				lrs := sls.At(j).LogRecords()
+				for k := 0; k < lrs.Len(); k++ {
+					records = append(records, lrs.At(k))
+				}
+			}
+		}
+	}
+	return records
+}
+
+func attr(record plog.LogRecord, key string) any {
+	if v, ok := record.Attributes().Get(key); ok {
+		return v.AsRaw()
+	}
+	return nil
+}
+
+func TestConsumeLogsCollapsesDuplicates(t *testing.T) {
+	now := evaluatedAt
+	p, sink := newTestProcessor(t, createDefaultConfig().(*Config), &now)
+
+	require.NoError(t, p.ConsumeLogs(context.Background(), evidenceLogs(
+		evidence("ac-2", "web-01", "Failed"),
+		evidence("ac-2", "web-01", "Failed"),
+		// A different result, target or control is not a duplicate.
+		evidence("ac-2", "web-01", "Passed"),
+		evidence("ac-2", "web-02", "Failed"),
+		evidence("au-2", "web-01", "Failed"),
+		// Records without a control or target ID pass straight through.
+		evidence("", "web-01", "Failed"),
+		evidence("ac-2", "", "Failed"),
+	)))
+	require.Len(t, sink.AllLogs(), 1)
+	assert.Equal(t, 2, sink.LogRecordCount())
+
+	// A later duplicate within the window is collapsed into the open group.
+	require.NoError(t, p.ConsumeLogs(context.Background(), evidenceLogs(
+		evidence("ac-2", "web-01", "Failed"),
+		evidence("ac-2", "web-01", "Failed"),
+		evidence("ac-2", "web-01", "Failed"),
+		evidence("ac-2", "web-01", "Failed"),
+		evidence("ac-2", "web-01", "Failed"),
+		evidence("ac-2", "web-01", "Failed"),
+	)))
+	assert.Equal(t, 2, sink.LogRecordCount())
+	assert.Len(t, p.groups, 4)
+
+	sink.Reset()
+	now = now.Add(defaultWindow)
+	require.NoError(t, p.flush(context.Background(), false))
+
+	records := allRecords(sink)
+	require.Len(t, records, 4)
+	first := records[0]
+	assert.Equal(t, "ac-2", attr(first, proofwatch.COMPLIANCE_CONTROL_ID))
+	assert.Equal(t, "web-01", attr(first, proofwatch.POLICY_TARGET_ID))
+	assert.Equal(t, "Failed", attr(first, proofwatch.POLICY_EVALUATION_RESULT))
+	assert.Equal(t, int64(8), attr(first, proofwatch.EVIDENCE_CORRELATION_COUNT))
+	assert.Equal(t, "2026-06-02T10:00:00Z", attr(first, proofwatch.EVIDENCE_CORRELATION_FIRST_SEEN))
+	assert.Equal(t, "2026-06-02T10:05:00Z", attr(first, proofwatch.EVIDENCE_CORRELATION_LAST_SEEN))
+	assert.Equal(t, evaluatedAt.Add(5*time.Minute), first.Timestamp().AsTime())
+
+	resource := sink.AllLogs()[0].ResourceLogs().At(0)
+	assert.Equal(t, map[string]any{"service.name": "openscap"}, resource.Resource().Attributes().AsRaw())
+	assert.Equal(t, "test", resource.ScopeLogs().At(0).Scope().Name())
+	assert.Empty(t, p.groups)
+}
+
+func TestFlushKeepsOpenWindows(t *testing.T) {
+	now := evaluatedAt
+	p, sink := newTestProcessor(t, createDefaultConfig().(*Config), &now)
+
+	require.NoError(t, p.ConsumeLogs(context.Background(), evidenceLogs(evidence("ac-2", "web-01", "Passed"))))
+	now = now.Add(defaultWindow - time.Second)
+	require.NoError(t, p.ConsumeLogs(context.Background(), evidenceLogs(evidence("ac-2", "web-02", "Passed"))))
+
+	now = now.Add(time.Second)
+	require.NoError(t, p.flush(context.Background(), false))
+	records := allRecords(sink)
+	require.Len(t, records, 1)
+	assert.Equal(t, "web-01", attr(records[0], proofwatch.POLICY_TARGET_ID))
+	assert.Len(t, p.groups, 1)
+
+	require.NoError(t, p.Shutdown(context.Background()))
+	assert.Len(t, allRecords(sink), 2)
+}
+
+func TestLateRecordKeepsLatestEvidence(t *testing.T) {
+	now := evaluatedAt
+	p, sink := newTestProcessor(t, createDefaultConfig().(*Config), &now)
+
+	newer := evidenceLogs(evidence("ac-2", "web-01", "Passed"))
+	newer.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).SetTimestamp(pcommon.NewTimestampFromTime(evaluatedAt.Add(time.Hour)))
+	require.NoError(t, p.ConsumeLogs(context.Background(), newer))
+	require.NoError(t, p.ConsumeLogs(context.Background(), evidenceLogs(evidence("ac-2", "web-01", "Passed"))))
+	require.NoError(t, p.flush(context.Background(), true))
+
+	records := allRecords(sink)
+	require.Len(t, records, 1)
+	assert.Equal(t, evaluatedAt.Add(time.Hour), records[0].Timestamp().AsTime())
+	assert.Equal(t, "2026-06-02T10:00:00Z", attr(records[0], proofwatch.EVIDENCE_CORRELATION_FIRST_SEEN))
+	assert.Equal(t, "2026-06-02T11:00:00Z", attr(records[0], proofwatch.EVIDENCE_CORRELATION_LAST_SEEN))
+}
+
+func TestKeyAttributes(t *testing.T) {
+	now := evaluatedAt
+	cfg := createDefaultConfig().(*Config)
+	// Without the result in the key, a changed result collapses into the group
+	// and the latest result is emitted.
+	cfg.KeyAttributes = []string{proofwatch.POLICY_RULE_ID}
+	p, sink := newTestProcessor(t, cfg, &now)
+
+	require.NoError(t, p.ConsumeLogs(context.Background(), evidenceLogs(
+		evidence("ac-2", "web-01", "Failed"),
+		evidence("ac-2", "web-01", "Passed"),
+	)))
+	require.NoError(t, p.flush(context.Background(), true))
+
+	records := allRecords(sink)
+	require.Len(t, records, 1)
+	assert.Equal(t, "Passed", attr(records[0], proofwatch.POLICY_EVALUATION_RESULT))
+	assert.Equal(t, int64(2), attr(records[0], proofwatch.EVIDENCE_CORRELATION_COUNT))
+}
+
+func TestMaxGroupsClosesOldestWindow(t *testing.T) {
+	now := evaluatedAt
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxGroups = 2
+	p, sink := newTestProcessor(t, cfg, &now)
+
+	for _, target := range []string{"web-01", "web-02", "web-03"} {
+		require.NoError(t, p.ConsumeLogs(context.Background(), evidenceLogs(evidence("ac-2", target, "Passed"))))
+		now = now.Add(time.Second)
+	}
+	assert.Len(t, p.groups, 2)
+
+	require.NoError(t, p.flush(context.Background(), false))
+	records := allRecords(sink)
+	require.Len(t, records, 1)
+	assert.Equal(t, "web-01", attr(records[0], proofwatch.POLICY_TARGET_ID))
+}
+
+func TestFlushReturnsConsumerError(t *testing.T) {
+	now := evaluatedAt
+	p := newCorrelationProcessor(createDefaultConfig().(*Config), processortest.NewNopSettings(componentType),
+		consumertest.NewErr(errors.New("pipeline full")))
+	p.now = func() time.Time { return now }
+
+	require.NoError(t, p.ConsumeLogs(context.Background(), evidenceLogs(evidence("ac-2", "web-01", "Passed"))))
+	assert.EqualError(t, p.flush(context.Background(), true), "pipeline full")
+	assert.Empty(t, p.groups)
+}
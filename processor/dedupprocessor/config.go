@@ -0,0 +1,66 @@
+package dedupprocessor
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+const (
+	defaultWindow     = time.Hour
+	defaultMaxEntries = 100000
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines the configuration for the dedup processor.
+type Config struct {
+	// Window is how long a forwarded record suppresses its duplicates. It
+	// slides: the next record forwarded for the same key starts a new window.
+	Window time.Duration `mapstructure:"window"`
+
+	// KeyAttributes are the attributes whose values identify duplicates.
+	KeyAttributes []string `mapstructure:"key_attributes"`
+
+	// ContentHash adds a hash of the record body and attributes to the key,
+	// so only records with identical content are duplicates.
+	ContentHash bool `mapstructure:"content_hash"`
+
+	// MaxEntries bounds the keys remembered at once. When reached, the key
+	// forwarded longest ago is forgotten.
+	MaxEntries int `mapstructure:"max_entries"`
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Window: defaultWindow,
+		KeyAttributes: []string{
+			proofwatch.POLICY_RULE_ID,
+			proofwatch.POLICY_TARGET_ID,
+			proofwatch.POLICY_EVALUATION_RESULT,
+		},
+		MaxEntries: defaultMaxEntries,
+	}
+}
+
+// Validate checks the processor configuration is valid.
+func (c *Config) Validate() error {
+	if c.Window <= 0 {
+		return errors.New("window must be positive")
+	}
+	if c.MaxEntries <= 0 {
+		return errors.New("max_entries must be positive")
+	}
+	if len(c.KeyAttributes) == 0 && !c.ContentHash {
+		return errors.New("at least one of key_attributes or content_hash must be configured")
+	}
+	for _, attr := range c.KeyAttributes {
+		if attr == "" {
+			return errors.New("key_attributes must not contain empty names")
+		}
+	}
+	return nil
+}
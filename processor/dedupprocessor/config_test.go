@@ -0,0 +1,104 @@
+package dedupprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id       component.ID
+		expected *Config
+	}{
+		{
+			id:       component.NewID(componentType),
+			expected: createDefaultConfig().(*Config),
+		},
+		{
+			id: component.NewIDWithName(componentType, "content"),
+			expected: &Config{
+				Window:        24 * time.Hour,
+				KeyAttributes: []string{proofwatch.POLICY_RULE_ID, proofwatch.POLICY_TARGET_ID},
+				ContentHash:   true,
+				MaxEntries:    5000,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, sub.Unmarshal(cfg))
+			require.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "zero window",
+			mutate:  func(cfg *Config) { cfg.Window = 0 },
+			wantErr: "window must be positive",
+		},
+		{
+			name:    "zero max entries",
+			mutate:  func(cfg *Config) { cfg.MaxEntries = 0 },
+			wantErr: "max_entries must be positive",
+		},
+		{
+			name:    "no key",
+			mutate:  func(cfg *Config) { cfg.KeyAttributes = nil },
+			wantErr: "at least one of key_attributes or content_hash must be configured",
+		},
+		{
+			name:    "empty key attribute",
+			mutate:  func(cfg *Config) { cfg.KeyAttributes = []string{""} },
+			wantErr: "key_attributes must not contain empty names",
+		},
+		{
+			name: "content hash only",
+			mutate: func(cfg *Config) {
+				cfg.KeyAttributes = nil
+				cfg.ContentHash = true
+			},
+		},
+		{
+			name:   "valid",
+			mutate: func(*Config) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package dedupprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor"
+)
+
+const stability = component.StabilityLevelAlpha
+
+var componentType = component.MustNewType("dedup")
+
+// NewFactory creates a factory for the dedup processor.
+func NewFactory() processor.Factory {
+	return processor.NewFactory(
+		componentType,
+		createDefaultConfig,
+		processor.WithLogs(createLogsProcessor, stability),
+	)
+}
+
+// createLogsProcessor does not use processorhelper: keys are only kept once
+// the next consumer accepted their records, which processorhelper does not
+// report back.
+func createLogsProcessor(
+	_ context.Context,
+	_ processor.Settings,
+	cfg component.Config,
+	next consumer.Logs,
+) (processor.Logs, error) {
+	return newDedupProcessor(cfg.(*Config), next), nil
+}
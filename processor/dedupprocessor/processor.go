@@ -0,0 +1,230 @@
+package dedupprocessor
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+type key [sha256.Size]byte
+
+// entry is a key and when its last record was forwarded.
+type entry struct {
+	key       key
+	forwarded time.Time
+}
+
+type dedupProcessor struct {
+	cfg  *Config
+	next consumer.Logs
+	now  func() time.Time
+
+	mu sync.Mutex
+	// order holds the remembered entries, forwarded longest ago first.
+	order   *list.List
+	entries map[key]*list.Element
+}
+
+func newDedupProcessor(cfg *Config, next consumer.Logs) *dedupProcessor {
+	return &dedupProcessor{
+		cfg:     cfg,
+		next:    next,
+		now:     time.Now,
+		order:   list.New(),
+		entries: make(map[key]*list.Element),
+	}
+}
+
+// Capabilities implements consumer.Logs. Duplicates are removed from the
+// batch before it is passed on.
+func (p *dedupProcessor) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+func (p *dedupProcessor) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (p *dedupProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// ConsumeLogs drops every record whose key was forwarded within the window
+// and passes the rest on. The keys of a batch the next consumer fails are
+// forgotten again, so a retried batch is not dropped as its own duplicate. A
+// batch left without records is not passed on.
+func (p *dedupProcessor) ConsumeLogs(ctx context.Context, logs plog.Logs) error {
+	now := p.now()
+
+	p.mu.Lock()
+	p.expire(now)
+	var added []*list.Element
+	logs.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+			sl.LogRecords().RemoveIf(func(lr plog.LogRecord) bool {
+				el, duplicate := p.remember(lr, now)
+				if el != nil {
+					added = append(added, el)
+				}
+				return duplicate
+			})
+			return sl.LogRecords().Len() == 0
+		})
+		return rl.ScopeLogs().Len() == 0
+	})
+	p.mu.Unlock()
+
+	if logs.LogRecordCount() == 0 {
+		return nil
+	}
+	if err := p.next.ConsumeLogs(ctx, logs); err != nil {
+		p.forget(added)
+		return err
+	}
+	return nil
+}
+
+// expire forgets the keys whose window has elapsed.
+func (p *dedupProcessor) expire(now time.Time) {
+	for el := p.order.Front(); el != nil; el = p.order.Front() {
+		e := el.Value.(*entry)
+		if now.Sub(e.forwarded) < p.cfg.Window {
+			return
+		}
+		p.order.Remove(el)
+		delete(p.entries, e.key)
+	}
+}
+
+// remember reports whether the record's key is remembered, and otherwise
+// remembers it and returns its entry. Records without a key are never
+// duplicates.
+func (p *dedupProcessor) remember(lr plog.LogRecord, now time.Time) (*list.Element, bool) {
+	k, ok := p.key(lr)
+	if !ok {
+		return nil, false
+	}
+	if _, ok := p.entries[k]; ok {
+		return nil, true
+	}
+	if p.order.Len() >= p.cfg.MaxEntries {
+		oldest := p.order.Front()
+		p.order.Remove(oldest)
+		delete(p.entries, oldest.Value.(*entry).key)
+	}
+	el := p.order.PushBack(&entry{key: k, forwarded: now})
+	p.entries[k] = el
+	return el, false
+}
+
+// forget removes the entries of a failed batch that are still remembered.
+func (p *dedupProcessor) forget(added []*list.Element) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, el := range added {
+		k := el.Value.(*entry).key
+		if p.entries[k] == el {
+			p.order.Remove(el)
+			delete(p.entries, k)
+		}
+	}
+}
+
+// key hashes the key attribute values and, with content_hash, the record body
+// and attributes. Records with none of the key attributes and no content
+// hash have no key.
+func (p *dedupProcessor) key(lr plog.LogRecord) (key, bool) {
+	h := sha256.New()
+	found := false
+	for _, attr := range p.cfg.KeyAttributes {
+		v, ok := lr.Attributes().Get(attr)
+		if !ok {
+			writePart(h, "\x00")
+			continue
+		}
+		found = true
+		writePart(h, "\x01")
+		writeValue(h, v)
+	}
+	if p.cfg.ContentHash {
+		found = true
+		writeValue(h, lr.Body())
+		writeMap(h, lr.Attributes())
+	}
+
+	var k key
+	if !found {
+		return k, false
+	}
+	copy(k[:], h.Sum(nil))
+	return k, true
+}
+
+// writeValue writes the type and content of a value, so values of different
+// types, such as the string "1" and the int 1, hash differently.
+func writeValue(h hash.Hash, v pcommon.Value) {
+	h.Write([]byte{byte(v.Type())})
+	switch v.Type() {
+	case pcommon.ValueTypeStr:
+		writePart(h, v.Str())
+	case pcommon.ValueTypeInt:
+		writeUint(h, uint64(v.Int()))
+	case pcommon.ValueTypeDouble:
+		writeUint(h, math.Float64bits(v.Double()))
+	case pcommon.ValueTypeBool:
+		if v.Bool() {
+			h.Write([]byte{1})
+		} else {
+			h.Write([]byte{0})
+		}
+	case pcommon.ValueTypeBytes:
+		writePart(h, string(v.Bytes().AsRaw()))
+	case pcommon.ValueTypeSlice:
+		writeUint(h, uint64(v.Slice().Len()))
+		for i := 0; i < v.Slice().Len(); i++ {
+			writeValue(h, v.Slice().At(i))
+		}
+	case pcommon.ValueTypeMap:
+		writeMap(h, v.Map())
+	}
+}
+
+// writeMap writes the entries of a map in key order, so equal maps hash
+// equally.
+func writeMap(h hash.Hash, m pcommon.Map) {
+	keys := make([]string, 0, m.Len())
+	m.Range(func(k string, _ pcommon.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	slices.Sort(keys)
+	writeUint(h, uint64(len(keys)))
+	for _, k := range keys {
+		v, _ := m.Get(k)
+		writePart(h, k)
+		writeValue(h, v)
+	}
+}
+
+// writePart writes a length-prefixed part, so part boundaries are unambiguous.
+func writePart(h hash.Hash, part string) {
+	writeUint(h, uint64(len(part)))
+	h.Write([]byte(part))
+}
+
+func writeUint(h hash.Hash, n uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], n)
+	h.Write(b[:])
+}
@@ -0,0 +1,174 @@
+package dedupprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+var start = time.Date(2026, 6, 2, 10, 0, 0, 0, time.UTC)
+
+func finding(ruleID, targetID, result string) map[string]any {
+	return map[string]any{
+		proofwatch.POLICY_RULE_ID:           ruleID,
+		proofwatch.POLICY_TARGET_ID:         targetID,
+		proofwatch.POLICY_EVALUATION_RESULT: result,
+	}
+}
+
+// evidenceLogs builds one record per attribute map.
+func evidenceLogs(records ...map[string]any) plog.Logs {
+	logs := plog.NewLogs()
+	lrs := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, attrs := range records {
+		_ = lrs.AppendEmpty().Attributes().FromRaw(attrs)
+	}
+	return logs
+}
+
+func newTestProcessor(cfg *Config, now *time.Time) *dedupProcessor {
+	p := newDedupProcessor(cfg, new(consumertest.LogsSink))
+	p.now = func() time.Time { return *now }
+	return p
+}
+
+// forwarded returns the number of records passed on.
+func forwarded(t *testing.T, p *dedupProcessor, logs plog.Logs) int {
+	t.Helper()
+	sink := new(consumertest.LogsSink)
+	p.next = sink
+	require.NoError(t, p.ConsumeLogs(context.Background(), logs))
+	return sink.LogRecordCount()
+}
+
+func TestProcessLogsDropsDuplicates(t *testing.T) {
+	now := start
+	p := newTestProcessor(createDefaultConfig().(*Config), &now)
+
+	assert.Equal(t, 6, forwarded(t, p, evidenceLogs(
+		finding("r1", "web-01", "Failed"),
+		finding("r1", "web-01", "Failed"),
+		finding("r1", "web-01", "Passed"),
+		finding("r1", "web-02", "Failed"),
+		finding("r2", "web-01", "Failed"),
+		// Records with none of the key attributes are never duplicates.
+		map[string]any{proofwatch.COMPLIANCE_CONTROL_ID: "ac-2"},
+		map[string]any{proofwatch.COMPLIANCE_CONTROL_ID: "ac-2"},
+	)))
+
+	now = now.Add(defaultWindow - time.Second)
+	assert.Zero(t, forwarded(t, p, evidenceLogs(finding("r1", "web-01", "Failed"))))
+
+	// The window slides from the forwarded record, so the finding is
+	// forwarded again once an hour while it keeps being re-emitted.
+	now = now.Add(time.Second)
+	assert.Equal(t, 1, forwarded(t, p, evidenceLogs(finding("r1", "web-01", "Failed"))))
+	now = now.Add(time.Minute)
+	assert.Zero(t, forwarded(t, p, evidenceLogs(finding("r1", "web-01", "Failed"))))
+}
+
+func TestProcessLogsRemovesEmptyResources(t *testing.T) {
+	now := start
+	p := newTestProcessor(createDefaultConfig().(*Config), &now)
+	require.Equal(t, 1, forwarded(t, p, evidenceLogs(finding("r1", "web-01", "Failed"))))
+
+	logs := evidenceLogs(finding("r1", "web-01", "Failed"))
+	lrs := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	_ = lrs.AppendEmpty().Attributes().FromRaw(finding("r1", "web-02", "Failed"))
+
+	sink := new(consumertest.LogsSink)
+	p.next = sink
+	require.NoError(t, p.ConsumeLogs(context.Background(), logs))
+	// The resource left without records is removed.
+	require.Len(t, sink.AllLogs(), 1)
+	assert.Equal(t, 1, sink.AllLogs()[0].ResourceLogs().Len())
+	assert.Equal(t, 1, sink.LogRecordCount())
+}
+
+func TestContentHash(t *testing.T) {
+	now := start
+	cfg := createDefaultConfig().(*Config)
+	cfg.KeyAttributes = []string{proofwatch.POLICY_RULE_ID}
+	cfg.ContentHash = true
+	p := newTestProcessor(cfg, &now)
+
+	withMessage := func(msg string) map[string]any {
+		attrs := finding("r1", "web-01", "Failed")
+		attrs[proofwatch.POLICY_EVALUATION_MESSAGE] = msg
+		return attrs
+	}
+	assert.Equal(t, 2, forwarded(t, p, evidenceLogs(
+		withMessage("3 packages outdated"),
+		withMessage("3 packages outdated"),
+		withMessage("4 packages outdated"),
+	)))
+
+	logs := evidenceLogs(withMessage("3 packages outdated"))
+	logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().SetStr("raw scanner output")
+	assert.Equal(t, 1, forwarded(t, p, logs))
+}
+
+func TestKeyDistinguishesMissingAttributes(t *testing.T) {
+	now := start
+	p := newTestProcessor(createDefaultConfig().(*Config), &now)
+
+	assert.Equal(t, 2, forwarded(t, p, evidenceLogs(
+		map[string]any{proofwatch.POLICY_RULE_ID: "r1", proofwatch.POLICY_TARGET_ID: ""},
+		map[string]any{proofwatch.POLICY_RULE_ID: "r1"},
+	)))
+}
+
+func TestKeyDistinguishesValueTypes(t *testing.T) {
+	now := start
+	cfg := createDefaultConfig().(*Config)
+	cfg.KeyAttributes = []string{proofwatch.POLICY_RULE_ID}
+	p := newTestProcessor(cfg, &now)
+
+	assert.Equal(t, 4, forwarded(t, p, evidenceLogs(
+		map[string]any{proofwatch.POLICY_RULE_ID: "1"},
+		map[string]any{proofwatch.POLICY_RULE_ID: int64(1)},
+		map[string]any{proofwatch.POLICY_RULE_ID: float64(1)},
+		map[string]any{proofwatch.POLICY_RULE_ID: true},
+		map[string]any{proofwatch.POLICY_RULE_ID: int64(1)},
+	)))
+}
+
+func TestFailedBatchIsNotRemembered(t *testing.T) {
+	now := start
+	p := newTestProcessor(createDefaultConfig().(*Config), &now)
+
+	p.next = consumertest.NewErr(errors.New("exporter unavailable"))
+	err := p.ConsumeLogs(context.Background(), evidenceLogs(
+		finding("r1", "web-01", "Failed"),
+		finding("r1", "web-01", "Failed"),
+	))
+	require.EqualError(t, err, "exporter unavailable")
+	assert.Zero(t, p.order.Len())
+
+	// The retried batch is forwarded rather than dropped as a duplicate.
+	assert.Equal(t, 1, forwarded(t, p, evidenceLogs(finding("r1", "web-01", "Failed"))))
+	assert.Zero(t, forwarded(t, p, evidenceLogs(finding("r1", "web-01", "Failed"))))
+}
+
+func TestMaxEntriesForgetsOldestKey(t *testing.T) {
+	now := start
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxEntries = 2
+	p := newTestProcessor(cfg, &now)
+
+	for _, target := range []string{"web-01", "web-02", "web-03"} {
+		require.Equal(t, 1, forwarded(t, p, evidenceLogs(finding("r1", target, "Failed"))))
+		now = now.Add(time.Second)
+	}
+	assert.Equal(t, 2, p.order.Len())
+	assert.Equal(t, 1, forwarded(t, p, evidenceLogs(finding("r1", "web-01", "Failed"))))
+	assert.Zero(t, forwarded(t, p, evidenceLogs(finding("r1", "web-03", "Failed"))))
+}
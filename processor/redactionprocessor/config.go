@@ -0,0 +1,95 @@
+package redactionprocessor
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+const (
+	// StrategyMask replaces the value with a fixed mask.
+	StrategyMask = "mask"
+	// StrategyHash replaces the value with its HMAC-SHA256 digest, so redacted
+	// values can still be matched with each other.
+	StrategyHash = "hash"
+	// StrategyRemove deletes the field.
+	StrategyRemove = "remove"
+
+	// ContextAttributes selects a log record attribute.
+	ContextAttributes = "attributes"
+	// ContextResource selects a resource attribute.
+	ContextResource = "resource"
+	// ContextBody selects a field of a map body.
+	ContextBody = "body"
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines the configuration for the redaction processor.
+type Config struct {
+	// Fields are the fields to redact.
+	Fields []FieldConfig `mapstructure:"fields"`
+
+	// HashKey keys the HMAC-SHA256 digests of the hash strategy. It is required
+	// with the hash strategy, since unkeyed digests of short values such as
+	// usernames or IP addresses can be recovered by hashing candidates.
+	HashKey configopaque.String `mapstructure:"hash_key"`
+}
+
+// FieldConfig selects a field and how it is redacted.
+type FieldConfig struct {
+	// Key is the attribute name, or for the body a dot-separated path into
+	// nested maps.
+	Key string `mapstructure:"key"`
+
+	// Context selects where the field is: "attributes", "resource" or "body".
+	Context string `mapstructure:"context"`
+
+	// Strategy is "mask", "hash" or "remove".
+	Strategy string `mapstructure:"strategy"`
+
+	// Pattern is a regular expression. When set, only the matching parts of
+	// the value are redacted.
+	Pattern string `mapstructure:"pattern"`
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{}
+}
+
+// Validate checks the processor configuration is valid.
+func (c *Config) Validate() error {
+	if len(c.Fields) == 0 {
+		return errors.New("at least one field must be configured")
+	}
+	for i, f := range c.Fields {
+		if f.Key == "" {
+			return fmt.Errorf("fields[%d]: key must not be empty", i)
+		}
+		switch f.Context {
+		case "", ContextAttributes, ContextResource, ContextBody:
+		default:
+			return fmt.Errorf("fields[%d]: context must be %q, %q or %q", i, ContextAttributes, ContextResource, ContextBody)
+		}
+		switch f.Strategy {
+		case StrategyMask:
+		case StrategyHash:
+			if c.HashKey == "" {
+				return fmt.Errorf("fields[%d]: hash_key is required with the %q strategy", i, StrategyHash)
+			}
+		case StrategyRemove:
+			if f.Pattern != "" {
+				return fmt.Errorf("fields[%d]: pattern cannot be used with the %q strategy", i, StrategyRemove)
+			}
+		default:
+			return fmt.Errorf("fields[%d]: strategy must be %q, %q or %q", i, StrategyMask, StrategyHash, StrategyRemove)
+		}
+		if _, err := regexp.Compile(f.Pattern); err != nil {
+			return fmt.Errorf("fields[%d]: invalid pattern: %w", i, err)
+		}
+	}
+	return nil
+}
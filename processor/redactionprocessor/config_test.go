@@ -0,0 +1,127 @@
+package redactionprocessor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id       component.ID
+		expected *Config
+	}{
+		{
+			id: component.NewID(componentType),
+			expected: &Config{
+				Fields: []FieldConfig{{Key: "user.name", Strategy: StrategyMask}},
+			},
+		},
+		{
+			id: component.NewIDWithName(componentType, "full"),
+			expected: &Config{
+				HashKey: "redaction-key",
+				Fields: []FieldConfig{
+					{Key: "user.name", Strategy: StrategyHash},
+					{Key: "host.ip", Context: ContextResource, Strategy: StrategyRemove},
+					{Key: "file.path", Context: ContextBody, Strategy: StrategyMask},
+					{Key: proofwatch.POLICY_EVALUATION_MESSAGE, Strategy: StrategyMask, Pattern: `\b\d{1,3}(\.\d{1,3}){3}\b`},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, sub.Unmarshal(cfg))
+			require.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "no fields",
+			mutate:  func(cfg *Config) { cfg.Fields = nil },
+			wantErr: "at least one field must be configured",
+		},
+		{
+			name:    "empty key",
+			mutate:  func(cfg *Config) { cfg.Fields[0].Key = "" },
+			wantErr: "fields[0]: key must not be empty",
+		},
+		{
+			name:    "unknown context",
+			mutate:  func(cfg *Config) { cfg.Fields[0].Context = "scope" },
+			wantErr: `fields[0]: context must be "attributes", "resource" or "body"`,
+		},
+		{
+			name:    "unknown strategy",
+			mutate:  func(cfg *Config) { cfg.Fields[0].Strategy = "encrypt" },
+			wantErr: `fields[0]: strategy must be "mask", "hash" or "remove"`,
+		},
+		{
+			name:    "hash without hash key",
+			mutate:  func(cfg *Config) { cfg.Fields[0].Strategy = StrategyHash },
+			wantErr: `fields[0]: hash_key is required with the "hash" strategy`,
+		},
+		{
+			name: "hash with hash key",
+			mutate: func(cfg *Config) {
+				cfg.Fields[0].Strategy = StrategyHash
+				cfg.HashKey = "redaction-key"
+			},
+		},
+		{
+			name: "pattern with remove",
+			mutate: func(cfg *Config) {
+				cfg.Fields[0].Strategy = StrategyRemove
+				cfg.Fields[0].Pattern = "root"
+			},
+			wantErr: `fields[0]: pattern cannot be used with the "remove" strategy`,
+		},
+		{
+			name:    "invalid pattern",
+			mutate:  func(cfg *Config) { cfg.Fields[0].Pattern = "(" },
+			wantErr: "fields[0]: invalid pattern: error parsing regexp: missing closing ): `(`",
+		},
+		{
+			name:   "valid",
+			mutate: func(*Config) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Fields = []FieldConfig{{Key: "user.name", Strategy: StrategyMask}}
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package redactionprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const stability = component.StabilityLevelAlpha
+
+var componentType = component.MustNewType("redaction")
+
+// NewFactory creates a factory for the redaction processor.
+func NewFactory() processor.Factory {
+	return processor.NewFactory(
+		componentType,
+		createDefaultConfig,
+		processor.WithLogs(createLogsProcessor, stability),
+	)
+}
+
+func createLogsProcessor(
+	ctx context.Context,
+	set processor.Settings,
+	cfg component.Config,
+	next consumer.Logs,
+) (processor.Logs, error) {
+	proc := newRedactionProcessor(cfg.(*Config))
+	return processorhelper.NewLogs(ctx, set, cfg, next,
+		proc.processLogs,
+		processorhelper.WithCapabilities(consumer.Capabilities{MutatesData: true}),
+	)
+}
@@ -0,0 +1,195 @@
+package redactionprocessor
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+// maskValue replaces values redacted with the mask strategy.
+const maskValue = "****"
+
+// field is a configured field, ready to be applied.
+type field struct {
+	context  string
+	key      string
+	path     []string
+	strategy string
+	pattern  *regexp.Regexp
+	// name identifies the field in evidence.redaction.fields.
+	name string
+}
+
+type redactionProcessor struct {
+	fields  []field
+	hashKey []byte
+}
+
+func newRedactionProcessor(cfg *Config) *redactionProcessor {
+	p := &redactionProcessor{hashKey: []byte(cfg.HashKey)}
+	for _, f := range cfg.Fields {
+		ctx := f.Context
+		if ctx == "" {
+			ctx = ContextAttributes
+		}
+		compiled := field{
+			context:  ctx,
+			key:      f.Key,
+			path:     strings.Split(f.Key, "."),
+			strategy: f.Strategy,
+			name:     ctx + "." + f.Key,
+		}
+		if f.Pattern != "" {
+			// Validate has already checked the pattern compiles.
+			compiled.pattern = regexp.MustCompile(f.Pattern)
+		}
+		p.fields = append(p.fields, compiled)
+	}
+	return p
+}
+
+// processLogs redacts the configured fields of every record and lists the
+// redacted fields in evidence.redaction.fields. Resource fields are listed on
+// every record of the resource.
+func (p *redactionProcessor) processLogs(_ context.Context, logs plog.Logs) (plog.Logs, error) {
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		rl := logs.ResourceLogs().At(i)
+		var resourceRedacted []string
+		for _, f := range p.fields {
+			if f.context == ContextResource && p.redactKey(rl.Resource().Attributes(), f) {
+				resourceRedacted = appendName(resourceRedacted, f.name)
+			}
+		}
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			lrs := rl.ScopeLogs().At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				p.redactRecord(lrs.At(k), resourceRedacted)
+			}
+		}
+	}
+	return logs, nil
+}
+
+func (p *redactionProcessor) redactRecord(lr plog.LogRecord, resourceRedacted []string) {
+	redacted := slices.Clone(resourceRedacted)
+	for _, f := range p.fields {
+		var changed bool
+		switch f.context {
+		case ContextAttributes:
+			changed = p.redactKey(lr.Attributes(), f)
+		case ContextBody:
+			changed = p.redactBody(lr.Body(), f)
+		}
+		if changed {
+			redacted = appendName(redacted, f.name)
+		}
+	}
+	if len(redacted) == 0 {
+		return
+	}
+
+	// Keep the fields listed by an earlier redaction in the pipeline.
+	var audit pcommon.Slice
+	if v, ok := lr.Attributes().Get(proofwatch.EVIDENCE_REDACTION_FIELDS); ok && v.Type() == pcommon.ValueTypeSlice {
+		audit = v.Slice()
+	} else {
+		audit = lr.Attributes().PutEmptySlice(proofwatch.EVIDENCE_REDACTION_FIELDS)
+	}
+	listed := audit.AsRaw()
+	for _, name := range redacted {
+		if !slices.Contains(listed, any(name)) {
+			audit.AppendEmpty().SetStr(name)
+		}
+	}
+}
+
+// redactBody redacts a field of a map body, following the path of the key
+// through nested maps.
+func (p *redactionProcessor) redactBody(body pcommon.Value, f field) bool {
+	if body.Type() != pcommon.ValueTypeMap {
+		return false
+	}
+	m := body.Map()
+	for _, part := range f.path[:len(f.path)-1] {
+		child, ok := m.Get(part)
+		if !ok || child.Type() != pcommon.ValueTypeMap {
+			return false
+		}
+		m = child.Map()
+	}
+	return p.redactKey(m, field{key: f.path[len(f.path)-1], strategy: f.strategy, pattern: f.pattern})
+}
+
+// redactKey redacts the value of the key in the map, and reports whether it
+// changed.
+func (p *redactionProcessor) redactKey(m pcommon.Map, f field) bool {
+	v, ok := m.Get(f.key)
+	if !ok {
+		return false
+	}
+	if f.strategy == StrategyRemove {
+		return m.Remove(f.key)
+	}
+	return p.redactValue(v, f)
+}
+
+// redactValue redacts a value in place. Every string of a slice is redacted
+// on its own; other values are redacted as their string form.
+func (p *redactionProcessor) redactValue(v pcommon.Value, f field) bool {
+	switch v.Type() {
+	case pcommon.ValueTypeEmpty:
+		return false
+	case pcommon.ValueTypeSlice:
+		changed := false
+		for i := 0; i < v.Slice().Len(); i++ {
+			if p.redactValue(v.Slice().At(i), f) {
+				changed = true
+			}
+		}
+		return changed
+	}
+
+	s := v.AsString()
+	var redacted string
+	if f.pattern == nil {
+		if s == "" {
+			return false
+		}
+		redacted = p.replace(s, f.strategy)
+	} else {
+		if !f.pattern.MatchString(s) {
+			return false
+		}
+		redacted = f.pattern.ReplaceAllStringFunc(s, func(match string) string {
+			return p.replace(match, f.strategy)
+		})
+	}
+	v.SetStr(redacted)
+	return true
+}
+
+// replace returns the replacement of a sensitive value.
+func (p *redactionProcessor) replace(s, strategy string) string {
+	if strategy == StrategyMask {
+		return maskValue
+	}
+	h := hmac.New(sha256.New, p.hashKey)
+	h.Write([]byte(s))
+	return "sha256:" + hex.EncodeToString(h.Sum(nil))
+}
+
+func appendName(names []string, name string) []string {
+	if slices.Contains(names, name) {
+		return names
+	}
+	return append(names, name)
+}
@@ -0,0 +1,173 @@
+package redactionprocessor
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+// evidenceLogs builds one record per attribute map.
+func evidenceLogs(records ...map[string]any) plog.Logs {
+	logs := plog.NewLogs()
+	lrs := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, attrs := range records {
+		_ = lrs.AppendEmpty().Attributes().FromRaw(attrs)
+	}
+	return logs
+}
+
+const testHashKey = "redaction-key"
+
+func digest(s string) string {
+	mac := hmac.New(sha256.New, []byte(testHashKey))
+	mac.Write([]byte(s))
+	return "sha256:" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func process(t *testing.T, cfg *Config, logs plog.Logs) plog.Logs {
+	t.Helper()
+	require.NoError(t, cfg.Validate())
+	out, err := newRedactionProcessor(cfg).processLogs(context.Background(), logs)
+	require.NoError(t, err)
+	return out
+}
+
+func record(logs plog.Logs, i int) plog.LogRecord {
+	return logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(i)
+}
+
+func TestProcessLogsRedactsAttributes(t *testing.T) {
+	cfg := &Config{HashKey: testHashKey, Fields: []FieldConfig{
+		{Key: "user.name", Strategy: StrategyHash},
+		{Key: "file.path", Strategy: StrategyMask},
+		{Key: "client.address", Strategy: StrategyRemove},
+		{Key: "process.command_args", Strategy: StrategyHash},
+	}}
+
+	out := process(t, cfg, evidenceLogs(
+		map[string]any{
+			proofwatch.POLICY_RULE_ID: "xccdf_rule_no_root_login",
+			"user.name":               "alice",
+			"file.path":               "/home/alice/.ssh/authorized_keys",
+			"client.address":          "10.0.0.7",
+			"process.command_args":    []any{"sudo", "-u", "root"},
+		},
+		map[string]any{proofwatch.POLICY_RULE_ID: "xccdf_rule_no_root_login"},
+	))
+
+	assert.Equal(t, map[string]any{
+		proofwatch.POLICY_RULE_ID: "xccdf_rule_no_root_login",
+		"user.name":               digest("alice"),
+		"file.path":               maskValue,
+		"process.command_args":    []any{digest("sudo"), digest("-u"), digest("root")},
+		proofwatch.EVIDENCE_REDACTION_FIELDS: []any{
+			"attributes.user.name",
+			"attributes.file.path",
+			"attributes.client.address",
+			"attributes.process.command_args",
+		},
+	}, record(out, 0).Attributes().AsRaw())
+
+	// Records without sensitive fields are left alone.
+	assert.Equal(t, map[string]any{
+		proofwatch.POLICY_RULE_ID: "xccdf_rule_no_root_login",
+	}, record(out, 1).Attributes().AsRaw())
+}
+
+func TestProcessLogsHashKey(t *testing.T) {
+	cfg := &Config{
+		HashKey: "other-key",
+		Fields:  []FieldConfig{{Key: "user.name", Strategy: StrategyHash}},
+	}
+
+	out := process(t, cfg, evidenceLogs(map[string]any{"user.name": "alice"}, map[string]any{"user.name": "alice"}))
+
+	first, _ := record(out, 0).Attributes().Get("user.name")
+	second, _ := record(out, 1).Attributes().Get("user.name")
+	assert.NotEqual(t, digest("alice"), first.Str(), "digests depend on the key")
+	assert.Equal(t, first.Str(), second.Str(), "equal values hash equally")
+}
+
+func TestProcessLogsPattern(t *testing.T) {
+	cfg := &Config{Fields: []FieldConfig{{
+		Key:      proofwatch.POLICY_EVALUATION_MESSAGE,
+		Strategy: StrategyMask,
+		Pattern:  `\b\d{1,3}(\.\d{1,3}){3}\b`,
+	}}}
+
+	out := process(t, cfg, evidenceLogs(
+		map[string]any{proofwatch.POLICY_EVALUATION_MESSAGE: "SSH login from 10.0.0.7 and 10.0.0.8 denied"},
+		map[string]any{proofwatch.POLICY_EVALUATION_MESSAGE: "Password policy satisfied"},
+	))
+
+	assert.Equal(t, map[string]any{
+		proofwatch.POLICY_EVALUATION_MESSAGE: "SSH login from **** and **** denied",
+		proofwatch.EVIDENCE_REDACTION_FIELDS: []any{"attributes.policy.evaluation.message"},
+	}, record(out, 0).Attributes().AsRaw())
+	assert.Equal(t, map[string]any{
+		proofwatch.POLICY_EVALUATION_MESSAGE: "Password policy satisfied",
+	}, record(out, 1).Attributes().AsRaw())
+}
+
+func TestProcessLogsRedactsResource(t *testing.T) {
+	cfg := &Config{Fields: []FieldConfig{{Key: "host.ip", Context: ContextResource, Strategy: StrategyRemove}}}
+	logs := evidenceLogs(map[string]any{proofwatch.POLICY_RULE_ID: "r1"}, map[string]any{proofwatch.POLICY_RULE_ID: "r2"})
+	logs.ResourceLogs().At(0).Resource().Attributes().PutStr("host.name", "web-01")
+	logs.ResourceLogs().At(0).Resource().Attributes().PutStr("host.ip", "10.0.0.7")
+
+	out := process(t, cfg, logs)
+
+	assert.Equal(t, map[string]any{"host.name": "web-01"}, out.ResourceLogs().At(0).Resource().Attributes().AsRaw())
+	for i := range 2 {
+		audit, ok := record(out, i).Attributes().Get(proofwatch.EVIDENCE_REDACTION_FIELDS)
+		require.True(t, ok)
+		assert.Equal(t, []any{"resource.host.ip"}, audit.Slice().AsRaw())
+	}
+}
+
+func TestProcessLogsRedactsBody(t *testing.T) {
+	cfg := &Config{Fields: []FieldConfig{{Key: "file.path", Context: ContextBody, Strategy: StrategyMask}}}
+	logs := evidenceLogs(map[string]any{}, map[string]any{})
+	require.NoError(t, record(logs, 0).Body().SetEmptyMap().FromRaw(map[string]any{
+		"file":   map[string]any{"path": "/home/alice/.bashrc", "mode": "0644"},
+		"result": "fail",
+	}))
+	record(logs, 1).Body().SetStr("file.path is not a map body")
+
+	out := process(t, cfg, logs)
+
+	assert.Equal(t, map[string]any{
+		"file":   map[string]any{"path": maskValue, "mode": "0644"},
+		"result": "fail",
+	}, record(out, 0).Body().Map().AsRaw())
+	assert.Equal(t, map[string]any{
+		proofwatch.EVIDENCE_REDACTION_FIELDS: []any{"body.file.path"},
+	}, record(out, 0).Attributes().AsRaw())
+	assert.Equal(t, "file.path is not a map body", record(out, 1).Body().Str())
+	assert.Zero(t, record(out, 1).Attributes().Len())
+}
+
+func TestProcessLogsKeepsEarlierAudit(t *testing.T) {
+	cfg := &Config{Fields: []FieldConfig{
+		{Key: "user.name", Strategy: StrategyMask},
+		{Key: "user.id", Strategy: StrategyMask},
+	}}
+
+	out := process(t, cfg, evidenceLogs(map[string]any{
+		"user.name":                          "alice",
+		"user.id":                            "1000",
+		proofwatch.EVIDENCE_REDACTION_FIELDS: []any{"resource.host.ip", "attributes.user.name"},
+	}))
+
+	audit, ok := record(out, 0).Attributes().Get(proofwatch.EVIDENCE_REDACTION_FIELDS)
+	require.True(t, ok)
+	assert.Equal(t, []any{"resource.host.ip", "attributes.user.name", "attributes.user.id"}, audit.Slice().AsRaw())
+}
@@ -0,0 +1,61 @@
+package rollupprocessor
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+const defaultWindow = 15 * time.Minute
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines the configuration for the rollup processor.
+type Config struct {
+	// Window is how often a summary record is emitted per group.
+	Window time.Duration `mapstructure:"window"`
+
+	// GroupBy are the attributes whose values define a group. Records without
+	// any of them are not rolled up.
+	GroupBy []string `mapstructure:"group_by"`
+
+	// DropRaw replaces the raw records of every group with the summaries.
+	DropRaw bool `mapstructure:"drop_raw"`
+
+	// RawThreshold is how many raw records of a group are passed on per
+	// window. Beyond it, raw records are only counted in the summary. Zero
+	// passes on every raw record.
+	RawThreshold int `mapstructure:"raw_threshold"`
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Window:  defaultWindow,
+		GroupBy: []string{proofwatch.COMPLIANCE_CONTROL_CATALOG_ID, proofwatch.COMPLIANCE_CONTROL_ID},
+	}
+}
+
+// Validate checks the processor configuration is valid.
+func (c *Config) Validate() error {
+	if c.Window <= 0 {
+		return errors.New("window must be positive")
+	}
+	if len(c.GroupBy) == 0 {
+		return errors.New("group_by must not be empty")
+	}
+	for _, attr := range c.GroupBy {
+		if attr == "" {
+			return errors.New("group_by must not contain empty names")
+		}
+	}
+	if c.RawThreshold < 0 {
+		return errors.New("raw_threshold must not be negative")
+	}
+	if c.DropRaw && c.RawThreshold > 0 {
+		return errors.New("raw_threshold cannot be set with drop_raw")
+	}
+	return nil
+}
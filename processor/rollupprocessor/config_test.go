@@ -0,0 +1,112 @@
+package rollupprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id       component.ID
+		expected *Config
+	}{
+		{
+			id:       component.NewID(componentType),
+			expected: createDefaultConfig().(*Config),
+		},
+		{
+			id: component.NewIDWithName(componentType, "replace"),
+			expected: &Config{
+				Window:  time.Hour,
+				GroupBy: []string{proofwatch.COMPLIANCE_FRAMEWORKS, proofwatch.COMPLIANCE_CONTROL_ID},
+				DropRaw: true,
+			},
+		},
+		{
+			id: component.NewIDWithName(componentType, "threshold"),
+			expected: &Config{
+				Window:       defaultWindow,
+				GroupBy:      []string{proofwatch.COMPLIANCE_CONTROL_CATALOG_ID, proofwatch.COMPLIANCE_CONTROL_ID},
+				RawThreshold: 100,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, sub.Unmarshal(cfg))
+			require.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "zero window",
+			mutate:  func(cfg *Config) { cfg.Window = 0 },
+			wantErr: "window must be positive",
+		},
+		{
+			name:    "no group by",
+			mutate:  func(cfg *Config) { cfg.GroupBy = nil },
+			wantErr: "group_by must not be empty",
+		},
+		{
+			name:    "empty group by attribute",
+			mutate:  func(cfg *Config) { cfg.GroupBy = []string{proofwatch.COMPLIANCE_CONTROL_ID, ""} },
+			wantErr: "group_by must not contain empty names",
+		},
+		{
+			name:    "negative raw threshold",
+			mutate:  func(cfg *Config) { cfg.RawThreshold = -1 },
+			wantErr: "raw_threshold must not be negative",
+		},
+		{
+			name: "raw threshold with drop raw",
+			mutate: func(cfg *Config) {
+				cfg.DropRaw = true
+				cfg.RawThreshold = 10
+			},
+			wantErr: "raw_threshold cannot be set with drop_raw",
+		},
+		{
+			name:   "valid",
+			mutate: func(*Config) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package rollupprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor"
+)
+
+const stability = component.StabilityLevelAlpha
+
+var componentType = component.MustNewType("rollup")
+
+// NewFactory creates a factory for the rollup processor.
+func NewFactory() processor.Factory {
+	return processor.NewFactory(
+		componentType,
+		createDefaultConfig,
+		processor.WithLogs(createLogsProcessor, stability),
+	)
+}
+
+// createLogsProcessor does not use processorhelper: summaries are emitted on
+// a timer, independently of the batches passing through.
+func createLogsProcessor(
+	_ context.Context,
+	set processor.Settings,
+	cfg component.Config,
+	next consumer.Logs,
+) (processor.Logs, error) {
+	return newRollupProcessor(cfg.(*Config), set, next), nil
+}
@@ -0,0 +1,177 @@
+package rollupprocessor
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/processor"
+	"go.uber.org/zap"
+)
+
+var _ processor.Logs = (*rollupProcessor)(nil)
+
+type rollupProcessor struct {
+	cfg      *Config
+	settings processor.Settings
+	next     consumer.Logs
+	now      func() time.Time
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu sync.Mutex
+	// start is when the current window began.
+	start      time.Time
+	aggregates map[string]*aggregate
+}
+
+func newRollupProcessor(cfg *Config, set processor.Settings, next consumer.Logs) *rollupProcessor {
+	return &rollupProcessor{
+		cfg:        cfg,
+		settings:   set,
+		next:       next,
+		now:        time.Now,
+		aggregates: make(map[string]*aggregate),
+	}
+}
+
+// Capabilities implements consumer.Logs. Withheld raw records are removed
+// from the batch before it is passed on.
+func (p *rollupProcessor) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+// Start opens the first window and emits summaries at the end of every window.
+func (p *rollupProcessor) Start(context.Context, component.Host) error {
+	p.mu.Lock()
+	p.start = p.now()
+	p.mu.Unlock()
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(p.cfg.Window)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-runCtx.Done():
+				return
+			case <-ticker.C:
+				if err := p.flush(runCtx); err != nil {
+					p.settings.Logger.Warn("failed to emit compliance rollup summaries", zap.Error(err))
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the window timer and emits the summaries of the current
+// window.
+func (p *rollupProcessor) Shutdown(ctx context.Context) error {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.wg.Wait()
+	return p.flush(ctx)
+}
+
+// ConsumeLogs counts every record with a group-by attribute into its group
+// and passes on the records that are not withheld.
+func (p *rollupProcessor) ConsumeLogs(ctx context.Context, logs plog.Logs) error {
+	p.mu.Lock()
+	logs.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+			sl.LogRecords().RemoveIf(func(lr plog.LogRecord) bool {
+				return p.rollUp(lr.Attributes())
+			})
+			return sl.LogRecords().Len() == 0
+		})
+		return rl.ScopeLogs().Len() == 0
+	})
+	p.mu.Unlock()
+
+	if logs.LogRecordCount() == 0 {
+		return nil
+	}
+	return p.next.ConsumeLogs(ctx, logs)
+}
+
+// rollUp adds the record to its group and reports whether the raw record is
+// withheld.
+func (p *rollupProcessor) rollUp(attrs pcommon.Map) bool {
+	key, groupAttrs, ok := p.group(attrs)
+	if !ok {
+		return false
+	}
+	a, ok := p.aggregates[key]
+	if !ok {
+		a = newAggregate(groupAttrs)
+		p.aggregates[key] = a
+	}
+	a.add(attrs)
+
+	if p.cfg.DropRaw || (p.cfg.RawThreshold > 0 && a.forwarded >= int64(p.cfg.RawThreshold)) {
+		a.dropped++
+		return true
+	}
+	a.forwarded++
+	return false
+}
+
+// group returns the key and group-by attributes of a record. Records without
+// any group-by attribute have no group.
+func (p *rollupProcessor) group(attrs pcommon.Map) (string, pcommon.Map, bool) {
+	groupAttrs := pcommon.NewMap()
+	parts := make([]string, 0, len(p.cfg.GroupBy))
+	for _, name := range p.cfg.GroupBy {
+		v, ok := attrs.Get(name)
+		if !ok {
+			parts = append(parts, "\x00")
+			continue
+		}
+		v.CopyTo(groupAttrs.PutEmpty(name))
+		parts = append(parts, "\x01"+v.AsString())
+	}
+	if groupAttrs.Len() == 0 {
+		return "", groupAttrs, false
+	}
+	return strings.Join(parts, "\x1f"), groupAttrs, true
+}
+
+// flush emits one summary per group seen in the current window and opens the
+// next window. Summaries that fail to emit are dropped.
+func (p *rollupProcessor) flush(ctx context.Context) error {
+	now := p.now()
+
+	p.mu.Lock()
+	start := p.start
+	aggregates := p.aggregates
+	p.start = now
+	p.aggregates = make(map[string]*aggregate)
+	p.mu.Unlock()
+
+	if len(aggregates) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(aggregates))
+	for key := range aggregates {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	ordered := make([]*aggregate, 0, len(keys))
+	for _, key := range keys {
+		ordered = append(ordered, aggregates[key])
+	}
+	return p.next.ConsumeLogs(ctx, buildSummaryLogs(ordered, start, now))
+}
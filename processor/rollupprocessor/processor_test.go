@@ -0,0 +1,203 @@
+package rollupprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+var windowStart = time.Date(2026, 6, 2, 10, 0, 0, 0, time.UTC)
+
+func evidence(controlID, targetID, result string) map[string]any {
+	attrs := map[string]any{
+		proofwatch.COMPLIANCE_CONTROL_CATALOG_ID: "nist-800-53",
+		proofwatch.POLICY_TARGET_ID:              targetID,
+		proofwatch.POLICY_EVALUATION_RESULT:      result,
+	}
+	if controlID != "" {
+		attrs[proofwatch.COMPLIANCE_CONTROL_ID] = controlID
+	}
+	return attrs
+}
+
+// evidenceLogs builds one record per attribute map.
+func evidenceLogs(records ...map[string]any) plog.Logs {
+	logs := plog.NewLogs()
+	lrs := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, attrs := range records {
+		_ = lrs.AppendEmpty().Attributes().FromRaw(attrs)
+	}
+	return logs
+}
+
+func newTestProcessor(t *testing.T, cfg *Config, now *time.Time) (*rollupProcessor, *consumertest.LogsSink) {
+	t.Helper()
+	sink := new(consumertest.LogsSink)
+	p := newRollupProcessor(cfg, processortest.NewNopSettings(componentType), sink)
+	p.now = func() time.Time { return *now }
+	p.start = *now
+	return p, sink
+}
+
+// summaries returns the summary records received by the sink.
+func summaries(sink *consumertest.LogsSink) []plog.LogRecord {
+	var records []plog.LogRecord
+	for _, logs := range sink.AllLogs() {
+		for i := 0; i < logs.ResourceLogs().Len(); i++ {
+			sls := logs.ResourceLogs().At(i).ScopeLogs()
+			for j := 0; j < sls.Len(); j++ {
+				lrs := sls.At(j).LogRecords()
+				for k := 0; k < lrs.Len(); k++ {
+					if lrs.At(k).EventName() == proofwatch.EVENT_COMPLIANCE_ROLLUP {
+						records = append(records, lrs.At(k))
+					}
+				}
+			}
+		}
+	}
+	return records
+}
+
+func TestFlushEmitsSummaries(t *testing.T) {
+	now := windowStart
+	p, sink := newTestProcessor(t, createDefaultConfig().(*Config), &now)
+
+	require.NoError(t, p.ConsumeLogs(context.Background(), evidenceLogs(
+		evidence("ac-2", "web-01", "Passed"),
+		evidence("ac-2", "web-02", "Failed"),
+		evidence("ac-2", "web-02", "Failed"),
+		evidence("au-2", "web-01", "Passed"),
+		evidence("au-2", "web-01", "Not Applicable"),
+	)))
+	// Raw records are passed on by default.
+	assert.Equal(t, 5, sink.LogRecordCount())
+
+	sink.Reset()
+	now = now.Add(defaultWindow)
+	require.NoError(t, p.flush(context.Background()))
+
+	records := summaries(sink)
+	require.Len(t, records, 2)
+	ac2 := records[0]
+	assert.Equal(t, map[string]any{
+		proofwatch.COMPLIANCE_CONTROL_CATALOG_ID: "nist-800-53",
+		proofwatch.COMPLIANCE_CONTROL_ID:         "ac-2",
+		proofwatch.COMPLIANCE_ROLLUP_EVALUATIONS: int64(3),
+		proofwatch.COMPLIANCE_ROLLUP_PASSED:      int64(1),
+		proofwatch.COMPLIANCE_ROLLUP_FAILED:      int64(2),
+		proofwatch.COMPLIANCE_ROLLUP_TARGETS:     int64(2),
+		proofwatch.COMPLIANCE_STATUS:             statusNonCompliant,
+	}, ac2.Attributes().AsRaw())
+	assert.Equal(t, map[string]any{
+		"start":       "2026-06-02T10:00:00Z",
+		"end":         "2026-06-02T10:15:00Z",
+		"evaluations": int64(3),
+		"raw_dropped": int64(0),
+		"results":     map[string]any{"Failed": int64(2), "Passed": int64(1)},
+	}, ac2.Body().Map().AsRaw())
+	assert.Equal(t, pcommon.NewTimestampFromTime(now), ac2.Timestamp())
+
+	au2 := records[1]
+	assert.Equal(t, "au-2", au2.Attributes().AsRaw()[proofwatch.COMPLIANCE_CONTROL_ID])
+	assert.Equal(t, statusCompliant, au2.Attributes().AsRaw()[proofwatch.COMPLIANCE_STATUS])
+
+	// The next window starts empty.
+	sink.Reset()
+	now = now.Add(defaultWindow)
+	require.NoError(t, p.flush(context.Background()))
+	assert.Zero(t, sink.LogRecordCount())
+}
+
+func TestConsumeLogsPassesUngroupedRecords(t *testing.T) {
+	now := windowStart
+	cfg := createDefaultConfig().(*Config)
+	cfg.GroupBy = []string{proofwatch.COMPLIANCE_CONTROL_ID}
+	cfg.DropRaw = true
+	p, sink := newTestProcessor(t, cfg, &now)
+
+	require.NoError(t, p.ConsumeLogs(context.Background(), evidenceLogs(
+		evidence("ac-2", "web-01", "Passed"),
+		evidence("", "web-01", "Passed"),
+	)))
+	assert.Equal(t, 1, sink.LogRecordCount())
+	raw := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	_, ok := raw.Attributes().Get(proofwatch.COMPLIANCE_CONTROL_ID)
+	assert.False(t, ok)
+
+	require.NoError(t, p.flush(context.Background()))
+	records := summaries(sink)
+	require.Len(t, records, 1)
+	assert.Equal(t, int64(1), records[0].Body().Map().AsRaw()["raw_dropped"])
+}
+
+func TestRawThreshold(t *testing.T) {
+	now := windowStart
+	cfg := createDefaultConfig().(*Config)
+	cfg.RawThreshold = 2
+	p, sink := newTestProcessor(t, cfg, &now)
+
+	require.NoError(t, p.ConsumeLogs(context.Background(), evidenceLogs(
+		evidence("ac-2", "web-01", "Passed"),
+		evidence("ac-2", "web-02", "Passed"),
+		evidence("ac-2", "web-03", "Passed"),
+		evidence("au-2", "web-01", "Passed"),
+	)))
+	// The third ac-2 record is over the threshold; au-2 has its own.
+	assert.Equal(t, 3, sink.LogRecordCount())
+
+	sink.Reset()
+	require.NoError(t, p.flush(context.Background()))
+	records := summaries(sink)
+	require.Len(t, records, 2)
+	assert.Equal(t, int64(3), records[0].Attributes().AsRaw()[proofwatch.COMPLIANCE_ROLLUP_EVALUATIONS])
+	assert.Equal(t, int64(1), records[0].Body().Map().AsRaw()["raw_dropped"])
+
+	// The threshold resets with the window.
+	sink.Reset()
+	require.NoError(t, p.ConsumeLogs(context.Background(), evidenceLogs(evidence("ac-2", "web-01", "Passed"))))
+	assert.Equal(t, 1, sink.LogRecordCount())
+}
+
+func TestGroupByKeepsAttributeTypes(t *testing.T) {
+	now := windowStart
+	cfg := createDefaultConfig().(*Config)
+	cfg.GroupBy = []string{proofwatch.COMPLIANCE_FRAMEWORKS}
+	p, sink := newTestProcessor(t, cfg, &now)
+
+	attrs := evidence("ac-2", "web-01", "Passed")
+	attrs[proofwatch.COMPLIANCE_FRAMEWORKS] = []any{"NIST-800-53", "FedRAMP"}
+	require.NoError(t, p.ConsumeLogs(context.Background(), evidenceLogs(attrs)))
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	records := summaries(sink)
+	require.Len(t, records, 1)
+	frameworks, ok := records[0].Attributes().Get(proofwatch.COMPLIANCE_FRAMEWORKS)
+	require.True(t, ok)
+	assert.Equal(t, pcommon.ValueTypeSlice, frameworks.Type())
+	assert.Equal(t, []any{"NIST-800-53", "FedRAMP"}, frameworks.Slice().AsRaw())
+}
+
+func TestStartEmitsOnWindow(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Window = 10 * time.Millisecond
+	sink := new(consumertest.LogsSink)
+	p := newRollupProcessor(cfg, processortest.NewNopSettings(componentType), sink)
+
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, p.Shutdown(context.Background())) }()
+	require.NoError(t, p.ConsumeLogs(context.Background(), evidenceLogs(evidence("ac-2", "web-01", "Passed"))))
+
+	assert.Eventually(t, func() bool {
+		return len(summaries(sink)) == 1
+	}, time.Second, 5*time.Millisecond)
+}
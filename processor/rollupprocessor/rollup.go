@@ -0,0 +1,111 @@
+package rollupprocessor
+
+import (
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+const (
+	scopeName = "github.com/complytime/complybeacon/processor/rollupprocessor"
+
+	resultPassed = "Passed"
+	resultFailed = "Failed"
+
+	statusCompliant    = "Compliant"
+	statusNonCompliant = "Non-Compliant"
+)
+
+// aggregate accumulates the evidence of one group during a window.
+type aggregate struct {
+	// attrs holds the group-by attribute values of the group.
+	attrs pcommon.Map
+	// results counts evaluations by policy.evaluation.result.
+	results     map[string]int64
+	evaluations int64
+	targets     map[string]struct{}
+	// forwarded and dropped count the raw records passed on and withheld.
+	forwarded, dropped int64
+}
+
+func newAggregate(attrs pcommon.Map) *aggregate {
+	return &aggregate{
+		attrs:   attrs,
+		results: make(map[string]int64),
+		targets: make(map[string]struct{}),
+	}
+}
+
+func (a *aggregate) add(attrs pcommon.Map) {
+	a.evaluations++
+	if v, ok := attrs.Get(proofwatch.POLICY_EVALUATION_RESULT); ok && v.AsString() != "" {
+		a.results[v.AsString()]++
+	}
+	if v, ok := attrs.Get(proofwatch.POLICY_TARGET_ID); ok && v.AsString() != "" {
+		a.targets[v.AsString()] = struct{}{}
+	}
+}
+
+// status is Non-Compliant when an evaluation failed, Compliant when one
+// passed, and empty otherwise.
+func (a *aggregate) status() string {
+	switch {
+	case a.results[resultFailed] > 0:
+		return statusNonCompliant
+	case a.results[resultPassed] > 0:
+		return statusCompliant
+	default:
+		return ""
+	}
+}
+
+// buildSummaryLogs emits one summary record per group for the window from
+// start to end.
+func buildSummaryLogs(aggregates []*aggregate, start, end time.Time) plog.Logs {
+	logs := plog.NewLogs()
+	sl := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	sl.Scope().SetName(scopeName)
+
+	for _, a := range aggregates {
+		lr := sl.LogRecords().AppendEmpty()
+		lr.SetEventName(proofwatch.EVENT_COMPLIANCE_ROLLUP)
+		lr.SetTimestamp(pcommon.NewTimestampFromTime(end))
+		lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(end))
+		lr.SetSeverityNumber(plog.SeverityNumberInfo)
+		lr.SetSeverityText(plog.SeverityNumberInfo.String())
+
+		attrs := lr.Attributes()
+		a.attrs.CopyTo(attrs)
+		attrs.PutInt(proofwatch.COMPLIANCE_ROLLUP_EVALUATIONS, a.evaluations)
+		attrs.PutInt(proofwatch.COMPLIANCE_ROLLUP_PASSED, a.results[resultPassed])
+		attrs.PutInt(proofwatch.COMPLIANCE_ROLLUP_FAILED, a.results[resultFailed])
+		attrs.PutInt(proofwatch.COMPLIANCE_ROLLUP_TARGETS, int64(len(a.targets)))
+		if status := a.status(); status != "" {
+			attrs.PutStr(proofwatch.COMPLIANCE_STATUS, status)
+		}
+
+		body := lr.Body().SetEmptyMap()
+		body.PutStr("start", start.UTC().Format(time.RFC3339Nano))
+		body.PutStr("end", end.UTC().Format(time.RFC3339Nano))
+		body.PutInt("evaluations", a.evaluations)
+		body.PutInt("raw_dropped", a.dropped)
+		results := body.PutEmptyMap("results")
+		for _, result := range sortedKeys(a.results) {
+			results.PutInt(result, a.results[result])
+		}
+	}
+	return logs
+}
+
+func sortedKeys(m map[string]int64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,53 @@
+package signingprocessor
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+const (
+	// ModeRecord signs every selected record individually.
+	ModeRecord = "record"
+	// ModeBatch signs the digests of all selected records in a batch with one signature.
+	ModeBatch = "batch"
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines the configuration for the signing processor.
+type Config struct {
+	// Key is the path to a PEM private key, optionally cosign-encrypted, or a
+	// KMS key reference such as awskms:///alias/name, gcpkms://..., azurekms://...
+	// or hashivault://name.
+	Key string `mapstructure:"key"`
+
+	// Password decrypts a cosign-encrypted private key.
+	Password configopaque.String `mapstructure:"password"`
+
+	// Mode is either "record" or "batch".
+	Mode string `mapstructure:"mode"`
+
+	// MatchAttributes selects the records to sign: a record is signed only when
+	// all of these attributes are present. Empty signs every record.
+	MatchAttributes []string `mapstructure:"match_attributes"`
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Mode: ModeRecord,
+	}
+}
+
+// Validate checks the processor configuration is valid.
+func (c *Config) Validate() error {
+	if c.Key == "" {
+		return errors.New("key must not be empty")
+	}
+	if c.Mode != ModeRecord && c.Mode != ModeBatch {
+		return fmt.Errorf("mode must be %q or %q, got %q", ModeRecord, ModeBatch, c.Mode)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package signingprocessor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id       component.ID
+		expected *Config
+	}{
+		{
+			id: component.NewID(componentType),
+			expected: &Config{
+				Key:      "/etc/complybeacon/cosign.key",
+				Password: "s3cret",
+				Mode:     ModeRecord,
+			},
+		},
+		{
+			id: component.NewIDWithName(componentType, "kms"),
+			expected: &Config{
+				Key:             "awskms:///alias/evidence-signing",
+				Mode:            ModeBatch,
+				MatchAttributes: []string{proofwatch.POLICY_EVALUATION_RESULT, proofwatch.COMPLIANCE_CONTROL_ID},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, sub.Unmarshal(cfg))
+			require.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "missing key",
+			mutate:  func(cfg *Config) { cfg.Key = "" },
+			wantErr: "key must not be empty",
+		},
+		{
+			name:    "unknown mode",
+			mutate:  func(cfg *Config) { cfg.Mode = "digest" },
+			wantErr: `mode must be "record" or "batch", got "digest"`,
+		},
+		{
+			name:   "valid",
+			mutate: func(*Config) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Key = "cosign.key"
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package signingprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const stability = component.StabilityLevelAlpha
+
+var componentType = component.MustNewType("signing")
+
+// NewFactory creates a factory for the signing processor.
+func NewFactory() processor.Factory {
+	return processor.NewFactory(
+		componentType,
+		createDefaultConfig,
+		processor.WithLogs(createLogsProcessor, stability),
+	)
+}
+
+func createLogsProcessor(
+	ctx context.Context,
+	set processor.Settings,
+	cfg component.Config,
+	next consumer.Logs,
+) (processor.Logs, error) {
+	proc := newSigningProcessor(cfg.(*Config), set.Logger)
+	return processorhelper.NewLogs(ctx, set, cfg, next,
+		proc.processLogs,
+		processorhelper.WithStart(proc.start),
+		processorhelper.WithCapabilities(consumer.Capabilities{MutatesData: true}),
+	)
+}
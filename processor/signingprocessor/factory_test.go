@@ -0,0 +1,40 @@
+package signingprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+func TestNewFactory(t *testing.T) {
+	factory := NewFactory()
+	assert.Equal(t, componentType, factory.Type())
+
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, componenttest.CheckConfigStruct(cfg))
+}
+
+func TestProcessorLifecycle(t *testing.T) {
+	_, der := newECKey(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Key = writeKey(t, "PRIVATE KEY", der)
+
+	sink := new(consumertest.LogsSink)
+	proc, err := NewFactory().CreateLogs(context.Background(), processortest.NewNopSettings(componentType), cfg, sink)
+	require.NoError(t, err)
+
+	require.NoError(t, proc.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, proc.ConsumeLogs(context.Background(), evidenceLogs()))
+	require.NoError(t, proc.Shutdown(context.Background()))
+
+	require.Len(t, sink.AllLogs(), 1)
+	_, _, record := recordAt(sink.AllLogs()[0], 0)
+	assert.NotEmpty(t, attr(record, proofwatch.EVIDENCE_SIGNATURE_VALUE))
+}
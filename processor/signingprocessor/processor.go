@@ -0,0 +1,206 @@
+package signingprocessor
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+// signatureAttributes are excluded from the canonical encoding so that a
+// record can be verified after the signature has been attached.
+var signatureAttributes = []string{
+	proofwatch.EVIDENCE_SIGNATURE_VALUE,
+	proofwatch.EVIDENCE_SIGNATURE_DIGEST,
+	proofwatch.EVIDENCE_SIGNATURE_BATCH_DIGEST,
+	proofwatch.EVIDENCE_SIGNATURE_KEY_ID,
+}
+
+type signingProcessor struct {
+	cfg    *Config
+	logger *zap.Logger
+	signer *evidenceSigner
+}
+
+func newSigningProcessor(cfg *Config, logger *zap.Logger) *signingProcessor {
+	return &signingProcessor{cfg: cfg, logger: logger}
+}
+
+func (p *signingProcessor) start(ctx context.Context, _ component.Host) error {
+	signer, err := loadSigner(ctx, p.cfg)
+	if err != nil {
+		return err
+	}
+	p.signer = signer
+	return nil
+}
+
+// signedRecord is a selected record and the canonical encoding it is signed over.
+type signedRecord struct {
+	record    plog.LogRecord
+	canonical []byte
+}
+
+// processLogs signs the selected records. A signing failure rejects the whole
+// batch so that no evidence leaves the pipeline without its signature. A
+// record that cannot be encoded is left unsigned rather than holding back the
+// rest of the batch.
+func (p *signingProcessor) processLogs(_ context.Context, logs plog.Logs) (plog.Logs, error) {
+	var selected []signedRecord
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		rl := logs.ResourceLogs().At(i)
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				record := sl.LogRecords().At(k)
+				if !p.matches(record.Attributes()) {
+					continue
+				}
+				canonical, err := canonicalize(rl.Resource(), sl.Scope(), record)
+				if err != nil {
+					p.logger.Warn("leaving record unsigned", zap.Error(err))
+					continue
+				}
+				selected = append(selected, signedRecord{record: record, canonical: canonical})
+			}
+		}
+	}
+	if len(selected) == 0 {
+		return logs, nil
+	}
+
+	if p.cfg.Mode == ModeBatch {
+		return logs, p.signBatch(selected)
+	}
+	for _, s := range selected {
+		sig, err := p.signer.signer.SignMessage(bytes.NewReader(s.canonical))
+		if err != nil {
+			return logs, err
+		}
+		p.attach(s.record, digest(s.canonical), sig)
+	}
+	return logs, nil
+}
+
+// signBatch signs the sorted record digests joined by newlines, so the batch
+// can be verified from any ordering of its records.
+func (p *signingProcessor) signBatch(selected []signedRecord) error {
+	digests := make([]string, len(selected))
+	for i, s := range selected {
+		digests[i] = digest(s.canonical)
+	}
+	sorted := append([]string(nil), digests...)
+	sort.Strings(sorted)
+	message := []byte(strings.Join(sorted, "\n"))
+
+	sig, err := p.signer.signer.SignMessage(bytes.NewReader(message))
+	if err != nil {
+		return err
+	}
+	batchDigest := digest(message)
+	for i, s := range selected {
+		p.attach(s.record, digests[i], sig)
+		s.record.Attributes().PutStr(proofwatch.EVIDENCE_SIGNATURE_BATCH_DIGEST, batchDigest)
+	}
+	return nil
+}
+
+func (p *signingProcessor) attach(record plog.LogRecord, recordDigest string, sig []byte) {
+	attrs := record.Attributes()
+	attrs.PutStr(proofwatch.EVIDENCE_SIGNATURE_VALUE, base64.StdEncoding.EncodeToString(sig))
+	attrs.PutStr(proofwatch.EVIDENCE_SIGNATURE_DIGEST, recordDigest)
+	attrs.PutStr(proofwatch.EVIDENCE_SIGNATURE_KEY_ID, p.signer.keyID)
+}
+
+func (p *signingProcessor) matches(attrs pcommon.Map) bool {
+	for _, key := range p.cfg.MatchAttributes {
+		if _, ok := attrs.Get(key); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// canonicalRecord is the signed representation of a log record. encoding/json
+// sorts map keys, which makes the encoding deterministic.
+type canonicalRecord struct {
+	Resource         map[string]any `json:"resource"`
+	Scope            string         `json:"scope"`
+	ScopeVersion     string         `json:"scope_version"`
+	TimeUnixNano     uint64         `json:"time_unix_nano"`
+	ObservedUnixNano uint64         `json:"observed_time_unix_nano"`
+	SeverityNumber   int32          `json:"severity_number"`
+	SeverityText     string         `json:"severity_text"`
+	Body             any            `json:"body"`
+	Attributes       map[string]any `json:"attributes"`
+	TraceID          string         `json:"trace_id"`
+	SpanID           string         `json:"span_id"`
+}
+
+func canonicalize(resource pcommon.Resource, scope pcommon.InstrumentationScope, record plog.LogRecord) ([]byte, error) {
+	attrs := canonicalMap(record.Attributes())
+	for _, key := range signatureAttributes {
+		delete(attrs, key)
+	}
+	return json.Marshal(canonicalRecord{
+		Resource:         canonicalMap(resource.Attributes()),
+		Scope:            scope.Name(),
+		ScopeVersion:     scope.Version(),
+		TimeUnixNano:     uint64(record.Timestamp()),
+		ObservedUnixNano: uint64(record.ObservedTimestamp()),
+		SeverityNumber:   int32(record.SeverityNumber()),
+		SeverityText:     record.SeverityText(),
+		Body:             canonicalValue(record.Body()),
+		Attributes:       attrs,
+		TraceID:          record.TraceID().String(),
+		SpanID:           record.SpanID().String(),
+	})
+}
+
+func canonicalMap(m pcommon.Map) map[string]any {
+	out := make(map[string]any, m.Len())
+	m.Range(func(k string, v pcommon.Value) bool {
+		out[k] = canonicalValue(v)
+		return true
+	})
+	return out
+}
+
+// canonicalValue converts a value to its JSON form. JSON has no NaN or
+// infinite numbers, so those doubles are encoded as the strings "NaN",
+// "Infinity" and "-Infinity". Bytes are encoded as base64 strings.
+func canonicalValue(v pcommon.Value) any {
+	switch v.Type() {
+	case pcommon.ValueTypeDouble:
+		d := v.Double()
+		switch {
+		case math.IsNaN(d):
+			return "NaN"
+		case math.IsInf(d, 1):
+			return "Infinity"
+		case math.IsInf(d, -1):
+			return "-Infinity"
+		}
+		return d
+	case pcommon.ValueTypeMap:
+		return canonicalMap(v.Map())
+	case pcommon.ValueTypeSlice:
+		out := make([]any, v.Slice().Len())
+		for i := range out {
+			out[i] = canonicalValue(v.Slice().At(i))
+		}
+		return out
+	default:
+		return v.AsRaw()
+	}
+}
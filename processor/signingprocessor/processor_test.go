@@ -0,0 +1,182 @@
+package signingprocessor
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+func newTestProcessor(t *testing.T, cfg *Config) (*signingProcessor, *ecdsa.PrivateKey) {
+	t.Helper()
+	priv, der := newECKey(t)
+	cfg.Key = writeKey(t, "PRIVATE KEY", der)
+	p := newSigningProcessor(cfg, zap.NewNop())
+	require.NoError(t, p.start(context.Background(), componenttest.NewNopHost()))
+	return p, priv
+}
+
+func evidenceLogs() plog.Logs {
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "oscap")
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("proofwatch")
+	for _, result := range []string{"Passed", "Failed"} {
+		record := sl.LogRecords().AppendEmpty()
+		record.Body().SetStr("rule: " + result)
+		record.Attributes().PutStr(proofwatch.POLICY_RULE_ID, "rule")
+		record.Attributes().PutStr(proofwatch.POLICY_EVALUATION_RESULT, result)
+	}
+	// A record without an evaluation result, e.g. a diagnostic message.
+	sl.LogRecords().AppendEmpty().Body().SetStr("scan started")
+	return logs
+}
+
+func recordAt(logs plog.Logs, i int) (plog.ResourceLogs, plog.ScopeLogs, plog.LogRecord) {
+	rl := logs.ResourceLogs().At(0)
+	sl := rl.ScopeLogs().At(0)
+	return rl, sl, sl.LogRecords().At(i)
+}
+
+func attr(record plog.LogRecord, key string) string {
+	v, _ := record.Attributes().Get(key)
+	return v.Str()
+}
+
+func verify(t *testing.T, priv *ecdsa.PrivateKey, message []byte, encodedSig string) {
+	t.Helper()
+	sig, err := base64.StdEncoding.DecodeString(encodedSig)
+	require.NoError(t, err)
+	sum := sha256.Sum256(message)
+	assert.True(t, ecdsa.VerifyASN1(&priv.PublicKey, sum[:], sig), "signature does not verify")
+}
+
+func TestProcessLogsSignsRecords(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MatchAttributes = []string{proofwatch.POLICY_EVALUATION_RESULT}
+	p, priv := newTestProcessor(t, cfg)
+
+	logs, err := p.processLogs(context.Background(), evidenceLogs())
+	require.NoError(t, err)
+
+	for i := range 2 {
+		rl, sl, record := recordAt(logs, i)
+		canonical, err := canonicalize(rl.Resource(), sl.Scope(), record)
+		require.NoError(t, err)
+
+		// The canonical encoding ignores the attached signature attributes.
+		assert.Equal(t, digest(canonical), attr(record, proofwatch.EVIDENCE_SIGNATURE_DIGEST))
+		assert.Equal(t, p.signer.keyID, attr(record, proofwatch.EVIDENCE_SIGNATURE_KEY_ID))
+		verify(t, priv, canonical, attr(record, proofwatch.EVIDENCE_SIGNATURE_VALUE))
+
+		_, batched := record.Attributes().Get(proofwatch.EVIDENCE_SIGNATURE_BATCH_DIGEST)
+		assert.False(t, batched)
+	}
+
+	_, _, unselected := recordAt(logs, 2)
+	assert.Equal(t, 0, unselected.Attributes().Len())
+}
+
+func TestProcessLogsSignsBatch(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Mode = ModeBatch
+	p, priv := newTestProcessor(t, cfg)
+
+	logs, err := p.processLogs(context.Background(), evidenceLogs())
+	require.NoError(t, err)
+
+	var digests []string
+	var batchDigest, sig string
+	for i := range 3 {
+		_, _, record := recordAt(logs, i)
+		digests = append(digests, attr(record, proofwatch.EVIDENCE_SIGNATURE_DIGEST))
+		if i == 0 {
+			batchDigest = attr(record, proofwatch.EVIDENCE_SIGNATURE_BATCH_DIGEST)
+			sig = attr(record, proofwatch.EVIDENCE_SIGNATURE_VALUE)
+		}
+		assert.Equal(t, batchDigest, attr(record, proofwatch.EVIDENCE_SIGNATURE_BATCH_DIGEST))
+		assert.Equal(t, sig, attr(record, proofwatch.EVIDENCE_SIGNATURE_VALUE))
+	}
+
+	sort.Strings(digests)
+	message := []byte(strings.Join(digests, "\n"))
+	assert.Equal(t, digest(message), batchDigest)
+	verify(t, priv, message, sig)
+}
+
+func TestProcessLogsWithoutMatches(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MatchAttributes = []string{proofwatch.COMPLIANCE_CONTROL_ID}
+	p, _ := newTestProcessor(t, cfg)
+
+	logs, err := p.processLogs(context.Background(), evidenceLogs())
+	require.NoError(t, err)
+	_, _, record := recordAt(logs, 0)
+	assert.Equal(t, 2, record.Attributes().Len())
+}
+
+func TestProcessLogsSignsNonFiniteDoubles(t *testing.T) {
+	p, priv := newTestProcessor(t, createDefaultConfig().(*Config))
+
+	logs := evidenceLogs()
+	_, _, record := recordAt(logs, 0)
+	record.Attributes().PutDouble("scan.score", math.NaN())
+	body := record.Body().SetEmptyMap()
+	body.PutDouble("max", math.Inf(1))
+	body.PutEmptySlice("samples").AppendEmpty().SetDouble(math.Inf(-1))
+
+	logs, err := p.processLogs(context.Background(), logs)
+	require.NoError(t, err)
+
+	// Every record of the batch is signed, including the one with NaN and
+	// infinite values.
+	for i := range 3 {
+		_, _, record := recordAt(logs, i)
+		assert.NotEmpty(t, attr(record, proofwatch.EVIDENCE_SIGNATURE_VALUE))
+	}
+	rl, sl, record := recordAt(logs, 0)
+	canonical, err := canonicalize(rl.Resource(), sl.Scope(), record)
+	require.NoError(t, err)
+	assert.Contains(t, string(canonical), `"scan.score":"NaN"`)
+	assert.Contains(t, string(canonical), `"body":{"max":"Infinity","samples":["-Infinity"]}`)
+	verify(t, priv, canonical, attr(record, proofwatch.EVIDENCE_SIGNATURE_VALUE))
+}
+
+type failingSigner struct{}
+
+func (failingSigner) PublicKey(...signature.PublicKeyOption) (crypto.PublicKey, error) {
+	return nil, nil
+}
+
+func (failingSigner) SignMessage(io.Reader, ...signature.SignOption) ([]byte, error) {
+	return nil, errors.New("kms unavailable")
+}
+
+func TestProcessLogsSigningFailure(t *testing.T) {
+	for _, mode := range []string{ModeRecord, ModeBatch} {
+		t.Run(mode, func(t *testing.T) {
+			p := newSigningProcessor(&Config{Mode: mode}, zap.NewNop())
+			p.signer = &evidenceSigner{signer: failingSigner{}, keyID: "test"}
+
+			_, err := p.processLogs(context.Background(), evidenceLogs())
+			assert.EqualError(t, err, "kms unavailable")
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package signingprocessor
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/secure-systems-lab/go-securesystemslib/encrypted"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+// writeKey writes key as a PEM block of the given type and returns its path.
+func writeKey(t *testing.T, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "signing.key")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+	return path
+}
+
+func newECKey(t *testing.T) (*ecdsa.PrivateKey, []byte) {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	return priv, der
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	ecPriv, pkcs8 := newECKey(t)
+	sec1, err := x509.MarshalECPrivateKey(ecPriv)
+	require.NoError(t, err)
+	rsaPriv, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	encryptedKey, err := encrypted.Encrypt(pkcs8, []byte("s3cret"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		blockType string
+		der       []byte
+		password  string
+		wantErr   string
+	}{
+		{name: "pkcs8", blockType: "PRIVATE KEY", der: pkcs8},
+		{name: "sec1", blockType: "EC PRIVATE KEY", der: sec1},
+		{name: "pkcs1", blockType: "RSA PRIVATE KEY", der: x509.MarshalPKCS1PrivateKey(rsaPriv)},
+		{name: "cosign encrypted", blockType: "ENCRYPTED SIGSTORE PRIVATE KEY", der: encryptedKey, password: "s3cret"},
+		{name: "legacy cosign encrypted", blockType: "ENCRYPTED COSIGN PRIVATE KEY", der: encryptedKey, password: "s3cret"},
+		{name: "wrong password", blockType: "ENCRYPTED SIGSTORE PRIVATE KEY", der: encryptedKey, password: "nope", wantErr: "failed to decrypt key"},
+		{name: "unsupported type", blockType: "CERTIFICATE", der: []byte{0}, wantErr: `unsupported PEM block type "CERTIFICATE"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := pem.EncodeToMemory(&pem.Block{Type: tt.blockType, Bytes: tt.der})
+			priv, err := parsePrivateKey(data, []byte(tt.password))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, priv)
+		})
+	}
+
+	_, err = parsePrivateKey([]byte("not pem"), nil)
+	assert.EqualError(t, err, "no PEM block found")
+}
+
+func TestLoadSigner(t *testing.T) {
+	priv, der := newECKey(t)
+	encryptedKey, err := encrypted.Encrypt(der, []byte("s3cret"))
+	require.NoError(t, err)
+
+	cfg := &Config{Key: writeKey(t, "ENCRYPTED SIGSTORE PRIVATE KEY", encryptedKey), Password: configopaque.String("s3cret")}
+	signer, err := loadSigner(context.Background(), cfg)
+	require.NoError(t, err)
+
+	wantID, err := publicKeyID(priv.Public())
+	require.NoError(t, err)
+	assert.Equal(t, wantID, signer.keyID)
+	assert.Regexp(t, `^sha256:[0-9a-f]{64}$`, signer.keyID)
+}
+
+func TestLoadSignerErrors(t *testing.T) {
+	_, err := loadSigner(context.Background(), &Config{Key: filepath.Join(t.TempDir(), "missing.key")})
+	assert.ErrorContains(t, err, "failed to read key")
+
+	_, err = loadSigner(context.Background(), &Config{Key: writeKey(t, "PRIVATE KEY", []byte("garbage"))})
+	assert.ErrorContains(t, err, "failed to load key")
+
+	_, err = loadSigner(context.Background(), &Config{Key: "unknownkms://key"})
+	assert.ErrorContains(t, err, `failed to load KMS key "unknownkms://key"`)
+}
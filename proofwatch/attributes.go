@@ -2,9 +2,18 @@
 
 package proofwatch
 
+// Number of policy evaluations reported by the compliance assessment run
+const COMPLIANCE_ASSESSMENT_EVALUATIONS = "compliance.assessment.evaluations"
+
+// Number of policy evaluations of the compliance assessment run with result `Failed`
+const COMPLIANCE_ASSESSMENT_FAILED = "compliance.assessment.failed"
+
 // Unique identifier for the compliance assessment run or session. Used to group findings from the same assessment execution
 const COMPLIANCE_ASSESSMENT_ID = "compliance.assessment.id"
 
+// Number of policy evaluations of the compliance assessment run with result `Passed`
+const COMPLIANCE_ASSESSMENT_PASSED = "compliance.assessment.passed"
+
 // Environments or contexts where this control applies
 const COMPLIANCE_CONTROL_APPLICABILITY = "compliance.control.applicability"
 
@@ -41,9 +50,45 @@ const COMPLIANCE_REQUIREMENTS = "compliance.requirements"
 // Severity classification of the risk posed by non-compliance with the control requirement
 const COMPLIANCE_RISK_LEVEL = "compliance.risk.level"
 
+// Number of compliance evidence records of the rollup group during the window
+const COMPLIANCE_ROLLUP_EVALUATIONS = "compliance.rollup.evaluations"
+
+// Number of compliance evidence records of the rollup group during the window with result `Failed`
+const COMPLIANCE_ROLLUP_FAILED = "compliance.rollup.failed"
+
+// Number of compliance evidence records of the rollup group during the window with result `Passed`
+const COMPLIANCE_ROLLUP_PASSED = "compliance.rollup.passed"
+
+// Number of distinct targets evaluated in the rollup group during the window
+const COMPLIANCE_ROLLUP_TARGETS = "compliance.rollup.targets"
+
 // Overall compliance determination for the assessed resource or control, indicating whether it meets the compliance requirements
 const COMPLIANCE_STATUS = "compliance.status"
 
+// Number of records for the same control and target collapsed into this record by correlation
+const EVIDENCE_CORRELATION_COUNT = "evidence.correlation.count"
+
+// RFC 3339 timestamp of the earliest record collapsed into this record by correlation
+const EVIDENCE_CORRELATION_FIRST_SEEN = "evidence.correlation.first_seen"
+
+// RFC 3339 timestamp of the latest record collapsed into this record by correlation
+const EVIDENCE_CORRELATION_LAST_SEEN = "evidence.correlation.last_seen"
+
+// Fields of the record redacted before export, named by context and key
+const EVIDENCE_REDACTION_FIELDS = "evidence.redaction.fields"
+
+// Digest over the sorted record digests of a batch signature. Records that share this value were signed together
+const EVIDENCE_SIGNATURE_BATCH_DIGEST = "evidence.signature.batch.digest"
+
+// Digest of the canonical encoding of the signed log record
+const EVIDENCE_SIGNATURE_DIGEST = "evidence.signature.digest"
+
+// Identifier of the key that produced the signature, either a KMS key reference or the digest of the public key
+const EVIDENCE_SIGNATURE_KEY_ID = "evidence.signature.key.id"
+
+// Base64-encoded signature over the record digest, or over the batch digest when the record was signed as part of a batch
+const EVIDENCE_SIGNATURE_VALUE = "evidence.signature.value"
+
 // Name of the policy engine that performed the evaluation or enforcement action
 const POLICY_ENGINE_NAME = "policy.engine.name"
 
@@ -0,0 +1,44 @@
+package admissionauditreceiver
+
+import (
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+const (
+	defaultEndpoint = "localhost:8094"
+	defaultPath     = "/audit"
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines the configuration for the admission audit receiver.
+type Config struct {
+	confighttp.ServerConfig `mapstructure:",squash"`
+
+	// Path is the URL path the API server audit webhook backend posts to.
+	Path string `mapstructure:"path"`
+}
+
+func createDefaultConfig() component.Config {
+	httpCfg := confighttp.NewDefaultServerConfig()
+	httpCfg.NetAddr.Endpoint = defaultEndpoint
+	return &Config{
+		ServerConfig: httpCfg,
+		Path:         defaultPath,
+	}
+}
+
+// Validate checks the receiver configuration is valid.
+func (c *Config) Validate() error {
+	if c.NetAddr.Endpoint == "" {
+		return errors.New("endpoint must not be empty")
+	}
+	if !strings.HasPrefix(c.Path, "/") {
+		return errors.New("path must start with /")
+	}
+	return nil
+}
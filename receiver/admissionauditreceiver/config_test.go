@@ -0,0 +1,84 @@
+package admissionauditreceiver
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id           component.ID
+		wantEndpoint string
+		wantPath     string
+	}{
+		{
+			id:           component.NewID(componentType),
+			wantEndpoint: defaultEndpoint,
+			wantPath:     defaultPath,
+		},
+		{
+			id:           component.NewIDWithName(componentType, "custom"),
+			wantEndpoint: "0.0.0.0:9443",
+			wantPath:     "/k8s/audit",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, sub.Unmarshal(cfg))
+			require.NoError(t, cfg.Validate())
+
+			assert.Equal(t, tt.wantEndpoint, cfg.NetAddr.Endpoint)
+			assert.Equal(t, tt.wantPath, cfg.Path)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "empty endpoint",
+			mutate:  func(cfg *Config) { cfg.NetAddr.Endpoint = "" },
+			wantErr: "endpoint must not be empty",
+		},
+		{
+			name:    "relative path",
+			mutate:  func(cfg *Config) { cfg.Path = "audit" },
+			wantErr: "path must start with /",
+		},
+		{
+			name:   "valid",
+			mutate: func(*Config) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
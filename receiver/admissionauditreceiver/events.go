@@ -0,0 +1,249 @@
+package admissionauditreceiver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+const (
+	scopeName = "github.com/complytime/complybeacon/receiver/admissionauditreceiver"
+
+	// policyEngineName and webhookEngineName are written to
+	// policy.engine.name for ValidatingAdmissionPolicy and admission webhook
+	// evaluations.
+	policyEngineName  = "ValidatingAdmissionPolicy"
+	webhookEngineName = "ValidatingAdmissionWebhook"
+
+	// validationFailureAnnotation holds the ValidatingAdmissionPolicy
+	// failures of a request whose binding has the Audit action.
+	validationFailureAnnotation = "validation.policy.admission.k8s.io/validation_failure"
+
+	// stageResponseComplete is the only stage whose events are converted, so a
+	// request logged at several stages yields its records once.
+	stageResponseComplete = "ResponseComplete"
+)
+
+// errInvalidPayload wraps failures caused by the content of a batch rather
+// than by the pipeline, so the batch can be rejected instead of retried.
+var errInvalidPayload = errors.New("invalid audit event list")
+
+var (
+	// policyDenial matches the response message of a request denied by a
+	// ValidatingAdmissionPolicy binding with the Deny action. The API server
+	// prefixes it with the object, as in `deployments.apps "api" is invalid: `.
+	policyDenial = regexp.MustCompile(`(?s)ValidatingAdmissionPolicy '([^']+)' with binding '([^']+)' denied request: (.*)`)
+	// webhookDenial matches the response message of a request denied by a
+	// validating admission webhook.
+	webhookDenial = regexp.MustCompile(`(?s)admission webhook "([^"]+)" denied the request(?:: (.*)| without explanation)`)
+)
+
+// eventList is the subset of an audit.k8s.io/v1 EventList used to build evidence.
+type eventList struct {
+	Kind  string  `json:"kind"`
+	Items []event `json:"items"`
+}
+
+type event struct {
+	AuditID    string `json:"auditID"`
+	Stage      string `json:"stage"`
+	RequestURI string `json:"requestURI"`
+	Verb       string `json:"verb"`
+	User       struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	SourceIPs []string `json:"sourceIPs"`
+	ObjectRef struct {
+		Resource    string `json:"resource"`
+		Subresource string `json:"subresource"`
+		Namespace   string `json:"namespace"`
+		Name        string `json:"name"`
+		UID         string `json:"uid"`
+		APIGroup    string `json:"apiGroup"`
+		APIVersion  string `json:"apiVersion"`
+	} `json:"objectRef"`
+	ResponseStatus struct {
+		Code    int    `json:"code"`
+		Reason  string `json:"reason"`
+		Message string `json:"message"`
+	} `json:"responseStatus"`
+	StageTimestamp string            `json:"stageTimestamp"`
+	Annotations    map[string]string `json:"annotations"`
+}
+
+// validationFailure is one entry of the validation failure annotation.
+type validationFailure struct {
+	Message           string   `json:"message"`
+	Policy            string   `json:"policy"`
+	Binding           string   `json:"binding"`
+	ExpressionIndex   int      `json:"expressionIndex"`
+	ValidationActions []string `json:"validationActions"`
+}
+
+// parseEvents decodes an audit event list and emits a log record for every
+// policy failure recorded in an annotation and every request denied by a
+// policy or a webhook.
+func parseEvents(r io.Reader, observed time.Time) (plog.Logs, error) {
+	var list eventList
+	if err := json.NewDecoder(r).Decode(&list); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return plog.Logs{}, err
+		}
+		return plog.Logs{}, fmt.Errorf("%w: %w", errInvalidPayload, err)
+	}
+	if list.Kind != "EventList" {
+		return plog.Logs{}, fmt.Errorf("%w: unexpected kind %q", errInvalidPayload, list.Kind)
+	}
+
+	logs := plog.NewLogs()
+	sl := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	sl.Scope().SetName(scopeName)
+	for _, e := range list.Items {
+		if e.Stage != stageResponseComplete {
+			continue
+		}
+		for _, f := range e.validationFailures() {
+			body := e.body()
+			body["binding"] = f.Binding
+			body["expression_index"] = f.ExpressionIndex
+			body["validation_actions"] = toRaw(f.ValidationActions)
+			appendEvaluation(sl.LogRecords(), e, policyEngineName, f.Policy, f.Message, body, observed)
+		}
+		e.appendDenial(sl.LogRecords(), observed)
+	}
+	return logs, nil
+}
+
+// validationFailures decodes the validation failure annotation. A malformed
+// annotation is ignored rather than failing the whole batch.
+func (e event) validationFailures() []validationFailure {
+	value, ok := e.Annotations[validationFailureAnnotation]
+	if !ok {
+		return nil
+	}
+	var failures []validationFailure
+	if err := json.Unmarshal([]byte(value), &failures); err != nil {
+		return nil
+	}
+	return failures
+}
+
+// appendDenial emits a record when the request was denied by a policy or a
+// webhook. A policy denial already recorded in the validation failure
+// annotation, because its binding also has the Audit action, is not repeated.
+func (e event) appendDenial(records plog.LogRecordSlice, observed time.Time) {
+	if e.ResponseStatus.Code < 400 {
+		return
+	}
+	message := e.ResponseStatus.Message
+	if m := policyDenial.FindStringSubmatch(message); m != nil {
+		if slices.ContainsFunc(e.validationFailures(), func(f validationFailure) bool {
+			return f.Policy == m[1] && f.Binding == m[2]
+		}) {
+			return
+		}
+		body := e.body()
+		body["binding"] = m[2]
+		appendEvaluation(records, e, policyEngineName, m[1], m[3], body, observed)
+		return
+	}
+	if m := webhookDenial.FindStringSubmatch(message); m != nil {
+		appendEvaluation(records, e, webhookEngineName, m[1], m[2], e.body(), observed)
+	}
+}
+
+// body describes the request the evaluation applies to.
+func (e event) body() map[string]any {
+	return map[string]any{
+		"audit_id":    e.AuditID,
+		"verb":        e.Verb,
+		"request_uri": e.RequestURI,
+		"username":    e.User.Username,
+		"source_ips":  toRaw(e.SourceIPs),
+		"object": map[string]any{
+			"api_group":   e.ObjectRef.APIGroup,
+			"api_version": e.ObjectRef.APIVersion,
+			"resource":    e.ObjectRef.Resource,
+			"subresource": e.ObjectRef.Subresource,
+			"namespace":   e.ObjectRef.Namespace,
+			"name":        e.ObjectRef.Name,
+		},
+		"response_code": e.ResponseStatus.Code,
+	}
+}
+
+func appendEvaluation(records plog.LogRecordSlice, e event, engineName, ruleID, message string, body map[string]any, observed time.Time) {
+	record := records.AppendEmpty()
+	record.SetObservedTimestamp(pcommon.NewTimestampFromTime(observed))
+	record.SetTimestamp(pcommon.NewTimestampFromTime(parseTime(e.StageTimestamp, observed)))
+	record.SetSeverityNumber(plog.SeverityNumberInfo)
+	record.SetSeverityText(plog.SeverityNumberInfo.String())
+	_ = record.Body().SetEmptyMap().FromRaw(body)
+
+	attrs := record.Attributes()
+	attrs.PutStr(proofwatch.POLICY_ENGINE_NAME, engineName)
+	attrs.PutStr(proofwatch.POLICY_RULE_ID, ruleID)
+	attrs.PutStr(proofwatch.POLICY_RULE_NAME, ruleID)
+	attrs.PutStr(proofwatch.POLICY_EVALUATION_RESULT, "Failed")
+	putStr(attrs, proofwatch.POLICY_EVALUATION_MESSAGE, strings.TrimSpace(message))
+	putStr(attrs, proofwatch.POLICY_TARGET_ID, e.targetID())
+	putStr(attrs, proofwatch.POLICY_TARGET_NAME, e.ObjectRef.Name)
+	putStr(attrs, proofwatch.POLICY_TARGET_TYPE, e.targetType())
+}
+
+// targetID identifies the object as namespace/name, or by name alone for
+// cluster-scoped objects, qualified by its resource type.
+func (e event) targetID() string {
+	ref := e.ObjectRef
+	if ref.Name == "" {
+		return ""
+	}
+	name := ref.Name
+	if ref.Namespace != "" {
+		name = ref.Namespace + "/" + name
+	}
+	return e.targetType() + "/" + name
+}
+
+// targetType is the resource, qualified by its API group, such as
+// "deployments.apps".
+func (e event) targetType() string {
+	if e.ObjectRef.APIGroup == "" {
+		return e.ObjectRef.Resource
+	}
+	return e.ObjectRef.Resource + "." + e.ObjectRef.APIGroup
+}
+
+func parseTime(value string, fallback time.Time) time.Time {
+	if t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(value)); err == nil {
+		return t
+	}
+	return fallback
+}
+
+// toRaw converts a string slice to the form pcommon accepts in raw maps.
+func toRaw(values []string) []any {
+	raw := make([]any, len(values))
+	for i, v := range values {
+		raw[i] = v
+	}
+	return raw
+}
+
+func putStr(attrs pcommon.Map, key, value string) {
+	if value != "" {
+		attrs.PutStr(key, value)
+	}
+}
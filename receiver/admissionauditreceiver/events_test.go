@@ -0,0 +1,121 @@
+package admissionauditreceiver
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+var observed = time.Date(2026, 6, 2, 10, 0, 0, 0, time.UTC)
+
+func TestParseEvents(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "events.json"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	logs, err := parseEvents(f, observed)
+	require.NoError(t, err)
+	require.Equal(t, 4, logs.LogRecordCount(), "one record per policy failure or denial")
+	assert.Equal(t, scopeName, logs.ResourceLogs().At(0).ScopeLogs().At(0).Scope().Name())
+	records := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+
+	audited := records.At(0)
+	assert.Equal(t, time.Date(2026, 6, 2, 8, 30, 0, 0, time.UTC), audited.Timestamp().AsTime())
+	assert.Equal(t, observed, audited.ObservedTimestamp().AsTime())
+	assert.Equal(t, map[string]any{
+		proofwatch.POLICY_ENGINE_NAME:        "ValidatingAdmissionPolicy",
+		proofwatch.POLICY_RULE_ID:            "replica-minimum.example.com",
+		proofwatch.POLICY_RULE_NAME:          "replica-minimum.example.com",
+		proofwatch.POLICY_EVALUATION_RESULT:  "Failed",
+		proofwatch.POLICY_EVALUATION_MESSAGE: "replicas must be at least 2",
+		proofwatch.POLICY_TARGET_ID:          "deployments.apps/payments/api",
+		proofwatch.POLICY_TARGET_NAME:        "api",
+		proofwatch.POLICY_TARGET_TYPE:        "deployments.apps",
+	}, audited.Attributes().AsRaw())
+
+	body := audited.Body().Map().AsRaw()
+	assert.Equal(t, "0b7c3a14-1d7e-4b0e-9d1c-5f0c6c3b2a01", body["audit_id"])
+	assert.Equal(t, "create", body["verb"])
+	assert.Equal(t, "alice@example.com", body["username"])
+	assert.Equal(t, []any{"10.0.0.7"}, body["source_ips"])
+	assert.Equal(t, "replica-minimum-audit", body["binding"])
+	assert.Equal(t, []any{"Audit"}, body["validation_actions"])
+	assert.Equal(t, int64(201), body["response_code"])
+
+	denied := records.At(1).Attributes().AsRaw()
+	assert.Equal(t, "no-latest-tag.example.com", denied[proofwatch.POLICY_RULE_ID])
+	assert.Equal(t, "image tag must not be latest", denied[proofwatch.POLICY_EVALUATION_MESSAGE])
+	assert.Equal(t, "no-latest-tag-deny", records.At(1).Body().Map().AsRaw()["binding"])
+
+	// A denial also recorded by the Audit action yields one record.
+	clusterScoped := records.At(2).Attributes().AsRaw()
+	assert.Equal(t, "namespace-labels.example.com", clusterScoped[proofwatch.POLICY_RULE_ID])
+	assert.Equal(t, "namespaces/scratch", clusterScoped[proofwatch.POLICY_TARGET_ID])
+	assert.Equal(t, "namespaces", clusterScoped[proofwatch.POLICY_TARGET_TYPE])
+
+	webhook := records.At(3).Attributes().AsRaw()
+	assert.Equal(t, "ValidatingAdmissionWebhook", webhook[proofwatch.POLICY_ENGINE_NAME])
+	assert.Equal(t, "validate.kyverno.svc-fail", webhook[proofwatch.POLICY_RULE_ID])
+	assert.True(t, strings.HasPrefix(webhook[proofwatch.POLICY_EVALUATION_MESSAGE].(string), "resource Pod/payments/debug was blocked"))
+	assert.Equal(t, "pods/payments/debug", webhook[proofwatch.POLICY_TARGET_ID])
+}
+
+func TestParseEventsInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		wantErr string
+	}{
+		{
+			name:    "not json",
+			payload: "not json",
+			wantErr: "invalid audit event list: invalid character 'o' in literal null (expecting 'u')",
+		},
+		{
+			name:    "single event",
+			payload: `{"kind": "Event", "stage": "ResponseComplete"}`,
+			wantErr: `invalid audit event list: unexpected kind "Event"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseEvents(strings.NewReader(tt.payload), observed)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestParseEventsIgnoresMalformedAnnotation(t *testing.T) {
+	payload := `{"kind": "EventList", "items": [{"stage": "ResponseComplete", "responseStatus": {"code": 201},
+		"annotations": {"validation.policy.admission.k8s.io/validation_failure": "not json"}}]}`
+
+	logs, err := parseEvents(strings.NewReader(payload), observed)
+	require.NoError(t, err)
+	assert.Zero(t, logs.LogRecordCount())
+}
+
+func TestWebhookDenialWithoutExplanation(t *testing.T) {
+	payload := `{"kind": "EventList", "items": [{"stage": "ResponseComplete", "stageTimestamp": "bogus",
+		"responseStatus": {"code": 403, "message": "admission webhook \"policy.example.com\" denied the request without explanation"}}]}`
+
+	logs, err := parseEvents(strings.NewReader(payload), observed)
+	require.NoError(t, err)
+	require.Equal(t, 1, logs.LogRecordCount())
+
+	record := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, observed, record.Timestamp().AsTime())
+	assert.Equal(t, map[string]any{
+		proofwatch.POLICY_ENGINE_NAME:       "ValidatingAdmissionWebhook",
+		proofwatch.POLICY_RULE_ID:           "policy.example.com",
+		proofwatch.POLICY_RULE_NAME:         "policy.example.com",
+		proofwatch.POLICY_EVALUATION_RESULT: "Failed",
+	}, record.Attributes().AsRaw())
+}
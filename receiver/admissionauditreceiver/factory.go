@@ -0,0 +1,31 @@
+package admissionauditreceiver
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+const stability = component.StabilityLevelAlpha
+
+var componentType = component.MustNewType("admissionaudit")
+
+// NewFactory creates a factory for the admission audit receiver.
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		componentType,
+		createDefaultConfig,
+		receiver.WithLogs(createLogsReceiver, stability),
+	)
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	next consumer.Logs,
+) (receiver.Logs, error) {
+	return newAdmissionAuditReceiver(cfg.(*Config), set, next), nil
+}
@@ -0,0 +1,110 @@
+package admissionauditreceiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+)
+
+// defaultMaxBatchBytes bounds the decompressed size of a single event batch.
+const defaultMaxBatchBytes = 64 << 20
+
+var _ receiver.Logs = (*admissionAuditReceiver)(nil)
+
+type admissionAuditReceiver struct {
+	cfg      *Config
+	settings receiver.Settings
+	next     consumer.Logs
+
+	server        *http.Server
+	maxBatchBytes int64
+	wg            sync.WaitGroup
+}
+
+func newAdmissionAuditReceiver(cfg *Config, set receiver.Settings, next consumer.Logs) *admissionAuditReceiver {
+	return &admissionAuditReceiver{
+		cfg:           cfg,
+		settings:      set,
+		next:          next,
+		maxBatchBytes: defaultMaxBatchBytes,
+	}
+}
+
+// Start begins accepting audit event batches.
+func (r *admissionAuditReceiver) Start(ctx context.Context, host component.Host) error {
+	listener, err := r.cfg.ToListener(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to bind to %s: %w", r.cfg.NetAddr.Endpoint, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(r.cfg.Path, r.handleEvents)
+
+	r.server, err = r.cfg.ToServer(ctx, host.GetExtensions(), r.settings.TelemetrySettings, mux)
+	if err != nil {
+		return err
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		if errHTTP := r.server.Serve(listener); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+			componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(errHTTP))
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the HTTP server.
+func (r *admissionAuditReceiver) Shutdown(ctx context.Context) error {
+	var err error
+	if r.server != nil {
+		err = r.server.Shutdown(ctx)
+	}
+	r.wg.Wait()
+	return err
+}
+
+// handleEvents answers 202 Accepted once the records of every admission
+// denial in the batch have been handed to the pipeline. The API server retries
+// a batch that fails with 503.
+func (r *admissionAuditReceiver) handleEvents(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body := http.MaxBytesReader(w, req.Body, r.maxBatchBytes)
+	defer body.Close()
+
+	logs, err := parseEvents(body, time.Now())
+	// Most audit events are not denials, so most batches yield no records.
+	if err == nil && logs.LogRecordCount() > 0 {
+		err = r.next.ConsumeLogs(req.Context(), logs)
+	}
+	if err != nil {
+		r.settings.Logger.Warn("rejected audit event batch", zap.Error(err))
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, "batch too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		if errors.Is(err, errInvalidPayload) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "failed to process batch", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
@@ -0,0 +1,89 @@
+package admissionauditreceiver
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+const (
+	deniedBatch  = `{"kind": "EventList", "items": [{"stage": "ResponseComplete", "responseStatus": {"code": 400, "message": "admission webhook \"policy.example.com\" denied the request without explanation"}}]}`
+	allowedBatch = `{"kind": "EventList", "items": [{"stage": "ResponseComplete", "responseStatus": {"code": 200}}]}`
+)
+
+func TestHandleEvents(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		next       consumer.Logs
+		wantStatus int
+		wantLogs   int
+	}{
+		{
+			name:       "non-POST is rejected",
+			method:     http.MethodGet,
+			next:       new(consumertest.LogsSink),
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "invalid batch",
+			method:     http.MethodPost,
+			body:       "not json",
+			next:       new(consumertest.LogsSink),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "oversized batch",
+			method:     http.MethodPost,
+			body:       "{" + strings.Repeat(" ", 1024) + "}",
+			next:       new(consumertest.LogsSink),
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "pipeline failure",
+			method:     http.MethodPost,
+			body:       deniedBatch,
+			next:       consumertest.NewErr(errors.New("pipeline unavailable")),
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:       "batch without denials skips the pipeline",
+			method:     http.MethodPost,
+			body:       allowedBatch,
+			next:       consumertest.NewErr(errors.New("pipeline unavailable")),
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name:       "accepted",
+			method:     http.MethodPost,
+			body:       deniedBatch,
+			next:       new(consumertest.LogsSink),
+			wantStatus: http.StatusAccepted,
+			wantLogs:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newAdmissionAuditReceiver(createDefaultConfig().(*Config), receivertest.NewNopSettings(componentType), tt.next)
+			r.maxBatchBytes = 512
+			req := httptest.NewRequest(tt.method, defaultPath, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			r.handleEvents(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if sink, ok := tt.next.(*consumertest.LogsSink); ok {
+				assert.Equal(t, tt.wantLogs, sink.LogRecordCount())
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package auditdreceiver
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+const (
+	scopeName = "github.com/complytime/complybeacon/receiver/auditdreceiver"
+
+	engineName = "auditd"
+
+	// typePath records a path a syscall touched.
+	typePath = 1302
+	// typeEOE is the end-of-event record that closes a multi-record event.
+	typeEOE = 1320
+	// User-space records, such as logins, have types in [typeFirstUser, typeLastUser].
+	typeFirstUser = 1100
+	typeLastUser  = 1299
+)
+
+// errInvalidRecord marks audit messages that cannot be parsed.
+var errInvalidRecord = errors.New("invalid audit record")
+
+// typeNames maps the audit record types most relevant to compliance rules to
+// the names auditd logs them with.
+var typeNames = map[int]string{
+	1100: "USER_AUTH",
+	1101: "USER_ACCT",
+	1102: "USER_MGMT",
+	1103: "CRED_ACQ",
+	1104: "CRED_DISP",
+	1105: "USER_START",
+	1106: "USER_END",
+	1108: "USER_CHAUTHTOK",
+	1110: "CRED_REFR",
+	1112: "USER_LOGIN",
+	1123: "USER_CMD",
+	1300: "SYSCALL",
+	1302: "PATH",
+	1305: "CONFIG_CHANGE",
+	1306: "SOCKADDR",
+	1307: "CWD",
+	1309: "EXECVE",
+	1320: "EOE",
+	1327: "PROCTITLE",
+}
+
+// record is one audit record. The records of an event share its serial.
+type record struct {
+	typ    int
+	serial uint64
+	time   time.Time
+	fields map[string]string
+}
+
+// typeName returns the name of the record type, or UNKNOWN[type] like ausearch.
+func (r record) typeName() string {
+	if name, ok := typeNames[r.typ]; ok {
+		return name
+	}
+	return "UNKNOWN[" + strconv.Itoa(r.typ) + "]"
+}
+
+// parseMessage parses the payload of an audit netlink message, such as
+// "audit(1717312512.123:4567): arch=c000003e syscall=257 key=\"identity\"".
+func parseMessage(typ int, msg string) (record, error) {
+	header, rest, _ := strings.Cut(msg, " ")
+	if !strings.HasPrefix(header, "audit(") || !strings.HasSuffix(header, "):") {
+		return record{}, fmt.Errorf("%w: missing audit header", errInvalidRecord)
+	}
+	stamp, serial, ok := strings.Cut(strings.TrimSuffix(strings.TrimPrefix(header, "audit("), "):"), ":")
+	if !ok {
+		return record{}, fmt.Errorf("%w: header %q", errInvalidRecord, header)
+	}
+	secs, frac, _ := strings.Cut(stamp, ".")
+	sec, err := strconv.ParseInt(secs, 10, 64)
+	if err != nil {
+		return record{}, fmt.Errorf("%w: header %q", errInvalidRecord, header)
+	}
+	var msec int64
+	if frac != "" {
+		if msec, err = strconv.ParseInt(frac, 10, 64); err != nil {
+			return record{}, fmt.Errorf("%w: header %q", errInvalidRecord, header)
+		}
+	}
+	r := record{
+		typ:    typ,
+		time:   time.Unix(sec, msec*int64(time.Millisecond)).UTC(),
+		fields: make(map[string]string),
+	}
+	if r.serial, err = strconv.ParseUint(serial, 10, 64); err != nil {
+		return record{}, fmt.Errorf("%w: header %q", errInvalidRecord, header)
+	}
+	parseFields(rest, r.fields)
+	return r, nil
+}
+
+// parseFields parses the name=value pairs of an audit record into fields.
+// Double and single quotes are removed. The single-quoted msg field of
+// user-space records is parsed into the same map. Words without a value,
+// such as the type name journald prefixes to its messages, are skipped.
+func parseFields(s string, fields map[string]string) {
+	for {
+		s = strings.TrimLeft(s, " ")
+		eq := strings.IndexByte(s, '=')
+		if eq < 0 {
+			return
+		}
+		if sp := strings.IndexByte(s, ' '); sp >= 0 && sp < eq {
+			s = s[sp+1:]
+			continue
+		}
+		name := s[:eq]
+		s = s[eq+1:]
+
+		var value string
+		if s != "" && (s[0] == '"' || s[0] == '\'') {
+			quote := s[0]
+			end := strings.IndexByte(s[1:], quote)
+			if end < 0 {
+				value, s = s[1:], ""
+			} else {
+				value, s = s[1:end+1], s[end+2:]
+			}
+			if name == "msg" && quote == '\'' {
+				parseFields(value, fields)
+				continue
+			}
+		} else if end := strings.IndexByte(s, ' '); end >= 0 {
+			value, s = s[:end], s[end+1:]
+		} else {
+			value, s = s, ""
+		}
+		fields[name] = value
+	}
+}
+
+// event collects the records that share a serial.
+type event struct {
+	serial  uint64
+	time    time.Time
+	records []record
+	// updated is when the last record was added.
+	updated time.Time
+}
+
+// key returns the audit rule key that matched the event, if any.
+func (e *event) key() string {
+	for _, r := range e.records {
+		if key := r.fields["key"]; key != "" && key != "(null)" {
+			return key
+		}
+	}
+	return ""
+}
+
+// target returns the object of the event: the first path it touched that is
+// not a parent directory, or else the executable.
+func (e *event) target() string {
+	for _, r := range e.records {
+		if r.typ == typePath && r.fields["nametype"] != "PARENT" && r.fields["name"] != "" {
+			return r.fields["name"]
+		}
+	}
+	for _, r := range e.records {
+		if exe := r.fields["exe"]; exe != "" {
+			return exe
+		}
+	}
+	return ""
+}
+
+// assembler groups records into events. Kernel events end with an EOE record.
+// User-space records are events of their own. Events that never see an EOE,
+// such as those read from journald, which does not store EOE records, are
+// completed after the timeout.
+type assembler struct {
+	timeout time.Duration
+	events  map[uint64]*event
+}
+
+func newAssembler(timeout time.Duration) *assembler {
+	return &assembler{timeout: timeout, events: make(map[uint64]*event)}
+}
+
+// add adds a record and returns the event it completes, if any.
+func (a *assembler) add(r record, now time.Time) *event {
+	if r.typ >= typeFirstUser && r.typ <= typeLastUser {
+		return &event{serial: r.serial, time: r.time, records: []record{r}, updated: now}
+	}
+	e, ok := a.events[r.serial]
+	if r.typ == typeEOE {
+		if ok {
+			delete(a.events, r.serial)
+		}
+		return e
+	}
+	if !ok {
+		e = &event{serial: r.serial, time: r.time}
+		a.events[r.serial] = e
+	}
+	e.records = append(e.records, r)
+	e.updated = now
+	return nil
+}
+
+// expire returns, in serial order, the events without records for the timeout.
+func (a *assembler) expire(now time.Time) []*event {
+	var expired []*event
+	for serial, e := range a.events {
+		if now.Sub(e.updated) >= a.timeout {
+			expired = append(expired, e)
+			delete(a.events, serial)
+		}
+	}
+	sort.Slice(expired, func(i, j int) bool { return expired[i].serial < expired[j].serial })
+	return expired
+}
+
+// appendEvent converts an event into a log record.
+func appendEvent(records plog.LogRecordSlice, e *event, key string, observed time.Time) {
+	lr := records.AppendEmpty()
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(observed))
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(e.time))
+	lr.SetSeverityNumber(plog.SeverityNumberInfo)
+	lr.SetSeverityText(plog.SeverityNumberInfo.String())
+
+	body := lr.Body().SetEmptyMap()
+	body.PutInt("serial", int64(e.serial))
+	bodyRecords := body.PutEmptySlice("records")
+	for _, r := range e.records {
+		m := bodyRecords.AppendEmpty().SetEmptyMap()
+		m.PutStr("type", r.typeName())
+		for name, value := range r.fields {
+			m.PutStr(name, value)
+		}
+	}
+
+	attrs := lr.Attributes()
+	attrs.PutStr(proofwatch.POLICY_ENGINE_NAME, engineName)
+	attrs.PutStr(proofwatch.POLICY_RULE_ID, key)
+	if target := e.target(); target != "" {
+		attrs.PutStr(proofwatch.POLICY_TARGET_ID, target)
+	}
+}
@@ -0,0 +1,118 @@
+package auditdreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+var observed = time.Date(2026, 6, 2, 10, 0, 0, 0, time.UTC)
+
+func mustParse(t *testing.T, typ int, msg string) record {
+	t.Helper()
+	r, err := parseMessage(typ, msg)
+	require.NoError(t, err)
+	return r
+}
+
+func TestParseMessage(t *testing.T) {
+	r := mustParse(t, 1300, `audit(1780389012.123:4567): arch=c000003e syscall=257 success=yes comm="vi" exe="/usr/bin/vi" key="identity"`)
+	assert.Equal(t, 1300, r.typ)
+	assert.Equal(t, "SYSCALL", r.typeName())
+	assert.Equal(t, uint64(4567), r.serial)
+	assert.Equal(t, time.Date(2026, 6, 2, 8, 30, 12, 123000000, time.UTC), r.time)
+	assert.Equal(t, map[string]string{
+		"arch":    "c000003e",
+		"syscall": "257",
+		"success": "yes",
+		"comm":    "vi",
+		"exe":     "/usr/bin/vi",
+		"key":     "identity",
+	}, r.fields)
+}
+
+func TestParseMessageUserRecord(t *testing.T) {
+	r := mustParse(t, 1112, `audit(1780389013.000:4570): pid=1201 uid=0 msg='op=login id=1000 exe="/usr/sbin/sshd" hostname=10.0.0.5 res=success'`)
+	assert.Equal(t, map[string]string{
+		"pid":      "1201",
+		"uid":      "0",
+		"op":       "login",
+		"id":       "1000",
+		"exe":      "/usr/sbin/sshd",
+		"hostname": "10.0.0.5",
+		"res":      "success",
+	}, r.fields)
+	assert.Equal(t, "UNKNOWN[1400]", record{typ: 1400}.typeName())
+}
+
+func TestParseMessageInvalid(t *testing.T) {
+	for _, msg := range []string{
+		"arch=c000003e syscall=257",
+		"audit(1780389012.123): arch=c000003e",
+		"audit(yesterday:4567): arch=c000003e",
+		"audit(1780389012.123:serial): arch=c000003e",
+	} {
+		_, err := parseMessage(1300, msg)
+		assert.ErrorIs(t, err, errInvalidRecord, msg)
+	}
+}
+
+func TestAssembler(t *testing.T) {
+	asm := newAssembler(time.Second)
+	now := observed
+
+	assert.Nil(t, asm.add(mustParse(t, 1300, `audit(1780389012.123:4567): syscall=257 exe="/usr/bin/vi" key="identity"`), now))
+	assert.Nil(t, asm.add(mustParse(t, 1302, `audit(1780389012.123:4567): item=0 name="/etc/" nametype=PARENT`), now))
+	assert.Nil(t, asm.add(mustParse(t, 1302, `audit(1780389012.123:4567): item=1 name="/etc/passwd" nametype=NORMAL`), now))
+	assert.Nil(t, asm.add(mustParse(t, 1300, `audit(1780389012.200:4568): syscall=59 exe="/usr/bin/ls" key=(null)`), now))
+
+	e := asm.add(mustParse(t, typeEOE, `audit(1780389012.123:4567):`), now)
+	require.NotNil(t, e)
+	assert.Len(t, e.records, 3)
+	assert.Equal(t, "identity", e.key())
+	assert.Equal(t, "/etc/passwd", e.target())
+
+	user := asm.add(mustParse(t, 1112, `audit(1780389013.000:4570): pid=1201 msg='op=login res=success'`), now)
+	require.NotNil(t, user, "user-space records are events of their own")
+	assert.Empty(t, user.key())
+
+	assert.Empty(t, asm.expire(now.Add(500*time.Millisecond)))
+	expired := asm.expire(now.Add(time.Second))
+	require.Len(t, expired, 1)
+	assert.Equal(t, uint64(4568), expired[0].serial)
+	assert.Empty(t, expired[0].key(), "(null) is no key")
+	assert.Equal(t, "/usr/bin/ls", expired[0].target())
+}
+
+func TestAppendEvent(t *testing.T) {
+	asm := newAssembler(time.Second)
+	asm.add(mustParse(t, 1300, `audit(1780389012.123:4567): syscall=257 exe="/usr/bin/vi" key="identity"`), observed)
+	asm.add(mustParse(t, 1302, `audit(1780389012.123:4567): item=0 name="/etc/passwd" nametype=NORMAL`), observed)
+	e := asm.add(mustParse(t, typeEOE, `audit(1780389012.123:4567):`), observed)
+	require.NotNil(t, e)
+
+	records := plog.NewLogRecordSlice()
+	appendEvent(records, e, e.key(), observed)
+	require.Equal(t, 1, records.Len())
+
+	lr := records.At(0)
+	assert.Equal(t, time.Date(2026, 6, 2, 8, 30, 12, 123000000, time.UTC), lr.Timestamp().AsTime())
+	assert.Equal(t, observed, lr.ObservedTimestamp().AsTime())
+	assert.Equal(t, map[string]any{
+		proofwatch.POLICY_ENGINE_NAME: engineName,
+		proofwatch.POLICY_RULE_ID:     "identity",
+		proofwatch.POLICY_TARGET_ID:   "/etc/passwd",
+	}, lr.Attributes().AsRaw())
+	assert.Equal(t, map[string]any{
+		"serial": int64(4567),
+		"records": []any{
+			map[string]any{"type": "SYSCALL", "syscall": "257", "exe": "/usr/bin/vi", "key": "identity"},
+			map[string]any{"type": "PATH", "item": "0", "name": "/etc/passwd", "nametype": "NORMAL"},
+		},
+	}, lr.Body().AsRaw())
+}
@@ -0,0 +1,83 @@
+package auditdreceiver
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	// SourceSocket reads the audit multicast netlink socket.
+	SourceSocket = "socket"
+	// SourceJournald follows audit records stored by journald.
+	SourceJournald = "journald"
+
+	defaultEventTimeout   = 2 * time.Second
+	defaultJournalctlPath = "journalctl"
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines the configuration for the auditd receiver.
+type Config struct {
+	// Source is where audit records are read from: socket or journald.
+	Source string `mapstructure:"source"`
+
+	// RuleKeys are the audit rule keys (-k) of the events to emit.
+	// Events without a matching key are dropped.
+	RuleKeys []string `mapstructure:"rule_keys"`
+
+	// EventTimeout is how long records of an event are collected before the
+	// event is emitted without an end-of-event record.
+	EventTimeout time.Duration `mapstructure:"event_timeout"`
+
+	// Journald configures the journald source.
+	Journald JournaldConfig `mapstructure:"journald"`
+}
+
+// JournaldConfig configures how journalctl is run.
+type JournaldConfig struct {
+	// JournalctlPath is the journalctl binary.
+	JournalctlPath string `mapstructure:"journalctl_path"`
+
+	// Directory reads the journal files in this directory instead of the
+	// system journal, for example a host journal mounted into a container.
+	Directory string `mapstructure:"directory"`
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Source:       SourceSocket,
+		EventTimeout: defaultEventTimeout,
+		Journald: JournaldConfig{
+			JournalctlPath: defaultJournalctlPath,
+		},
+	}
+}
+
+// Validate checks the receiver configuration is valid.
+func (c *Config) Validate() error {
+	switch c.Source {
+	case SourceSocket:
+	case SourceJournald:
+		if c.Journald.JournalctlPath == "" {
+			return errors.New("journald.journalctl_path must not be empty")
+		}
+	default:
+		return fmt.Errorf("source must be %q or %q, got %q", SourceSocket, SourceJournald, c.Source)
+	}
+	if len(c.RuleKeys) == 0 {
+		return errors.New("rule_keys must not be empty")
+	}
+	for _, key := range c.RuleKeys {
+		if key == "" {
+			return errors.New("rule_keys must not contain empty keys")
+		}
+	}
+	if c.EventTimeout <= 0 {
+		return errors.New("event_timeout must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package auditdreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id       component.ID
+		expected *Config
+	}{
+		{
+			id: component.NewID(componentType),
+			expected: &Config{
+				Source:       SourceSocket,
+				RuleKeys:     []string{"identity", "privileged"},
+				EventTimeout: defaultEventTimeout,
+				Journald:     JournaldConfig{JournalctlPath: defaultJournalctlPath},
+			},
+		},
+		{
+			id: component.NewIDWithName(componentType, "journald"),
+			expected: &Config{
+				Source:       SourceJournald,
+				RuleKeys:     []string{"identity"},
+				EventTimeout: 5 * time.Second,
+				Journald: JournaldConfig{
+					JournalctlPath: "/usr/bin/journalctl",
+					Directory:      "/var/log/host-journal",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, sub.Unmarshal(cfg))
+			require.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "unknown source",
+			mutate:  func(cfg *Config) { cfg.Source = "file" },
+			wantErr: `source must be "socket" or "journald", got "file"`,
+		},
+		{
+			name: "journald without journalctl",
+			mutate: func(cfg *Config) {
+				cfg.Source = SourceJournald
+				cfg.Journald.JournalctlPath = ""
+			},
+			wantErr: "journald.journalctl_path must not be empty",
+		},
+		{
+			name:    "no rule keys",
+			mutate:  func(cfg *Config) { cfg.RuleKeys = nil },
+			wantErr: "rule_keys must not be empty",
+		},
+		{
+			name:    "empty rule key",
+			mutate:  func(cfg *Config) { cfg.RuleKeys = []string{"identity", ""} },
+			wantErr: "rule_keys must not contain empty keys",
+		},
+		{
+			name:    "zero event timeout",
+			mutate:  func(cfg *Config) { cfg.EventTimeout = 0 },
+			wantErr: "event_timeout must be positive",
+		},
+		{
+			name:   "valid",
+			mutate: func(*Config) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.RuleKeys = []string{"identity"}
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
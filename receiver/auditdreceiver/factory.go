@@ -0,0 +1,31 @@
+package auditdreceiver
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+const stability = component.StabilityLevelAlpha
+
+var componentType = component.MustNewType("auditd")
+
+// NewFactory creates a factory for the auditd receiver.
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		componentType,
+		createDefaultConfig,
+		receiver.WithLogs(createLogsReceiver, stability),
+	)
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	next consumer.Logs,
+) (receiver.Logs, error) {
+	return newAuditdReceiver(cfg.(*Config), set, next), nil
+}
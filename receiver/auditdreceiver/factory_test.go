@@ -0,0 +1,37 @@
+package auditdreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func TestNewFactory(t *testing.T) {
+	factory := NewFactory()
+	assert.Equal(t, componentType, factory.Type())
+
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, componenttest.CheckConfigStruct(cfg))
+
+	rcvr, err := factory.CreateLogs(context.Background(), receivertest.NewNopSettings(componentType), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.NotNil(t, rcvr)
+}
+
+func TestReceiverLifecycle(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.RuleKeys = []string{"identity"}
+
+	// Without CAP_AUDIT_READ the socket source fails and is retried, which
+	// must not keep the receiver from starting or stopping.
+	rcvr, err := NewFactory().CreateLogs(context.Background(), receivertest.NewNopSettings(componentType), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+
+	require.NoError(t, rcvr.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, rcvr.Shutdown(context.Background()))
+}
@@ -0,0 +1,117 @@
+package auditdreceiver
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// maxJournalEntrySize bounds the size of one journal entry in JSON.
+const maxJournalEntrySize = 1 << 20
+
+// journaldSource follows the audit records journald stores, by running
+// journalctl.
+type journaldSource struct {
+	cfg    JournaldConfig
+	logger *zap.Logger
+}
+
+func (s *journaldSource) run(ctx context.Context, out chan<- record) error {
+	args := []string{"--follow", "--lines=0", "--output=json", "_TRANSPORT=audit"}
+	if s.cfg.Directory != "" {
+		args = append(args, "--directory="+s.cfg.Directory)
+	}
+	cmd := exec.CommandContext(ctx, s.cfg.JournalctlPath, args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to run journalctl: %w", err)
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), maxJournalEntrySize)
+	for scanner.Scan() {
+		r, err := parseJournalEntry(scanner.Bytes())
+		if err != nil {
+			s.logger.Debug("discarding journal entry", zap.Error(err))
+			continue
+		}
+		select {
+		case out <- r:
+		case <-ctx.Done():
+		}
+	}
+	scanErr := scanner.Err()
+	waitErr := cmd.Wait()
+	if ctx.Err() != nil {
+		return nil
+	}
+	if scanErr != nil {
+		return fmt.Errorf("failed to read journalctl output: %w", scanErr)
+	}
+	if waitErr != nil {
+		return fmt.Errorf("journalctl exited: %w", waitErr)
+	}
+	return errors.New("journalctl exited")
+}
+
+// journalEntry is the subset of a journal entry in journalctl JSON output
+// that describes an audit record.
+type journalEntry struct {
+	// Message is a string, or an array of bytes when it is not valid UTF-8.
+	Message   json.RawMessage `json:"MESSAGE"`
+	AuditType string          `json:"_AUDIT_TYPE"`
+	AuditID   string          `json:"_AUDIT_ID"`
+	// SourceRealtime is the audit timestamp, Realtime when journald received
+	// the record. Both are microseconds since the epoch.
+	SourceRealtime string `json:"_SOURCE_REALTIME_TIMESTAMP"`
+	Realtime       string `json:"__REALTIME_TIMESTAMP"`
+}
+
+// parseJournalEntry converts a journal entry of an audit record. Its MESSAGE
+// holds the record fields without the audit header, which journald stores in
+// separate fields.
+func parseJournalEntry(line []byte) (record, error) {
+	var entry journalEntry
+	if err := json.Unmarshal(line, &entry); err != nil {
+		return record{}, fmt.Errorf("%w: %w", errInvalidRecord, err)
+	}
+	var message string
+	if err := json.Unmarshal(entry.Message, &message); err != nil {
+		return record{}, fmt.Errorf("%w: MESSAGE is not a string", errInvalidRecord)
+	}
+	typ, err := strconv.Atoi(entry.AuditType)
+	if err != nil {
+		return record{}, fmt.Errorf("%w: _AUDIT_TYPE %q", errInvalidRecord, entry.AuditType)
+	}
+	serial, err := strconv.ParseUint(entry.AuditID, 10, 64)
+	if err != nil {
+		return record{}, fmt.Errorf("%w: _AUDIT_ID %q", errInvalidRecord, entry.AuditID)
+	}
+	stamp := entry.SourceRealtime
+	if stamp == "" {
+		stamp = entry.Realtime
+	}
+	usec, err := strconv.ParseInt(stamp, 10, 64)
+	if err != nil {
+		return record{}, fmt.Errorf("%w: timestamp %q", errInvalidRecord, stamp)
+	}
+
+	r := record{
+		typ:    typ,
+		serial: serial,
+		time:   time.UnixMicro(usec).UTC(),
+		fields: make(map[string]string),
+	}
+	parseFields(message, r.fields)
+	return r, nil
+}
@@ -0,0 +1,80 @@
+package auditdreceiver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+func TestParseJournalEntry(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "journal.json"))
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+
+	r, err := parseJournalEntry([]byte(lines[0]))
+	require.NoError(t, err)
+	assert.Equal(t, 1300, r.typ)
+	assert.Equal(t, uint64(4567), r.serial)
+	assert.Equal(t, time.Date(2026, 6, 2, 8, 30, 12, 123000000, time.UTC), r.time)
+	assert.Equal(t, "identity", r.fields["key"])
+	assert.NotContains(t, r.fields, "SYSCALL")
+
+	user, err := parseJournalEntry([]byte(lines[4]))
+	require.NoError(t, err)
+	assert.Equal(t, "success", user.fields["res"])
+}
+
+func TestParseJournalEntryInvalid(t *testing.T) {
+	for _, line := range []string{
+		`not json`,
+		`{"MESSAGE": [83, 89, 83], "_AUDIT_TYPE": "1300", "_AUDIT_ID": "1", "__REALTIME_TIMESTAMP": "1"}`,
+		`{"MESSAGE": "SYSCALL", "_AUDIT_ID": "1", "__REALTIME_TIMESTAMP": "1"}`,
+		`{"MESSAGE": "SYSCALL", "_AUDIT_TYPE": "1300", "__REALTIME_TIMESTAMP": "1"}`,
+		`{"MESSAGE": "SYSCALL", "_AUDIT_TYPE": "1300", "_AUDIT_ID": "1"}`,
+	} {
+		_, err := parseJournalEntry([]byte(line))
+		assert.ErrorIs(t, err, errInvalidRecord, line)
+	}
+}
+
+func TestReceiverReadsJournald(t *testing.T) {
+	script, err := filepath.Abs(filepath.Join("testdata", "journalctl.sh"))
+	require.NoError(t, err)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Source = SourceJournald
+	cfg.RuleKeys = []string{"identity"}
+	cfg.EventTimeout = 100 * time.Millisecond
+	cfg.Journald.JournalctlPath = script
+
+	sink := new(consumertest.LogsSink)
+	r := newAuditdReceiver(cfg, receivertest.NewNopSettings(componentType), sink)
+	r.now = func() time.Time { return observed }
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Eventually(t, func() bool { return sink.LogRecordCount() > 0 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	// Only the event with the identity key is emitted; the login and the
+	// exec event carry no configured key.
+	require.Equal(t, 1, sink.LogRecordCount())
+	sl := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0)
+	assert.Equal(t, scopeName, sl.Scope().Name())
+	attrs := sl.LogRecords().At(0).Attributes().AsRaw()
+	assert.Equal(t, "identity", attrs[proofwatch.POLICY_RULE_ID])
+	assert.Equal(t, "/etc/passwd", attrs[proofwatch.POLICY_TARGET_ID])
+	records, ok := sl.LogRecords().At(0).Body().Map().Get("records")
+	require.True(t, ok)
+	assert.Equal(t, 4, records.Slice().Len())
+}
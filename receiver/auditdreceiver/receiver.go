@@ -0,0 +1,146 @@
+package auditdreceiver
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+)
+
+// sourceRetryDelay is the pause before a failed source is restarted.
+const sourceRetryDelay = 5 * time.Second
+
+var _ receiver.Logs = (*auditdReceiver)(nil)
+
+// source reads audit records until the context is done or reading fails.
+type source interface {
+	run(ctx context.Context, out chan<- record) error
+}
+
+type auditdReceiver struct {
+	cfg      *Config
+	settings receiver.Settings
+	next     consumer.Logs
+	now      func() time.Time
+	source   source
+	keys     map[string]bool
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newAuditdReceiver(cfg *Config, set receiver.Settings, next consumer.Logs) *auditdReceiver {
+	r := &auditdReceiver{
+		cfg:      cfg,
+		settings: set,
+		next:     next,
+		now:      time.Now,
+		keys:     make(map[string]bool, len(cfg.RuleKeys)),
+	}
+	for _, key := range cfg.RuleKeys {
+		r.keys[key] = true
+	}
+	switch cfg.Source {
+	case SourceJournald:
+		r.source = &journaldSource{cfg: cfg.Journald, logger: set.Logger}
+	default:
+		r.source = &socketSource{logger: set.Logger}
+	}
+	return r
+}
+
+// Start begins reading audit records.
+func (r *auditdReceiver) Start(context.Context, component.Host) error {
+	runCtx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	records := make(chan record, 1024)
+	r.wg.Add(2)
+	go r.readSource(runCtx, records)
+	go r.assemble(runCtx, records)
+	return nil
+}
+
+// Shutdown stops reading and emits the events collected so far.
+func (r *auditdReceiver) Shutdown(context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	return nil
+}
+
+// readSource runs the source, restarting it when it fails.
+func (r *auditdReceiver) readSource(ctx context.Context, out chan<- record) {
+	defer r.wg.Done()
+
+	for ctx.Err() == nil {
+		if err := r.source.run(ctx, out); err != nil && ctx.Err() == nil {
+			r.settings.Logger.Warn("failed to read audit records; will retry", zap.String("source", r.cfg.Source), zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(sourceRetryDelay):
+		}
+	}
+}
+
+// assemble groups records into events and emits the events with a configured
+// rule key. Completed events are batched and emitted every half event timeout.
+func (r *auditdReceiver) assemble(ctx context.Context, in <-chan record) {
+	defer r.wg.Done()
+
+	asm := newAssembler(r.cfg.EventTimeout)
+	logs := plog.NewLogs()
+	var sl plog.ScopeLogs
+
+	add := func(e *event) {
+		key := e.key()
+		if !r.keys[key] {
+			return
+		}
+		if logs.ResourceLogs().Len() == 0 {
+			sl = logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+			sl.Scope().SetName(scopeName)
+		}
+		appendEvent(sl.LogRecords(), e, key, r.now())
+	}
+	emit := func(ctx context.Context) {
+		if logs.LogRecordCount() == 0 {
+			return
+		}
+		if err := r.next.ConsumeLogs(ctx, logs); err != nil {
+			r.settings.Logger.Warn("failed to emit audit events", zap.Int("events", logs.LogRecordCount()), zap.Error(err))
+		}
+		logs = plog.NewLogs()
+	}
+
+	ticker := time.NewTicker(r.cfg.EventTimeout / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// The source has stopped; emit incomplete events as they are.
+			for _, e := range asm.expire(time.Now().Add(r.cfg.EventTimeout)) {
+				add(e)
+			}
+			emit(context.Background())
+			return
+		case rec := <-in:
+			if e := asm.add(rec, time.Now()); e != nil {
+				add(e)
+			}
+		case <-ticker.C:
+			for _, e := range asm.expire(time.Now()) {
+				add(e)
+			}
+			emit(ctx)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package auditdreceiver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+
+	"go.uber.org/zap"
+)
+
+// auditGroupReadLog is the multicast group the kernel sends a read-only copy
+// of audit records to. Reading it requires CAP_AUDIT_READ and leaves auditd
+// as the audit daemon.
+const auditGroupReadLog = 1
+
+// socketSource reads records from the audit multicast netlink socket.
+type socketSource struct {
+	logger *zap.Logger
+}
+
+func (s *socketSource) run(ctx context.Context, out chan<- record) error {
+	fd, err := syscall.Socket(syscall.AF_NETLINK, syscall.SOCK_RAW|syscall.SOCK_CLOEXEC, syscall.NETLINK_AUDIT)
+	if err != nil {
+		return fmt.Errorf("failed to open audit socket: %w", err)
+	}
+	defer syscall.Close(fd)
+
+	if err := syscall.Bind(fd, &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK, Groups: auditGroupReadLog}); err != nil {
+		return fmt.Errorf("failed to join audit multicast group: %w", err)
+	}
+	// Wake up every second to notice cancellation.
+	if err := syscall.SetsockoptTimeval(fd, syscall.SOL_SOCKET, syscall.SO_RCVTIMEO, &syscall.Timeval{Sec: 1}); err != nil {
+		return fmt.Errorf("failed to set audit socket timeout: %w", err)
+	}
+
+	buf := make([]byte, 1<<16)
+	for ctx.Err() == nil {
+		n, _, err := syscall.Recvfrom(fd, buf, 0)
+		switch {
+		case errors.Is(err, syscall.EAGAIN), errors.Is(err, syscall.EINTR):
+			continue
+		case errors.Is(err, syscall.ENOBUFS):
+			s.logger.Warn("audit records were lost because the socket buffer overflowed")
+			continue
+		case err != nil:
+			return fmt.Errorf("failed to read audit socket: %w", err)
+		}
+
+		msgs, err := syscall.ParseNetlinkMessage(buf[:n])
+		if err != nil {
+			s.logger.Warn("discarding audit message", zap.Error(err))
+			continue
+		}
+		for _, msg := range msgs {
+			r, err := parseMessage(int(msg.Header.Type), string(bytes.TrimRight(msg.Data, "\x00\n")))
+			if err != nil {
+				s.logger.Debug("discarding audit record", zap.Error(err))
+				continue
+			}
+			select {
+			case out <- r:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+//go:build !linux
+
+package auditdreceiver
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+)
+
+// socketSource reads records from the audit multicast netlink socket, which
+// only exists on Linux.
+type socketSource struct {
+	logger *zap.Logger
+}
+
+func (s *socketSource) run(context.Context, chan<- record) error {
+	return errors.New("the audit socket is only available on Linux")
+}
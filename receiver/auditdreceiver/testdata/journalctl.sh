@@ -0,0 +1,4 @@
+#!/bin/sh
+# Stands in for journalctl --follow: prints the test journal and keeps running.
+cat "$(dirname "$0")/journal.json"
+exec sleep 60
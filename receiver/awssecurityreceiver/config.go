@@ -0,0 +1,134 @@
+package awssecurityreceiver
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const (
+	defaultPollInterval    = 5 * time.Minute
+	defaultInitialLookback = 24 * time.Hour
+	defaultWaitTime        = 20 * time.Second
+	maxWaitTime            = 20 * time.Second
+
+	securityHubKey = "security_hub"
+	sqsKey         = "sqs"
+)
+
+var (
+	_ component.Config    = (*Config)(nil)
+	_ confmap.Unmarshaler = (*Config)(nil)
+)
+
+// Config defines the configuration for the AWS security receiver.
+// At least one of SecurityHub or SQS must be set.
+type Config struct {
+	// SecurityHub polls the Security Hub GetFindings API.
+	SecurityHub *SecurityHubConfig `mapstructure:"security_hub"`
+
+	// SQS receives Security Hub and AWS Config events that EventBridge
+	// delivers to a queue.
+	SQS *SQSConfig `mapstructure:"sqs"`
+}
+
+// SecurityHubConfig configures Security Hub polling. Requests are expected to be
+// signed by an authenticator extension such as sigv4auth.
+type SecurityHubConfig struct {
+	confighttp.ClientConfig `mapstructure:",squash"`
+
+	// PollInterval is how often findings updated since the previous poll are fetched.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+
+	// InitialLookback is how far back the first poll reaches.
+	InitialLookback time.Duration `mapstructure:"initial_lookback"`
+}
+
+// SQSConfig configures the queue subscription. Requests are expected to be
+// signed by an authenticator extension such as sigv4auth.
+type SQSConfig struct {
+	confighttp.ClientConfig `mapstructure:",squash"`
+
+	// QueueURL is the queue that EventBridge rules deliver events to.
+	// The endpoint defaults to the scheme and host of the queue URL.
+	QueueURL string `mapstructure:"queue_url"`
+
+	// WaitTime is the long polling duration of each receive request.
+	WaitTime time.Duration `mapstructure:"wait_time"`
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		SecurityHub: &SecurityHubConfig{
+			ClientConfig:    confighttp.NewDefaultClientConfig(),
+			PollInterval:    defaultPollInterval,
+			InitialLookback: defaultInitialLookback,
+		},
+		SQS: &SQSConfig{
+			ClientConfig: confighttp.NewDefaultClientConfig(),
+			WaitTime:     defaultWaitTime,
+		},
+	}
+}
+
+// Unmarshal keeps only the sources that are present in the user configuration.
+func (c *Config) Unmarshal(conf *confmap.Conf) error {
+	if err := conf.Unmarshal(c); err != nil {
+		return err
+	}
+	if !conf.IsSet(securityHubKey) {
+		c.SecurityHub = nil
+	}
+	if !conf.IsSet(sqsKey) {
+		c.SQS = nil
+	}
+	return nil
+}
+
+// Validate checks the receiver configuration is valid.
+func (c *Config) Validate() error {
+	if c.SecurityHub == nil && c.SQS == nil {
+		return errors.New("at least one of security_hub or sqs must be configured")
+	}
+	if c.SecurityHub != nil {
+		if c.SecurityHub.Endpoint == "" {
+			return errors.New("security_hub.endpoint must not be empty")
+		}
+		if c.SecurityHub.PollInterval <= 0 {
+			return errors.New("security_hub.poll_interval must be positive")
+		}
+		if c.SecurityHub.InitialLookback < 0 {
+			return errors.New("security_hub.initial_lookback must not be negative")
+		}
+	}
+	if c.SQS != nil {
+		if c.SQS.QueueURL == "" {
+			return errors.New("sqs.queue_url must not be empty")
+		}
+		u, err := url.Parse(c.SQS.QueueURL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("sqs.queue_url %q is not an absolute URL", c.SQS.QueueURL)
+		}
+		if c.SQS.WaitTime < 0 || c.SQS.WaitTime > maxWaitTime {
+			return fmt.Errorf("sqs.wait_time must be between 0s and %s", maxWaitTime)
+		}
+	}
+	return nil
+}
+
+// endpoint returns the SQS API endpoint.
+func (c *SQSConfig) endpoint() string {
+	if c.Endpoint != "" {
+		return c.Endpoint
+	}
+	u, err := url.Parse(c.QueueURL)
+	if err != nil {
+		return ""
+	}
+	return u.Scheme + "://" + u.Host
+}
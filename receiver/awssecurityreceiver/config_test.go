@@ -0,0 +1,136 @@
+package awssecurityreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id              component.ID
+		wantSecurityHub bool
+		wantSQS         bool
+		check           func(t *testing.T, cfg *Config)
+	}{
+		{
+			id:              component.NewID(componentType),
+			wantSecurityHub: true,
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "https://securityhub.us-east-1.amazonaws.com", cfg.SecurityHub.Endpoint)
+				assert.Equal(t, defaultPollInterval, cfg.SecurityHub.PollInterval)
+				assert.Equal(t, defaultInitialLookback, cfg.SecurityHub.InitialLookback)
+			},
+		},
+		{
+			id:      component.NewIDWithName(componentType, "sqs"),
+			wantSQS: true,
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "https://sqs.eu-west-1.amazonaws.com/123456789012/complybeacon-findings", cfg.SQS.QueueURL)
+				assert.Equal(t, "https://sqs.eu-west-1.amazonaws.com", cfg.SQS.endpoint())
+				assert.Equal(t, 10*time.Second, cfg.SQS.WaitTime)
+			},
+		},
+		{
+			id:              component.NewIDWithName(componentType, "both"),
+			wantSecurityHub: true,
+			wantSQS:         true,
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, 15*time.Minute, cfg.SecurityHub.PollInterval)
+				assert.Zero(t, cfg.SecurityHub.InitialLookback)
+				assert.Equal(t, "https://sqs.us-east-1.amazonaws.com", cfg.SQS.endpoint())
+				assert.Equal(t, defaultWaitTime, cfg.SQS.WaitTime)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, cfg.Unmarshal(sub))
+			require.NoError(t, cfg.Validate())
+
+			assert.Equal(t, tt.wantSecurityHub, cfg.SecurityHub != nil)
+			assert.Equal(t, tt.wantSQS, cfg.SQS != nil)
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name: "no source",
+			mutate: func(cfg *Config) {
+				cfg.SecurityHub = nil
+				cfg.SQS = nil
+			},
+			wantErr: "at least one of security_hub or sqs must be configured",
+		},
+		{
+			name:    "empty security hub endpoint",
+			mutate:  func(cfg *Config) { cfg.SecurityHub.Endpoint = "" },
+			wantErr: "security_hub.endpoint must not be empty",
+		},
+		{
+			name:    "zero poll interval",
+			mutate:  func(cfg *Config) { cfg.SecurityHub.PollInterval = 0 },
+			wantErr: "security_hub.poll_interval must be positive",
+		},
+		{
+			name:    "negative lookback",
+			mutate:  func(cfg *Config) { cfg.SecurityHub.InitialLookback = -time.Hour },
+			wantErr: "security_hub.initial_lookback must not be negative",
+		},
+		{
+			name:    "empty queue url",
+			mutate:  func(cfg *Config) { cfg.SQS.QueueURL = "" },
+			wantErr: "sqs.queue_url must not be empty",
+		},
+		{
+			name:    "relative queue url",
+			mutate:  func(cfg *Config) { cfg.SQS.QueueURL = "complybeacon-findings" },
+			wantErr: `sqs.queue_url "complybeacon-findings" is not an absolute URL`,
+		},
+		{
+			name:    "wait time too long",
+			mutate:  func(cfg *Config) { cfg.SQS.WaitTime = time.Minute },
+			wantErr: "sqs.wait_time must be between 0s and 20s",
+		},
+		{
+			name:   "valid",
+			mutate: func(*Config) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.SecurityHub.Endpoint = "https://securityhub.us-east-1.amazonaws.com"
+			cfg.SQS.QueueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/findings"
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package awssecurityreceiver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+const (
+	scopeName = "github.com/complytime/complybeacon/receiver/awssecurityreceiver"
+
+	securityHubEngine = "AWS Security Hub"
+	configEngine      = "AWS Config"
+
+	cloudProvider  = "cloud.provider"
+	cloudAccountID = "cloud.account.id"
+	cloudRegion    = "cloud.region"
+
+	detailTypeFindingsImported = "Security Hub Findings - Imported"
+	detailTypeComplianceChange = "Config Rules Compliance Change"
+)
+
+// errUnsupportedEvent marks messages that carry no compliance evidence, so they
+// can be removed from the queue instead of being redelivered.
+var errUnsupportedEvent = errors.New("unsupported event")
+
+// finding is the subset of an AWS Security Finding Format (ASFF) finding used
+// to build evidence.
+type finding struct {
+	ID           string `json:"Id"`
+	GeneratorID  string `json:"GeneratorId"`
+	AwsAccountID string `json:"AwsAccountId"`
+	Region       string `json:"Region"`
+	ProductName  string `json:"ProductName"`
+	Title        string `json:"Title"`
+	Description  string `json:"Description"`
+	UpdatedAt    string `json:"UpdatedAt"`
+	Severity     struct {
+		Label string `json:"Label"`
+	} `json:"Severity"`
+	Compliance struct {
+		Status              string   `json:"Status"`
+		SecurityControlID   string   `json:"SecurityControlId"`
+		RelatedRequirements []string `json:"RelatedRequirements"`
+	} `json:"Compliance"`
+	Remediation struct {
+		Recommendation struct {
+			Text string `json:"Text"`
+			URL  string `json:"Url"`
+		} `json:"Recommendation"`
+	} `json:"Remediation"`
+	Resources []struct {
+		ID   string `json:"Id"`
+		Type string `json:"Type"`
+	} `json:"Resources"`
+}
+
+// configComplianceChange is the detail of an AWS Config compliance change event.
+type configComplianceChange struct {
+	ResourceID          string `json:"resourceId"`
+	ResourceType        string `json:"resourceType"`
+	AwsRegion           string `json:"awsRegion"`
+	AwsAccountID        string `json:"awsAccountId"`
+	ConfigRuleName      string `json:"configRuleName"`
+	NewEvaluationResult struct {
+		ComplianceType     string `json:"complianceType"`
+		ResultRecordedTime string `json:"resultRecordedTime"`
+		Annotation         string `json:"annotation"`
+	} `json:"newEvaluationResult"`
+}
+
+// eventBridgeEvent is the envelope of events delivered by EventBridge.
+type eventBridgeEvent struct {
+	DetailType string          `json:"detail-type"`
+	Source     string          `json:"source"`
+	Account    string          `json:"account"`
+	Region     string          `json:"region"`
+	Time       string          `json:"time"`
+	Detail     json.RawMessage `json:"detail"`
+}
+
+// snsNotification wraps events delivered to the queue through an SNS topic.
+type snsNotification struct {
+	Type    string `json:"Type"`
+	Message string `json:"Message"`
+}
+
+// appendFindings converts raw ASFF findings into log records. Findings without a
+// compliance status, such as threat detections, are skipped.
+func appendFindings(logs plog.Logs, raw []json.RawMessage, observed time.Time) error {
+	for i, data := range raw {
+		var f finding
+		if err := json.Unmarshal(data, &f); err != nil {
+			return fmt.Errorf("finding %d: %w", i, err)
+		}
+		if f.Compliance.Status == "" {
+			continue
+		}
+		var body map[string]any
+		if err := json.Unmarshal(data, &body); err != nil {
+			return fmt.Errorf("finding %d: %w", i, err)
+		}
+		appendFinding(logs, f, body, observed)
+	}
+	return nil
+}
+
+func appendFinding(logs plog.Logs, f finding, body map[string]any, observed time.Time) {
+	record := newRecord(logs, f.AwsAccountID, f.Region, parseTime(f.UpdatedAt, observed), observed)
+	_ = record.Body().SetEmptyMap().FromRaw(body)
+
+	engine := f.ProductName
+	if engine == "" {
+		engine = securityHubEngine
+	}
+	ruleID := f.Compliance.SecurityControlID
+	if ruleID == "" {
+		ruleID = f.GeneratorID
+	}
+
+	attrs := record.Attributes()
+	attrs.PutStr(proofwatch.POLICY_ENGINE_NAME, engine)
+	attrs.PutStr(proofwatch.POLICY_RULE_ID, ruleID)
+	putStr(attrs, proofwatch.POLICY_RULE_NAME, f.Title)
+	putStr(attrs, proofwatch.POLICY_RULE_URI, f.Remediation.Recommendation.URL)
+	attrs.PutStr(proofwatch.POLICY_EVALUATION_RESULT, mapFindingStatus(f.Compliance.Status))
+	putStr(attrs, proofwatch.POLICY_EVALUATION_MESSAGE, f.Description)
+	if len(f.Resources) > 0 {
+		putStr(attrs, proofwatch.POLICY_TARGET_ID, f.Resources[0].ID)
+		putStr(attrs, proofwatch.POLICY_TARGET_NAME, f.Resources[0].ID)
+		putStr(attrs, proofwatch.POLICY_TARGET_TYPE, f.Resources[0].Type)
+	}
+	if level := mapSeverity(f.Severity.Label); level != "" {
+		attrs.PutStr(proofwatch.COMPLIANCE_RISK_LEVEL, level)
+	}
+	if len(f.Compliance.RelatedRequirements) > 0 {
+		requirements := attrs.PutEmptySlice(proofwatch.COMPLIANCE_REQUIREMENTS)
+		for _, r := range f.Compliance.RelatedRequirements {
+			requirements.AppendEmpty().SetStr(r)
+		}
+	}
+	putStr(attrs, proofwatch.COMPLIANCE_REMEDIATION_DESCRIPTION, f.Remediation.Recommendation.Text)
+}
+
+func appendComplianceChange(logs plog.Logs, event eventBridgeEvent, body map[string]any, observed time.Time) error {
+	var change configComplianceChange
+	if err := json.Unmarshal(event.Detail, &change); err != nil {
+		return fmt.Errorf("compliance change: %w", err)
+	}
+	account, region := change.AwsAccountID, change.AwsRegion
+	if account == "" {
+		account = event.Account
+	}
+	if region == "" {
+		region = event.Region
+	}
+	timestamp := parseTime(change.NewEvaluationResult.ResultRecordedTime, parseTime(event.Time, observed))
+
+	record := newRecord(logs, account, region, timestamp, observed)
+	_ = record.Body().SetEmptyMap().FromRaw(body)
+
+	attrs := record.Attributes()
+	attrs.PutStr(proofwatch.POLICY_ENGINE_NAME, configEngine)
+	attrs.PutStr(proofwatch.POLICY_RULE_ID, change.ConfigRuleName)
+	attrs.PutStr(proofwatch.POLICY_EVALUATION_RESULT, mapComplianceType(change.NewEvaluationResult.ComplianceType))
+	putStr(attrs, proofwatch.POLICY_EVALUATION_MESSAGE, change.NewEvaluationResult.Annotation)
+	putStr(attrs, proofwatch.POLICY_TARGET_ID, change.ResourceID)
+	putStr(attrs, proofwatch.POLICY_TARGET_NAME, change.ResourceID)
+	putStr(attrs, proofwatch.POLICY_TARGET_TYPE, change.ResourceType)
+	return nil
+}
+
+// appendMessage converts the body of a queue message: an EventBridge event,
+// delivered directly or through SNS.
+func appendMessage(logs plog.Logs, message string, observed time.Time) error {
+	var sns snsNotification
+	if err := json.Unmarshal([]byte(message), &sns); err == nil && sns.Type == "Notification" {
+		message = sns.Message
+	}
+
+	var event eventBridgeEvent
+	if err := json.Unmarshal([]byte(message), &event); err != nil {
+		return fmt.Errorf("%w: %w", errUnsupportedEvent, err)
+	}
+	switch event.DetailType {
+	case detailTypeFindingsImported:
+		var detail struct {
+			Findings []json.RawMessage `json:"findings"`
+		}
+		if err := json.Unmarshal(event.Detail, &detail); err != nil {
+			return fmt.Errorf("%w: %w", errUnsupportedEvent, err)
+		}
+		if err := appendFindings(logs, detail.Findings, observed); err != nil {
+			return fmt.Errorf("%w: %w", errUnsupportedEvent, err)
+		}
+		return nil
+	case detailTypeComplianceChange:
+		var body map[string]any
+		if err := json.Unmarshal([]byte(message), &body); err != nil {
+			return fmt.Errorf("%w: %w", errUnsupportedEvent, err)
+		}
+		if err := appendComplianceChange(logs, event, body, observed); err != nil {
+			return fmt.Errorf("%w: %w", errUnsupportedEvent, err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: detail-type %q", errUnsupportedEvent, event.DetailType)
+	}
+}
+
+// newRecord appends a record to the resource of its account and region.
+func newRecord(logs plog.Logs, account, region string, timestamp, observed time.Time) plog.LogRecord {
+	var sl plog.ScopeLogs
+	found := false
+	for i := 0; i < logs.ResourceLogs().Len() && !found; i++ {
+		rl := logs.ResourceLogs().At(i)
+		if str(rl.Resource().Attributes(), cloudAccountID) == account && str(rl.Resource().Attributes(), cloudRegion) == region {
+			sl, found = rl.ScopeLogs().At(0), true
+		}
+	}
+	if !found {
+		rl := logs.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr(cloudProvider, "aws")
+		putStr(rl.Resource().Attributes(), cloudAccountID, account)
+		putStr(rl.Resource().Attributes(), cloudRegion, region)
+		sl = rl.ScopeLogs().AppendEmpty()
+		sl.Scope().SetName(scopeName)
+	}
+
+	record := sl.LogRecords().AppendEmpty()
+	record.SetObservedTimestamp(pcommon.NewTimestampFromTime(observed))
+	record.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
+	record.SetSeverityNumber(plog.SeverityNumberInfo)
+	record.SetSeverityText(plog.SeverityNumberInfo.String())
+	return record
+}
+
+// mapFindingStatus maps an ASFF Compliance.Status to policy.evaluation.result.
+func mapFindingStatus(status string) string {
+	switch status {
+	case "PASSED":
+		return "Passed"
+	case "FAILED":
+		return "Failed"
+	case "WARNING":
+		return "Needs Review"
+	default:
+		// NOT_AVAILABLE
+		return "Unknown"
+	}
+}
+
+// mapComplianceType maps an AWS Config compliance type to policy.evaluation.result.
+func mapComplianceType(complianceType string) string {
+	switch complianceType {
+	case "COMPLIANT":
+		return "Passed"
+	case "NON_COMPLIANT":
+		return "Failed"
+	case "NOT_APPLICABLE":
+		return "Not Applicable"
+	default:
+		// INSUFFICIENT_DATA
+		return "Unknown"
+	}
+}
+
+// mapSeverity maps an ASFF severity label to compliance.risk.level.
+func mapSeverity(label string) string {
+	switch label {
+	case "CRITICAL":
+		return "Critical"
+	case "HIGH":
+		return "High"
+	case "MEDIUM":
+		return "Medium"
+	case "LOW":
+		return "Low"
+	case "INFORMATIONAL":
+		return "Informational"
+	default:
+		return ""
+	}
+}
+
+func parseTime(value string, fallback time.Time) time.Time {
+	if t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(value)); err == nil {
+		return t
+	}
+	return fallback
+}
+
+func putStr(attrs pcommon.Map, key, value string) {
+	if value != "" {
+		attrs.PutStr(key, value)
+	}
+}
+
+func str(attrs pcommon.Map, key string) string {
+	if v, ok := attrs.Get(key); ok {
+		return v.AsString()
+	}
+	return ""
+}
@@ -0,0 +1,160 @@
+package awssecurityreceiver
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+var observed = time.Date(2026, 6, 2, 10, 0, 0, 0, time.UTC)
+
+func readTestdata(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return data
+}
+
+// eventBridge wraps Security Hub findings in an EventBridge event.
+func eventBridge(t *testing.T, findings ...json.RawMessage) string {
+	t.Helper()
+	data, err := json.Marshal(map[string]any{
+		"detail-type": detailTypeFindingsImported,
+		"source":      "aws.securityhub",
+		"detail":      map[string]any{"findings": findings},
+	})
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestAppendFindings(t *testing.T) {
+	logs := plog.NewLogs()
+	threat := json.RawMessage(`{"Id": "guardduty-1", "AwsAccountId": "123456789012", "Region": "us-east-1"}`)
+	require.NoError(t, appendFindings(logs, []json.RawMessage{readTestdata(t, "finding.json"), threat}, observed))
+	require.Equal(t, 1, logs.LogRecordCount(), "findings without a compliance status are skipped")
+
+	rl := logs.ResourceLogs().At(0)
+	assert.Equal(t, map[string]any{
+		cloudProvider:  "aws",
+		cloudAccountID: "123456789012",
+		cloudRegion:    "us-east-1",
+	}, rl.Resource().Attributes().AsRaw())
+	assert.Equal(t, scopeName, rl.ScopeLogs().At(0).Scope().Name())
+
+	record := rl.ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, time.Date(2026, 6, 2, 8, 30, 0, 0, time.UTC), record.Timestamp().AsTime())
+	assert.Equal(t, map[string]any{
+		proofwatch.POLICY_ENGINE_NAME:                 "Security Hub",
+		proofwatch.POLICY_RULE_ID:                     "S3.5",
+		proofwatch.POLICY_RULE_NAME:                   "S3 general purpose buckets should require requests to use SSL",
+		proofwatch.POLICY_RULE_URI:                    "https://docs.aws.amazon.com/console/securityhub/S3.5/remediation",
+		proofwatch.POLICY_EVALUATION_RESULT:           "Failed",
+		proofwatch.POLICY_EVALUATION_MESSAGE:          "This control checks whether S3 general purpose buckets have policies that require requests to use SSL.",
+		proofwatch.POLICY_TARGET_ID:                   "arn:aws:s3:::audit-evidence",
+		proofwatch.POLICY_TARGET_NAME:                 "arn:aws:s3:::audit-evidence",
+		proofwatch.POLICY_TARGET_TYPE:                 "AwsS3Bucket",
+		proofwatch.COMPLIANCE_RISK_LEVEL:              "Medium",
+		proofwatch.COMPLIANCE_REQUIREMENTS:            []any{"NIST.800-53.r5 AC-17(2)", "NIST.800-53.r5 SC-8"},
+		proofwatch.COMPLIANCE_REMEDIATION_DESCRIPTION: "For information on how to correct this issue, consult the AWS Security Hub controls documentation.",
+	}, record.Attributes().AsRaw())
+
+	id, ok := record.Body().Map().Get("Id")
+	require.True(t, ok)
+	assert.Contains(t, id.Str(), "security-control/S3.5")
+}
+
+func TestAppendMessage(t *testing.T) {
+	complianceChange := string(readTestdata(t, "config-compliance-change.json"))
+	sns, err := json.Marshal(snsNotification{Type: "Notification", Message: complianceChange})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		message    string
+		wantEngine string
+		wantResult string
+	}{
+		{
+			name:       "security hub findings",
+			message:    eventBridge(t, readTestdata(t, "finding.json")),
+			wantEngine: "Security Hub",
+			wantResult: "Failed",
+		},
+		{
+			name:       "config compliance change",
+			message:    complianceChange,
+			wantEngine: configEngine,
+			wantResult: "Failed",
+		},
+		{
+			name:       "through sns",
+			message:    string(sns),
+			wantEngine: configEngine,
+			wantResult: "Failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs := plog.NewLogs()
+			require.NoError(t, appendMessage(logs, tt.message, observed))
+			require.Equal(t, 1, logs.LogRecordCount())
+
+			attrs := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw()
+			assert.Equal(t, tt.wantEngine, attrs[proofwatch.POLICY_ENGINE_NAME])
+			assert.Equal(t, tt.wantResult, attrs[proofwatch.POLICY_EVALUATION_RESULT])
+		})
+	}
+}
+
+func TestAppendComplianceChange(t *testing.T) {
+	logs := plog.NewLogs()
+	require.NoError(t, appendMessage(logs, string(readTestdata(t, "config-compliance-change.json")), observed))
+
+	rl := logs.ResourceLogs().At(0)
+	assert.Equal(t, "eu-west-1", rl.Resource().Attributes().AsRaw()[cloudRegion])
+	record := rl.ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, time.Date(2026, 6, 2, 9, 0, 1, 250000000, time.UTC), record.Timestamp().AsTime())
+	assert.Equal(t, map[string]any{
+		proofwatch.POLICY_ENGINE_NAME:        configEngine,
+		proofwatch.POLICY_RULE_ID:            "s3-bucket-versioning-enabled",
+		proofwatch.POLICY_EVALUATION_RESULT:  "Failed",
+		proofwatch.POLICY_EVALUATION_MESSAGE: "Versioning is not enabled.",
+		proofwatch.POLICY_TARGET_ID:          "audit-evidence",
+		proofwatch.POLICY_TARGET_NAME:        "audit-evidence",
+		proofwatch.POLICY_TARGET_TYPE:        "AWS::S3::Bucket",
+	}, record.Attributes().AsRaw())
+}
+
+func TestAppendMessageUnsupported(t *testing.T) {
+	for _, message := range []string{
+		"not json",
+		`{"detail-type": "EC2 Instance State-change Notification", "detail": {}}`,
+		`{"detail-type": "Security Hub Findings - Imported", "detail": {"findings": "none"}}`,
+	} {
+		err := appendMessage(plog.NewLogs(), message, observed)
+		assert.ErrorIs(t, err, errUnsupportedEvent, message)
+	}
+}
+
+func TestMapComplianceType(t *testing.T) {
+	assert.Equal(t, "Passed", mapComplianceType("COMPLIANT"))
+	assert.Equal(t, "Failed", mapComplianceType("NON_COMPLIANT"))
+	assert.Equal(t, "Not Applicable", mapComplianceType("NOT_APPLICABLE"))
+	assert.Equal(t, "Unknown", mapComplianceType("INSUFFICIENT_DATA"))
+}
+
+func TestMapFindingStatus(t *testing.T) {
+	assert.Equal(t, "Passed", mapFindingStatus("PASSED"))
+	assert.Equal(t, "Failed", mapFindingStatus("FAILED"))
+	assert.Equal(t, "Needs Review", mapFindingStatus("WARNING"))
+	assert.Equal(t, "Unknown", mapFindingStatus("NOT_AVAILABLE"))
+}
@@ -0,0 +1,325 @@
+package awssecurityreceiver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+)
+
+const (
+	// findingsPageSize is the GetFindings page size limit.
+	findingsPageSize = 100
+	// sqsMaxMessages is the ReceiveMessage limit on messages per request.
+	sqsMaxMessages = 10
+	// sqsRetryDelay is the pause after a failed receive.
+	sqsRetryDelay = 5 * time.Second
+
+	sqsReceiveTarget = "AmazonSQS.ReceiveMessage"
+	sqsDeleteTarget  = "AmazonSQS.DeleteMessageBatch"
+)
+
+var _ receiver.Logs = (*awsSecurityReceiver)(nil)
+
+type awsSecurityReceiver struct {
+	cfg      *Config
+	settings receiver.Settings
+	next     consumer.Logs
+	now      func() time.Time
+
+	hubClient *http.Client
+	sqsClient *http.Client
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	// since is the UpdatedAt lower bound of the next Security Hub poll.
+	since time.Time
+}
+
+func newAWSSecurityReceiver(cfg *Config, set receiver.Settings, next consumer.Logs) *awsSecurityReceiver {
+	return &awsSecurityReceiver{
+		cfg:      cfg,
+		settings: set,
+		next:     next,
+		now:      time.Now,
+	}
+}
+
+// Start begins polling Security Hub and/or receiving from the queue.
+func (r *awsSecurityReceiver) Start(ctx context.Context, host component.Host) error {
+	if r.cfg.SecurityHub != nil {
+		client, err := r.cfg.SecurityHub.ToClient(ctx, host.GetExtensions(), r.settings.TelemetrySettings)
+		if err != nil {
+			return fmt.Errorf("failed to create Security Hub client: %w", err)
+		}
+		r.hubClient = client
+		r.since = r.now().Add(-r.cfg.SecurityHub.InitialLookback)
+	}
+	if r.cfg.SQS != nil {
+		client, err := r.cfg.SQS.ToClient(ctx, host.GetExtensions(), r.settings.TelemetrySettings)
+		if err != nil {
+			return fmt.Errorf("failed to create SQS client: %w", err)
+		}
+		r.sqsClient = client
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	if r.hubClient != nil {
+		r.wg.Add(1)
+		go r.pollSecurityHub(runCtx)
+	}
+	if r.sqsClient != nil {
+		r.wg.Add(1)
+		go r.receiveQueue(runCtx)
+	}
+	return nil
+}
+
+// Shutdown stops polling and receiving.
+func (r *awsSecurityReceiver) Shutdown(context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	return nil
+}
+
+func (r *awsSecurityReceiver) pollSecurityHub(ctx context.Context) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.cfg.SecurityHub.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.pollFindings(ctx); err != nil && ctx.Err() == nil {
+			r.settings.Logger.Warn("failed to poll Security Hub findings; will retry on the next poll", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+type getFindingsRequest struct {
+	Filters    findingFilters `json:"Filters"`
+	MaxResults int            `json:"MaxResults"`
+	NextToken  string         `json:"NextToken,omitempty"`
+}
+
+type findingFilters struct {
+	UpdatedAt   []dateFilter   `json:"UpdatedAt"`
+	RecordState []stringFilter `json:"RecordState"`
+}
+
+type dateFilter struct {
+	Start string `json:"Start"`
+	End   string `json:"End"`
+}
+
+type stringFilter struct {
+	Value      string `json:"Value"`
+	Comparison string `json:"Comparison"`
+}
+
+type getFindingsResponse struct {
+	Findings  []json.RawMessage `json:"Findings"`
+	NextToken string            `json:"NextToken"`
+}
+
+// pollFindings fetches active findings updated since the previous successful
+// poll. The window only advances when every page was consumed, so a failed
+// poll is repeated in full.
+func (r *awsSecurityReceiver) pollFindings(ctx context.Context) error {
+	end := r.now()
+	req := getFindingsRequest{
+		Filters: findingFilters{
+			UpdatedAt:   []dateFilter{{Start: r.since.UTC().Format(time.RFC3339Nano), End: end.UTC().Format(time.RFC3339Nano)}},
+			RecordState: []stringFilter{{Value: "ACTIVE", Comparison: "EQUALS"}},
+		},
+		MaxResults: findingsPageSize,
+	}
+	url := strings.TrimSuffix(r.cfg.SecurityHub.Endpoint, "/") + "/findings"
+
+	for {
+		var resp getFindingsResponse
+		if err := callJSON(ctx, r.hubClient, url, "application/json", "", req, &resp); err != nil {
+			return fmt.Errorf("GetFindings: %w", err)
+		}
+
+		logs := plog.NewLogs()
+		if err := appendFindings(logs, resp.Findings, end); err != nil {
+			return err
+		}
+		if logs.LogRecordCount() > 0 {
+			if err := r.next.ConsumeLogs(ctx, logs); err != nil {
+				return err
+			}
+		}
+
+		if resp.NextToken == "" {
+			break
+		}
+		req.NextToken = resp.NextToken
+	}
+	r.since = end
+	return nil
+}
+
+func (r *awsSecurityReceiver) receiveQueue(ctx context.Context) {
+	defer r.wg.Done()
+
+	for ctx.Err() == nil {
+		if err := r.receiveMessages(ctx); err != nil && ctx.Err() == nil {
+			r.settings.Logger.Warn("failed to receive queue messages", zap.Error(err))
+			select {
+			case <-ctx.Done():
+			case <-time.After(sqsRetryDelay):
+			}
+		}
+	}
+}
+
+type receiveMessageRequest struct {
+	QueueURL            string `json:"QueueUrl"`
+	MaxNumberOfMessages int    `json:"MaxNumberOfMessages"`
+	WaitTimeSeconds     int    `json:"WaitTimeSeconds"`
+}
+
+type receiveMessageResponse struct {
+	Messages []struct {
+		MessageID     string `json:"MessageId"`
+		ReceiptHandle string `json:"ReceiptHandle"`
+		Body          string `json:"Body"`
+	} `json:"Messages"`
+}
+
+type deleteMessageBatchRequest struct {
+	QueueURL string               `json:"QueueUrl"`
+	Entries  []deleteMessageEntry `json:"Entries"`
+}
+
+type deleteMessageEntry struct {
+	ID            string `json:"Id"`
+	ReceiptHandle string `json:"ReceiptHandle"`
+}
+
+type deleteMessageBatchResponse struct {
+	Failed []struct {
+		ID      string `json:"Id"`
+		Message string `json:"Message"`
+	} `json:"Failed"`
+}
+
+// receiveMessages converts one batch of queue messages and deletes them once
+// the pipeline accepted the records. Messages without compliance evidence are
+// deleted as well. On pipeline failure nothing is deleted, and SQS redelivers
+// the batch after its visibility timeout.
+func (r *awsSecurityReceiver) receiveMessages(ctx context.Context) error {
+	var resp receiveMessageResponse
+	err := callJSON(ctx, r.sqsClient, r.sqsURL(), "application/x-amz-json-1.0", sqsReceiveTarget, receiveMessageRequest{
+		QueueURL:            r.cfg.SQS.QueueURL,
+		MaxNumberOfMessages: sqsMaxMessages,
+		WaitTimeSeconds:     int(r.cfg.SQS.WaitTime / time.Second),
+	}, &resp)
+	if err != nil {
+		return fmt.Errorf("ReceiveMessage: %w", err)
+	}
+	if len(resp.Messages) == 0 {
+		return nil
+	}
+
+	observed := r.now()
+	logs := plog.NewLogs()
+	entries := make([]deleteMessageEntry, 0, len(resp.Messages))
+	for i, msg := range resp.Messages {
+		msgLogs := plog.NewLogs()
+		if err := appendMessage(msgLogs, msg.Body, observed); err != nil {
+			r.settings.Logger.Warn("discarding queue message", zap.String("message_id", msg.MessageID), zap.Error(err))
+		} else {
+			msgLogs.ResourceLogs().MoveAndAppendTo(logs.ResourceLogs())
+		}
+		entries = append(entries, deleteMessageEntry{ID: strconv.Itoa(i), ReceiptHandle: msg.ReceiptHandle})
+	}
+
+	if logs.LogRecordCount() > 0 {
+		if err := r.next.ConsumeLogs(ctx, logs); err != nil {
+			return err
+		}
+	}
+
+	var deleted deleteMessageBatchResponse
+	err = callJSON(ctx, r.sqsClient, r.sqsURL(), "application/x-amz-json-1.0", sqsDeleteTarget, deleteMessageBatchRequest{
+		QueueURL: r.cfg.SQS.QueueURL,
+		Entries:  entries,
+	}, &deleted)
+	if err != nil {
+		return fmt.Errorf("DeleteMessageBatch: %w", err)
+	}
+	if len(deleted.Failed) > 0 {
+		r.settings.Logger.Warn("failed to delete queue messages; they will be redelivered",
+			zap.Int("messages", len(deleted.Failed)), zap.String("reason", deleted.Failed[0].Message))
+	}
+	return nil
+}
+
+func (r *awsSecurityReceiver) sqsURL() string {
+	return strings.TrimSuffix(r.cfg.SQS.endpoint(), "/") + "/"
+}
+
+// apiError is the error body of AWS JSON protocol responses.
+type apiError struct {
+	Type    string `json:"__type"`
+	Message string `json:"message"`
+}
+
+// callJSON POSTs a JSON request to an AWS JSON API and decodes the response.
+func callJSON(ctx context.Context, client *http.Client, url, contentType, target string, in, out any) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	if target != "" {
+		req.Header.Set("X-Amz-Target", target)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiErr apiError
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("%s: %s: %s", resp.Status, apiErr.Type, apiErr.Message)
+		}
+		return errors.New(resp.Status)
+	}
+	return json.Unmarshal(data, out)
+}
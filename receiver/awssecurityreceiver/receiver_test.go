@@ -0,0 +1,204 @@
+package awssecurityreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+// newSecurityHubServer serves pages of findings from GetFindings, chained by
+// NextToken, and records the requests it received.
+func newSecurityHubServer(t *testing.T, pages [][]json.RawMessage) (*httptest.Server, *[]getFindingsRequest) {
+	t.Helper()
+	var mu sync.Mutex
+	var requests []getFindingsRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/findings", req.URL.Path)
+		var body getFindingsRequest
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		mu.Lock()
+		requests = append(requests, body)
+		mu.Unlock()
+
+		page := 0
+		if body.NextToken != "" {
+			page = int(body.NextToken[0] - '0')
+		}
+		resp := getFindingsResponse{}
+		if page < len(pages) {
+			resp.Findings = pages[page]
+		}
+		if page+1 < len(pages) {
+			resp.NextToken = string(rune('0' + page + 1))
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func newTestReceiver(t *testing.T, cfg *Config, next consumer.Logs) *awsSecurityReceiver {
+	t.Helper()
+	r := newAWSSecurityReceiver(cfg, receivertest.NewNopSettings(componentType), next)
+	r.now = func() time.Time { return observed }
+	return r
+}
+
+func TestPollFindings(t *testing.T) {
+	finding := readTestdata(t, "finding.json")
+	server, requests := newSecurityHubServer(t, [][]json.RawMessage{{finding}, {finding, finding}})
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.SQS = nil
+	cfg.SecurityHub.Endpoint = server.URL
+	sink := new(consumertest.LogsSink)
+	r := newTestReceiver(t, cfg, sink)
+	r.hubClient = server.Client()
+	r.since = observed.Add(-time.Hour)
+
+	require.NoError(t, r.pollFindings(context.Background()))
+	assert.Equal(t, 3, sink.LogRecordCount())
+	assert.Equal(t, observed, r.since)
+
+	require.Len(t, *requests, 2)
+	first := (*requests)[0]
+	assert.Equal(t, "2026-06-02T09:00:00Z", first.Filters.UpdatedAt[0].Start)
+	assert.Equal(t, "2026-06-02T10:00:00Z", first.Filters.UpdatedAt[0].End)
+	assert.Equal(t, []stringFilter{{Value: "ACTIVE", Comparison: "EQUALS"}}, first.Filters.RecordState)
+	assert.Equal(t, "1", (*requests)[1].NextToken)
+}
+
+func TestPollFindingsKeepsWindowOnFailure(t *testing.T) {
+	server, _ := newSecurityHubServer(t, [][]json.RawMessage{{readTestdata(t, "finding.json")}})
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.SecurityHub.Endpoint = server.URL
+	r := newTestReceiver(t, cfg, consumertest.NewErr(errors.New("pipeline unavailable")))
+	r.hubClient = server.Client()
+	since := observed.Add(-time.Hour)
+	r.since = since
+
+	assert.Error(t, r.pollFindings(context.Background()))
+	assert.Equal(t, since, r.since)
+}
+
+func TestPollFindingsAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"__type": "AccessDeniedException", "message": "not subscribed"}`))
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.SecurityHub.Endpoint = server.URL
+	r := newTestReceiver(t, cfg, consumertest.NewNop())
+	r.hubClient = server.Client()
+
+	assert.EqualError(t, r.pollFindings(context.Background()), "GetFindings: 403 Forbidden: AccessDeniedException: not subscribed")
+}
+
+// fakeQueue implements ReceiveMessage and DeleteMessageBatch over a fixed set of messages.
+type fakeQueue struct {
+	mu       sync.Mutex
+	messages []string
+	deleted  []string
+}
+
+func (q *fakeQueue) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	switch req.Header.Get("X-Amz-Target") {
+	case sqsReceiveTarget:
+		resp := map[string]any{}
+		var messages []map[string]string
+		for i, m := range q.messages {
+			messages = append(messages, map[string]string{
+				"MessageId":     string(rune('a' + i)),
+				"ReceiptHandle": "handle-" + string(rune('a'+i)),
+				"Body":          m,
+			})
+		}
+		resp["Messages"] = messages
+		_ = json.NewEncoder(w).Encode(resp)
+	case sqsDeleteTarget:
+		var body deleteMessageBatchRequest
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		for _, e := range body.Entries {
+			q.deleted = append(q.deleted, e.ReceiptHandle)
+		}
+		q.messages = nil
+		_, _ = w.Write([]byte(`{"Successful": [], "Failed": []}`))
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func TestReceiveMessages(t *testing.T) {
+	queue := &fakeQueue{messages: []string{
+		eventBridge(t, readTestdata(t, "finding.json")),
+		string(readTestdata(t, "config-compliance-change.json")),
+		`{"detail-type": "EC2 Instance State-change Notification", "detail": {}}`,
+	}}
+	server := httptest.NewServer(queue)
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.SecurityHub = nil
+	cfg.SQS.QueueURL = server.URL + "/123456789012/findings"
+	sink := new(consumertest.LogsSink)
+	r := newTestReceiver(t, cfg, sink)
+	r.sqsClient = server.Client()
+
+	require.NoError(t, r.receiveMessages(context.Background()))
+	assert.Equal(t, 2, sink.LogRecordCount())
+	// The unsupported event is deleted too, so it is not redelivered.
+	assert.Equal(t, []string{"handle-a", "handle-b", "handle-c"}, queue.deleted)
+}
+
+func TestReceiveMessagesKeepsMessagesOnFailure(t *testing.T) {
+	queue := &fakeQueue{messages: []string{eventBridge(t, readTestdata(t, "finding.json"))}}
+	server := httptest.NewServer(queue)
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.SQS.QueueURL = server.URL + "/123456789012/findings"
+	r := newTestReceiver(t, cfg, consumertest.NewErr(errors.New("pipeline unavailable")))
+	r.sqsClient = server.Client()
+
+	assert.Error(t, r.receiveMessages(context.Background()))
+	assert.Empty(t, queue.deleted)
+}
+
+func TestReceiverReceivesFromQueue(t *testing.T) {
+	queue := &fakeQueue{}
+	server := httptest.NewServer(queue)
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.SecurityHub = nil
+	cfg.SQS.QueueURL = server.URL + "/123456789012/findings"
+	cfg.SQS.WaitTime = 0
+	sink := new(consumertest.LogsSink)
+	r := newTestReceiver(t, cfg, sink)
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	queue.mu.Lock()
+	queue.messages = []string{string(readTestdata(t, "config-compliance-change.json"))}
+	queue.mu.Unlock()
+
+	assert.Eventually(t, func() bool { return sink.LogRecordCount() > 0 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, r.Shutdown(context.Background()))
+}
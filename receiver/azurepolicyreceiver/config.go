@@ -0,0 +1,83 @@
+package azurepolicyreceiver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+const (
+	defaultEndpoint        = "https://management.azure.com"
+	defaultAPIVersion      = "2019-10-01"
+	defaultPollInterval    = time.Hour
+	defaultInitialLookback = 24 * time.Hour
+
+	subscriptionPrefix    = "subscriptions/"
+	managementGroupPrefix = "providers/Microsoft.Management/managementGroups/"
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines the configuration for the Azure Policy receiver. Requests are
+// expected to carry an Azure Resource Manager token from an authenticator
+// extension such as oauth2client.
+type Config struct {
+	confighttp.ClientConfig `mapstructure:",squash"`
+
+	// Scopes lists the subscriptions, resource groups or management groups whose
+	// policy states are queried, as resource paths such as
+	// "subscriptions/<id>" or "providers/Microsoft.Management/managementGroups/<name>".
+	Scopes []string `mapstructure:"scopes"`
+
+	// Filter is an optional OData filter applied to the policy states.
+	Filter string `mapstructure:"filter"`
+
+	// PollInterval is how often states evaluated since the previous poll are fetched.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+
+	// InitialLookback is how far back the first poll reaches.
+	InitialLookback time.Duration `mapstructure:"initial_lookback"`
+
+	// APIVersion is the Policy Insights API version.
+	APIVersion string `mapstructure:"api_version"`
+}
+
+func createDefaultConfig() component.Config {
+	httpCfg := confighttp.NewDefaultClientConfig()
+	httpCfg.Endpoint = defaultEndpoint
+	return &Config{
+		ClientConfig:    httpCfg,
+		PollInterval:    defaultPollInterval,
+		InitialLookback: defaultInitialLookback,
+		APIVersion:      defaultAPIVersion,
+	}
+}
+
+// Validate checks the receiver configuration is valid.
+func (c *Config) Validate() error {
+	if c.Endpoint == "" {
+		return errors.New("endpoint must not be empty")
+	}
+	if len(c.Scopes) == 0 {
+		return errors.New("scopes must not be empty")
+	}
+	for _, scope := range c.Scopes {
+		if !strings.HasPrefix(scope, subscriptionPrefix) && !strings.HasPrefix(scope, managementGroupPrefix) {
+			return fmt.Errorf("scope %q must start with %q or %q", scope, subscriptionPrefix, managementGroupPrefix)
+		}
+	}
+	if c.PollInterval <= 0 {
+		return errors.New("poll_interval must be positive")
+	}
+	if c.InitialLookback < 0 {
+		return errors.New("initial_lookback must not be negative")
+	}
+	if c.APIVersion == "" {
+		return errors.New("api_version must not be empty")
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package azurepolicyreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id    component.ID
+		check func(t *testing.T, cfg *Config)
+	}{
+		{
+			id: component.NewID(componentType),
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, defaultEndpoint, cfg.Endpoint)
+				assert.Equal(t, []string{"subscriptions/00000000-0000-0000-0000-000000000001"}, cfg.Scopes)
+				assert.Empty(t, cfg.Filter)
+				assert.Equal(t, defaultPollInterval, cfg.PollInterval)
+				assert.Equal(t, defaultInitialLookback, cfg.InitialLookback)
+				assert.Equal(t, defaultAPIVersion, cfg.APIVersion)
+			},
+		},
+		{
+			id: component.NewIDWithName(componentType, "filtered"),
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, []string{
+					"subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/payments",
+					"providers/Microsoft.Management/managementGroups/production",
+				}, cfg.Scopes)
+				assert.Equal(t, "complianceState eq 'NonCompliant'", cfg.Filter)
+				assert.Equal(t, 6*time.Hour, cfg.PollInterval)
+				assert.Equal(t, 72*time.Hour, cfg.InitialLookback)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, sub.Unmarshal(cfg))
+			require.NoError(t, cfg.Validate())
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "empty endpoint",
+			mutate:  func(cfg *Config) { cfg.Endpoint = "" },
+			wantErr: "endpoint must not be empty",
+		},
+		{
+			name:    "no scopes",
+			mutate:  func(cfg *Config) { cfg.Scopes = nil },
+			wantErr: "scopes must not be empty",
+		},
+		{
+			name:    "invalid scope",
+			mutate:  func(cfg *Config) { cfg.Scopes = []string{"/subscriptions/1"} },
+			wantErr: `scope "/subscriptions/1" must start with "subscriptions/" or "providers/Microsoft.Management/managementGroups/"`,
+		},
+		{
+			name:    "zero poll interval",
+			mutate:  func(cfg *Config) { cfg.PollInterval = 0 },
+			wantErr: "poll_interval must be positive",
+		},
+		{
+			name:    "negative lookback",
+			mutate:  func(cfg *Config) { cfg.InitialLookback = -time.Hour },
+			wantErr: "initial_lookback must not be negative",
+		},
+		{
+			name:    "empty api version",
+			mutate:  func(cfg *Config) { cfg.APIVersion = "" },
+			wantErr: "api_version must not be empty",
+		},
+		{
+			name:   "valid",
+			mutate: func(*Config) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Scopes = []string{"subscriptions/00000000-0000-0000-0000-000000000001"}
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
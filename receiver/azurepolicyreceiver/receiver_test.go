@@ -0,0 +1,112 @@
+package azurepolicyreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+const testScope = "subscriptions/00000000-0000-0000-0000-000000000001"
+
+// newPolicyInsightsServer serves pages of policy states chained by
+// @odata.nextLink and records the query of every request.
+func newPolicyInsightsServer(t *testing.T, pages [][]json.RawMessage) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		page := 0
+		if token := req.URL.Query().Get("$skiptoken"); token != "" {
+			page = int(token[0] - '0')
+		}
+		resp := queryResultsResponse{}
+		if page < len(pages) {
+			resp.Value = pages[page]
+		}
+		if page+1 < len(pages) {
+			next := *req.URL
+			query := next.Query()
+			query.Set("$skiptoken", string(rune('0'+page+1)))
+			next.RawQuery = query.Encode()
+			resp.NextLink = server.URL + next.String()
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestReceiver(t *testing.T, endpoint string, next consumer.Logs) *azurePolicyReceiver {
+	t.Helper()
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.Scopes = []string{testScope}
+	r := newAzurePolicyReceiver(cfg, receivertest.NewNopSettings(componentType), next)
+	r.now = func() time.Time { return observed }
+	r.client = http.DefaultClient
+	r.since[testScope] = observed.Add(-time.Hour)
+	return r
+}
+
+func TestPollScope(t *testing.T) {
+	states := readStates(t)
+	var mu sync.Mutex
+	var queries []url.Values
+	pages := newPolicyInsightsServer(t, [][]json.RawMessage{states[:1], states[1:]})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/"+testScope+"/providers/Microsoft.PolicyInsights/policyStates/latest/queryResults", req.URL.Path)
+		mu.Lock()
+		queries = append(queries, req.URL.Query())
+		mu.Unlock()
+		pages.Config.Handler.ServeHTTP(w, req)
+	}))
+	defer server.Close()
+
+	sink := new(consumertest.LogsSink)
+	r := newTestReceiver(t, server.URL, sink)
+	r.cfg.Filter = "complianceState eq 'NonCompliant'"
+
+	require.NoError(t, r.pollScope(context.Background(), testScope))
+	assert.Equal(t, 2, sink.LogRecordCount())
+	assert.Equal(t, observed, r.since[testScope])
+
+	require.NotEmpty(t, queries)
+	first := queries[0]
+	assert.Equal(t, defaultAPIVersion, first.Get("api-version"))
+	assert.Equal(t, "2026-06-02T09:00:00Z", first.Get("$from"))
+	assert.Equal(t, "2026-06-02T10:00:00Z", first.Get("$to"))
+	assert.Equal(t, "complianceState eq 'NonCompliant'", first.Get("$filter"))
+}
+
+func TestPollScopeKeepsWindowOnFailure(t *testing.T) {
+	server := newPolicyInsightsServer(t, [][]json.RawMessage{readStates(t)})
+	r := newTestReceiver(t, server.URL, consumertest.NewErr(errors.New("pipeline unavailable")))
+	since := r.since[testScope]
+
+	assert.Error(t, r.pollScope(context.Background(), testScope))
+	assert.Equal(t, since, r.since[testScope])
+}
+
+func TestPollScopeAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error": {"code": "AuthorizationFailed", "message": "no read access"}}`))
+	}))
+	defer server.Close()
+	r := newTestReceiver(t, server.URL, consumertest.NewNop())
+
+	assert.EqualError(t, r.pollScope(context.Background(), testScope),
+		"query policy states: 403 Forbidden: AuthorizationFailed: no read access")
+}
@@ -0,0 +1,135 @@
+package azurepolicyreceiver
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+const (
+	scopeName  = "github.com/complytime/complybeacon/receiver/azurepolicyreceiver"
+	engineName = "Azure Policy"
+
+	cloudProvider  = "cloud.provider"
+	cloudAccountID = "cloud.account.id"
+)
+
+// policyState is the subset of a Policy Insights policy state used to build evidence.
+type policyState struct {
+	Timestamp                   time.Time `json:"timestamp"`
+	ResourceID                  string    `json:"resourceId"`
+	ResourceType                string    `json:"resourceType"`
+	SubscriptionID              string    `json:"subscriptionId"`
+	PolicyAssignmentID          string    `json:"policyAssignmentId"`
+	PolicyDefinitionID          string    `json:"policyDefinitionId"`
+	PolicyDefinitionName        string    `json:"policyDefinitionName"`
+	PolicyDefinitionReferenceID string    `json:"policyDefinitionReferenceId"`
+	PolicyDefinitionGroupNames  []string  `json:"policyDefinitionGroupNames"`
+	IsCompliant                 *bool     `json:"isCompliant"`
+	ComplianceState             string    `json:"complianceState"`
+}
+
+// appendStates converts raw policy states into log records, with one resource
+// per subscription.
+func appendStates(logs plog.Logs, raw []json.RawMessage, observed time.Time) error {
+	scopes := map[string]plog.ScopeLogs{}
+	for i, data := range raw {
+		var state policyState
+		if err := json.Unmarshal(data, &state); err != nil {
+			return fmt.Errorf("policy state %d: %w", i, err)
+		}
+		var body map[string]any
+		if err := json.Unmarshal(data, &body); err != nil {
+			return fmt.Errorf("policy state %d: %w", i, err)
+		}
+
+		sl, ok := scopes[state.SubscriptionID]
+		if !ok {
+			rl := logs.ResourceLogs().AppendEmpty()
+			rl.Resource().Attributes().PutStr(cloudProvider, "azure")
+			if state.SubscriptionID != "" {
+				rl.Resource().Attributes().PutStr(cloudAccountID, state.SubscriptionID)
+			}
+			sl = rl.ScopeLogs().AppendEmpty()
+			sl.Scope().SetName(scopeName)
+			scopes[state.SubscriptionID] = sl
+		}
+		appendState(sl.LogRecords(), state, body, observed)
+	}
+	return nil
+}
+
+func appendState(records plog.LogRecordSlice, state policyState, body map[string]any, observed time.Time) {
+	record := records.AppendEmpty()
+	record.SetObservedTimestamp(pcommon.NewTimestampFromTime(observed))
+	timestamp := state.Timestamp
+	if timestamp.IsZero() {
+		timestamp = observed
+	}
+	record.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
+	record.SetSeverityNumber(plog.SeverityNumberInfo)
+	record.SetSeverityText(plog.SeverityNumberInfo.String())
+	_ = record.Body().SetEmptyMap().FromRaw(body)
+
+	ruleName := state.PolicyDefinitionReferenceID
+	if ruleName == "" {
+		ruleName = state.PolicyDefinitionName
+	}
+	result, exempt := mapComplianceState(state)
+
+	attrs := record.Attributes()
+	attrs.PutStr(proofwatch.POLICY_ENGINE_NAME, engineName)
+	attrs.PutStr(proofwatch.POLICY_RULE_ID, state.PolicyDefinitionID)
+	if ruleName != "" {
+		attrs.PutStr(proofwatch.POLICY_RULE_NAME, ruleName)
+	}
+	attrs.PutStr(proofwatch.POLICY_EVALUATION_RESULT, result)
+	attrs.PutStr(proofwatch.POLICY_TARGET_ID, state.ResourceID)
+	attrs.PutStr(proofwatch.POLICY_TARGET_NAME, resourceName(state.ResourceID))
+	if state.ResourceType != "" {
+		attrs.PutStr(proofwatch.POLICY_TARGET_TYPE, state.ResourceType)
+	}
+	if len(state.PolicyDefinitionGroupNames) > 0 {
+		requirements := attrs.PutEmptySlice(proofwatch.COMPLIANCE_REQUIREMENTS)
+		for _, group := range state.PolicyDefinitionGroupNames {
+			requirements.AppendEmpty().SetStr(group)
+		}
+	}
+	if exempt {
+		attrs.PutBool(proofwatch.COMPLIANCE_REMEDIATION_EXCEPTION_ACTIVE, true)
+	}
+}
+
+// mapComplianceState maps a policy state to policy.evaluation.result, and
+// reports whether the resource is exempt from the policy.
+func mapComplianceState(state policyState) (string, bool) {
+	switch state.ComplianceState {
+	case "Compliant":
+		return "Passed", false
+	case "NonCompliant":
+		return "Failed", false
+	case "Exempt":
+		return "Not Applicable", true
+	case "":
+		// Older API versions only report isCompliant.
+		if state.IsCompliant != nil {
+			if *state.IsCompliant {
+				return "Passed", false
+			}
+			return "Failed", false
+		}
+	}
+	// Unknown, Conflicting, Error
+	return "Unknown", false
+}
+
+// resourceName returns the last segment of an Azure resource ID.
+func resourceName(resourceID string) string {
+	return resourceID[strings.LastIndex(resourceID, "/")+1:]
+}
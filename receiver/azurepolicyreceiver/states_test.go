@@ -0,0 +1,95 @@
+package azurepolicyreceiver
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+var observed = time.Date(2026, 6, 2, 10, 0, 0, 0, time.UTC)
+
+func readStates(t *testing.T) []json.RawMessage {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "policy-states.json"))
+	require.NoError(t, err)
+	var states []json.RawMessage
+	require.NoError(t, json.Unmarshal(data, &states))
+	return states
+}
+
+func TestAppendStates(t *testing.T) {
+	logs := plog.NewLogs()
+	require.NoError(t, appendStates(logs, readStates(t), observed))
+	require.Equal(t, 2, logs.LogRecordCount())
+	require.Equal(t, 2, logs.ResourceLogs().Len(), "one resource per subscription")
+
+	rl := logs.ResourceLogs().At(0)
+	assert.Equal(t, map[string]any{
+		cloudProvider:  "azure",
+		cloudAccountID: "00000000-0000-0000-0000-000000000001",
+	}, rl.Resource().Attributes().AsRaw())
+	assert.Equal(t, scopeName, rl.ScopeLogs().At(0).Scope().Name())
+
+	record := rl.ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, time.Date(2026, 6, 2, 7, 45, 12, 500000000, time.UTC), record.Timestamp().AsTime())
+	assert.Equal(t, map[string]any{
+		proofwatch.POLICY_ENGINE_NAME:       engineName,
+		proofwatch.POLICY_RULE_ID:           "/providers/Microsoft.Authorization/policyDefinitions/404c3081-a854-4457-ae30-26a93ef643f9",
+		proofwatch.POLICY_RULE_NAME:         "SecureTransferToStorageAccountMonitoring",
+		proofwatch.POLICY_EVALUATION_RESULT: "Failed",
+		proofwatch.POLICY_TARGET_ID:         "/subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/payments/providers/Microsoft.Storage/storageAccounts/paymentslogs",
+		proofwatch.POLICY_TARGET_NAME:       "paymentslogs",
+		proofwatch.POLICY_TARGET_TYPE:       "Microsoft.Storage/storageAccounts",
+		proofwatch.COMPLIANCE_REQUIREMENTS:  []any{"NIST_SP_800-53_R5_SC-8", "NIST_SP_800-53_R5_SC-8(1)"},
+	}, record.Attributes().AsRaw())
+	assignment, ok := record.Body().Map().Get("policyAssignmentName")
+	require.True(t, ok)
+	assert.Equal(t, "nist-800-53", assignment.Str())
+
+	compliant := logs.ResourceLogs().At(1).ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw()
+	assert.Equal(t, "Passed", compliant[proofwatch.POLICY_EVALUATION_RESULT])
+	assert.Equal(t, "a4af4a39-4135-47fb-b175-47fbdf85311d", compliant[proofwatch.POLICY_RULE_NAME])
+}
+
+func TestMapComplianceState(t *testing.T) {
+	compliant, nonCompliant := true, false
+	tests := []struct {
+		state      policyState
+		wantResult string
+		wantExempt bool
+	}{
+		{state: policyState{ComplianceState: "Compliant"}, wantResult: "Passed"},
+		{state: policyState{ComplianceState: "NonCompliant"}, wantResult: "Failed"},
+		{state: policyState{ComplianceState: "Exempt"}, wantResult: "Not Applicable", wantExempt: true},
+		{state: policyState{ComplianceState: "Conflicting"}, wantResult: "Unknown"},
+		{state: policyState{IsCompliant: &compliant}, wantResult: "Passed"},
+		{state: policyState{IsCompliant: &nonCompliant}, wantResult: "Failed"},
+		{state: policyState{}, wantResult: "Unknown"},
+	}
+
+	for _, tt := range tests {
+		result, exempt := mapComplianceState(tt.state)
+		assert.Equal(t, tt.wantResult, result)
+		assert.Equal(t, tt.wantExempt, exempt)
+	}
+}
+
+func TestAppendStatesExempt(t *testing.T) {
+	logs := plog.NewLogs()
+	require.NoError(t, appendStates(logs, []json.RawMessage{
+		json.RawMessage(`{"resourceId": "/subscriptions/1/resourceGroups/rg", "complianceState": "Exempt"}`),
+	}, observed))
+
+	attrs := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw()
+	assert.Equal(t, "Not Applicable", attrs[proofwatch.POLICY_EVALUATION_RESULT])
+	assert.Equal(t, true, attrs[proofwatch.COMPLIANCE_REMEDIATION_EXCEPTION_ACTIVE])
+	assert.Equal(t, "rg", attrs[proofwatch.POLICY_TARGET_NAME])
+}
@@ -0,0 +1,96 @@
+package ciwebhookreceiver
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+const (
+	defaultEndpoint   = "localhost:8093"
+	defaultGitHubPath = "/github"
+	defaultGitLabPath = "/gitlab"
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines the configuration for the CI webhook receiver.
+type Config struct {
+	confighttp.ServerConfig `mapstructure:",squash"`
+
+	// GitHub configures the endpoint for GitHub check_run webhooks.
+	GitHub GitHubConfig `mapstructure:"github"`
+
+	// GitLab configures the endpoint for GitLab job webhooks.
+	GitLab GitLabConfig `mapstructure:"gitlab"`
+
+	// Include is a regular expression selecting the check runs and jobs
+	// reported as evidence by name. Empty reports every one.
+	Include string `mapstructure:"include"`
+}
+
+// GitHubConfig configures the GitHub webhook endpoint.
+type GitHubConfig struct {
+	// Path is the URL path that accepts GitHub webhooks. Empty disables it.
+	Path string `mapstructure:"path"`
+
+	// Secret is the webhook secret deliveries are signed with.
+	Secret configopaque.String `mapstructure:"secret"`
+}
+
+// GitLabConfig configures the GitLab webhook endpoint.
+type GitLabConfig struct {
+	// Path is the URL path that accepts GitLab webhooks. Empty disables it.
+	Path string `mapstructure:"path"`
+
+	// Token is the secret token GitLab sends with every delivery.
+	Token configopaque.String `mapstructure:"token"`
+}
+
+func createDefaultConfig() component.Config {
+	httpCfg := confighttp.NewDefaultServerConfig()
+	httpCfg.NetAddr.Endpoint = defaultEndpoint
+	return &Config{
+		ServerConfig: httpCfg,
+		GitHub:       GitHubConfig{Path: defaultGitHubPath},
+		GitLab:       GitLabConfig{Path: defaultGitLabPath},
+	}
+}
+
+// Validate checks the receiver configuration is valid.
+func (c *Config) Validate() error {
+	if c.NetAddr.Endpoint == "" {
+		return errors.New("endpoint must not be empty")
+	}
+	if c.GitHub.Path == "" && c.GitLab.Path == "" {
+		return errors.New("at least one of github.path and gitlab.path must be set")
+	}
+	if c.GitHub.Path != "" {
+		if !strings.HasPrefix(c.GitHub.Path, "/") {
+			return errors.New("github.path must start with /")
+		}
+		if c.GitHub.Secret == "" {
+			return errors.New("github.secret must not be empty")
+		}
+	}
+	if c.GitLab.Path != "" {
+		if !strings.HasPrefix(c.GitLab.Path, "/") {
+			return errors.New("gitlab.path must start with /")
+		}
+		if c.GitLab.Token == "" {
+			return errors.New("gitlab.token must not be empty")
+		}
+	}
+	if c.GitHub.Path != "" && c.GitHub.Path == c.GitLab.Path {
+		return errors.New("github.path and gitlab.path must differ")
+	}
+	if _, err := regexp.Compile(c.Include); err != nil {
+		return fmt.Errorf("invalid include pattern: %w", err)
+	}
+	return nil
+}
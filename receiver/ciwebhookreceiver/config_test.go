@@ -0,0 +1,132 @@
+package ciwebhookreceiver
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id             component.ID
+		wantEndpoint   string
+		wantGitHubPath string
+		wantGitLabPath string
+		wantInclude    string
+	}{
+		{
+			id:             component.NewID(componentType),
+			wantEndpoint:   defaultEndpoint,
+			wantGitHubPath: defaultGitHubPath,
+			wantGitLabPath: defaultGitLabPath,
+		},
+		{
+			id:             component.NewIDWithName(componentType, "github"),
+			wantEndpoint:   "0.0.0.0:9093",
+			wantGitHubPath: "/hooks/github",
+			wantInclude:    "^compliance",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, sub.Unmarshal(cfg))
+			require.NoError(t, cfg.Validate())
+
+			assert.Equal(t, tt.wantEndpoint, cfg.NetAddr.Endpoint)
+			assert.Equal(t, tt.wantGitHubPath, cfg.GitHub.Path)
+			assert.Equal(t, tt.wantGitLabPath, cfg.GitLab.Path)
+			assert.Equal(t, tt.wantInclude, cfg.Include)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "empty endpoint",
+			mutate:  func(cfg *Config) { cfg.NetAddr.Endpoint = "" },
+			wantErr: "endpoint must not be empty",
+		},
+		{
+			name: "no provider",
+			mutate: func(cfg *Config) {
+				cfg.GitHub.Path = ""
+				cfg.GitLab.Path = ""
+			},
+			wantErr: "at least one of github.path and gitlab.path must be set",
+		},
+		{
+			name:    "relative github path",
+			mutate:  func(cfg *Config) { cfg.GitHub.Path = "github" },
+			wantErr: "github.path must start with /",
+		},
+		{
+			name:    "missing github secret",
+			mutate:  func(cfg *Config) { cfg.GitHub.Secret = "" },
+			wantErr: "github.secret must not be empty",
+		},
+		{
+			name:    "relative gitlab path",
+			mutate:  func(cfg *Config) { cfg.GitLab.Path = "gitlab" },
+			wantErr: "gitlab.path must start with /",
+		},
+		{
+			name:    "missing gitlab token",
+			mutate:  func(cfg *Config) { cfg.GitLab.Token = "" },
+			wantErr: "gitlab.token must not be empty",
+		},
+		{
+			name:    "shared path",
+			mutate:  func(cfg *Config) { cfg.GitLab.Path = defaultGitHubPath },
+			wantErr: "github.path and gitlab.path must differ",
+		},
+		{
+			name:    "invalid include",
+			mutate:  func(cfg *Config) { cfg.Include = "(" },
+			wantErr: "invalid include pattern: error parsing regexp: missing closing ): `(`",
+		},
+		{
+			name: "gitlab disabled",
+			mutate: func(cfg *Config) {
+				cfg.GitLab.Path = ""
+				cfg.GitLab.Token = ""
+			},
+		},
+		{
+			name:   "valid",
+			mutate: func(*Config) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.GitHub.Secret = "github-secret"
+			cfg.GitLab.Token = "gitlab-token"
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package ciwebhookreceiver
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+const (
+	scopeName = "github.com/complytime/complybeacon/receiver/ciwebhookreceiver"
+
+	// targetType is written to policy.target.type: a job evaluates a commit
+	// of a repository.
+	targetType = "repository"
+)
+
+// errInvalidPayload wraps failures caused by the content of a delivery rather
+// than by the pipeline, so the delivery can be rejected instead of retried.
+var errInvalidPayload = errors.New("invalid webhook payload")
+
+// evaluation is the outcome of one finished CI job.
+type evaluation struct {
+	engineName   string
+	ruleID       string
+	ruleName     string
+	result       string
+	message      string
+	target       string
+	environment  string
+	assessmentID string
+	timestamp    time.Time
+	body         map[string]any
+}
+
+// newLogs wraps a single evaluation into its log record.
+func newLogs(e evaluation, observed time.Time) plog.Logs {
+	logs := plog.NewLogs()
+	sl := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	sl.Scope().SetName(scopeName)
+
+	record := sl.LogRecords().AppendEmpty()
+	record.SetObservedTimestamp(pcommon.NewTimestampFromTime(observed))
+	record.SetTimestamp(pcommon.NewTimestampFromTime(e.timestamp))
+	record.SetSeverityNumber(plog.SeverityNumberInfo)
+	record.SetSeverityText(plog.SeverityNumberInfo.String())
+	_ = record.Body().SetEmptyMap().FromRaw(e.body)
+
+	attrs := record.Attributes()
+	attrs.PutStr(proofwatch.POLICY_ENGINE_NAME, e.engineName)
+	attrs.PutStr(proofwatch.POLICY_RULE_ID, e.ruleID)
+	putStr(attrs, proofwatch.POLICY_RULE_NAME, e.ruleName)
+	attrs.PutStr(proofwatch.POLICY_EVALUATION_RESULT, e.result)
+	putStr(attrs, proofwatch.POLICY_EVALUATION_MESSAGE, e.message)
+	putStr(attrs, proofwatch.POLICY_TARGET_ID, e.target)
+	putStr(attrs, proofwatch.POLICY_TARGET_NAME, e.target)
+	attrs.PutStr(proofwatch.POLICY_TARGET_TYPE, targetType)
+	putStr(attrs, proofwatch.POLICY_TARGET_ENVIRONMENT, e.environment)
+	putStr(attrs, proofwatch.COMPLIANCE_ASSESSMENT_ID, e.assessmentID)
+	return logs
+}
+
+// parseTime parses an RFC 3339 timestamp, or one in the format of GitLab job
+// events, falling back to the given time.
+func parseTime(value string, fallback time.Time) time.Time {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05 MST"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return fallback
+}
+
+func putStr(attrs pcommon.Map, key, value string) {
+	if value != "" {
+		attrs.PutStr(key, value)
+	}
+}
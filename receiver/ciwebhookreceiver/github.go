@@ -0,0 +1,119 @@
+package ciwebhookreceiver
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// defaultGitHubEngineName is used when a check run names no GitHub App.
+const defaultGitHubEngineName = "GitHub Checks"
+
+// checkRunEvent is the subset of a GitHub check_run webhook payload used to
+// build evidence.
+type checkRunEvent struct {
+	Action   string `json:"action"`
+	CheckRun struct {
+		ID          int64  `json:"id"`
+		Name        string `json:"name"`
+		HeadSHA     string `json:"head_sha"`
+		Status      string `json:"status"`
+		Conclusion  string `json:"conclusion"`
+		StartedAt   string `json:"started_at"`
+		CompletedAt string `json:"completed_at"`
+		HTMLURL     string `json:"html_url"`
+		Output      struct {
+			Title   string `json:"title"`
+			Summary string `json:"summary"`
+		} `json:"output"`
+		App struct {
+			Name string `json:"name"`
+		} `json:"app"`
+		CheckSuite struct {
+			ID         int64  `json:"id"`
+			HeadBranch string `json:"head_branch"`
+		} `json:"check_suite"`
+	} `json:"check_run"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+// parseCheckRun turns a completed check run into a log record. Check runs
+// that are not completed or not included yield no records.
+func parseCheckRun(payload []byte, include *regexp.Regexp, observed time.Time) (plog.Logs, error) {
+	var event checkRunEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return plog.Logs{}, fmt.Errorf("%w: %w", errInvalidPayload, err)
+	}
+	run := event.CheckRun
+	if event.Action != "completed" || !include.MatchString(run.Name) {
+		return plog.NewLogs(), nil
+	}
+	if run.Name == "" {
+		return plog.Logs{}, fmt.Errorf("%w: check run has no name", errInvalidPayload)
+	}
+
+	engineName := run.App.Name
+	if engineName == "" {
+		engineName = defaultGitHubEngineName
+	}
+	message := strings.TrimSpace(run.Output.Title)
+	if message == "" {
+		message = strings.TrimSpace(run.Output.Summary)
+	}
+	return newLogs(evaluation{
+		engineName:   engineName,
+		ruleID:       run.Name,
+		ruleName:     run.Name,
+		result:       mapConclusion(run.Conclusion),
+		message:      message,
+		target:       event.Repository.FullName,
+		assessmentID: formatID(run.CheckSuite.ID),
+		timestamp:    parseTime(run.CompletedAt, observed),
+		body: map[string]any{
+			"provider":       "github",
+			"repository":     event.Repository.FullName,
+			"head_sha":       run.HeadSHA,
+			"head_branch":    run.CheckSuite.HeadBranch,
+			"check_run_id":   run.ID,
+			"check_suite_id": run.CheckSuite.ID,
+			"name":           run.Name,
+			"conclusion":     run.Conclusion,
+			"started_at":     run.StartedAt,
+			"completed_at":   run.CompletedAt,
+			"url":            run.HTMLURL,
+		},
+	}, observed), nil
+}
+
+// mapConclusion maps a check run conclusion to policy.evaluation.result.
+func mapConclusion(conclusion string) string {
+	switch conclusion {
+	case "success":
+		return "Passed"
+	case "failure":
+		return "Failed"
+	case "neutral":
+		return "Not Applicable"
+	case "action_required":
+		return "Needs Review"
+	case "cancelled", "skipped":
+		return "Not Run"
+	default:
+		// timed_out, stale
+		return "Unknown"
+	}
+}
+
+func formatID(id int64) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.FormatInt(id, 10)
+}
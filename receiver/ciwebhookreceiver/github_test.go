@@ -0,0 +1,85 @@
+package ciwebhookreceiver
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+var (
+	observed   = time.Date(2026, 6, 2, 10, 0, 0, 0, time.UTC)
+	includeAll = regexp.MustCompile("")
+)
+
+func TestParseCheckRun(t *testing.T) {
+	payload, err := os.ReadFile(filepath.Join("testdata", "check_run.json"))
+	require.NoError(t, err)
+
+	logs, err := parseCheckRun(payload, includeAll, observed)
+	require.NoError(t, err)
+	require.Equal(t, 1, logs.LogRecordCount())
+	assert.Equal(t, scopeName, logs.ResourceLogs().At(0).ScopeLogs().At(0).Scope().Name())
+
+	record := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, time.Date(2026, 6, 2, 8, 30, 0, 0, time.UTC), record.Timestamp().AsTime())
+	assert.Equal(t, observed, record.ObservedTimestamp().AsTime())
+	assert.Equal(t, map[string]any{
+		proofwatch.POLICY_ENGINE_NAME:        "GitHub Actions",
+		proofwatch.POLICY_RULE_ID:            "compliance / conftest",
+		proofwatch.POLICY_RULE_NAME:          "compliance / conftest",
+		proofwatch.POLICY_EVALUATION_RESULT:  "Failed",
+		proofwatch.POLICY_EVALUATION_MESSAGE: "2 policy violations",
+		proofwatch.POLICY_TARGET_ID:          "octo-org/payments",
+		proofwatch.POLICY_TARGET_NAME:        "octo-org/payments",
+		proofwatch.POLICY_TARGET_TYPE:        "repository",
+		proofwatch.COMPLIANCE_ASSESSMENT_ID:  "1185764906",
+	}, record.Attributes().AsRaw())
+
+	body := record.Body().Map().AsRaw()
+	assert.Equal(t, "github", body["provider"])
+	assert.Equal(t, "ec26c3e57ca3a959ca5aad62de7213c562f8c821", body["head_sha"])
+	assert.Equal(t, "main", body["head_branch"])
+	assert.Equal(t, int64(128620228), body["check_run_id"])
+	assert.Equal(t, "https://github.com/octo-org/payments/runs/128620228", body["url"])
+}
+
+func TestParseCheckRunSkipsExcluded(t *testing.T) {
+	payload, err := os.ReadFile(filepath.Join("testdata", "check_run.json"))
+	require.NoError(t, err)
+
+	logs, err := parseCheckRun(payload, regexp.MustCompile("^lint"), observed)
+	require.NoError(t, err)
+	assert.Zero(t, logs.LogRecordCount())
+}
+
+func TestParseCheckRunWithoutApp(t *testing.T) {
+	logs, err := parseCheckRun([]byte(`{"action": "completed", "check_run": {"name": "scan", "conclusion": "success"}}`), includeAll, observed)
+	require.NoError(t, err)
+
+	record := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, observed, record.Timestamp().AsTime())
+	engine, _ := record.Attributes().Get(proofwatch.POLICY_ENGINE_NAME)
+	assert.Equal(t, defaultGitHubEngineName, engine.Str())
+}
+
+func TestMapConclusion(t *testing.T) {
+	for conclusion, want := range map[string]string{
+		"success":         "Passed",
+		"failure":         "Failed",
+		"neutral":         "Not Applicable",
+		"action_required": "Needs Review",
+		"cancelled":       "Not Run",
+		"skipped":         "Not Run",
+		"timed_out":       "Unknown",
+		"stale":           "Unknown",
+	} {
+		assert.Equal(t, want, mapConclusion(conclusion), conclusion)
+	}
+}
@@ -0,0 +1,110 @@
+package ciwebhookreceiver
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// gitLabEngineName is written to policy.engine.name for GitLab jobs.
+const gitLabEngineName = "GitLab CI"
+
+// jobEvent is the subset of a GitLab job webhook payload used to build
+// evidence.
+type jobEvent struct {
+	ObjectKind         string `json:"object_kind"`
+	Ref                string `json:"ref"`
+	SHA                string `json:"sha"`
+	BuildID            int64  `json:"build_id"`
+	BuildName          string `json:"build_name"`
+	BuildStage         string `json:"build_stage"`
+	BuildStatus        string `json:"build_status"`
+	BuildStartedAt     string `json:"build_started_at"`
+	BuildFinishedAt    string `json:"build_finished_at"`
+	BuildFailureReason string `json:"build_failure_reason"`
+	BuildAllowFailure  bool   `json:"build_allow_failure"`
+	PipelineID         int64  `json:"pipeline_id"`
+	ProjectName        string `json:"project_name"`
+	Project            struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+		WebURL            string `json:"web_url"`
+	} `json:"project"`
+	Environment struct {
+		Name string `json:"name"`
+	} `json:"environment"`
+}
+
+// parseJob turns a finished job into a log record. Jobs that have not
+// finished or are not included yield no records.
+func parseJob(payload []byte, include *regexp.Regexp, observed time.Time) (plog.Logs, error) {
+	var event jobEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return plog.Logs{}, fmt.Errorf("%w: %w", errInvalidPayload, err)
+	}
+	result, finished := mapJobStatus(event.BuildStatus)
+	if event.ObjectKind != "build" || !finished || !include.MatchString(event.BuildName) {
+		return plog.NewLogs(), nil
+	}
+	if event.BuildName == "" {
+		return plog.Logs{}, fmt.Errorf("%w: job has no name", errInvalidPayload)
+	}
+
+	// Older GitLab versions send only the display name of the project.
+	project := event.Project.PathWithNamespace
+	if project == "" {
+		project = event.ProjectName
+	}
+	url := ""
+	if event.Project.WebURL != "" && event.BuildID != 0 {
+		url = fmt.Sprintf("%s/-/jobs/%d", event.Project.WebURL, event.BuildID)
+	}
+	message := ""
+	if event.BuildStatus == "failed" {
+		message = event.BuildFailureReason
+	}
+	return newLogs(evaluation{
+		engineName:   gitLabEngineName,
+		ruleID:       event.BuildName,
+		ruleName:     event.BuildName,
+		result:       result,
+		message:      message,
+		target:       project,
+		environment:  event.Environment.Name,
+		assessmentID: formatID(event.PipelineID),
+		timestamp:    parseTime(event.BuildFinishedAt, observed),
+		body: map[string]any{
+			"provider":      "gitlab",
+			"project":       project,
+			"sha":           event.SHA,
+			"ref":           event.Ref,
+			"job_id":        event.BuildID,
+			"pipeline_id":   event.PipelineID,
+			"name":          event.BuildName,
+			"stage":         event.BuildStage,
+			"status":        event.BuildStatus,
+			"allow_failure": event.BuildAllowFailure,
+			"started_at":    event.BuildStartedAt,
+			"finished_at":   event.BuildFinishedAt,
+			"url":           url,
+		},
+	}, observed), nil
+}
+
+// mapJobStatus maps a GitLab job status to policy.evaluation.result, and
+// reports whether the job has finished.
+func mapJobStatus(status string) (string, bool) {
+	switch status {
+	case "success":
+		return "Passed", true
+	case "failed":
+		return "Failed", true
+	case "canceled", "skipped":
+		return "Not Run", true
+	default:
+		// created, pending, running, manual
+		return "", false
+	}
+}
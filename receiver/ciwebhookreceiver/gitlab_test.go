@@ -0,0 +1,76 @@
+package ciwebhookreceiver
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+func TestParseJob(t *testing.T) {
+	payload, err := os.ReadFile(filepath.Join("testdata", "job.json"))
+	require.NoError(t, err)
+
+	logs, err := parseJob(payload, regexp.MustCompile("^compliance:"), observed)
+	require.NoError(t, err)
+	require.Equal(t, 1, logs.LogRecordCount())
+
+	record := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, time.Date(2026, 6, 2, 8, 30, 0, 0, time.UTC), record.Timestamp().AsTime())
+	assert.Equal(t, map[string]any{
+		proofwatch.POLICY_ENGINE_NAME:        "GitLab CI",
+		proofwatch.POLICY_RULE_ID:            "compliance:kyverno",
+		proofwatch.POLICY_RULE_NAME:          "compliance:kyverno",
+		proofwatch.POLICY_EVALUATION_RESULT:  "Failed",
+		proofwatch.POLICY_EVALUATION_MESSAGE: "script_failure",
+		proofwatch.POLICY_TARGET_ID:          "payments/api",
+		proofwatch.POLICY_TARGET_NAME:        "payments/api",
+		proofwatch.POLICY_TARGET_TYPE:        "repository",
+		proofwatch.POLICY_TARGET_ENVIRONMENT: "production",
+		proofwatch.COMPLIANCE_ASSESSMENT_ID:  "2366",
+	}, record.Attributes().AsRaw())
+
+	body := record.Body().Map().AsRaw()
+	assert.Equal(t, "gitlab", body["provider"])
+	assert.Equal(t, "95790bf891e76fee5e1747ab589903a6a1f80f22", body["sha"])
+	assert.Equal(t, "test", body["stage"])
+	assert.Equal(t, int64(1977), body["job_id"])
+	assert.Equal(t, "https://gitlab.example.com/payments/api/-/jobs/1977", body["url"])
+}
+
+func TestParseJobFallsBackToProjectName(t *testing.T) {
+	logs, err := parseJob([]byte(`{"object_kind": "build", "build_name": "scan", "build_status": "success", "project_name": "Payments / API"}`), includeAll, observed)
+	require.NoError(t, err)
+
+	attrs := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw()
+	assert.Equal(t, "Payments / API", attrs[proofwatch.POLICY_TARGET_ID])
+	assert.Equal(t, "Passed", attrs[proofwatch.POLICY_EVALUATION_RESULT])
+	assert.NotContains(t, attrs, proofwatch.POLICY_EVALUATION_MESSAGE)
+}
+
+func TestMapJobStatus(t *testing.T) {
+	tests := []struct {
+		status       string
+		want         string
+		wantFinished bool
+	}{
+		{status: "success", want: "Passed", wantFinished: true},
+		{status: "failed", want: "Failed", wantFinished: true},
+		{status: "canceled", want: "Not Run", wantFinished: true},
+		{status: "skipped", want: "Not Run", wantFinished: true},
+		{status: "running"},
+		{status: "manual"},
+	}
+
+	for _, tt := range tests {
+		got, finished := mapJobStatus(tt.status)
+		assert.Equal(t, tt.want, got, tt.status)
+		assert.Equal(t, tt.wantFinished, finished, tt.status)
+	}
+}
@@ -0,0 +1,199 @@
+package ciwebhookreceiver
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+)
+
+// defaultMaxPayloadBytes bounds the size of a single webhook delivery. GitHub
+// caps deliveries at 25 MB.
+const defaultMaxPayloadBytes = 25 << 20
+
+var _ receiver.Logs = (*ciWebhookReceiver)(nil)
+
+type ciWebhookReceiver struct {
+	cfg      *Config
+	settings receiver.Settings
+	next     consumer.Logs
+	include  *regexp.Regexp
+
+	server          *http.Server
+	maxPayloadBytes int64
+	wg              sync.WaitGroup
+}
+
+// webhook describes the deliveries of one CI provider.
+type webhook struct {
+	provider string
+	// eventHeader names the header carrying the event type, and event the
+	// type that reports job results. Other events are acknowledged and ignored.
+	eventHeader string
+	event       string
+	// authenticate reports whether the delivery was sent by the provider.
+	authenticate func(req *http.Request, payload []byte) bool
+	parse        func(payload []byte, include *regexp.Regexp, observed time.Time) (plog.Logs, error)
+}
+
+func newCIWebhookReceiver(cfg *Config, set receiver.Settings, next consumer.Logs) *ciWebhookReceiver {
+	return &ciWebhookReceiver{
+		cfg:      cfg,
+		settings: set,
+		next:     next,
+		// Validate has already checked the pattern compiles.
+		include:         regexp.MustCompile(cfg.Include),
+		maxPayloadBytes: defaultMaxPayloadBytes,
+	}
+}
+
+// Start begins accepting webhook deliveries.
+func (r *ciWebhookReceiver) Start(ctx context.Context, host component.Host) error {
+	listener, err := r.cfg.ToListener(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to bind to %s: %w", r.cfg.NetAddr.Endpoint, err)
+	}
+
+	mux := http.NewServeMux()
+	if r.cfg.GitHub.Path != "" {
+		mux.HandleFunc(r.cfg.GitHub.Path, r.handler(r.gitHubWebhook()))
+	}
+	if r.cfg.GitLab.Path != "" {
+		mux.HandleFunc(r.cfg.GitLab.Path, r.handler(r.gitLabWebhook()))
+	}
+
+	r.server, err = r.cfg.ToServer(ctx, host.GetExtensions(), r.settings.TelemetrySettings, mux)
+	if err != nil {
+		return err
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		if errHTTP := r.server.Serve(listener); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+			componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(errHTTP))
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the HTTP server.
+func (r *ciWebhookReceiver) Shutdown(ctx context.Context) error {
+	var err error
+	if r.server != nil {
+		err = r.server.Shutdown(ctx)
+	}
+	r.wg.Wait()
+	return err
+}
+
+func (r *ciWebhookReceiver) gitHubWebhook() webhook {
+	return webhook{
+		provider:    "GitHub",
+		eventHeader: "X-GitHub-Event",
+		event:       "check_run",
+		authenticate: func(req *http.Request, payload []byte) bool {
+			return validSignature(string(r.cfg.GitHub.Secret), req.Header.Get("X-Hub-Signature-256"), payload)
+		},
+		parse: parseCheckRun,
+	}
+}
+
+func (r *ciWebhookReceiver) gitLabWebhook() webhook {
+	return webhook{
+		provider:    "GitLab",
+		eventHeader: "X-Gitlab-Event",
+		event:       "Job Hook",
+		authenticate: func(req *http.Request, _ []byte) bool {
+			token := req.Header.Get("X-Gitlab-Token")
+			return subtle.ConstantTimeCompare([]byte(token), []byte(r.cfg.GitLab.Token)) == 1
+		},
+		parse: parseJob,
+	}
+}
+
+// handler answers 202 Accepted once the records of a finished job have been
+// handed to the pipeline, and 204 No Content for deliveries without one.
+func (r *ciWebhookReceiver) handler(h webhook) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		body := http.MaxBytesReader(w, req.Body, r.maxPayloadBytes)
+		defer body.Close()
+		payload, err := io.ReadAll(body)
+		if err != nil {
+			r.settings.Logger.Warn("rejected webhook delivery", zap.String("provider", h.provider), zap.Error(err))
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			http.Error(w, "failed to read payload", http.StatusBadRequest)
+			return
+		}
+		if !h.authenticate(req, payload) {
+			r.settings.Logger.Warn("rejected unauthenticated webhook delivery", zap.String("provider", h.provider))
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if req.Header.Get(h.eventHeader) != h.event {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		logs, err := h.parse(payload, r.include, time.Now())
+		if err == nil && logs.LogRecordCount() == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if err == nil {
+			err = r.next.ConsumeLogs(req.Context(), logs)
+		}
+		if err != nil {
+			r.settings.Logger.Warn("rejected webhook delivery", zap.String("provider", h.provider), zap.Error(err))
+			if errors.Is(err, errInvalidPayload) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			http.Error(w, "failed to process delivery", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+// validSignature checks a GitHub X-Hub-Signature-256 header, the hex
+// HMAC-SHA256 of the payload keyed with the webhook secret.
+func validSignature(secret, header string, payload []byte) bool {
+	signature, ok := strings.CutPrefix(header, "sha256=")
+	if !ok {
+		return false
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hmac.Equal(got, mac.Sum(nil))
+}
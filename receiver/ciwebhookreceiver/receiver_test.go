@@ -0,0 +1,213 @@
+package ciwebhookreceiver
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+const (
+	testSecret = "github-secret"
+	testToken  = "gitlab-token"
+
+	completedCheckRun = `{"action": "completed", "check_run": {"name": "compliance", "conclusion": "success"}, "repository": {"full_name": "octo-org/payments"}}`
+	finishedJob       = `{"object_kind": "build", "build_name": "compliance", "build_status": "success", "project_name": "payments"}`
+)
+
+func sign(payload string) string {
+	mac := hmac.New(sha256.New, []byte(testSecret))
+	mac.Write([]byte(payload))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newTestReceiver(next consumer.Logs) *ciWebhookReceiver {
+	cfg := createDefaultConfig().(*Config)
+	cfg.GitHub.Secret = testSecret
+	cfg.GitLab.Token = testToken
+	r := newCIWebhookReceiver(cfg, receivertest.NewNopSettings(componentType), next)
+	r.maxPayloadBytes = 512
+	return r
+}
+
+func TestGitHubHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		event      string
+		body       string
+		signature  string
+		unsigned   bool
+		next       consumer.Logs
+		wantStatus int
+		wantLogs   int
+	}{
+		{
+			name:       "non-POST is rejected",
+			method:     http.MethodGet,
+			next:       new(consumertest.LogsSink),
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "missing signature",
+			method:     http.MethodPost,
+			event:      "check_run",
+			body:       completedCheckRun,
+			unsigned:   true,
+			next:       new(consumertest.LogsSink),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "wrong signature",
+			method:     http.MethodPost,
+			event:      "check_run",
+			body:       completedCheckRun,
+			signature:  sign("other payload"),
+			next:       new(consumertest.LogsSink),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "oversized payload",
+			method:     http.MethodPost,
+			event:      "check_run",
+			body:       "{" + strings.Repeat(" ", 1024) + "}",
+			next:       new(consumertest.LogsSink),
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "other event is ignored",
+			method:     http.MethodPost,
+			event:      "ping",
+			body:       `{"zen": "Keep it logically awesome."}`,
+			next:       new(consumertest.LogsSink),
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "check run in progress is ignored",
+			method:     http.MethodPost,
+			event:      "check_run",
+			body:       `{"action": "created", "check_run": {"name": "compliance"}}`,
+			next:       new(consumertest.LogsSink),
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "invalid payload",
+			method:     http.MethodPost,
+			event:      "check_run",
+			body:       "not json",
+			next:       new(consumertest.LogsSink),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "pipeline failure",
+			method:     http.MethodPost,
+			event:      "check_run",
+			body:       completedCheckRun,
+			next:       consumertest.NewErr(errors.New("pipeline unavailable")),
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:       "accepted",
+			method:     http.MethodPost,
+			event:      "check_run",
+			body:       completedCheckRun,
+			next:       new(consumertest.LogsSink),
+			wantStatus: http.StatusAccepted,
+			wantLogs:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReceiver(tt.next)
+			req := httptest.NewRequest(tt.method, defaultGitHubPath, strings.NewReader(tt.body))
+			req.Header.Set("X-GitHub-Event", tt.event)
+			signature := tt.signature
+			if signature == "" && !tt.unsigned {
+				signature = sign(tt.body)
+			}
+			req.Header.Set("X-Hub-Signature-256", signature)
+			rec := httptest.NewRecorder()
+
+			r.handler(r.gitHubWebhook())(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if sink, ok := tt.next.(*consumertest.LogsSink); ok {
+				assert.Equal(t, tt.wantLogs, sink.LogRecordCount())
+			}
+		})
+	}
+}
+
+func TestGitLabHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		event      string
+		token      string
+		body       string
+		wantStatus int
+		wantLogs   int
+	}{
+		{
+			name:       "wrong token",
+			event:      "Job Hook",
+			token:      "guess",
+			body:       finishedJob,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "other event is ignored",
+			event:      "Push Hook",
+			token:      testToken,
+			body:       `{"object_kind": "push"}`,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "running job is ignored",
+			event:      "Job Hook",
+			token:      testToken,
+			body:       `{"object_kind": "build", "build_name": "compliance", "build_status": "running"}`,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "invalid payload",
+			event:      "Job Hook",
+			token:      testToken,
+			body:       "not json",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "accepted",
+			event:      "Job Hook",
+			token:      testToken,
+			body:       finishedJob,
+			wantStatus: http.StatusAccepted,
+			wantLogs:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(consumertest.LogsSink)
+			r := newTestReceiver(sink)
+			req := httptest.NewRequest(http.MethodPost, defaultGitLabPath, strings.NewReader(tt.body))
+			req.Header.Set("X-Gitlab-Event", tt.event)
+			req.Header.Set("X-Gitlab-Token", tt.token)
+			rec := httptest.NewRecorder()
+
+			r.handler(r.gitLabWebhook())(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantLogs, sink.LogRecordCount())
+		})
+	}
+}
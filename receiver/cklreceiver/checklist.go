@@ -0,0 +1,251 @@
+package cklreceiver
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+const (
+	scopeName = "github.com/complytime/complybeacon/receiver/cklreceiver"
+
+	// viewerComment prefixes the comment STIG Viewer writes before the
+	// checklist, such as "DISA STIG Viewer :: 2.17".
+	viewerComment = "DISA STIG Viewer ::"
+)
+
+// errInvalidChecklist wraps failures caused by the content of a file rather than
+// by the pipeline, so the file is skipped instead of retried.
+var errInvalidChecklist = errors.New("invalid STIG checklist")
+
+// checklist is the subset of a STIG Viewer CKL document used to build evidence.
+type checklist struct {
+	Asset struct {
+		HostName string `xml:"HOST_NAME"`
+		HostIP   string `xml:"HOST_IP"`
+		HostFQDN string `xml:"HOST_FQDN"`
+	} `xml:"ASSET"`
+	STIGs []istig `xml:"STIGS>iSTIG"`
+}
+
+// istig is one STIG of a checklist and the review of its vulnerabilities.
+type istig struct {
+	Info  []siData `xml:"STIG_INFO>SI_DATA"`
+	Vulns []vuln   `xml:"VULN"`
+}
+
+type siData struct {
+	Name string `xml:"SID_NAME"`
+	Data string `xml:"SID_DATA"`
+}
+
+type vuln struct {
+	Data             []stigData `xml:"STIG_DATA"`
+	Status           string     `xml:"STATUS"`
+	FindingDetails   string     `xml:"FINDING_DETAILS"`
+	Comments         string     `xml:"COMMENTS"`
+	SeverityOverride string     `xml:"SEVERITY_OVERRIDE"`
+}
+
+type stigData struct {
+	Attribute string `xml:"VULN_ATTRIBUTE"`
+	Data      string `xml:"ATTRIBUTE_DATA"`
+}
+
+// info returns the value of a STIG_INFO entry.
+func (s istig) info(name string) string {
+	for _, d := range s.Info {
+		if d.Name == name {
+			return strings.TrimSpace(d.Data)
+		}
+	}
+	return ""
+}
+
+// attr returns the first value of a vulnerability attribute.
+func (v vuln) attr(name string) string {
+	for _, d := range v.Data {
+		if d.Attribute == name {
+			return strings.TrimSpace(d.Data)
+		}
+	}
+	return ""
+}
+
+// attrs returns every value of a repeated vulnerability attribute, such as CCI_REF.
+func (v vuln) attrs(name string) []string {
+	var values []string
+	for _, d := range v.Data {
+		if d.Attribute == name {
+			if value := strings.TrimSpace(d.Data); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// parseChecklist decodes a CKL document and converts the review of every
+// vulnerability into a log record. Checklists carry no review time, so
+// records are stamped with the time the file was last modified.
+func parseChecklist(r io.Reader, engineName string, modified, observed time.Time) (plog.Logs, error) {
+	decoder := xml.NewDecoder(r)
+	engineVersion := ""
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return plog.Logs{}, fmt.Errorf("%w: no CHECKLIST element found", errInvalidChecklist)
+		}
+		if err != nil {
+			return plog.Logs{}, fmt.Errorf("%w: %w", errInvalidChecklist, err)
+		}
+
+		switch t := token.(type) {
+		case xml.Comment:
+			if v, ok := strings.CutPrefix(strings.TrimSpace(string(t)), viewerComment); ok {
+				engineVersion = strings.TrimSpace(v)
+			}
+		case xml.StartElement:
+			if t.Name.Local != "CHECKLIST" {
+				return plog.Logs{}, fmt.Errorf("%w: unexpected root element %s", errInvalidChecklist, t.Name.Local)
+			}
+			var ckl checklist
+			if err := decoder.DecodeElement(&ckl, &t); err != nil {
+				return plog.Logs{}, fmt.Errorf("%w: CHECKLIST: %w", errInvalidChecklist, err)
+			}
+			return convertChecklist(ckl, engineName, engineVersion, modified, observed), nil
+		}
+	}
+}
+
+func convertChecklist(ckl checklist, engineName, engineVersion string, modified, observed time.Time) plog.Logs {
+	logs := plog.NewLogs()
+	sl := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	sl.Scope().SetName(scopeName)
+
+	// The asset is identified by its FQDN where the reviewer recorded one.
+	targetID := firstNonEmpty(ckl.Asset.HostFQDN, ckl.Asset.HostName, ckl.Asset.HostIP)
+	targetName := firstNonEmpty(ckl.Asset.HostName, targetID)
+
+	for _, s := range ckl.STIGs {
+		stigID := s.info("stigid")
+		for _, v := range s.Vulns {
+			record := sl.LogRecords().AppendEmpty()
+			record.SetObservedTimestamp(pcommon.NewTimestampFromTime(observed))
+			record.SetTimestamp(pcommon.NewTimestampFromTime(modified))
+			record.SetSeverityNumber(plog.SeverityNumberInfo)
+			record.SetSeverityText(plog.SeverityNumberInfo.String())
+
+			status := strings.TrimSpace(v.Status)
+			attrs := record.Attributes()
+			attrs.PutStr(proofwatch.POLICY_ENGINE_NAME, engineName)
+			putStr(attrs, proofwatch.POLICY_ENGINE_VERSION, engineVersion)
+			putStr(attrs, proofwatch.POLICY_RULE_ID, v.attr("Rule_ID"))
+			putStr(attrs, proofwatch.POLICY_RULE_NAME, v.attr("Rule_Title"))
+			attrs.PutStr(proofwatch.POLICY_EVALUATION_RESULT, mapStatus(status))
+			putStr(attrs, proofwatch.POLICY_EVALUATION_MESSAGE, message(v))
+			if targetID != "" {
+				attrs.PutStr(proofwatch.POLICY_TARGET_ID, targetID)
+				attrs.PutStr(proofwatch.POLICY_TARGET_NAME, targetName)
+				attrs.PutStr(proofwatch.POLICY_TARGET_TYPE, "host")
+			}
+			putStr(attrs, proofwatch.COMPLIANCE_CONTROL_CATALOG_ID, stigID)
+			putStr(attrs, proofwatch.COMPLIANCE_CONTROL_ID, v.attr("Rule_Ver"))
+			putStr(attrs, proofwatch.COMPLIANCE_REMEDIATION_DESCRIPTION, v.attr("Fix_Text"))
+			if sev := severity(v); sev != "" {
+				attrs.PutStr(proofwatch.COMPLIANCE_RISK_LEVEL, mapSeverity(sev))
+			}
+			if ccis := v.attrs("CCI_REF"); len(ccis) > 0 {
+				requirements := attrs.PutEmptySlice(proofwatch.COMPLIANCE_REQUIREMENTS)
+				for _, cci := range ccis {
+					requirements.AppendEmpty().SetStr(cci)
+				}
+			}
+
+			body := record.Body().SetEmptyMap()
+			putStr(body, "vuln_num", v.attr("Vuln_Num"))
+			putStr(body, "group_title", v.attr("Group_Title"))
+			putStr(body, "rule_id", v.attr("Rule_ID"))
+			putStr(body, "stig_id", v.attr("Rule_Ver"))
+			putStr(body, "status", status)
+			putStr(body, "finding_details", strings.TrimSpace(v.FindingDetails))
+			putStr(body, "comments", strings.TrimSpace(v.Comments))
+			stig := body.PutEmptyMap("stig")
+			putStr(stig, "id", stigID)
+			putStr(stig, "title", s.info("title"))
+			putStr(stig, "version", s.info("version"))
+			putStr(stig, "release", s.info("releaseinfo"))
+		}
+	}
+	return logs
+}
+
+// message is the finding details of a review, falling back to its comments.
+func message(v vuln) string {
+	if details := strings.TrimSpace(v.FindingDetails); details != "" {
+		return details
+	}
+	return strings.TrimSpace(v.Comments)
+}
+
+// severity is the reviewer's severity override, falling back to the STIG severity.
+func severity(v vuln) string {
+	if override := strings.TrimSpace(v.SeverityOverride); override != "" {
+		return override
+	}
+	return v.attr("Severity")
+}
+
+// mapStatus maps a CKL review status to policy.evaluation.result.
+func mapStatus(status string) string {
+	switch status {
+	case "NotAFinding":
+		return "Passed"
+	case "Open":
+		return "Failed"
+	case "Not_Applicable":
+		return "Not Applicable"
+	case "Not_Reviewed":
+		return "Needs Review"
+	default:
+		return "Unknown"
+	}
+}
+
+// mapSeverity maps a STIG severity (CAT I to CAT III) to compliance.risk.level.
+func mapSeverity(severity string) string {
+	switch strings.ToLower(severity) {
+	case "high":
+		return "High"
+	case "medium":
+		return "Medium"
+	case "low":
+		return "Low"
+	default:
+		return "Informational"
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func putStr(m pcommon.Map, key, value string) {
+	if value != "" {
+		m.PutStr(key, value)
+	}
+}
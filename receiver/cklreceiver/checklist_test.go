@@ -0,0 +1,118 @@
+package cklreceiver
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+func recordAttrs(record plog.LogRecord) map[string]any {
+	return record.Attributes().AsRaw()
+}
+
+func TestParseChecklist(t *testing.T) {
+	modified := time.Date(2026, 6, 1, 10, 0, 0, 0, time.UTC)
+	observed := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	f, err := os.Open(filepath.Join("testdata", "rhel8.ckl"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	logs, err := parseChecklist(f, defaultEngineName, modified, observed)
+	require.NoError(t, err)
+	require.Equal(t, 3, logs.LogRecordCount())
+	records := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+
+	open := records.At(0)
+	assert.Equal(t, map[string]any{
+		proofwatch.POLICY_ENGINE_NAME:                 "STIG Viewer",
+		proofwatch.POLICY_ENGINE_VERSION:              "2.17",
+		proofwatch.POLICY_RULE_ID:                     "SV-230221r858734_rule",
+		proofwatch.POLICY_RULE_NAME:                   "RHEL 8 must be a vendor-supported release.",
+		proofwatch.POLICY_EVALUATION_RESULT:           "Failed",
+		proofwatch.POLICY_EVALUATION_MESSAGE:          "Host runs RHEL 8.4, which is out of support.",
+		proofwatch.POLICY_TARGET_ID:                   "web-01.example.com",
+		proofwatch.POLICY_TARGET_NAME:                 "web-01",
+		proofwatch.POLICY_TARGET_TYPE:                 "host",
+		proofwatch.COMPLIANCE_CONTROL_CATALOG_ID:      "RHEL_8_STIG",
+		proofwatch.COMPLIANCE_CONTROL_ID:              "RHEL-08-010000",
+		proofwatch.COMPLIANCE_REMEDIATION_DESCRIPTION: "Upgrade to a supported version of RHEL 8.",
+		proofwatch.COMPLIANCE_RISK_LEVEL:              "High",
+		proofwatch.COMPLIANCE_REQUIREMENTS:            []any{"CCI-000366"},
+	}, recordAttrs(open))
+	assert.Equal(t, modified, open.Timestamp().AsTime())
+	assert.Equal(t, observed, open.ObservedTimestamp().AsTime())
+	body := open.Body().Map().AsRaw()
+	assert.Equal(t, "V-230221", body["vuln_num"])
+	assert.Equal(t, "RHEL-08-010000", body["stig_id"])
+	assert.Equal(t, map[string]any{
+		"id":      "RHEL_8_STIG",
+		"title":   "Red Hat Enterprise Linux 8 Security Technical Implementation Guide",
+		"version": "1",
+		"release": "Release: 12 Benchmark Date: 25 Oct 2023",
+	}, body["stig"])
+
+	// The severity override and the comments are used when present.
+	notAFinding := recordAttrs(records.At(1))
+	assert.Equal(t, "Passed", notAFinding[proofwatch.POLICY_EVALUATION_RESULT])
+	assert.Equal(t, "Low", notAFinding[proofwatch.COMPLIANCE_RISK_LEVEL])
+	assert.Equal(t, "Patched during the monthly window.", notAFinding[proofwatch.POLICY_EVALUATION_MESSAGE])
+	assert.Equal(t, []any{"CCI-000366", "CCI-001227"}, notAFinding[proofwatch.COMPLIANCE_REQUIREMENTS])
+
+	notReviewed := recordAttrs(records.At(2))
+	assert.Equal(t, "Needs Review", notReviewed[proofwatch.POLICY_EVALUATION_RESULT])
+	assert.NotContains(t, notReviewed, proofwatch.COMPLIANCE_RISK_LEVEL)
+	assert.NotContains(t, notReviewed, proofwatch.POLICY_EVALUATION_MESSAGE)
+}
+
+func TestParseChecklistInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "not xml",
+			content: "not xml",
+			wantErr: "no CHECKLIST element found",
+		},
+		{
+			name:    "other document",
+			content: `<Benchmark id="b"/>`,
+			wantErr: "unexpected root element Benchmark",
+		},
+		{
+			name:    "truncated",
+			content: `<CHECKLIST><STIGS>`,
+			wantErr: "CHECKLIST",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseChecklist(strings.NewReader(tt.content), defaultEngineName, time.Now(), time.Now())
+			require.ErrorIs(t, err, errInvalidChecklist)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestMapStatus(t *testing.T) {
+	tests := map[string]string{
+		"NotAFinding":    "Passed",
+		"Open":           "Failed",
+		"Not_Applicable": "Not Applicable",
+		"Not_Reviewed":   "Needs Review",
+		"":               "Unknown",
+	}
+	for status, want := range tests {
+		assert.Equal(t, want, mapStatus(status), status)
+	}
+}
@@ -0,0 +1,58 @@
+package cklreceiver
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	defaultInclude      = "*.ckl"
+	defaultPollInterval = 30 * time.Second
+	defaultEngineName   = "STIG Viewer"
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines the configuration for the CKL receiver.
+type Config struct {
+	// Path is the directory that checklists are written to.
+	Path string `mapstructure:"path"`
+
+	// Include is a glob pattern, relative to Path, selecting checklist files.
+	Include string `mapstructure:"include"`
+
+	// PollInterval is how often the directory is scanned for new or modified files.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+
+	// EngineName is written to policy.engine.name on every emitted record.
+	EngineName string `mapstructure:"engine_name"`
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Include:      defaultInclude,
+		PollInterval: defaultPollInterval,
+		EngineName:   defaultEngineName,
+	}
+}
+
+// Validate checks the receiver configuration is valid.
+func (c *Config) Validate() error {
+	if c.Path == "" {
+		return errors.New("path must not be empty")
+	}
+	if _, err := filepath.Match(c.Include, ""); err != nil {
+		return fmt.Errorf("include is not a valid glob pattern: %w", err)
+	}
+	if c.PollInterval <= 0 {
+		return errors.New("poll_interval must be positive")
+	}
+	if c.EngineName == "" {
+		return errors.New("engine_name must not be empty")
+	}
+	return nil
+}
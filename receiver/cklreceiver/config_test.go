@@ -0,0 +1,101 @@
+package cklreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id       component.ID
+		expected *Config
+	}{
+		{
+			id: component.NewID(componentType),
+			expected: &Config{
+				Path:         "/var/lib/stig/checklists",
+				Include:      defaultInclude,
+				PollInterval: defaultPollInterval,
+				EngineName:   defaultEngineName,
+			},
+		},
+		{
+			id: component.NewIDWithName(componentType, "custom"),
+			expected: &Config{
+				Path:         "/srv/checklists",
+				Include:      "rhel*.ckl",
+				PollInterval: 5 * time.Minute,
+				EngineName:   "STIG Viewer 2",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, sub.Unmarshal(cfg))
+			require.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "empty path",
+			mutate:  func(cfg *Config) { cfg.Path = "" },
+			wantErr: "path must not be empty",
+		},
+		{
+			name:    "invalid include",
+			mutate:  func(cfg *Config) { cfg.Include = "[" },
+			wantErr: "include is not a valid glob pattern: syntax error in pattern",
+		},
+		{
+			name:    "zero poll interval",
+			mutate:  func(cfg *Config) { cfg.PollInterval = 0 },
+			wantErr: "poll_interval must be positive",
+		},
+		{
+			name:    "empty engine name",
+			mutate:  func(cfg *Config) { cfg.EngineName = "" },
+			wantErr: "engine_name must not be empty",
+		},
+		{
+			name:   "valid",
+			mutate: func(*Config) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Path = t.TempDir()
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package cklreceiver
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+)
+
+var _ receiver.Logs = (*cklReceiver)(nil)
+
+type cklReceiver struct {
+	cfg      *Config
+	settings receiver.Settings
+	next     consumer.Logs
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	// seen tracks the modification time of checklists already consumed.
+	seen map[string]time.Time
+}
+
+func newCKLReceiver(cfg *Config, set receiver.Settings, next consumer.Logs) *cklReceiver {
+	return &cklReceiver{
+		cfg:      cfg,
+		settings: set,
+		next:     next,
+		seen:     make(map[string]time.Time),
+	}
+}
+
+// Start begins watching the configured directory.
+func (r *cklReceiver) Start(_ context.Context, _ component.Host) error {
+	runCtx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	r.wg.Add(1)
+	go r.pollDirectory(runCtx)
+	return nil
+}
+
+// Shutdown stops the directory watch.
+func (r *cklReceiver) Shutdown(_ context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	return nil
+}
+
+func (r *cklReceiver) pollDirectory(ctx context.Context) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		r.scanDirectory(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// scanDirectory consumes checklists that are new or modified since the previous scan.
+// Processed files are tracked in memory only, so a restart re-reads the directory.
+func (r *cklReceiver) scanDirectory(ctx context.Context) {
+	matches, err := filepath.Glob(filepath.Join(r.cfg.Path, r.cfg.Include))
+	if err != nil {
+		r.settings.Logger.Error("failed to list checklist files", zap.Error(err))
+		return
+	}
+
+	for _, path := range matches {
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() {
+			continue
+		}
+		if modTime, ok := r.seen[path]; ok && modTime.Equal(info.ModTime()) {
+			continue
+		}
+		if err := r.consumeFile(ctx, path, info.ModTime()); err != nil {
+			r.settings.Logger.Warn("failed to process checklist", zap.String("path", path), zap.Error(err))
+			if !errors.Is(err, errInvalidChecklist) {
+				// Retry on the next scan; the pipeline may be temporarily unavailable.
+				continue
+			}
+		}
+		r.seen[path] = info.ModTime()
+	}
+}
+
+func (r *cklReceiver) consumeFile(ctx context.Context, path string, modTime time.Time) error {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	logs, err := parseChecklist(f, r.cfg.EngineName, modTime, time.Now())
+	if err != nil {
+		return err
+	}
+	return r.next.ConsumeLogs(ctx, logs)
+}
@@ -0,0 +1,69 @@
+package cklreceiver
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+const minimalChecklist = `<CHECKLIST><STIGS><iSTIG><VULN><STATUS>Open</STATUS></VULN></iSTIG></STIGS></CHECKLIST>`
+
+func newTestReceiver(t *testing.T, next consumer.Logs) *cklReceiver {
+	t.Helper()
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = t.TempDir()
+	return newCKLReceiver(cfg, receivertest.NewNopSettings(componentType), next)
+}
+
+func TestScanDirectory(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	r := newTestReceiver(t, sink)
+
+	path := filepath.Join(r.cfg.Path, "web-01.ckl")
+	require.NoError(t, os.WriteFile(path, []byte(minimalChecklist), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(r.cfg.Path, "notes.txt"), []byte("ignored"), 0o600))
+
+	r.scanDirectory(context.Background())
+	assert.Equal(t, 1, sink.LogRecordCount(), "new file is consumed")
+
+	r.scanDirectory(context.Background())
+	assert.Equal(t, 1, sink.LogRecordCount(), "unchanged file is skipped")
+
+	modified := time.Now().Add(time.Minute).Truncate(time.Second)
+	require.NoError(t, os.Chtimes(path, modified, modified))
+	r.scanDirectory(context.Background())
+	require.Equal(t, 2, sink.LogRecordCount(), "modified file is re-read")
+	record := sink.AllLogs()[1].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.True(t, modified.Equal(record.Timestamp().AsTime()), "records carry the file modification time")
+}
+
+func TestScanDirectorySkipsInvalidFilesOnce(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	r := newTestReceiver(t, sink)
+
+	path := filepath.Join(r.cfg.Path, "broken.ckl")
+	require.NoError(t, os.WriteFile(path, []byte("<CHECKLIST>"), 0o600))
+
+	r.scanDirectory(context.Background())
+	assert.Contains(t, r.seen, path, "invalid checklists are not retried until modified")
+	assert.Equal(t, 0, sink.LogRecordCount())
+}
+
+func TestScanDirectoryRetriesOnPipelineFailure(t *testing.T) {
+	r := newTestReceiver(t, consumertest.NewErr(errors.New("pipeline unavailable")))
+
+	path := filepath.Join(r.cfg.Path, "web-01.ckl")
+	require.NoError(t, os.WriteFile(path, []byte(minimalChecklist), 0o600))
+
+	r.scanDirectory(context.Background())
+	assert.NotContains(t, r.seen, path)
+}
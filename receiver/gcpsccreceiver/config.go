@@ -0,0 +1,152 @@
+package gcpsccreceiver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const (
+	defaultAPIEndpoint     = "https://securitycenter.googleapis.com"
+	defaultPubSubEndpoint  = "https://pubsub.googleapis.com"
+	defaultPollInterval    = 15 * time.Minute
+	defaultInitialLookback = 24 * time.Hour
+	defaultMaxMessages     = 100
+	maxMaxMessages         = 1000
+
+	apiKey    = "api"
+	pubSubKey = "pubsub"
+)
+
+// parentPrefixes are the resources Security Command Center findings can be
+// listed under.
+var parentPrefixes = []string{"organizations/", "folders/", "projects/"}
+
+var (
+	_ component.Config    = (*Config)(nil)
+	_ confmap.Unmarshaler = (*Config)(nil)
+)
+
+// Config defines the configuration for the Security Command Center receiver.
+// At least one of API or PubSub must be set.
+type Config struct {
+	// API polls the Security Command Center ListFindings API.
+	API *APIConfig `mapstructure:"api"`
+
+	// PubSub pulls finding notifications from a Pub/Sub subscription.
+	PubSub *PubSubConfig `mapstructure:"pubsub"`
+}
+
+// APIConfig configures finding polling. Requests are expected to be
+// authenticated by an authenticator extension such as googleclientauth.
+type APIConfig struct {
+	confighttp.ClientConfig `mapstructure:",squash"`
+
+	// Parent is the organization, folder or project whose findings are listed,
+	// such as organizations/123456789.
+	Parent string `mapstructure:"parent"`
+
+	// Filter is an additional ListFindings filter expression.
+	Filter string `mapstructure:"filter"`
+
+	// PollInterval is how often findings with an event time since the
+	// previous poll are fetched.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+
+	// InitialLookback is how far back the first poll reaches.
+	InitialLookback time.Duration `mapstructure:"initial_lookback"`
+}
+
+// PubSubConfig configures the notification subscription. Requests are expected
+// to be authenticated by an authenticator extension such as googleclientauth.
+type PubSubConfig struct {
+	confighttp.ClientConfig `mapstructure:",squash"`
+
+	// Subscription receives the messages of a Security Command Center
+	// notification config, as projects/{project}/subscriptions/{subscription}.
+	Subscription string `mapstructure:"subscription"`
+
+	// MaxMessages is the maximum number of messages returned by each pull.
+	MaxMessages int `mapstructure:"max_messages"`
+}
+
+func createDefaultConfig() component.Config {
+	api := confighttp.NewDefaultClientConfig()
+	api.Endpoint = defaultAPIEndpoint
+	pubSub := confighttp.NewDefaultClientConfig()
+	pubSub.Endpoint = defaultPubSubEndpoint
+
+	return &Config{
+		API: &APIConfig{
+			ClientConfig:    api,
+			PollInterval:    defaultPollInterval,
+			InitialLookback: defaultInitialLookback,
+		},
+		PubSub: &PubSubConfig{
+			ClientConfig: pubSub,
+			MaxMessages:  defaultMaxMessages,
+		},
+	}
+}
+
+// Unmarshal keeps only the sources that are present in the user configuration.
+func (c *Config) Unmarshal(conf *confmap.Conf) error {
+	if err := conf.Unmarshal(c); err != nil {
+		return err
+	}
+	if !conf.IsSet(apiKey) {
+		c.API = nil
+	}
+	if !conf.IsSet(pubSubKey) {
+		c.PubSub = nil
+	}
+	return nil
+}
+
+// Validate checks the receiver configuration is valid.
+func (c *Config) Validate() error {
+	if c.API == nil && c.PubSub == nil {
+		return errors.New("at least one of api or pubsub must be configured")
+	}
+	if c.API != nil {
+		if c.API.Endpoint == "" {
+			return errors.New("api.endpoint must not be empty")
+		}
+		if !hasParentPrefix(c.API.Parent) {
+			return fmt.Errorf("api.parent %q must start with one of %s", c.API.Parent, strings.Join(parentPrefixes, ", "))
+		}
+		if c.API.PollInterval <= 0 {
+			return errors.New("api.poll_interval must be positive")
+		}
+		if c.API.InitialLookback < 0 {
+			return errors.New("api.initial_lookback must not be negative")
+		}
+	}
+	if c.PubSub != nil {
+		if c.PubSub.Endpoint == "" {
+			return errors.New("pubsub.endpoint must not be empty")
+		}
+		parts := strings.Split(c.PubSub.Subscription, "/")
+		if len(parts) != 4 || parts[0] != "projects" || parts[1] == "" || parts[2] != "subscriptions" || parts[3] == "" {
+			return fmt.Errorf("pubsub.subscription %q must be projects/{project}/subscriptions/{subscription}", c.PubSub.Subscription)
+		}
+		if c.PubSub.MaxMessages < 1 || c.PubSub.MaxMessages > maxMaxMessages {
+			return fmt.Errorf("pubsub.max_messages must be between 1 and %d", maxMaxMessages)
+		}
+	}
+	return nil
+}
+
+func hasParentPrefix(parent string) bool {
+	for _, prefix := range parentPrefixes {
+		if strings.HasPrefix(parent, prefix) && len(parent) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package gcpsccreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id    component.ID
+		check func(t *testing.T, cfg *Config)
+	}{
+		{
+			id: component.NewID(componentType),
+			check: func(t *testing.T, cfg *Config) {
+				require.NotNil(t, cfg.API)
+				assert.Nil(t, cfg.PubSub)
+				assert.Equal(t, defaultAPIEndpoint, cfg.API.Endpoint)
+				assert.Equal(t, "organizations/123456789012", cfg.API.Parent)
+				assert.Equal(t, defaultPollInterval, cfg.API.PollInterval)
+				assert.Equal(t, defaultInitialLookback, cfg.API.InitialLookback)
+			},
+		},
+		{
+			id: component.NewIDWithName(componentType, "pubsub"),
+			check: func(t *testing.T, cfg *Config) {
+				assert.Nil(t, cfg.API)
+				require.NotNil(t, cfg.PubSub)
+				assert.Equal(t, defaultPubSubEndpoint, cfg.PubSub.Endpoint)
+				assert.Equal(t, "projects/security-tooling/subscriptions/scc-findings", cfg.PubSub.Subscription)
+				assert.Equal(t, 50, cfg.PubSub.MaxMessages)
+			},
+		},
+		{
+			id: component.NewIDWithName(componentType, "both"),
+			check: func(t *testing.T, cfg *Config) {
+				require.NotNil(t, cfg.API)
+				require.NotNil(t, cfg.PubSub)
+				assert.Equal(t, `severity="HIGH"`, cfg.API.Filter)
+				assert.Equal(t, time.Hour, cfg.API.PollInterval)
+				assert.Zero(t, cfg.API.InitialLookback)
+				assert.Equal(t, defaultMaxMessages, cfg.PubSub.MaxMessages)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, sub.Unmarshal(cfg))
+			require.NoError(t, cfg.Validate())
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name: "no source",
+			mutate: func(cfg *Config) {
+				cfg.API = nil
+				cfg.PubSub = nil
+			},
+			wantErr: "at least one of api or pubsub must be configured",
+		},
+		{
+			name:    "empty api endpoint",
+			mutate:  func(cfg *Config) { cfg.API.Endpoint = "" },
+			wantErr: "api.endpoint must not be empty",
+		},
+		{
+			name:    "invalid parent",
+			mutate:  func(cfg *Config) { cfg.API.Parent = "123456789012" },
+			wantErr: `api.parent "123456789012" must start with one of organizations/, folders/, projects/`,
+		},
+		{
+			name:    "zero poll interval",
+			mutate:  func(cfg *Config) { cfg.API.PollInterval = 0 },
+			wantErr: "api.poll_interval must be positive",
+		},
+		{
+			name:    "negative lookback",
+			mutate:  func(cfg *Config) { cfg.API.InitialLookback = -time.Hour },
+			wantErr: "api.initial_lookback must not be negative",
+		},
+		{
+			name:    "empty pubsub endpoint",
+			mutate:  func(cfg *Config) { cfg.PubSub.Endpoint = "" },
+			wantErr: "pubsub.endpoint must not be empty",
+		},
+		{
+			name:    "invalid subscription",
+			mutate:  func(cfg *Config) { cfg.PubSub.Subscription = "scc-findings" },
+			wantErr: `pubsub.subscription "scc-findings" must be projects/{project}/subscriptions/{subscription}`,
+		},
+		{
+			name:    "too many messages",
+			mutate:  func(cfg *Config) { cfg.PubSub.MaxMessages = 1001 },
+			wantErr: "pubsub.max_messages must be between 1 and 1000",
+		},
+		{
+			name:   "valid",
+			mutate: func(*Config) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.API.Parent = "organizations/123456789012"
+			cfg.PubSub.Subscription = "projects/security-tooling/subscriptions/scc-findings"
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package gcpsccreceiver
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+const (
+	scopeName = "github.com/complytime/complybeacon/receiver/gcpsccreceiver"
+
+	engineName = "Security Command Center"
+
+	cloudProvider  = "cloud.provider"
+	cloudAccountID = "cloud.account.id"
+)
+
+// evidenceClasses are the finding classes that report the compliance of a
+// resource. Threats, observations and errors are skipped.
+var evidenceClasses = map[string]bool{
+	"MISCONFIGURATION":  true,
+	"VULNERABILITY":     true,
+	"POSTURE_VIOLATION": true,
+}
+
+// findingResult pairs a finding with the resource it applies to. It is both an
+// element of a ListFindings response and the payload of a notification.
+type findingResult struct {
+	Finding  finding  `json:"finding"`
+	Resource resource `json:"resource"`
+}
+
+// finding is the subset of a Security Command Center finding used to build
+// evidence.
+type finding struct {
+	Name         string `json:"name"`
+	ResourceName string `json:"resourceName"`
+	State        string `json:"state"`
+	Category     string `json:"category"`
+	ExternalURI  string `json:"externalUri"`
+	EventTime    string `json:"eventTime"`
+	Severity     string `json:"severity"`
+	FindingClass string `json:"findingClass"`
+	Mute         string `json:"mute"`
+	Description  string `json:"description"`
+	NextSteps    string `json:"nextSteps"`
+	Compliances  []struct {
+		Standard string   `json:"standard"`
+		Version  string   `json:"version"`
+		IDs      []string `json:"ids"`
+	} `json:"compliances"`
+}
+
+type resource struct {
+	ProjectName        string `json:"projectName"`
+	ProjectDisplayName string `json:"projectDisplayName"`
+	Type               string `json:"type"`
+	DisplayName        string `json:"displayName"`
+}
+
+// appendResults converts raw finding results into log records. Findings that
+// are not compliance evidence are skipped.
+func appendResults(logs plog.Logs, raw []json.RawMessage, observed time.Time) error {
+	for i, data := range raw {
+		if err := appendResult(logs, data, observed); err != nil {
+			return fmt.Errorf("finding %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func appendResult(logs plog.Logs, data []byte, observed time.Time) error {
+	var result findingResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+	if !evidenceClasses[result.Finding.FindingClass] {
+		return nil
+	}
+	var body map[string]any
+	if err := json.Unmarshal(data, &body); err != nil {
+		return err
+	}
+
+	f, res := result.Finding, result.Resource
+	record := newRecord(logs, projectID(res), parseTime(f.EventTime, observed), observed)
+	_ = record.Body().SetEmptyMap().FromRaw(body)
+
+	targetName := res.DisplayName
+	if targetName == "" {
+		targetName = f.ResourceName[strings.LastIndex(f.ResourceName, "/")+1:]
+	}
+
+	attrs := record.Attributes()
+	attrs.PutStr(proofwatch.POLICY_ENGINE_NAME, engineName)
+	attrs.PutStr(proofwatch.POLICY_RULE_ID, f.Category)
+	putStr(attrs, proofwatch.POLICY_RULE_URI, f.ExternalURI)
+	attrs.PutStr(proofwatch.POLICY_EVALUATION_RESULT, mapState(f.State))
+	putStr(attrs, proofwatch.POLICY_EVALUATION_MESSAGE, f.Description)
+	putStr(attrs, proofwatch.POLICY_TARGET_ID, f.ResourceName)
+	putStr(attrs, proofwatch.POLICY_TARGET_NAME, targetName)
+	putStr(attrs, proofwatch.POLICY_TARGET_TYPE, res.Type)
+	if level := mapSeverity(f.Severity); level != "" {
+		attrs.PutStr(proofwatch.COMPLIANCE_RISK_LEVEL, level)
+	}
+	if len(f.Compliances) > 0 {
+		requirements := attrs.PutEmptySlice(proofwatch.COMPLIANCE_REQUIREMENTS)
+		for _, c := range f.Compliances {
+			prefix := strings.TrimSpace(c.Standard + " " + c.Version)
+			for _, id := range c.IDs {
+				requirements.AppendEmpty().SetStr(strings.TrimSpace(prefix + " " + id))
+			}
+		}
+	}
+	putStr(attrs, proofwatch.COMPLIANCE_REMEDIATION_DESCRIPTION, f.NextSteps)
+	if f.Mute == "MUTED" {
+		attrs.PutBool(proofwatch.COMPLIANCE_REMEDIATION_EXCEPTION_ACTIVE, true)
+	}
+	return nil
+}
+
+// newRecord appends a record to the resource of its project.
+func newRecord(logs plog.Logs, project string, timestamp, observed time.Time) plog.LogRecord {
+	var sl plog.ScopeLogs
+	found := false
+	for i := 0; i < logs.ResourceLogs().Len() && !found; i++ {
+		rl := logs.ResourceLogs().At(i)
+		if str(rl.Resource().Attributes(), cloudAccountID) == project {
+			sl, found = rl.ScopeLogs().At(0), true
+		}
+	}
+	if !found {
+		rl := logs.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr(cloudProvider, "gcp")
+		putStr(rl.Resource().Attributes(), cloudAccountID, project)
+		sl = rl.ScopeLogs().AppendEmpty()
+		sl.Scope().SetName(scopeName)
+	}
+
+	record := sl.LogRecords().AppendEmpty()
+	record.SetObservedTimestamp(pcommon.NewTimestampFromTime(observed))
+	record.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
+	record.SetSeverityNumber(plog.SeverityNumberInfo)
+	record.SetSeverityText(plog.SeverityNumberInfo.String())
+	return record
+}
+
+// projectID returns the project ID of a resource. Security Command Center
+// reports it as the project display name; the project number from the
+// project resource name is used when it is missing.
+func projectID(res resource) string {
+	if res.ProjectDisplayName != "" {
+		return res.ProjectDisplayName
+	}
+	return res.ProjectName[strings.LastIndex(res.ProjectName, "/")+1:]
+}
+
+// mapState maps a finding state to policy.evaluation.result. An active finding
+// is a failed check; an inactive one was resolved.
+func mapState(state string) string {
+	switch state {
+	case "ACTIVE":
+		return "Failed"
+	case "INACTIVE":
+		return "Passed"
+	default:
+		return "Unknown"
+	}
+}
+
+// mapSeverity maps a finding severity to compliance.risk.level.
+func mapSeverity(severity string) string {
+	switch severity {
+	case "CRITICAL":
+		return "Critical"
+	case "HIGH":
+		return "High"
+	case "MEDIUM":
+		return "Medium"
+	case "LOW":
+		return "Low"
+	default:
+		return ""
+	}
+}
+
+func parseTime(value string, fallback time.Time) time.Time {
+	if t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(value)); err == nil {
+		return t
+	}
+	return fallback
+}
+
+func putStr(attrs pcommon.Map, key, value string) {
+	if value != "" {
+		attrs.PutStr(key, value)
+	}
+}
+
+func str(attrs pcommon.Map, key string) string {
+	if v, ok := attrs.Get(key); ok {
+		return v.AsString()
+	}
+	return ""
+}
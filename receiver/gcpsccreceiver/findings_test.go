@@ -0,0 +1,94 @@
+package gcpsccreceiver
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+var observed = time.Date(2026, 6, 2, 10, 0, 0, 0, time.UTC)
+
+func readTestdata(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return data
+}
+
+func TestAppendResults(t *testing.T) {
+	logs := plog.NewLogs()
+	threat := json.RawMessage(`{"finding": {"category": "Persistence: IAM Anomalous Grant", "findingClass": "THREAT"}}`)
+	require.NoError(t, appendResults(logs, []json.RawMessage{readTestdata(t, "finding.json"), threat}, observed))
+	require.Equal(t, 1, logs.LogRecordCount(), "threat findings are skipped")
+
+	rl := logs.ResourceLogs().At(0)
+	assert.Equal(t, map[string]any{
+		cloudProvider:  "gcp",
+		cloudAccountID: "payments-prod",
+	}, rl.Resource().Attributes().AsRaw())
+	assert.Equal(t, scopeName, rl.ScopeLogs().At(0).Scope().Name())
+
+	record := rl.ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, time.Date(2026, 6, 2, 8, 30, 0, 0, time.UTC), record.Timestamp().AsTime())
+	assert.Equal(t, observed, record.ObservedTimestamp().AsTime())
+	assert.Equal(t, map[string]any{
+		proofwatch.POLICY_ENGINE_NAME:                 engineName,
+		proofwatch.POLICY_RULE_ID:                     "BUCKET_POLICY_ONLY_DISABLED",
+		proofwatch.POLICY_RULE_URI:                    "https://console.cloud.google.com/storage/browser/payments-logs",
+		proofwatch.POLICY_EVALUATION_RESULT:           "Failed",
+		proofwatch.POLICY_EVALUATION_MESSAGE:          "Uniform bucket-level access is not enabled on the bucket.",
+		proofwatch.POLICY_TARGET_ID:                   "//storage.googleapis.com/payments-logs",
+		proofwatch.POLICY_TARGET_NAME:                 "payments-logs",
+		proofwatch.POLICY_TARGET_TYPE:                 "google.cloud.storage.Bucket",
+		proofwatch.COMPLIANCE_RISK_LEVEL:              "Medium",
+		proofwatch.COMPLIANCE_REQUIREMENTS:            []any{"cis 2.0 5.2", "nist 800-53 R5 AC-3", "nist 800-53 R5 AC-6"},
+		proofwatch.COMPLIANCE_REMEDIATION_DESCRIPTION: "Enable uniform bucket-level access on the bucket.",
+	}, record.Attributes().AsRaw())
+
+	notification, ok := record.Body().Map().Get("notificationConfigName")
+	require.True(t, ok)
+	assert.Equal(t, "organizations/123456789012/notificationConfigs/complybeacon", notification.Str())
+}
+
+func TestAppendResultsMutedInactive(t *testing.T) {
+	logs := plog.NewLogs()
+	require.NoError(t, appendResults(logs, []json.RawMessage{json.RawMessage(`{
+		"finding": {
+			"resourceName": "//compute.googleapis.com/projects/payments-prod/zones/europe-west1-b/instances/web-1",
+			"state": "INACTIVE",
+			"category": "OS_LOGIN_DISABLED",
+			"findingClass": "VULNERABILITY",
+			"mute": "MUTED",
+			"eventTime": "not a time"
+		},
+		"resource": {"projectName": "//cloudresourcemanager.googleapis.com/projects/401234567890"}
+	}`)}, observed))
+
+	rl := logs.ResourceLogs().At(0)
+	assert.Equal(t, "401234567890", rl.Resource().Attributes().AsRaw()[cloudAccountID])
+
+	record := rl.ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, observed, record.Timestamp().AsTime())
+	attrs := record.Attributes().AsRaw()
+	assert.Equal(t, "Passed", attrs[proofwatch.POLICY_EVALUATION_RESULT])
+	assert.Equal(t, "web-1", attrs[proofwatch.POLICY_TARGET_NAME])
+	assert.Equal(t, true, attrs[proofwatch.COMPLIANCE_REMEDIATION_EXCEPTION_ACTIVE])
+}
+
+func TestAppendResultsInvalid(t *testing.T) {
+	assert.Error(t, appendResults(plog.NewLogs(), []json.RawMessage{json.RawMessage(`[]`)}, observed))
+}
+
+func TestMapState(t *testing.T) {
+	assert.Equal(t, "Failed", mapState("ACTIVE"))
+	assert.Equal(t, "Passed", mapState("INACTIVE"))
+	assert.Equal(t, "Unknown", mapState("STATE_UNSPECIFIED"))
+}
@@ -0,0 +1,286 @@
+package gcpsccreceiver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+)
+
+const (
+	// findingsPageSize is the ListFindings page size limit.
+	findingsPageSize = 1000
+	// pullRetryDelay is the pause after a failed pull.
+	pullRetryDelay = 5 * time.Second
+)
+
+var _ receiver.Logs = (*sccReceiver)(nil)
+
+type sccReceiver struct {
+	cfg      *Config
+	settings receiver.Settings
+	next     consumer.Logs
+	now      func() time.Time
+
+	apiClient    *http.Client
+	pubSubClient *http.Client
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	// since is the event time lower bound of the next ListFindings poll.
+	since time.Time
+}
+
+func newSCCReceiver(cfg *Config, set receiver.Settings, next consumer.Logs) *sccReceiver {
+	return &sccReceiver{
+		cfg:      cfg,
+		settings: set,
+		next:     next,
+		now:      time.Now,
+	}
+}
+
+// Start begins polling findings and/or pulling notifications.
+func (r *sccReceiver) Start(ctx context.Context, host component.Host) error {
+	if r.cfg.API != nil {
+		client, err := r.cfg.API.ToClient(ctx, host.GetExtensions(), r.settings.TelemetrySettings)
+		if err != nil {
+			return fmt.Errorf("failed to create Security Command Center client: %w", err)
+		}
+		r.apiClient = client
+		r.since = r.now().Add(-r.cfg.API.InitialLookback)
+	}
+	if r.cfg.PubSub != nil {
+		client, err := r.cfg.PubSub.ToClient(ctx, host.GetExtensions(), r.settings.TelemetrySettings)
+		if err != nil {
+			return fmt.Errorf("failed to create Pub/Sub client: %w", err)
+		}
+		r.pubSubClient = client
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	if r.apiClient != nil {
+		r.wg.Add(1)
+		go r.pollAPI(runCtx)
+	}
+	if r.pubSubClient != nil {
+		r.wg.Add(1)
+		go r.pullSubscription(runCtx)
+	}
+	return nil
+}
+
+// Shutdown stops polling and pulling.
+func (r *sccReceiver) Shutdown(context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	return nil
+}
+
+func (r *sccReceiver) pollAPI(ctx context.Context) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.cfg.API.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.pollFindings(ctx); err != nil && ctx.Err() == nil {
+			r.settings.Logger.Warn("failed to poll Security Command Center findings; will retry on the next poll", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+type listFindingsResponse struct {
+	ListFindingsResults []json.RawMessage `json:"listFindingsResults"`
+	NextPageToken       string            `json:"nextPageToken"`
+}
+
+// pollFindings fetches the findings of every source under the parent with an
+// event time since the previous successful poll. The window only advances
+// when every page was consumed, so a failed poll is repeated in full.
+func (r *sccReceiver) pollFindings(ctx context.Context) error {
+	end := r.now()
+	filter := fmt.Sprintf("event_time >= %q AND event_time < %q",
+		r.since.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339))
+	if r.cfg.API.Filter != "" {
+		filter += " AND (" + r.cfg.API.Filter + ")"
+	}
+	query := url.Values{}
+	query.Set("filter", filter)
+	query.Set("pageSize", fmt.Sprint(findingsPageSize))
+	base := strings.TrimSuffix(r.cfg.API.Endpoint, "/") + "/v1/" + r.cfg.API.Parent + "/sources/-/findings?"
+
+	for {
+		var resp listFindingsResponse
+		if err := callJSON(ctx, r.apiClient, http.MethodGet, base+query.Encode(), nil, &resp); err != nil {
+			return fmt.Errorf("ListFindings: %w", err)
+		}
+
+		logs := plog.NewLogs()
+		if err := appendResults(logs, resp.ListFindingsResults, end); err != nil {
+			return err
+		}
+		if logs.LogRecordCount() > 0 {
+			if err := r.next.ConsumeLogs(ctx, logs); err != nil {
+				return err
+			}
+		}
+
+		if resp.NextPageToken == "" {
+			break
+		}
+		query.Set("pageToken", resp.NextPageToken)
+	}
+	r.since = end
+	return nil
+}
+
+func (r *sccReceiver) pullSubscription(ctx context.Context) {
+	defer r.wg.Done()
+
+	for ctx.Err() == nil {
+		if err := r.pullMessages(ctx); err != nil && ctx.Err() == nil {
+			r.settings.Logger.Warn("failed to pull finding notifications", zap.Error(err))
+			select {
+			case <-ctx.Done():
+			case <-time.After(pullRetryDelay):
+			}
+		}
+	}
+}
+
+type pullRequest struct {
+	MaxMessages int `json:"maxMessages"`
+}
+
+type pullResponse struct {
+	ReceivedMessages []struct {
+		AckID   string `json:"ackId"`
+		Message struct {
+			MessageID string `json:"messageId"`
+			Data      []byte `json:"data"`
+		} `json:"message"`
+	} `json:"receivedMessages"`
+}
+
+type acknowledgeRequest struct {
+	AckIDs []string `json:"ackIds"`
+}
+
+// pullMessages converts one batch of notifications and acknowledges them once
+// the pipeline accepted the records. Messages that cannot be converted are
+// acknowledged as well. On pipeline failure nothing is acknowledged, and
+// Pub/Sub redelivers the batch after its ack deadline.
+func (r *sccReceiver) pullMessages(ctx context.Context) error {
+	var resp pullResponse
+	err := callJSON(ctx, r.pubSubClient, http.MethodPost, r.subscriptionURL("pull"),
+		pullRequest{MaxMessages: r.cfg.PubSub.MaxMessages}, &resp)
+	if err != nil {
+		return fmt.Errorf("pull: %w", err)
+	}
+	if len(resp.ReceivedMessages) == 0 {
+		return nil
+	}
+
+	observed := r.now()
+	logs := plog.NewLogs()
+	ackIDs := make([]string, 0, len(resp.ReceivedMessages))
+	for _, received := range resp.ReceivedMessages {
+		msgLogs := plog.NewLogs()
+		if err := appendResult(msgLogs, received.Message.Data, observed); err != nil {
+			r.settings.Logger.Warn("discarding finding notification",
+				zap.String("message_id", received.Message.MessageID), zap.Error(err))
+		} else {
+			msgLogs.ResourceLogs().MoveAndAppendTo(logs.ResourceLogs())
+		}
+		ackIDs = append(ackIDs, received.AckID)
+	}
+
+	if logs.LogRecordCount() > 0 {
+		if err := r.next.ConsumeLogs(ctx, logs); err != nil {
+			return err
+		}
+	}
+
+	if err := callJSON(ctx, r.pubSubClient, http.MethodPost, r.subscriptionURL("acknowledge"),
+		acknowledgeRequest{AckIDs: ackIDs}, nil); err != nil {
+		return fmt.Errorf("acknowledge: %w", err)
+	}
+	return nil
+}
+
+func (r *sccReceiver) subscriptionURL(method string) string {
+	return strings.TrimSuffix(r.cfg.PubSub.Endpoint, "/") + "/v1/" + r.cfg.PubSub.Subscription + ":" + method
+}
+
+// apiError is the error body of Google API responses.
+type apiError struct {
+	Error struct {
+		Message string `json:"message"`
+		Status  string `json:"status"`
+	} `json:"error"`
+}
+
+// callJSON sends a JSON request to a Google API and decodes the response into
+// out, unless out is nil.
+func callJSON(ctx context.Context, client *http.Client, method, endpoint string, in, out any) error {
+	body := io.Reader(http.NoBody)
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiErr apiError
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Error.Message != "" {
+			return fmt.Errorf("%s: %s: %s", resp.Status, apiErr.Error.Status, apiErr.Error.Message)
+		}
+		return errors.New(resp.Status)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
@@ -0,0 +1,203 @@
+package gcpsccreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+const testSubscription = "projects/security-tooling/subscriptions/scc-findings"
+
+// newSCCServer serves pages of finding results from ListFindings, chained by
+// nextPageToken, and records the queries it received.
+func newSCCServer(t *testing.T, pages [][]json.RawMessage) (*httptest.Server, *[]url.Values) {
+	t.Helper()
+	var mu sync.Mutex
+	var queries []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, "/v1/organizations/123456789012/sources/-/findings", req.URL.Path)
+		mu.Lock()
+		queries = append(queries, req.URL.Query())
+		mu.Unlock()
+
+		page := 0
+		if token := req.URL.Query().Get("pageToken"); token != "" {
+			page = int(token[0] - '0')
+		}
+		resp := listFindingsResponse{}
+		if page < len(pages) {
+			resp.ListFindingsResults = pages[page]
+		}
+		if page+1 < len(pages) {
+			resp.NextPageToken = string(rune('0' + page + 1))
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return server, &queries
+}
+
+func newTestReceiver(t *testing.T, cfg *Config, next consumer.Logs) *sccReceiver {
+	t.Helper()
+	r := newSCCReceiver(cfg, receivertest.NewNopSettings(componentType), next)
+	r.now = func() time.Time { return observed }
+	return r
+}
+
+func newAPIConfig(endpoint string) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.PubSub = nil
+	cfg.API.Endpoint = endpoint
+	cfg.API.Parent = "organizations/123456789012"
+	return cfg
+}
+
+func TestPollFindings(t *testing.T) {
+	result := readTestdata(t, "finding.json")
+	server, queries := newSCCServer(t, [][]json.RawMessage{{result}, {result, result}})
+
+	cfg := newAPIConfig(server.URL)
+	cfg.API.Filter = `severity="HIGH"`
+	sink := new(consumertest.LogsSink)
+	r := newTestReceiver(t, cfg, sink)
+	r.apiClient = server.Client()
+	r.since = observed.Add(-time.Hour)
+
+	require.NoError(t, r.pollFindings(context.Background()))
+	assert.Equal(t, 3, sink.LogRecordCount())
+	assert.Equal(t, observed, r.since)
+
+	require.Len(t, *queries, 2)
+	first := (*queries)[0]
+	assert.Equal(t, `event_time >= "2026-06-02T09:00:00Z" AND event_time < "2026-06-02T10:00:00Z" AND (severity="HIGH")`, first.Get("filter"))
+	assert.Equal(t, "1000", first.Get("pageSize"))
+	assert.Equal(t, "1", (*queries)[1].Get("pageToken"))
+}
+
+func TestPollFindingsKeepsWindowOnFailure(t *testing.T) {
+	server, _ := newSCCServer(t, [][]json.RawMessage{{readTestdata(t, "finding.json")}})
+
+	r := newTestReceiver(t, newAPIConfig(server.URL), consumertest.NewErr(errors.New("pipeline unavailable")))
+	r.apiClient = server.Client()
+	since := observed.Add(-time.Hour)
+	r.since = since
+
+	assert.Error(t, r.pollFindings(context.Background()))
+	assert.Equal(t, since, r.since)
+}
+
+func TestPollFindingsAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error": {"code": 403, "message": "The caller does not have permission", "status": "PERMISSION_DENIED"}}`))
+	}))
+	defer server.Close()
+
+	r := newTestReceiver(t, newAPIConfig(server.URL), consumertest.NewNop())
+	r.apiClient = server.Client()
+
+	assert.EqualError(t, r.pollFindings(context.Background()),
+		"ListFindings: 403 Forbidden: PERMISSION_DENIED: The caller does not have permission")
+}
+
+// fakeSubscription implements pull and acknowledge over a fixed set of messages.
+type fakeSubscription struct {
+	mu       sync.Mutex
+	messages [][]byte
+	acked    []string
+}
+
+func (s *fakeSubscription) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch req.URL.Path {
+	case "/v1/" + testSubscription + ":pull":
+		var received []map[string]any
+		for i, data := range s.messages {
+			received = append(received, map[string]any{
+				"ackId":   "ack-" + string(rune('a'+i)),
+				"message": map[string]any{"messageId": string(rune('a' + i)), "data": data},
+			})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"receivedMessages": received})
+	case "/v1/" + testSubscription + ":acknowledge":
+		var body acknowledgeRequest
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		s.acked = append(s.acked, body.AckIDs...)
+		s.messages = nil
+		_, _ = w.Write([]byte(`{}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newPubSubConfig(endpoint string) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.API = nil
+	cfg.PubSub.Endpoint = endpoint
+	cfg.PubSub.Subscription = testSubscription
+	return cfg
+}
+
+func TestPullMessages(t *testing.T) {
+	subscription := &fakeSubscription{messages: [][]byte{
+		readTestdata(t, "finding.json"),
+		[]byte(`{"finding": {"findingClass": "THREAT"}}`),
+		[]byte(`not json`),
+	}}
+	server := httptest.NewServer(subscription)
+	defer server.Close()
+
+	sink := new(consumertest.LogsSink)
+	r := newTestReceiver(t, newPubSubConfig(server.URL), sink)
+	r.pubSubClient = server.Client()
+
+	require.NoError(t, r.pullMessages(context.Background()))
+	assert.Equal(t, 1, sink.LogRecordCount())
+	// Skipped and invalid notifications are acknowledged too, so they are not redelivered.
+	assert.Equal(t, []string{"ack-a", "ack-b", "ack-c"}, subscription.acked)
+}
+
+func TestPullMessagesKeepsMessagesOnFailure(t *testing.T) {
+	subscription := &fakeSubscription{messages: [][]byte{readTestdata(t, "finding.json")}}
+	server := httptest.NewServer(subscription)
+	defer server.Close()
+
+	r := newTestReceiver(t, newPubSubConfig(server.URL), consumertest.NewErr(errors.New("pipeline unavailable")))
+	r.pubSubClient = server.Client()
+
+	assert.Error(t, r.pullMessages(context.Background()))
+	assert.Empty(t, subscription.acked)
+}
+
+func TestReceiverPullsFromSubscription(t *testing.T) {
+	subscription := &fakeSubscription{}
+	server := httptest.NewServer(subscription)
+	defer server.Close()
+
+	sink := new(consumertest.LogsSink)
+	r := newTestReceiver(t, newPubSubConfig(server.URL), sink)
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	subscription.mu.Lock()
+	subscription.messages = [][]byte{readTestdata(t, "finding.json")}
+	subscription.mu.Unlock()
+
+	assert.Eventually(t, func() bool { return sink.LogRecordCount() > 0 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, r.Shutdown(context.Background()))
+}
@@ -0,0 +1,52 @@
+package inspecreceiver
+
+import (
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+const (
+	defaultEndpoint   = "localhost:8091"
+	defaultPath       = "/inspec/results"
+	defaultEngineName = "InSpec"
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines the configuration for the InSpec receiver.
+type Config struct {
+	confighttp.ServerConfig `mapstructure:",squash"`
+
+	// Path is the URL path that accepts InSpec JSON reporter payloads.
+	Path string `mapstructure:"path"`
+
+	// EngineName is written to policy.engine.name on every emitted record.
+	EngineName string `mapstructure:"engine_name"`
+}
+
+func createDefaultConfig() component.Config {
+	httpCfg := confighttp.NewDefaultServerConfig()
+	httpCfg.NetAddr.Endpoint = defaultEndpoint
+	return &Config{
+		ServerConfig: httpCfg,
+		Path:         defaultPath,
+		EngineName:   defaultEngineName,
+	}
+}
+
+// Validate checks the receiver configuration is valid.
+func (c *Config) Validate() error {
+	if c.NetAddr.Endpoint == "" {
+		return errors.New("endpoint must not be empty")
+	}
+	if !strings.HasPrefix(c.Path, "/") {
+		return errors.New("path must start with /")
+	}
+	if c.EngineName == "" {
+		return errors.New("engine_name must not be empty")
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package inspecreceiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+)
+
+// defaultMaxUploadBytes bounds the decompressed size of a single report.
+const defaultMaxUploadBytes = 64 << 20
+
+var _ receiver.Logs = (*inspecReceiver)(nil)
+
+type inspecReceiver struct {
+	cfg      *Config
+	settings receiver.Settings
+	next     consumer.Logs
+
+	server         *http.Server
+	maxUploadBytes int64
+	wg             sync.WaitGroup
+}
+
+func newInSpecReceiver(cfg *Config, set receiver.Settings, next consumer.Logs) *inspecReceiver {
+	return &inspecReceiver{
+		cfg:            cfg,
+		settings:       set,
+		next:           next,
+		maxUploadBytes: defaultMaxUploadBytes,
+	}
+}
+
+// Start begins accepting InSpec reports.
+func (r *inspecReceiver) Start(ctx context.Context, host component.Host) error {
+	listener, err := r.cfg.ToListener(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to bind to %s: %w", r.cfg.NetAddr.Endpoint, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(r.cfg.Path, r.handleUpload)
+
+	r.server, err = r.cfg.ToServer(ctx, host.GetExtensions(), r.settings.TelemetrySettings, mux)
+	if err != nil {
+		return err
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		if errHTTP := r.server.Serve(listener); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+			componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(errHTTP))
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the HTTP server.
+func (r *inspecReceiver) Shutdown(ctx context.Context) error {
+	var err error
+	if r.server != nil {
+		err = r.server.Shutdown(ctx)
+	}
+	r.wg.Wait()
+	return err
+}
+
+// handleUpload answers 202 Accepted once the records of every control result
+// have been handed to the pipeline.
+func (r *inspecReceiver) handleUpload(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body := http.MaxBytesReader(w, req.Body, r.maxUploadBytes)
+	defer body.Close()
+
+	logs, err := parseReport(body, r.cfg.EngineName, time.Now())
+	if err == nil {
+		err = r.next.ConsumeLogs(req.Context(), logs)
+	}
+	if err != nil {
+		r.settings.Logger.Warn("rejected InSpec report", zap.Error(err))
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, "report too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		if errors.Is(err, errInvalidPayload) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "failed to process report", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
@@ -0,0 +1,79 @@
+package inspecreceiver
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+const minimalReport = `{"version": "5.22.55", "profiles": [{"name": "p", "controls": [{"id": "c1", "impact": 0.5, "results": [{"status": "passed"}]}]}]}`
+
+func TestHandleUpload(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		next       consumer.Logs
+		wantStatus int
+		wantLogs   int
+	}{
+		{
+			name:       "non-POST is rejected",
+			method:     http.MethodGet,
+			next:       new(consumertest.LogsSink),
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "invalid report",
+			method:     http.MethodPost,
+			body:       "not json",
+			next:       new(consumertest.LogsSink),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "oversized report",
+			method:     http.MethodPost,
+			body:       "{" + strings.Repeat(" ", 1024) + "}",
+			next:       new(consumertest.LogsSink),
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "pipeline failure",
+			method:     http.MethodPost,
+			body:       minimalReport,
+			next:       consumertest.NewErr(errors.New("pipeline unavailable")),
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:       "accepted",
+			method:     http.MethodPost,
+			body:       minimalReport,
+			next:       new(consumertest.LogsSink),
+			wantStatus: http.StatusAccepted,
+			wantLogs:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newInSpecReceiver(createDefaultConfig().(*Config), receivertest.NewNopSettings(componentType), tt.next)
+			r.maxUploadBytes = 512
+			req := httptest.NewRequest(tt.method, defaultPath, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			r.handleUpload(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if sink, ok := tt.next.(*consumertest.LogsSink); ok {
+				assert.Equal(t, tt.wantLogs, sink.LogRecordCount())
+			}
+		})
+	}
+}
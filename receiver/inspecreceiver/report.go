@@ -0,0 +1,221 @@
+package inspecreceiver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+const (
+	scopeName = "github.com/complytime/complybeacon/receiver/inspecreceiver"
+
+	// fixLabel labels the control description that holds the remediation.
+	fixLabel = "fix"
+)
+
+// errInvalidPayload wraps failures caused by the content of an upload rather
+// than by the pipeline, so the upload can be rejected instead of retried.
+var errInvalidPayload = errors.New("invalid InSpec report")
+
+// requirementTags are the control tags listing the requirements a control
+// implements, such as NIST SP 800-53 controls and DISA CCIs.
+var requirementTags = []string{"nist", "cci"}
+
+// report is the subset of an InSpec JSON reporter payload used to build evidence.
+type report struct {
+	Version  string `json:"version"`
+	Platform struct {
+		Name     string `json:"name"`
+		Release  string `json:"release"`
+		TargetID string `json:"target_id"`
+	} `json:"platform"`
+	Profiles []profile `json:"profiles"`
+}
+
+type profile struct {
+	Name     string    `json:"name"`
+	Version  string    `json:"version"`
+	Controls []control `json:"controls"`
+}
+
+type control struct {
+	ID           string         `json:"id"`
+	Title        string         `json:"title"`
+	Impact       float64        `json:"impact"`
+	Tags         map[string]any `json:"tags"`
+	Descriptions []struct {
+		Label string `json:"label"`
+		Data  string `json:"data"`
+	} `json:"descriptions"`
+	// Results holds the raw results, which become the record bodies.
+	Results []json.RawMessage `json:"results"`
+}
+
+// result is one test result of a control, the outcome of one describe block.
+type result struct {
+	Status      string `json:"status"`
+	CodeDesc    string `json:"code_desc"`
+	StartTime   string `json:"start_time"`
+	Message     string `json:"message"`
+	SkipMessage string `json:"skip_message"`
+	Exception   string `json:"exception"`
+}
+
+// parseReport decodes an InSpec JSON reporter payload and fans every control
+// result out into its own log record.
+func parseReport(r io.Reader, engineName string, observed time.Time) (plog.Logs, error) {
+	var rep report
+	if err := json.NewDecoder(r).Decode(&rep); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return plog.Logs{}, err
+		}
+		return plog.Logs{}, fmt.Errorf("%w: %w", errInvalidPayload, err)
+	}
+	if len(rep.Profiles) == 0 {
+		return plog.Logs{}, fmt.Errorf("%w: no profiles", errInvalidPayload)
+	}
+
+	logs := plog.NewLogs()
+	sl := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	sl.Scope().SetName(scopeName)
+	for _, p := range rep.Profiles {
+		for _, c := range p.Controls {
+			for i, data := range c.Results {
+				var res result
+				if err := json.Unmarshal(data, &res); err != nil {
+					return plog.Logs{}, fmt.Errorf("%w: control %s result %d: %w", errInvalidPayload, c.ID, i, err)
+				}
+				var body map[string]any
+				if err := json.Unmarshal(data, &body); err != nil {
+					return plog.Logs{}, fmt.Errorf("%w: control %s result %d: %w", errInvalidPayload, c.ID, i, err)
+				}
+				appendResult(sl.LogRecords(), rep, p, c, res, body, engineName, observed)
+			}
+		}
+	}
+	return logs, nil
+}
+
+func appendResult(records plog.LogRecordSlice, rep report, p profile, c control, res result, body map[string]any, engineName string, observed time.Time) {
+	record := records.AppendEmpty()
+	record.SetObservedTimestamp(pcommon.NewTimestampFromTime(observed))
+	record.SetTimestamp(pcommon.NewTimestampFromTime(parseTime(res.StartTime, observed)))
+	record.SetSeverityNumber(plog.SeverityNumberInfo)
+	record.SetSeverityText(plog.SeverityNumberInfo.String())
+
+	// The body is the result, with the control, profile and platform it belongs to.
+	body["control"] = map[string]any{"id": c.ID, "title": c.Title, "impact": c.Impact}
+	body["profile"] = map[string]any{"name": p.Name, "version": p.Version}
+	body["platform"] = map[string]any{"name": rep.Platform.Name, "release": rep.Platform.Release}
+	_ = record.Body().SetEmptyMap().FromRaw(body)
+
+	attrs := record.Attributes()
+	attrs.PutStr(proofwatch.POLICY_ENGINE_NAME, engineName)
+	putStr(attrs, proofwatch.POLICY_ENGINE_VERSION, rep.Version)
+	attrs.PutStr(proofwatch.POLICY_RULE_ID, c.ID)
+	putStr(attrs, proofwatch.POLICY_RULE_NAME, c.Title)
+	attrs.PutStr(proofwatch.POLICY_EVALUATION_RESULT, mapStatus(res.Status))
+	putStr(attrs, proofwatch.POLICY_EVALUATION_MESSAGE, message(res))
+	putStr(attrs, proofwatch.POLICY_TARGET_ID, rep.Platform.TargetID)
+	putStr(attrs, proofwatch.POLICY_TARGET_NAME, rep.Platform.TargetID)
+	putStr(attrs, proofwatch.COMPLIANCE_CONTROL_CATALOG_ID, p.Name)
+	attrs.PutStr(proofwatch.COMPLIANCE_RISK_LEVEL, mapImpact(c.Impact))
+	if requirements := tagValues(c.Tags, requirementTags); len(requirements) > 0 {
+		slice := attrs.PutEmptySlice(proofwatch.COMPLIANCE_REQUIREMENTS)
+		for _, r := range requirements {
+			slice.AppendEmpty().SetStr(r)
+		}
+	}
+	for _, d := range c.Descriptions {
+		if d.Label == fixLabel {
+			putStr(attrs, proofwatch.COMPLIANCE_REMEDIATION_DESCRIPTION, strings.TrimSpace(d.Data))
+		}
+	}
+}
+
+// message is the failure, skip or error message of a result, falling back to
+// the description of the test.
+func message(res result) string {
+	for _, m := range []string{res.Message, res.SkipMessage, res.Exception} {
+		if m = strings.TrimSpace(m); m != "" {
+			return m
+		}
+	}
+	return res.CodeDesc
+}
+
+// tagValues returns the string values of the named tags. A tag is a single
+// string or a list of strings.
+func tagValues(tags map[string]any, names []string) []string {
+	var values []string
+	for _, name := range names {
+		switch v := tags[name].(type) {
+		case string:
+			if v != "" {
+				values = append(values, v)
+			}
+		case []any:
+			for _, item := range v {
+				if s, ok := item.(string); ok && s != "" {
+					values = append(values, s)
+				}
+			}
+		}
+	}
+	return values
+}
+
+// mapStatus maps an InSpec result status to policy.evaluation.result.
+func mapStatus(status string) string {
+	switch status {
+	case "passed":
+		return "Passed"
+	case "failed":
+		return "Failed"
+	case "skipped":
+		return "Not Run"
+	default:
+		// error
+		return "Unknown"
+	}
+}
+
+// mapImpact maps an InSpec control impact to compliance.risk.level, using
+// the ranges InSpec names none, low, medium, high and critical.
+func mapImpact(impact float64) string {
+	switch {
+	case impact < 0.1:
+		return "Informational"
+	case impact < 0.4:
+		return "Low"
+	case impact < 0.7:
+		return "Medium"
+	case impact < 0.9:
+		return "High"
+	default:
+		return "Critical"
+	}
+}
+
+func parseTime(value string, fallback time.Time) time.Time {
+	if t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(value)); err == nil {
+		return t
+	}
+	return fallback
+}
+
+func putStr(attrs pcommon.Map, key, value string) {
+	if value != "" {
+		attrs.PutStr(key, value)
+	}
+}
@@ -0,0 +1,99 @@
+package inspecreceiver
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+var observed = time.Date(2026, 6, 2, 10, 0, 0, 0, time.UTC)
+
+func TestParseReport(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "report.json"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	logs, err := parseReport(f, defaultEngineName, observed)
+	require.NoError(t, err)
+	require.Equal(t, 3, logs.LogRecordCount(), "one record per control result")
+	assert.Equal(t, scopeName, logs.ResourceLogs().At(0).ScopeLogs().At(0).Scope().Name())
+
+	records := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	passed := records.At(0)
+	assert.Equal(t, time.Date(2026, 6, 2, 8, 30, 0, 0, time.UTC), passed.Timestamp().AsTime())
+	assert.Equal(t, observed, passed.ObservedTimestamp().AsTime())
+	assert.Equal(t, map[string]any{
+		proofwatch.POLICY_ENGINE_NAME:                 "InSpec",
+		proofwatch.POLICY_ENGINE_VERSION:              "5.22.55",
+		proofwatch.POLICY_RULE_ID:                     "os-02",
+		proofwatch.POLICY_RULE_NAME:                   "Check owner and permissions for /etc/shadow",
+		proofwatch.POLICY_EVALUATION_RESULT:           "Passed",
+		proofwatch.POLICY_EVALUATION_MESSAGE:          "File /etc/shadow is expected to exist",
+		proofwatch.POLICY_TARGET_ID:                   "web-01.example.com",
+		proofwatch.POLICY_TARGET_NAME:                 "web-01.example.com",
+		proofwatch.COMPLIANCE_CONTROL_CATALOG_ID:      "linux-baseline",
+		proofwatch.COMPLIANCE_RISK_LEVEL:              "Critical",
+		proofwatch.COMPLIANCE_REQUIREMENTS:            []any{"AC-3", "AC-6", "CCI-000213"},
+		proofwatch.COMPLIANCE_REMEDIATION_DESCRIPTION: "Run chown root:shadow /etc/shadow and chmod 0640 /etc/shadow.",
+	}, passed.Attributes().AsRaw())
+
+	body := passed.Body().Map().AsRaw()
+	assert.Equal(t, "File /etc/shadow", body["resource"])
+	assert.Equal(t, map[string]any{"id": "os-02", "title": "Check owner and permissions for /etc/shadow", "impact": 1.0}, body["control"])
+	assert.Equal(t, map[string]any{"name": "linux-baseline", "version": "2.9.0"}, body["profile"])
+	assert.Equal(t, map[string]any{"name": "ubuntu", "release": "22.04"}, body["platform"])
+
+	failed := records.At(1).Attributes().AsRaw()
+	assert.Equal(t, "Failed", failed[proofwatch.POLICY_EVALUATION_RESULT])
+	assert.Equal(t, "expected File /etc/shadow not to be readable by other", failed[proofwatch.POLICY_EVALUATION_MESSAGE])
+
+	skipped := records.At(2).Attributes().AsRaw()
+	assert.Equal(t, "Not Run", skipped[proofwatch.POLICY_EVALUATION_RESULT])
+	assert.Equal(t, "Skipped control due to only_if condition.", skipped[proofwatch.POLICY_EVALUATION_MESSAGE])
+	assert.Equal(t, "Medium", skipped[proofwatch.COMPLIANCE_RISK_LEVEL])
+	assert.NotContains(t, skipped, proofwatch.COMPLIANCE_REQUIREMENTS)
+}
+
+func TestParseReportInvalid(t *testing.T) {
+	for name, payload := range map[string]string{
+		"not JSON":       "not json",
+		"no profiles":    `{"version": "5.22.55"}`,
+		"invalid result": `{"profiles": [{"controls": [{"id": "c1", "results": [{"status": 1}]}]}]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseReport(strings.NewReader(payload), defaultEngineName, observed)
+			assert.ErrorIs(t, err, errInvalidPayload)
+		})
+	}
+}
+
+func TestMapStatus(t *testing.T) {
+	for status, want := range map[string]string{
+		"passed":  "Passed",
+		"failed":  "Failed",
+		"skipped": "Not Run",
+		"error":   "Unknown",
+	} {
+		assert.Equal(t, want, mapStatus(status), status)
+	}
+}
+
+func TestMapImpact(t *testing.T) {
+	for impact, want := range map[float64]string{
+		0.0: "Informational",
+		0.3: "Low",
+		0.5: "Medium",
+		0.7: "High",
+		0.9: "Critical",
+		1.0: "Critical",
+	} {
+		assert.Equal(t, want, mapImpact(impact), impact)
+	}
+}
@@ -0,0 +1,53 @@
+package opadecisionlogreceiver
+
+import (
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+const (
+	defaultEndpoint   = "localhost:8182"
+	defaultPath       = "/logs"
+	defaultEngineName = "OPA"
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines the configuration for the OPA decision log receiver.
+type Config struct {
+	confighttp.ServerConfig `mapstructure:",squash"`
+
+	// Path is the URL path that accepts decision log uploads. OPA posts to
+	// the service URL followed by the decision_logs resource, "/logs" by default.
+	Path string `mapstructure:"path"`
+
+	// EngineName is written to policy.engine.name on every emitted record.
+	EngineName string `mapstructure:"engine_name"`
+}
+
+func createDefaultConfig() component.Config {
+	httpCfg := confighttp.NewDefaultServerConfig()
+	httpCfg.NetAddr.Endpoint = defaultEndpoint
+	return &Config{
+		ServerConfig: httpCfg,
+		Path:         defaultPath,
+		EngineName:   defaultEngineName,
+	}
+}
+
+// Validate checks the receiver configuration is valid.
+func (c *Config) Validate() error {
+	if c.NetAddr.Endpoint == "" {
+		return errors.New("endpoint must not be empty")
+	}
+	if !strings.HasPrefix(c.Path, "/") {
+		return errors.New("path must start with /")
+	}
+	if c.EngineName == "" {
+		return errors.New("engine_name must not be empty")
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package opadecisionlogreceiver
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+const (
+	scopeName = "github.com/complytime/complybeacon/receiver/opadecisionlogreceiver"
+
+	resultPassed  = "Passed"
+	resultFailed  = "Failed"
+	resultUnknown = "Unknown"
+
+	serviceInstanceID = "service.instance.id"
+)
+
+// errInvalidPayload wraps failures caused by the content of an upload rather
+// than by the pipeline, so the upload can be rejected instead of retried.
+var errInvalidPayload = errors.New("invalid decision log payload")
+
+// decisionEvent is the subset of an OPA decision log event used to build evidence.
+type decisionEvent struct {
+	Labels     map[string]string `json:"labels"`
+	DecisionID string            `json:"decision_id"`
+	Path       string            `json:"path"`
+	Query      string            `json:"query"`
+	Result     json.RawMessage   `json:"result"`
+	Timestamp  time.Time         `json:"timestamp"`
+}
+
+// parseDecisions decodes an uploaded JSON array of decision events and converts
+// each event into a log record. Events are grouped into one resource per OPA
+// instance.
+func parseDecisions(r io.Reader, engineName string, observed time.Time) (plog.Logs, error) {
+	var raw []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return plog.Logs{}, err
+		}
+		return plog.Logs{}, fmt.Errorf("%w: %w", errInvalidPayload, err)
+	}
+
+	logs := plog.NewLogs()
+	scopes := map[string]plog.ScopeLogs{}
+	for i, data := range raw {
+		var event decisionEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			return plog.Logs{}, fmt.Errorf("%w: event %d: %w", errInvalidPayload, i, err)
+		}
+		var body map[string]any
+		if err := json.Unmarshal(data, &body); err != nil {
+			return plog.Logs{}, fmt.Errorf("%w: event %d: %w", errInvalidPayload, i, err)
+		}
+
+		instanceID := event.Labels["id"]
+		sl, ok := scopes[instanceID]
+		if !ok {
+			rl := logs.ResourceLogs().AppendEmpty()
+			if instanceID != "" {
+				rl.Resource().Attributes().PutStr(serviceInstanceID, instanceID)
+			}
+			sl = rl.ScopeLogs().AppendEmpty()
+			sl.Scope().SetName(scopeName)
+			scopes[instanceID] = sl
+		}
+		appendDecision(sl.LogRecords(), event, body, engineName, observed)
+	}
+	return logs, nil
+}
+
+func appendDecision(records plog.LogRecordSlice, event decisionEvent, body map[string]any, engineName string, observed time.Time) {
+	record := records.AppendEmpty()
+	record.SetObservedTimestamp(pcommon.NewTimestampFromTime(observed))
+	timestamp := event.Timestamp
+	if timestamp.IsZero() {
+		timestamp = observed
+	}
+	record.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
+	record.SetSeverityNumber(plog.SeverityNumberInfo)
+	record.SetSeverityText(plog.SeverityNumberInfo.String())
+	_ = record.Body().SetEmptyMap().FromRaw(body)
+
+	result, message := evaluate(event.Result)
+	attrs := record.Attributes()
+	attrs.PutStr(proofwatch.POLICY_ENGINE_NAME, engineName)
+	if version := event.Labels["version"]; version != "" {
+		attrs.PutStr(proofwatch.POLICY_ENGINE_VERSION, version)
+	}
+	ruleID := event.Path
+	if ruleID == "" {
+		ruleID = event.Query
+	}
+	if ruleID != "" {
+		attrs.PutStr(proofwatch.POLICY_RULE_ID, ruleID)
+	}
+	attrs.PutStr(proofwatch.POLICY_EVALUATION_RESULT, result)
+	if message != "" {
+		attrs.PutStr(proofwatch.POLICY_EVALUATION_MESSAGE, message)
+	}
+}
+
+// evaluate derives policy.evaluation.result from a decision result. A boolean
+// result, or an object with a boolean "allow", passes when true. A deny-style
+// set, or an object with a "deny", "violation" or "violations" set, passes when
+// empty; its entries become the evaluation message. Undefined and other results
+// are Unknown.
+func evaluate(raw json.RawMessage) (string, string) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return resultUnknown, "decision was undefined"
+	}
+
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return resultUnknown, ""
+	}
+
+	switch v := value.(type) {
+	case bool:
+		return passed(v), ""
+	case []any:
+		return violations(v)
+	case map[string]any:
+		if allow, ok := v["allow"].(bool); ok {
+			return passed(allow), ""
+		}
+		for _, key := range []string{"deny", "violation", "violations"} {
+			if set, ok := v[key].([]any); ok {
+				return violations(set)
+			}
+		}
+	}
+	return resultUnknown, ""
+}
+
+func passed(allowed bool) string {
+	if allowed {
+		return resultPassed
+	}
+	return resultFailed
+}
+
+// violations fails on a non-empty set and joins the messages of its entries,
+// which are either strings or objects with a "msg" field.
+func violations(set []any) (string, string) {
+	if len(set) == 0 {
+		return resultPassed, ""
+	}
+	messages := make([]string, 0, len(set))
+	for _, entry := range set {
+		switch e := entry.(type) {
+		case string:
+			messages = append(messages, e)
+		case map[string]any:
+			if msg, ok := e["msg"].(string); ok {
+				messages = append(messages, msg)
+			}
+		}
+	}
+	return resultFailed, strings.Join(messages, "\n")
+}
@@ -0,0 +1,94 @@
+package opadecisionlogreceiver
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+var observed = time.Date(2026, 6, 2, 10, 20, 0, 0, time.UTC)
+
+func TestParseDecisions(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "decisions.json"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	logs, err := parseDecisions(f, defaultEngineName, observed)
+	require.NoError(t, err)
+	require.Equal(t, 3, logs.LogRecordCount())
+	require.Equal(t, 2, logs.ResourceLogs().Len(), "one resource per OPA instance")
+
+	rl := logs.ResourceLogs().At(0)
+	instance, ok := rl.Resource().Attributes().Get(serviceInstanceID)
+	require.True(t, ok)
+	assert.Equal(t, "5c9a6e1c-0d3b-4a5e-9d49-6b0f3f6c2a11", instance.Str())
+	assert.Equal(t, scopeName, rl.ScopeLogs().At(0).Scope().Name())
+
+	records := rl.ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 2, records.Len())
+
+	denied := records.At(0)
+	assert.Equal(t, time.Date(2026, 6, 2, 10, 15, 30, 123456789, time.UTC), denied.Timestamp().AsTime())
+	assert.Equal(t, observed, denied.ObservedTimestamp().AsTime())
+	assert.Equal(t, map[string]any{
+		proofwatch.POLICY_ENGINE_NAME:       "OPA",
+		proofwatch.POLICY_ENGINE_VERSION:    "1.4.2",
+		proofwatch.POLICY_RULE_ID:           "http/authz/allow",
+		proofwatch.POLICY_EVALUATION_RESULT: "Failed",
+	}, denied.Attributes().AsRaw())
+	decisionID, ok := denied.Body().Map().Get("decision_id")
+	require.True(t, ok)
+	assert.Equal(t, "4ca636c1-55e4-417a-b1d8-4aceb67960d1", decisionID.Str())
+
+	admission := records.At(1).Attributes().AsRaw()
+	assert.Equal(t, "Failed", admission[proofwatch.POLICY_EVALUATION_RESULT])
+	assert.Equal(t, "container nginx has no resource limits\nimage nginx:latest uses the latest tag",
+		admission[proofwatch.POLICY_EVALUATION_MESSAGE])
+
+	query := logs.ResourceLogs().At(1).ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw()
+	assert.Equal(t, "data.terraform.analysis", query[proofwatch.POLICY_RULE_ID])
+	assert.Equal(t, "Passed", query[proofwatch.POLICY_EVALUATION_RESULT])
+}
+
+func TestParseDecisionsInvalid(t *testing.T) {
+	for _, body := range []string{"not json", `{"decision_id": "x"}`, `[{"timestamp": "yesterday"}]`} {
+		_, err := parseDecisions(strings.NewReader(body), defaultEngineName, observed)
+		assert.ErrorIs(t, err, errInvalidPayload, body)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		result      string
+		wantResult  string
+		wantMessage string
+	}{
+		{result: ``, wantResult: "Unknown", wantMessage: "decision was undefined"},
+		{result: `null`, wantResult: "Unknown", wantMessage: "decision was undefined"},
+		{result: `true`, wantResult: "Passed"},
+		{result: `false`, wantResult: "Failed"},
+		{result: `[]`, wantResult: "Passed"},
+		{result: `[{"msg": "privileged container"}, "host network"]`, wantResult: "Failed", wantMessage: "privileged container\nhost network"},
+		{result: `{"allow": false, "reason": "no role"}`, wantResult: "Failed"},
+		{result: `{"violations": []}`, wantResult: "Passed"},
+		{result: `{"deny": ["missing label team"]}`, wantResult: "Failed", wantMessage: "missing label team"},
+		{result: `{"score": 3}`, wantResult: "Unknown"},
+		{result: `"allowed"`, wantResult: "Unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.result, func(t *testing.T) {
+			result, message := evaluate(json.RawMessage(tt.result))
+			assert.Equal(t, tt.wantResult, result)
+			assert.Equal(t, tt.wantMessage, message)
+		})
+	}
+}
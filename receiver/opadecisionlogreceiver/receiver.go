@@ -0,0 +1,109 @@
+package opadecisionlogreceiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+)
+
+// defaultMaxUploadBytes bounds the decompressed size of a single upload.
+const defaultMaxUploadBytes = 16 << 20
+
+var _ receiver.Logs = (*decisionLogReceiver)(nil)
+
+type decisionLogReceiver struct {
+	cfg      *Config
+	settings receiver.Settings
+	next     consumer.Logs
+
+	server         *http.Server
+	maxUploadBytes int64
+	wg             sync.WaitGroup
+}
+
+func newDecisionLogReceiver(cfg *Config, set receiver.Settings, next consumer.Logs) *decisionLogReceiver {
+	return &decisionLogReceiver{
+		cfg:            cfg,
+		settings:       set,
+		next:           next,
+		maxUploadBytes: defaultMaxUploadBytes,
+	}
+}
+
+// Start begins accepting decision log uploads.
+func (r *decisionLogReceiver) Start(ctx context.Context, host component.Host) error {
+	listener, err := r.cfg.ToListener(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to bind to %s: %w", r.cfg.NetAddr.Endpoint, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(r.cfg.Path, r.handleUpload)
+
+	// The server handles the gzip Content-Encoding that OPA uploads with.
+	r.server, err = r.cfg.ToServer(ctx, host.GetExtensions(), r.settings.TelemetrySettings, mux)
+	if err != nil {
+		return err
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		if errHTTP := r.server.Serve(listener); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+			componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(errHTTP))
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the HTTP server.
+func (r *decisionLogReceiver) Shutdown(ctx context.Context) error {
+	var err error
+	if r.server != nil {
+		err = r.server.Shutdown(ctx)
+	}
+	r.wg.Wait()
+	return err
+}
+
+// handleUpload answers 200 OK on success, since OPA treats any other status
+// as a failed upload and retries it.
+func (r *decisionLogReceiver) handleUpload(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body := http.MaxBytesReader(w, req.Body, r.maxUploadBytes)
+	defer body.Close()
+
+	logs, err := parseDecisions(body, r.cfg.EngineName, time.Now())
+	if err == nil {
+		err = r.next.ConsumeLogs(req.Context(), logs)
+	}
+	if err != nil {
+		r.settings.Logger.Warn("rejected decision log upload", zap.Error(err))
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, "decision log upload too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		if errors.Is(err, errInvalidPayload) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "failed to process decision logs", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,90 @@
+package policyreportreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id       component.ID
+		expected *Config
+	}{
+		{
+			id:       component.NewID(componentType),
+			expected: createDefaultConfig().(*Config),
+		},
+		{
+			id: component.NewIDWithName(componentType, "scoped"),
+			expected: &Config{
+				AuthType:       AuthTypeKubeConfig,
+				Namespaces:     []string{"payments", "ledger"},
+				ClusterReports: false,
+				ResyncPeriod:   time.Minute,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, sub.Unmarshal(cfg))
+			require.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "unknown auth type",
+			mutate:  func(cfg *Config) { cfg.AuthType = "token" },
+			wantErr: `auth_type must be one of "serviceAccount" or "kubeConfig", got "token"`,
+		},
+		{
+			name:    "negative resync period",
+			mutate:  func(cfg *Config) { cfg.ResyncPeriod = -time.Second },
+			wantErr: "resync_period must not be negative",
+		},
+		{
+			name:    "empty namespace",
+			mutate:  func(cfg *Config) { cfg.Namespaces = []string{"payments", ""} },
+			wantErr: "namespaces must not contain empty values",
+		},
+		{
+			name:   "valid",
+			mutate: func(*Config) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package policyreportreceiver
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+var _ receiver.Logs = (*policyReportReceiver)(nil)
+
+type policyReportReceiver struct {
+	cfg      *Config
+	settings receiver.Settings
+	next     consumer.Logs
+
+	// makeClient is replaced in tests with a fake dynamic client.
+	makeClient func(*Config) (dynamic.Interface, error)
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+
+	mu sync.Mutex
+	// outcomes holds the last delivered result value per result key, per report.
+	outcomes map[types.UID]map[string]string
+}
+
+func newPolicyReportReceiver(cfg *Config, set receiver.Settings, next consumer.Logs) *policyReportReceiver {
+	return &policyReportReceiver{
+		cfg:        cfg,
+		settings:   set,
+		next:       next,
+		makeClient: newDynamicClient,
+		outcomes:   make(map[types.UID]map[string]string),
+	}
+}
+
+func newDynamicClient(cfg *Config) (dynamic.Interface, error) {
+	var restCfg *rest.Config
+	var err error
+	switch cfg.AuthType {
+	case AuthTypeKubeConfig:
+		rules := clientcmd.NewDefaultClientConfigLoadingRules()
+		restCfg, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{}).ClientConfig()
+	default:
+		restCfg, err = rest.InClusterConfig()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Kubernetes client configuration: %w", err)
+	}
+	return dynamic.NewForConfig(restCfg)
+}
+
+// Start builds the informers and begins watching reports.
+func (r *policyReportReceiver) Start(_ context.Context, _ component.Host) error {
+	client, err := r.makeClient(r.cfg)
+	if err != nil {
+		return err
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	namespaces := r.cfg.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+	for _, ns := range namespaces {
+		r.watch(runCtx, dynamicinformer.NewFilteredDynamicSharedInformerFactory(client, r.cfg.ResyncPeriod, ns, nil), policyReportGVR)
+	}
+	if r.cfg.ClusterReports {
+		r.watch(runCtx, dynamicinformer.NewDynamicSharedInformerFactory(client, r.cfg.ResyncPeriod), clusterPolicyReportGVR)
+	}
+	return nil
+}
+
+// Shutdown stops the informers.
+func (r *policyReportReceiver) Shutdown(context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	return nil
+}
+
+func (r *policyReportReceiver) watch(ctx context.Context, factory dynamicinformer.DynamicSharedInformerFactory, gvr schema.GroupVersionResource) {
+	informer := factory.ForResource(gvr).Informer()
+	_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj any) { r.handleReport(ctx, obj) },
+		UpdateFunc: func(_, obj any) { r.handleReport(ctx, obj) },
+		DeleteFunc: r.handleDelete,
+	})
+	if err != nil {
+		r.settings.Logger.Error("failed to register report handler", zap.String("resource", gvr.Resource), zap.Error(err))
+		return
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		informer.Run(ctx.Done())
+	}()
+}
+
+// handleReport emits the changed results of a report. The stored outcomes are
+// only advanced once the pipeline accepts the records, so a rejected batch is
+// retried on the next update or resync of the report.
+func (r *policyReportReceiver) handleReport(ctx context.Context, obj any) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	var report policyReport
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &report); err != nil {
+		r.settings.Logger.Warn("failed to decode policy report", zap.String("name", u.GetName()), zap.Error(err))
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	logs, current := convertReport(report, r.outcomes[report.UID], time.Now())
+	if logs.LogRecordCount() > 0 {
+		if err := r.next.ConsumeLogs(ctx, logs); err != nil {
+			r.settings.Logger.Warn("failed to deliver policy report results",
+				zap.String("namespace", report.Namespace), zap.String("name", report.Name), zap.Error(err))
+			return
+		}
+	}
+	r.outcomes[report.UID] = current
+}
+
+func (r *policyReportReceiver) handleDelete(obj any) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.outcomes, u.GetUID())
+}
@@ -0,0 +1,91 @@
+package policyreportreceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+func newTestReceiver(next consumer.Logs) *policyReportReceiver {
+	return newPolicyReportReceiver(createDefaultConfig().(*Config), receivertest.NewNopSettings(componentType), next)
+}
+
+func TestHandleReport(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	r := newTestReceiver(sink)
+	obj := loadReportObject(t)
+
+	r.handleReport(context.Background(), obj)
+	assert.Equal(t, 4, sink.LogRecordCount())
+
+	// Resyncs deliver the unchanged report again.
+	r.handleReport(context.Background(), obj)
+	assert.Equal(t, 4, sink.LogRecordCount())
+
+	// Deleting the report forgets its outcomes, so a re-created report is emitted in full.
+	r.handleDelete(cache.DeletedFinalStateUnknown{Key: "payments/cpol-require-labels", Obj: obj})
+	r.handleReport(context.Background(), obj)
+	assert.Equal(t, 8, sink.LogRecordCount())
+}
+
+func TestHandleReportRetriesOnPipelineFailure(t *testing.T) {
+	r := newTestReceiver(consumertest.NewErr(errors.New("pipeline unavailable")))
+	obj := loadReportObject(t)
+
+	r.handleReport(context.Background(), obj)
+	assert.Empty(t, r.outcomes)
+
+	sink := new(consumertest.LogsSink)
+	r.next = sink
+	r.handleReport(context.Background(), obj)
+	assert.Equal(t, 4, sink.LogRecordCount())
+	assert.Len(t, r.outcomes, 1)
+}
+
+func TestHandleReportIgnoresUnknownObjects(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	r := newTestReceiver(sink)
+
+	r.handleReport(context.Background(), "not a report")
+	r.handleDelete("not a report")
+	assert.Equal(t, 0, sink.LogRecordCount())
+}
+
+func TestReceiverWatchesReports(t *testing.T) {
+	obj := loadReportObject(t)
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			policyReportGVR:        "PolicyReportList",
+			clusterPolicyReportGVR: "ClusterPolicyReportList",
+		}, obj)
+
+	sink := new(consumertest.LogsSink)
+	r := newTestReceiver(sink)
+	r.makeClient = func(*Config) (dynamic.Interface, error) { return client, nil }
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+	assert.Eventually(t, func() bool { return sink.LogRecordCount() == 4 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestStartFailsWithoutClient(t *testing.T) {
+	r := newTestReceiver(consumertest.NewNop())
+	r.makeClient = func(*Config) (dynamic.Interface, error) { return nil, errors.New("no cluster") }
+
+	require.EqualError(t, r.Start(context.Background(), componenttest.NewNopHost()), "no cluster")
+	require.NoError(t, r.Shutdown(context.Background()))
+}
@@ -0,0 +1,198 @@
+package policyreportreceiver
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+const (
+	scopeName = "github.com/complytime/complybeacon/receiver/policyreportreceiver"
+
+	// k8sNamespaceName is the OpenTelemetry resource semantic convention for the namespace.
+	k8sNamespaceName = "k8s.namespace.name"
+)
+
+var (
+	policyReportGVR = schema.GroupVersionResource{
+		Group:    "wgpolicyk8s.io",
+		Version:  "v1alpha2",
+		Resource: "policyreports",
+	}
+	clusterPolicyReportGVR = schema.GroupVersionResource{
+		Group:    "wgpolicyk8s.io",
+		Version:  "v1alpha2",
+		Resource: "clusterpolicyreports",
+	}
+)
+
+// policyReport is the subset of a wgpolicyk8s.io/v1alpha2 PolicyReport or
+// ClusterPolicyReport used to build evidence.
+type policyReport struct {
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Scope is the resource the whole report applies to, used when a result
+	// does not list its own resources.
+	Scope   *corev1.ObjectReference `json:"scope,omitempty"`
+	Results []reportResult          `json:"results,omitempty"`
+}
+
+type reportResult struct {
+	Source    string                   `json:"source,omitempty"`
+	Policy    string                   `json:"policy"`
+	Rule      string                   `json:"rule,omitempty"`
+	Category  string                   `json:"category,omitempty"`
+	Severity  string                   `json:"severity,omitempty"`
+	Message   string                   `json:"message,omitempty"`
+	Result    string                   `json:"result,omitempty"`
+	Timestamp metav1.Timestamp         `json:"timestamp,omitempty"`
+	Resources []corev1.ObjectReference `json:"resources,omitempty"`
+}
+
+// convertReport returns a log record for every result of report whose outcome
+// differs from previous, together with the complete outcome set of the report.
+// Outcomes are keyed by policy, rule and resource, so a result is emitted when it
+// first appears and again whenever its result value changes.
+func convertReport(report policyReport, previous map[string]string, observed time.Time) (plog.Logs, map[string]string) {
+	logs := plog.NewLogs()
+	current := make(map[string]string, len(report.Results))
+
+	rl := logs.ResourceLogs().AppendEmpty()
+	if report.Namespace != "" {
+		rl.Resource().Attributes().PutStr(k8sNamespaceName, report.Namespace)
+	}
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName(scopeName)
+
+	for _, result := range report.Results {
+		targets := result.Resources
+		if len(targets) == 0 && report.Scope != nil {
+			targets = []corev1.ObjectReference{*report.Scope}
+		}
+		if len(targets) == 0 {
+			// Results without a resource, e.g. node-level kube-bench checks, still carry evidence.
+			targets = []corev1.ObjectReference{{}}
+		}
+
+		for _, target := range targets {
+			key := resultKey(result, target)
+			current[key] = result.Result
+			if prev, ok := previous[key]; ok && prev == result.Result {
+				continue
+			}
+			appendRecord(sl.LogRecords().AppendEmpty(), report, result, target, observed)
+		}
+	}
+
+	if sl.LogRecords().Len() == 0 {
+		logs.ResourceLogs().RemoveIf(func(plog.ResourceLogs) bool { return true })
+	}
+	return logs, current
+}
+
+func appendRecord(record plog.LogRecord, report policyReport, result reportResult, target corev1.ObjectReference, observed time.Time) {
+	record.SetObservedTimestamp(pcommon.NewTimestampFromTime(observed))
+	timestamp := observed
+	if result.Timestamp.Seconds != 0 {
+		timestamp = time.Unix(result.Timestamp.Seconds, int64(result.Timestamp.Nanos))
+	}
+	record.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
+	record.SetSeverityNumber(plog.SeverityNumberInfo)
+	record.SetSeverityText(plog.SeverityNumberInfo.String())
+
+	attrs := record.Attributes()
+	if result.Source != "" {
+		attrs.PutStr(proofwatch.POLICY_ENGINE_NAME, result.Source)
+	}
+	attrs.PutStr(proofwatch.POLICY_RULE_ID, result.Policy)
+	if result.Rule != "" {
+		attrs.PutStr(proofwatch.POLICY_RULE_NAME, result.Rule)
+	}
+	attrs.PutStr(proofwatch.POLICY_EVALUATION_RESULT, mapResult(result.Result))
+	if result.Message != "" {
+		attrs.PutStr(proofwatch.POLICY_EVALUATION_MESSAGE, result.Message)
+	}
+	if target.Name != "" {
+		attrs.PutStr(proofwatch.POLICY_TARGET_ID, targetID(target))
+		attrs.PutStr(proofwatch.POLICY_TARGET_NAME, target.Name)
+	}
+	if target.Kind != "" {
+		attrs.PutStr(proofwatch.POLICY_TARGET_TYPE, target.Kind)
+	}
+	if report.UID != "" {
+		attrs.PutStr(proofwatch.COMPLIANCE_ASSESSMENT_ID, string(report.UID))
+	}
+	if result.Category != "" {
+		attrs.PutStr(proofwatch.COMPLIANCE_CONTROL_CATEGORY, result.Category)
+	}
+	if result.Severity != "" {
+		attrs.PutStr(proofwatch.COMPLIANCE_RISK_LEVEL, mapSeverity(result.Severity))
+	}
+
+	name := result.Policy
+	if result.Rule != "" {
+		name += "/" + result.Rule
+	}
+	record.Body().SetStr(fmt.Sprintf("%s: %s", name, result.Result))
+}
+
+func resultKey(result reportResult, target corev1.ObjectReference) string {
+	return strings.Join([]string{result.Policy, result.Rule, targetID(target)}, "|")
+}
+
+// targetID prefers the resource UID and falls back to kind/namespace/name for
+// references that do not carry one.
+func targetID(target corev1.ObjectReference) string {
+	if target.UID != "" {
+		return string(target.UID)
+	}
+	if target.Name == "" {
+		return ""
+	}
+	if target.Namespace == "" {
+		return target.Kind + "/" + target.Name
+	}
+	return target.Kind + "/" + target.Namespace + "/" + target.Name
+}
+
+// mapResult maps a PolicyReport result value to policy.evaluation.result.
+func mapResult(result string) string {
+	switch result {
+	case "pass":
+		return "Passed"
+	case "fail":
+		return "Failed"
+	case "warn":
+		return "Needs Review"
+	case "skip":
+		return "Not Run"
+	default:
+		// error
+		return "Unknown"
+	}
+}
+
+// mapSeverity maps a PolicyReport severity to compliance.risk.level.
+func mapSeverity(severity string) string {
+	switch severity {
+	case "critical":
+		return "Critical"
+	case "high":
+		return "High"
+	case "medium":
+		return "Medium"
+	case "low":
+		return "Low"
+	default:
+		// info
+		return "Informational"
+	}
+}
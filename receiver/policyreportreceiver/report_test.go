@@ -0,0 +1,156 @@
+package policyreportreceiver
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	"sigs.k8s.io/yaml"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+var observed = time.Date(2026, 6, 2, 12, 0, 0, 0, time.UTC)
+
+func loadReportObject(t *testing.T) *unstructured.Unstructured {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "policyreport.yaml"))
+	require.NoError(t, err)
+	jsonData, err := yaml.YAMLToJSON(data)
+	require.NoError(t, err)
+
+	obj := map[string]any{}
+	require.NoError(t, utiljson.Unmarshal(jsonData, &obj))
+	return &unstructured.Unstructured{Object: obj}
+}
+
+func loadReport(t *testing.T) policyReport {
+	t.Helper()
+	var report policyReport
+	require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(loadReportObject(t).Object, &report))
+	return report
+}
+
+func records(logs plog.Logs) []plog.LogRecord {
+	var out []plog.LogRecord
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		sls := logs.ResourceLogs().At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				out = append(out, lrs.At(k))
+			}
+		}
+	}
+	return out
+}
+
+func TestConvertReport(t *testing.T) {
+	logs, current := convertReport(loadReport(t), nil, observed)
+
+	require.Equal(t, 4, logs.LogRecordCount())
+	assert.Len(t, current, 4)
+
+	resource := logs.ResourceLogs().At(0).Resource().Attributes().AsRaw()
+	assert.Equal(t, "payments", resource[k8sNamespaceName])
+	assert.Equal(t, scopeName, logs.ResourceLogs().At(0).ScopeLogs().At(0).Scope().Name())
+
+	recs := records(logs)
+
+	pass := recs[0]
+	assert.Equal(t, "require-labels/check-team-label: pass", pass.Body().Str())
+	assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(1780387200, 0)), pass.Timestamp())
+	assert.Equal(t, map[string]any{
+		proofwatch.POLICY_ENGINE_NAME:          "kyverno",
+		proofwatch.POLICY_RULE_ID:              "require-labels",
+		proofwatch.POLICY_RULE_NAME:            "check-team-label",
+		proofwatch.POLICY_EVALUATION_RESULT:    "Passed",
+		proofwatch.POLICY_EVALUATION_MESSAGE:   "validation rule 'check-team-label' passed.",
+		proofwatch.POLICY_TARGET_ID:            "9a2e7c1d-5b44-4f0a-8e6d-3c1b2a4f5e60",
+		proofwatch.POLICY_TARGET_NAME:          "checkout-7d9f",
+		proofwatch.POLICY_TARGET_TYPE:          "Pod",
+		proofwatch.COMPLIANCE_ASSESSMENT_ID:    "6f1c2b8e-0d2a-4c55-9b1e-7a3f5d9e1c01",
+		proofwatch.COMPLIANCE_CONTROL_CATEGORY: "Best Practices",
+		proofwatch.COMPLIANCE_RISK_LEVEL:       "Medium",
+	}, pass.Attributes().AsRaw())
+
+	// A result with several resources yields one record per resource; references
+	// without a UID fall back to kind/namespace/name.
+	target, _ := recs[2].Attributes().Get(proofwatch.POLICY_TARGET_ID)
+	assert.Equal(t, "Pod/payments/checkout-8a1c", target.Str())
+
+	// A result without resources falls back to the report scope and observed time.
+	warn := recs[3]
+	target, _ = warn.Attributes().Get(proofwatch.POLICY_TARGET_ID)
+	assert.Equal(t, "0b6d4f7a-31a1-4a8e-8a5c-2f9b1d7e4c10", target.Str())
+	result, _ := warn.Attributes().Get(proofwatch.POLICY_EVALUATION_RESULT)
+	assert.Equal(t, "Needs Review", result.Str())
+	assert.Equal(t, pcommon.NewTimestampFromTime(observed), warn.Timestamp())
+	_, hasMessage := warn.Attributes().Get(proofwatch.POLICY_EVALUATION_MESSAGE)
+	assert.False(t, hasMessage)
+}
+
+func TestConvertReportEmitsOnlyChanges(t *testing.T) {
+	report := loadReport(t)
+	_, previous := convertReport(report, nil, observed)
+
+	logs, _ := convertReport(report, previous, observed)
+	assert.Equal(t, 0, logs.LogRecordCount())
+	assert.Equal(t, 0, logs.ResourceLogs().Len())
+
+	report.Results[0].Result = "fail"
+	logs, current := convertReport(report, previous, observed)
+	require.Equal(t, 1, logs.LogRecordCount())
+	assert.Equal(t, "require-labels/check-team-label: fail", records(logs)[0].Body().Str())
+	assert.Len(t, current, 4)
+}
+
+func TestConvertReportWithoutResources(t *testing.T) {
+	report := policyReport{Results: []reportResult{{Source: "kube-bench", Policy: "1.1.1", Result: "error"}}}
+
+	logs, current := convertReport(report, nil, observed)
+	require.Equal(t, 1, logs.LogRecordCount())
+	assert.Len(t, current, 1)
+
+	attrs := records(logs)[0].Attributes().AsRaw()
+	assert.Equal(t, "Unknown", attrs[proofwatch.POLICY_EVALUATION_RESULT])
+	assert.NotContains(t, attrs, proofwatch.POLICY_TARGET_ID)
+	assert.NotContains(t, attrs, proofwatch.COMPLIANCE_ASSESSMENT_ID)
+	assert.Equal(t, 0, logs.ResourceLogs().At(0).Resource().Attributes().Len())
+}
+
+func TestMapResult(t *testing.T) {
+	tests := map[string]string{
+		"pass":  "Passed",
+		"fail":  "Failed",
+		"warn":  "Needs Review",
+		"skip":  "Not Run",
+		"error": "Unknown",
+		"":      "Unknown",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, mapResult(in), in)
+	}
+}
+
+func TestMapSeverity(t *testing.T) {
+	tests := map[string]string{
+		"critical": "Critical",
+		"high":     "High",
+		"medium":   "Medium",
+		"low":      "Low",
+		"info":     "Informational",
+		"":         "Informational",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, mapSeverity(in), in)
+	}
+}
@@ -0,0 +1,74 @@
+package trivyoperatorreceiver
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	// AuthTypeServiceAccount uses the in-cluster service account token.
+	AuthTypeServiceAccount = "serviceAccount"
+	// AuthTypeKubeConfig uses the default kubeconfig loading rules (KUBECONFIG, ~/.kube/config).
+	AuthTypeKubeConfig = "kubeConfig"
+
+	defaultResyncPeriod = 10 * time.Minute
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines the configuration for the Trivy Operator receiver.
+type Config struct {
+	// AuthType selects how the receiver authenticates to the Kubernetes API server.
+	AuthType string `mapstructure:"auth_type"`
+
+	// Namespaces restricts the namespaced ConfigAuditReports that are watched.
+	// An empty list watches all namespaces.
+	Namespaces []string `mapstructure:"namespaces"`
+
+	// ConfigAuditReports enables watching ConfigAuditReports.
+	ConfigAuditReports bool `mapstructure:"config_audit_reports"`
+
+	// ClusterConfigAuditReports enables watching ClusterConfigAuditReports.
+	ClusterConfigAuditReports bool `mapstructure:"cluster_config_audit_reports"`
+
+	// ComplianceReports enables watching ClusterComplianceReports.
+	ComplianceReports bool `mapstructure:"compliance_reports"`
+
+	// ResyncPeriod is how often the informers replay the full report set.
+	// A resync re-delivers checks that the pipeline previously rejected.
+	ResyncPeriod time.Duration `mapstructure:"resync_period"`
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		AuthType:                  AuthTypeServiceAccount,
+		ConfigAuditReports:        true,
+		ClusterConfigAuditReports: true,
+		ComplianceReports:         true,
+		ResyncPeriod:              defaultResyncPeriod,
+	}
+}
+
+// Validate checks the receiver configuration is valid.
+func (c *Config) Validate() error {
+	switch c.AuthType {
+	case AuthTypeServiceAccount, AuthTypeKubeConfig:
+	default:
+		return fmt.Errorf("auth_type must be one of %q or %q, got %q", AuthTypeServiceAccount, AuthTypeKubeConfig, c.AuthType)
+	}
+	if !c.ConfigAuditReports && !c.ClusterConfigAuditReports && !c.ComplianceReports {
+		return errors.New("at least one of config_audit_reports, cluster_config_audit_reports or compliance_reports must be enabled")
+	}
+	if c.ResyncPeriod < 0 {
+		return errors.New("resync_period must not be negative")
+	}
+	for _, ns := range c.Namespaces {
+		if ns == "" {
+			return errors.New("namespaces must not contain empty values")
+		}
+	}
+	return nil
+}
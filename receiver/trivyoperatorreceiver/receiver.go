@@ -0,0 +1,186 @@
+package trivyoperatorreceiver
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+var _ receiver.Logs = (*trivyOperatorReceiver)(nil)
+
+// convertFunc converts a report object into the records of its changed
+// results and its complete result set.
+type convertFunc func(u *unstructured.Unstructured, previous map[string]string, observed time.Time) (plog.Logs, map[string]string, error)
+
+type trivyOperatorReceiver struct {
+	cfg      *Config
+	settings receiver.Settings
+	next     consumer.Logs
+
+	// makeClient is replaced in tests with a fake dynamic client.
+	makeClient func(*Config) (dynamic.Interface, error)
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+
+	mu sync.Mutex
+	// outcomes holds the last delivered result per check or control, per report.
+	outcomes map[types.UID]map[string]string
+}
+
+func newTrivyOperatorReceiver(cfg *Config, set receiver.Settings, next consumer.Logs) *trivyOperatorReceiver {
+	return &trivyOperatorReceiver{
+		cfg:        cfg,
+		settings:   set,
+		next:       next,
+		makeClient: newDynamicClient,
+		outcomes:   make(map[types.UID]map[string]string),
+	}
+}
+
+func newDynamicClient(cfg *Config) (dynamic.Interface, error) {
+	var restCfg *rest.Config
+	var err error
+	switch cfg.AuthType {
+	case AuthTypeKubeConfig:
+		rules := clientcmd.NewDefaultClientConfigLoadingRules()
+		restCfg, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{}).ClientConfig()
+	default:
+		restCfg, err = rest.InClusterConfig()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Kubernetes client configuration: %w", err)
+	}
+	return dynamic.NewForConfig(restCfg)
+}
+
+// Start builds the informers and begins watching reports.
+func (r *trivyOperatorReceiver) Start(_ context.Context, _ component.Host) error {
+	client, err := r.makeClient(r.cfg)
+	if err != nil {
+		return err
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	if r.cfg.ConfigAuditReports {
+		namespaces := r.cfg.Namespaces
+		if len(namespaces) == 0 {
+			namespaces = []string{metav1.NamespaceAll}
+		}
+		for _, ns := range namespaces {
+			r.watch(runCtx, dynamicinformer.NewFilteredDynamicSharedInformerFactory(client, r.cfg.ResyncPeriod, ns, nil), configAuditReportGVR, decodeConfigAudit)
+		}
+	}
+	if r.cfg.ClusterConfigAuditReports {
+		r.watch(runCtx, dynamicinformer.NewDynamicSharedInformerFactory(client, r.cfg.ResyncPeriod), clusterConfigAuditReportGVR, decodeConfigAudit)
+	}
+	if r.cfg.ComplianceReports {
+		r.watch(runCtx, dynamicinformer.NewDynamicSharedInformerFactory(client, r.cfg.ResyncPeriod), clusterComplianceReportGVR, decodeCompliance)
+	}
+	return nil
+}
+
+// Shutdown stops the informers.
+func (r *trivyOperatorReceiver) Shutdown(context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	return nil
+}
+
+func (r *trivyOperatorReceiver) watch(ctx context.Context, factory dynamicinformer.DynamicSharedInformerFactory, gvr schema.GroupVersionResource, convert convertFunc) {
+	informer := factory.ForResource(gvr).Informer()
+	_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj any) { r.handleReport(ctx, obj, convert) },
+		UpdateFunc: func(_, obj any) { r.handleReport(ctx, obj, convert) },
+		DeleteFunc: r.handleDelete,
+	})
+	if err != nil {
+		r.settings.Logger.Error("failed to register report handler", zap.String("resource", gvr.Resource), zap.Error(err))
+		return
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		informer.Run(ctx.Done())
+	}()
+}
+
+// handleReport emits the changed results of a report. The stored outcomes are
+// only advanced once the pipeline accepts the records, so a rejected batch is
+// retried on the next update or resync of the report.
+func (r *trivyOperatorReceiver) handleReport(ctx context.Context, obj any, convert convertFunc) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	logs, current, err := convert(u, r.outcomes[u.GetUID()], time.Now())
+	if err != nil {
+		r.settings.Logger.Warn("failed to decode Trivy Operator report",
+			zap.String("kind", u.GetKind()), zap.String("name", u.GetName()), zap.Error(err))
+		return
+	}
+	if logs.LogRecordCount() > 0 {
+		if err := r.next.ConsumeLogs(ctx, logs); err != nil {
+			r.settings.Logger.Warn("failed to deliver Trivy Operator report results",
+				zap.String("kind", u.GetKind()), zap.String("namespace", u.GetNamespace()), zap.String("name", u.GetName()), zap.Error(err))
+			return
+		}
+	}
+	r.outcomes[u.GetUID()] = current
+}
+
+func (r *trivyOperatorReceiver) handleDelete(obj any) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.outcomes, u.GetUID())
+}
+
+func decodeConfigAudit(u *unstructured.Unstructured, previous map[string]string, observed time.Time) (plog.Logs, map[string]string, error) {
+	var report configAuditReport
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &report); err != nil {
+		return plog.Logs{}, nil, err
+	}
+	logs, current := convertConfigAudit(report, previous, observed)
+	return logs, current, nil
+}
+
+func decodeCompliance(u *unstructured.Unstructured, previous map[string]string, observed time.Time) (plog.Logs, map[string]string, error) {
+	var report complianceReport
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &report); err != nil {
+		return plog.Logs{}, nil, err
+	}
+	logs, current := convertCompliance(report, previous, observed)
+	return logs, current, nil
+}
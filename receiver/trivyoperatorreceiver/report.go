@@ -0,0 +1,330 @@
+package trivyoperatorreceiver
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+const (
+	scopeName = "github.com/complytime/complybeacon/receiver/trivyoperatorreceiver"
+
+	// k8sNamespaceName is the OpenTelemetry resource semantic convention for the namespace.
+	k8sNamespaceName = "k8s.namespace.name"
+
+	// defaultEngineName is used when a report does not name its scanner.
+	defaultEngineName = "Trivy"
+
+	// Labels Trivy Operator sets on a config audit report to name the audited resource.
+	labelResourceKind      = "trivy-operator.resource.kind"
+	labelResourceName      = "trivy-operator.resource.name"
+	labelResourceNamespace = "trivy-operator.resource.namespace"
+
+	resultPassed      = "Passed"
+	resultFailed      = "Failed"
+	resultNeedsReview = "Needs Review"
+)
+
+var (
+	configAuditReportGVR = schema.GroupVersionResource{
+		Group:    "aquasecurity.github.io",
+		Version:  "v1alpha1",
+		Resource: "configauditreports",
+	}
+	clusterConfigAuditReportGVR = schema.GroupVersionResource{
+		Group:    "aquasecurity.github.io",
+		Version:  "v1alpha1",
+		Resource: "clusterconfigauditreports",
+	}
+	clusterComplianceReportGVR = schema.GroupVersionResource{
+		Group:    "aquasecurity.github.io",
+		Version:  "v1alpha1",
+		Resource: "clustercompliancereports",
+	}
+)
+
+// configAuditReport is the subset of an aquasecurity.github.io/v1alpha1
+// ConfigAuditReport or ClusterConfigAuditReport used to build evidence.
+type configAuditReport struct {
+	metav1.ObjectMeta `json:"metadata"`
+
+	Report struct {
+		UpdateTimestamp metav1.Time `json:"updateTimestamp,omitempty"`
+		Scanner         struct {
+			Name    string `json:"name,omitempty"`
+			Version string `json:"version,omitempty"`
+		} `json:"scanner,omitempty"`
+		Checks []auditCheck `json:"checks,omitempty"`
+	} `json:"report"`
+}
+
+type auditCheck struct {
+	CheckID     string   `json:"checkID"`
+	Title       string   `json:"title,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Severity    string   `json:"severity,omitempty"`
+	Category    string   `json:"category,omitempty"`
+	Messages    []string `json:"messages,omitempty"`
+	Success     bool     `json:"success"`
+	Remediation string   `json:"remediation,omitempty"`
+}
+
+// complianceReport is the subset of an aquasecurity.github.io/v1alpha1
+// ClusterComplianceReport used to build evidence.
+type complianceReport struct {
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec struct {
+		Compliance struct {
+			ID    string `json:"id"`
+			Title string `json:"title,omitempty"`
+		} `json:"compliance"`
+	} `json:"spec"`
+
+	Status struct {
+		UpdateTimestamp metav1.Time     `json:"updateTimestamp,omitempty"`
+		SummaryReport   *summaryReport  `json:"summaryReport,omitempty"`
+		DetailReport    *detailedReport `json:"detailReport,omitempty"`
+	} `json:"status"`
+}
+
+// summaryReport is the status of a report with reportType summary.
+type summaryReport struct {
+	ControlChecks []struct {
+		ID       string `json:"id"`
+		Name     string `json:"name,omitempty"`
+		Severity string `json:"severity,omitempty"`
+		// TotalFail is unset for manual controls that Trivy cannot check.
+		TotalFail *int `json:"totalFail,omitempty"`
+	} `json:"controlCheck,omitempty"`
+}
+
+// detailedReport is the status of a report with reportType all.
+type detailedReport struct {
+	Results []controlResult `json:"results,omitempty"`
+}
+
+// controlResult is a control of a detailed report with the checks it ran.
+type controlResult struct {
+	ID       string       `json:"id"`
+	Name     string       `json:"name,omitempty"`
+	Severity string       `json:"severity,omitempty"`
+	Checks   []auditCheck `json:"checks,omitempty"`
+}
+
+// controlOutcome is the result of one control of a compliance report.
+type controlOutcome struct {
+	id, name, severity, result, message string
+}
+
+// convertConfigAudit returns a log record for every check of report whose
+// result differs from previous, together with the complete result set of the
+// report. Results are keyed by check ID, so a check is emitted when it first
+// appears and again whenever its result changes.
+func convertConfigAudit(report configAuditReport, previous map[string]string, observed time.Time) (plog.Logs, map[string]string) {
+	logs, sl := newLogs(report.Namespace)
+	current := make(map[string]string, len(report.Report.Checks))
+
+	timestamp := observed
+	if !report.Report.UpdateTimestamp.IsZero() {
+		timestamp = report.Report.UpdateTimestamp.Time
+	}
+	engine := report.Report.Scanner.Name
+	if engine == "" {
+		engine = defaultEngineName
+	}
+	kind := report.Labels[labelResourceKind]
+	name := report.Labels[labelResourceName]
+	namespace := report.Labels[labelResourceNamespace]
+
+	for _, check := range report.Report.Checks {
+		result := resultFailed
+		if check.Success {
+			result = resultPassed
+		}
+		current[check.CheckID] = result
+		if prev, ok := previous[check.CheckID]; ok && prev == result {
+			continue
+		}
+
+		record := newRecord(sl, timestamp, observed, check.CheckID, result)
+		attrs := record.Attributes()
+		attrs.PutStr(proofwatch.POLICY_ENGINE_NAME, engine)
+		putStr(attrs, proofwatch.POLICY_ENGINE_VERSION, report.Report.Scanner.Version)
+		attrs.PutStr(proofwatch.POLICY_RULE_ID, check.CheckID)
+		putStr(attrs, proofwatch.POLICY_RULE_NAME, check.Title)
+		attrs.PutStr(proofwatch.POLICY_EVALUATION_RESULT, result)
+		message := strings.Join(check.Messages, "\n")
+		if message == "" {
+			message = check.Description
+		}
+		putStr(attrs, proofwatch.POLICY_EVALUATION_MESSAGE, message)
+		if name != "" {
+			attrs.PutStr(proofwatch.POLICY_TARGET_ID, targetID(report.OwnerReferences, kind, namespace, name))
+			attrs.PutStr(proofwatch.POLICY_TARGET_NAME, name)
+		}
+		putStr(attrs, proofwatch.POLICY_TARGET_TYPE, kind)
+		putStr(attrs, proofwatch.COMPLIANCE_ASSESSMENT_ID, string(report.UID))
+		putStr(attrs, proofwatch.COMPLIANCE_CONTROL_CATEGORY, check.Category)
+		attrs.PutStr(proofwatch.COMPLIANCE_RISK_LEVEL, mapSeverity(check.Severity))
+		putStr(attrs, proofwatch.COMPLIANCE_REMEDIATION_DESCRIPTION, check.Remediation)
+	}
+
+	dropEmpty(logs, sl)
+	return logs, current
+}
+
+// convertCompliance returns a log record for every control of report whose
+// result differs from previous, together with the complete result set of the
+// report. Results are keyed by control ID.
+func convertCompliance(report complianceReport, previous map[string]string, observed time.Time) (plog.Logs, map[string]string) {
+	logs, sl := newLogs("")
+	outcomes := controlOutcomes(report)
+	current := make(map[string]string, len(outcomes))
+
+	timestamp := observed
+	if !report.Status.UpdateTimestamp.IsZero() {
+		timestamp = report.Status.UpdateTimestamp.Time
+	}
+	compliance := report.Spec.Compliance
+
+	for _, outcome := range outcomes {
+		current[outcome.id] = outcome.result
+		if prev, ok := previous[outcome.id]; ok && prev == outcome.result {
+			continue
+		}
+
+		record := newRecord(sl, timestamp, observed, outcome.id, outcome.result)
+		attrs := record.Attributes()
+		attrs.PutStr(proofwatch.POLICY_ENGINE_NAME, defaultEngineName)
+		attrs.PutStr(proofwatch.POLICY_RULE_ID, outcome.id)
+		putStr(attrs, proofwatch.POLICY_RULE_NAME, outcome.name)
+		attrs.PutStr(proofwatch.POLICY_EVALUATION_RESULT, outcome.result)
+		putStr(attrs, proofwatch.POLICY_EVALUATION_MESSAGE, outcome.message)
+		putStr(attrs, proofwatch.COMPLIANCE_ASSESSMENT_ID, string(report.UID))
+		putStr(attrs, proofwatch.COMPLIANCE_CONTROL_CATALOG_ID, compliance.ID)
+		attrs.PutStr(proofwatch.COMPLIANCE_CONTROL_ID, outcome.id)
+		if compliance.Title != "" {
+			attrs.PutEmptySlice(proofwatch.COMPLIANCE_FRAMEWORKS).AppendEmpty().SetStr(compliance.Title)
+		}
+		attrs.PutStr(proofwatch.COMPLIANCE_RISK_LEVEL, mapSeverity(outcome.severity))
+	}
+
+	dropEmpty(logs, sl)
+	return logs, current
+}
+
+// controlOutcomes derives the result of every control from the summary or
+// detailed status of a compliance report. A control passes when none of its
+// checks failed. Controls without checks need manual review.
+func controlOutcomes(report complianceReport) []controlOutcome {
+	var outcomes []controlOutcome
+	switch status := report.Status; {
+	case status.SummaryReport != nil:
+		for _, c := range status.SummaryReport.ControlChecks {
+			outcome := controlOutcome{id: c.ID, name: c.Name, severity: c.Severity}
+			switch {
+			case c.TotalFail == nil:
+				outcome.result = resultNeedsReview
+			case *c.TotalFail > 0:
+				outcome.result = resultFailed
+				outcome.message = fmt.Sprintf("%d checks failed", *c.TotalFail)
+			default:
+				outcome.result = resultPassed
+			}
+			outcomes = append(outcomes, outcome)
+		}
+	case status.DetailReport != nil:
+		for _, c := range status.DetailReport.Results {
+			outcome := controlOutcome{id: c.ID, name: c.Name, severity: c.Severity, result: resultPassed}
+			var failed []string
+			for _, check := range c.Checks {
+				if !check.Success {
+					failed = append(failed, strings.TrimSpace(check.CheckID+": "+check.Title))
+				}
+			}
+			switch {
+			case len(c.Checks) == 0:
+				outcome.result = resultNeedsReview
+			case len(failed) > 0:
+				outcome.result = resultFailed
+				outcome.message = strings.Join(failed, "\n")
+			}
+			outcomes = append(outcomes, outcome)
+		}
+	}
+	return outcomes
+}
+
+func newLogs(namespace string) (plog.Logs, plog.ScopeLogs) {
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	if namespace != "" {
+		rl.Resource().Attributes().PutStr(k8sNamespaceName, namespace)
+	}
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName(scopeName)
+	return logs, sl
+}
+
+func newRecord(sl plog.ScopeLogs, timestamp, observed time.Time, id, result string) plog.LogRecord {
+	record := sl.LogRecords().AppendEmpty()
+	record.SetObservedTimestamp(pcommon.NewTimestampFromTime(observed))
+	record.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
+	record.SetSeverityNumber(plog.SeverityNumberInfo)
+	record.SetSeverityText(plog.SeverityNumberInfo.String())
+	record.Body().SetStr(fmt.Sprintf("%s: %s", id, result))
+	return record
+}
+
+// dropEmpty removes the resource of a conversion that emitted no records.
+func dropEmpty(logs plog.Logs, sl plog.ScopeLogs) {
+	if sl.LogRecords().Len() == 0 {
+		logs.ResourceLogs().RemoveIf(func(plog.ResourceLogs) bool { return true })
+	}
+}
+
+// targetID prefers the UID of the audited resource, taken from the owner
+// reference Trivy Operator sets on the report, and falls back to
+// kind/namespace/name.
+func targetID(owners []metav1.OwnerReference, kind, namespace, name string) string {
+	for _, owner := range owners {
+		if owner.Kind == kind && owner.Name == name && owner.UID != "" {
+			return string(owner.UID)
+		}
+	}
+	if namespace == "" {
+		return kind + "/" + name
+	}
+	return kind + "/" + namespace + "/" + name
+}
+
+// mapSeverity maps a Trivy severity to compliance.risk.level.
+func mapSeverity(severity string) string {
+	switch strings.ToUpper(severity) {
+	case "CRITICAL":
+		return "Critical"
+	case "HIGH":
+		return "High"
+	case "MEDIUM":
+		return "Medium"
+	case "LOW":
+		return "Low"
+	default:
+		// UNKNOWN
+		return "Informational"
+	}
+}
+
+func putStr(attrs pcommon.Map, key, value string) {
+	if value != "" {
+		attrs.PutStr(key, value)
+	}
+}
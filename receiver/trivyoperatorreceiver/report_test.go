@@ -0,0 +1,184 @@
+package trivyoperatorreceiver
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	"sigs.k8s.io/yaml"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+var observed = time.Date(2026, 6, 2, 12, 0, 0, 0, time.UTC)
+
+func loadObject(t *testing.T, name string) *unstructured.Unstructured {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	jsonData, err := yaml.YAMLToJSON(data)
+	require.NoError(t, err)
+
+	obj := map[string]any{}
+	require.NoError(t, utiljson.Unmarshal(jsonData, &obj))
+	return &unstructured.Unstructured{Object: obj}
+}
+
+func loadConfigAudit(t *testing.T) configAuditReport {
+	t.Helper()
+	var report configAuditReport
+	require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(loadObject(t, "configauditreport.yaml").Object, &report))
+	return report
+}
+
+func loadCompliance(t *testing.T) complianceReport {
+	t.Helper()
+	var report complianceReport
+	require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(loadObject(t, "clustercompliancereport.yaml").Object, &report))
+	return report
+}
+
+func records(logs plog.Logs) []plog.LogRecord {
+	var out []plog.LogRecord
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		sls := logs.ResourceLogs().At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				out = append(out, lrs.At(k))
+			}
+		}
+	}
+	return out
+}
+
+func TestConvertConfigAudit(t *testing.T) {
+	logs, current := convertConfigAudit(loadConfigAudit(t), nil, observed)
+
+	require.Equal(t, 2, logs.LogRecordCount())
+	assert.Equal(t, map[string]string{"KSV017": "Failed", "KSV001": "Passed"}, current)
+
+	resource := logs.ResourceLogs().At(0).Resource().Attributes().AsRaw()
+	assert.Equal(t, "payments", resource[k8sNamespaceName])
+	assert.Equal(t, scopeName, logs.ResourceLogs().At(0).ScopeLogs().At(0).Scope().Name())
+
+	recs := records(logs)
+	failed := recs[0]
+	assert.Equal(t, "KSV017: Failed", failed.Body().Str())
+	assert.Equal(t, time.Date(2026, 6, 2, 8, 30, 0, 0, time.UTC), failed.Timestamp().AsTime())
+	assert.Equal(t, map[string]any{
+		proofwatch.POLICY_ENGINE_NAME:                 "Trivy",
+		proofwatch.POLICY_ENGINE_VERSION:              "0.62.1",
+		proofwatch.POLICY_RULE_ID:                     "KSV017",
+		proofwatch.POLICY_RULE_NAME:                   "Privileged container",
+		proofwatch.POLICY_EVALUATION_RESULT:           "Failed",
+		proofwatch.POLICY_EVALUATION_MESSAGE:          "Container 'checkout' of ReplicaSet 'checkout-7d9f' should set 'securityContext.privileged' to false",
+		proofwatch.POLICY_TARGET_ID:                   "9a2e7c1d-5b44-4f0a-8e6d-3c1b2a4f5e60",
+		proofwatch.POLICY_TARGET_NAME:                 "checkout-7d9f",
+		proofwatch.POLICY_TARGET_TYPE:                 "ReplicaSet",
+		proofwatch.COMPLIANCE_ASSESSMENT_ID:           "3c8e1f2a-7b4d-4e6a-9f1c-5d2b8a0e4c71",
+		proofwatch.COMPLIANCE_CONTROL_CATEGORY:        "Kubernetes Security Check",
+		proofwatch.COMPLIANCE_RISK_LEVEL:              "High",
+		proofwatch.COMPLIANCE_REMEDIATION_DESCRIPTION: "Change 'containers[].securityContext.privileged' to 'false'.",
+	}, failed.Attributes().AsRaw())
+
+	// A check without messages falls back to its description.
+	message, _ := recs[1].Attributes().Get(proofwatch.POLICY_EVALUATION_MESSAGE)
+	assert.Equal(t, "A program inside the container can elevate its own privileges and run as root.", message.Str())
+}
+
+func TestConvertConfigAuditEmitsOnlyChanges(t *testing.T) {
+	report := loadConfigAudit(t)
+	_, previous := convertConfigAudit(report, nil, observed)
+
+	logs, _ := convertConfigAudit(report, previous, observed)
+	assert.Equal(t, 0, logs.LogRecordCount())
+	assert.Equal(t, 0, logs.ResourceLogs().Len())
+
+	report.Report.Checks[0].Success = true
+	logs, current := convertConfigAudit(report, previous, observed)
+	require.Equal(t, 1, logs.LogRecordCount())
+	assert.Equal(t, "KSV017: Passed", records(logs)[0].Body().Str())
+	assert.Len(t, current, 2)
+}
+
+func TestConvertConfigAuditWithoutOwner(t *testing.T) {
+	report := loadConfigAudit(t)
+	report.OwnerReferences = nil
+	report.Namespace = ""
+	report.Labels[labelResourceKind] = "ClusterRole"
+	report.Labels[labelResourceName] = "admin"
+	delete(report.Labels, labelResourceNamespace)
+	report.Report.Scanner.Name = ""
+
+	logs, _ := convertConfigAudit(report, nil, observed)
+	assert.Equal(t, 0, logs.ResourceLogs().At(0).Resource().Attributes().Len())
+	attrs := records(logs)[0].Attributes().AsRaw()
+	assert.Equal(t, "ClusterRole/admin", attrs[proofwatch.POLICY_TARGET_ID])
+	assert.Equal(t, "Trivy", attrs[proofwatch.POLICY_ENGINE_NAME])
+}
+
+func TestConvertCompliance(t *testing.T) {
+	logs, current := convertCompliance(loadCompliance(t), nil, observed)
+
+	require.Equal(t, 3, logs.LogRecordCount())
+	assert.Equal(t, map[string]string{"1.1.1": "Passed", "5.2.2": "Failed", "5.1.1": "Needs Review"}, current)
+	assert.Equal(t, 0, logs.ResourceLogs().At(0).Resource().Attributes().Len())
+
+	recs := records(logs)
+	failed := recs[1]
+	assert.Equal(t, time.Date(2026, 6, 2, 9, 0, 0, 0, time.UTC), failed.Timestamp().AsTime())
+	assert.Equal(t, map[string]any{
+		proofwatch.POLICY_ENGINE_NAME:            "Trivy",
+		proofwatch.POLICY_RULE_ID:                "5.2.2",
+		proofwatch.POLICY_RULE_NAME:              "Minimize the admission of privileged containers",
+		proofwatch.POLICY_EVALUATION_RESULT:      "Failed",
+		proofwatch.POLICY_EVALUATION_MESSAGE:     "3 checks failed",
+		proofwatch.COMPLIANCE_ASSESSMENT_ID:      "7e4a9c2b-1d3f-4b8e-a6c5-0f9d2e7b1a34",
+		proofwatch.COMPLIANCE_CONTROL_CATALOG_ID: "k8s-cis-1.23",
+		proofwatch.COMPLIANCE_CONTROL_ID:         "5.2.2",
+		proofwatch.COMPLIANCE_FRAMEWORKS:         []any{"CIS Kubernetes Benchmarks v1.23"},
+		proofwatch.COMPLIANCE_RISK_LEVEL:         "High",
+	}, failed.Attributes().AsRaw())
+
+	_, previous := convertCompliance(loadCompliance(t), nil, observed)
+	logs, _ = convertCompliance(loadCompliance(t), previous, observed)
+	assert.Equal(t, 0, logs.LogRecordCount())
+}
+
+func TestControlOutcomesFromDetailReport(t *testing.T) {
+	var report complianceReport
+	report.Status.DetailReport = &detailedReport{Results: []controlResult{
+		{ID: "5.2.2", Severity: "HIGH", Checks: []auditCheck{
+			{CheckID: "KSV017", Title: "Privileged container"},
+			{CheckID: "KSV001", Success: true},
+		}},
+		{ID: "5.1.1"},
+	}}
+
+	assert.Equal(t, []controlOutcome{
+		{id: "5.2.2", severity: "HIGH", result: "Failed", message: "KSV017: Privileged container"},
+		{id: "5.1.1", result: "Needs Review"},
+	}, controlOutcomes(report))
+}
+
+func TestMapSeverity(t *testing.T) {
+	tests := map[string]string{
+		"CRITICAL": "Critical",
+		"HIGH":     "High",
+		"MEDIUM":   "Medium",
+		"low":      "Low",
+		"UNKNOWN":  "Informational",
+		"":         "Informational",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, mapSeverity(in), in)
+	}
+}
@@ -0,0 +1,288 @@
+package wazuhreceiver
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+const (
+	scopeName = "github.com/complytime/complybeacon/receiver/wazuhreceiver"
+
+	engineName = "Wazuh"
+	targetType = "host"
+
+	// timestampLayout is the layout of the alert timestamp, such as
+	// 2026-06-02T08:30:00.123+0000.
+	timestampLayout = "2006-01-02T15:04:05.000-0700"
+
+	scaTypeCheck = "check"
+)
+
+var errNoAlert = errors.New("no JSON alert found")
+
+// frameworkLabels names the compliance frameworks of the tag fields Wazuh
+// adds to rules and SCA checks. SCA tag fields that are not listed are named
+// by upper-casing them.
+var frameworkLabels = map[string]string{
+	"pci_dss":     "PCI-DSS",
+	"hipaa":       "HIPAA",
+	"gdpr":        "GDPR",
+	"nist_800_53": "NIST-800-53",
+	"tsc":         "TSC",
+	"gpg13":       "GPG13",
+	"cis":         "CIS",
+	"cis_csc":     "CIS-CSC",
+}
+
+// alert is the subset of a Wazuh alert used to build evidence.
+type alert struct {
+	Timestamp string `json:"timestamp"`
+	ID        string `json:"id"`
+	Rule      struct {
+		Level       int      `json:"level"`
+		ID          string   `json:"id"`
+		Description string   `json:"description"`
+		Groups      []string `json:"groups"`
+		PCIDSS      []string `json:"pci_dss"`
+		HIPAA       []string `json:"hipaa"`
+		GDPR        []string `json:"gdpr"`
+		NIST80053   []string `json:"nist_800_53"`
+		TSC         []string `json:"tsc"`
+		GPG13       []string `json:"gpg13"`
+	} `json:"rule"`
+	Agent struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		IP   string `json:"ip"`
+	} `json:"agent"`
+	FullLog string `json:"full_log"`
+	Data    struct {
+		SCA *scaEvent `json:"sca"`
+	} `json:"data"`
+}
+
+// scaEvent is the Security Configuration Assessment data of an alert.
+type scaEvent struct {
+	Type     string `json:"type"`
+	Policy   string `json:"policy"`
+	PolicyID string `json:"policy_id"`
+	Check    struct {
+		ID          string `json:"id"`
+		Title       string `json:"title"`
+		Result      string `json:"result"`
+		Reason      string `json:"reason"`
+		Remediation string `json:"remediation"`
+		// Compliance maps a framework to a comma-separated list of requirements.
+		Compliance map[string]string `json:"compliance"`
+	} `json:"check"`
+}
+
+// parseAlert decodes one alert. Anything before the first '{', such as the
+// syslog header of alerts forwarded by Wazuh syslog output, is ignored.
+func parseAlert(data []byte) (alert, map[string]any, error) {
+	start := bytes.IndexByte(data, '{')
+	if start < 0 {
+		return alert{}, nil, errNoAlert
+	}
+	data = data[start:]
+
+	var a alert
+	if err := json.Unmarshal(data, &a); err != nil {
+		return alert{}, nil, fmt.Errorf("failed to decode alert: %w", err)
+	}
+	var body map[string]any
+	if err := json.Unmarshal(data, &body); err != nil {
+		return alert{}, nil, fmt.Errorf("failed to decode alert: %w", err)
+	}
+	return a, body, nil
+}
+
+// evidence reports whether the alert carries evidence. SCA scan summaries
+// are not evidence, since their checks are reported one alert each.
+func (a alert) evidence() bool {
+	return a.Data.SCA == nil || a.Data.SCA.Type == scaTypeCheck
+}
+
+// matches reports whether the alert passes the level and rule group filters.
+func (a alert) matches(cfg *Config) bool {
+	if a.Rule.Level < cfg.MinLevel {
+		return false
+	}
+	if len(cfg.RuleGroups) == 0 {
+		return true
+	}
+	for _, group := range a.Rule.Groups {
+		if slices.Contains(cfg.RuleGroups, group) {
+			return true
+		}
+	}
+	return false
+}
+
+// appendAlert converts an alert into a log record. SCA check alerts become
+// the evaluation of their check; other alerts report their rule as needing
+// review.
+func appendAlert(records plog.LogRecordSlice, a alert, body map[string]any, observed time.Time) {
+	record := records.AppendEmpty()
+	record.SetObservedTimestamp(pcommon.NewTimestampFromTime(observed))
+	record.SetTimestamp(pcommon.NewTimestampFromTime(parseTime(a.Timestamp, observed)))
+	record.SetSeverityNumber(plog.SeverityNumberInfo)
+	record.SetSeverityText(plog.SeverityNumberInfo.String())
+	_ = record.Body().SetEmptyMap().FromRaw(body)
+
+	attrs := record.Attributes()
+	attrs.PutStr(proofwatch.POLICY_ENGINE_NAME, engineName)
+
+	var frameworks, requirements []string
+	if sca := a.Data.SCA; sca != nil {
+		attrs.PutStr(proofwatch.POLICY_RULE_ID, sca.Check.ID)
+		putStr(attrs, proofwatch.POLICY_RULE_NAME, sca.Check.Title)
+		attrs.PutStr(proofwatch.POLICY_EVALUATION_RESULT, mapSCAResult(sca.Check.Result))
+		putStr(attrs, proofwatch.POLICY_EVALUATION_MESSAGE, sca.Check.Reason)
+		putStr(attrs, proofwatch.COMPLIANCE_REMEDIATION_DESCRIPTION, sca.Check.Remediation)
+		frameworks, requirements = scaCompliance(sca.Check.Compliance)
+	} else {
+		attrs.PutStr(proofwatch.POLICY_RULE_ID, a.Rule.ID)
+		putStr(attrs, proofwatch.POLICY_RULE_NAME, a.Rule.Description)
+		attrs.PutStr(proofwatch.POLICY_EVALUATION_RESULT, "Needs Review")
+		putStr(attrs, proofwatch.POLICY_EVALUATION_MESSAGE, a.FullLog)
+		// The level of SCA alerts follows the check result rather than the
+		// severity of the check, so only rule alerts carry a risk level.
+		attrs.PutStr(proofwatch.COMPLIANCE_RISK_LEVEL, mapLevel(a.Rule.Level))
+		frameworks, requirements = ruleCompliance(a)
+	}
+
+	putStr(attrs, proofwatch.POLICY_TARGET_ID, a.Agent.ID)
+	putStr(attrs, proofwatch.POLICY_TARGET_NAME, a.Agent.Name)
+	if a.Agent.ID != "" {
+		attrs.PutStr(proofwatch.POLICY_TARGET_TYPE, targetType)
+	}
+	putSlice(attrs, proofwatch.COMPLIANCE_FRAMEWORKS, frameworks)
+	putSlice(attrs, proofwatch.COMPLIANCE_REQUIREMENTS, requirements)
+}
+
+// ruleCompliance returns the frameworks and requirements of the compliance
+// tags of the alert rule.
+func ruleCompliance(a alert) ([]string, []string) {
+	var frameworks, requirements []string
+	for _, tag := range []struct {
+		field  string
+		values []string
+	}{
+		{"pci_dss", a.Rule.PCIDSS},
+		{"hipaa", a.Rule.HIPAA},
+		{"gdpr", a.Rule.GDPR},
+		{"nist_800_53", a.Rule.NIST80053},
+		{"tsc", a.Rule.TSC},
+		{"gpg13", a.Rule.GPG13},
+	} {
+		frameworks, requirements = addRequirements(frameworks, requirements, tag.field, tag.values)
+	}
+	return frameworks, requirements
+}
+
+// scaCompliance returns the frameworks and requirements of the compliance
+// tags of an SCA check, ordered by tag field.
+func scaCompliance(compliance map[string]string) ([]string, []string) {
+	fields := make([]string, 0, len(compliance))
+	for field := range compliance {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var frameworks, requirements []string
+	for _, field := range fields {
+		frameworks, requirements = addRequirements(frameworks, requirements, field, strings.Split(compliance[field], ","))
+	}
+	return frameworks, requirements
+}
+
+// addRequirements adds the framework of a tag field and one requirement per
+// value, such as "PCI-DSS 10.2.4".
+func addRequirements(frameworks, requirements []string, field string, values []string) ([]string, []string) {
+	label, ok := frameworkLabels[field]
+	if !ok {
+		label = strings.ToUpper(strings.ReplaceAll(field, "_", "-"))
+	}
+	found := false
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		found = true
+		requirements = append(requirements, label+" "+value)
+	}
+	if found && !slices.Contains(frameworks, label) {
+		frameworks = append(frameworks, label)
+	}
+	return frameworks, requirements
+}
+
+// mapSCAResult maps an SCA check result to policy.evaluation.result.
+func mapSCAResult(result string) string {
+	switch result {
+	case "passed":
+		return "Passed"
+	case "failed":
+		return "Failed"
+	case "not applicable":
+		return "Not Applicable"
+	default:
+		return "Unknown"
+	}
+}
+
+// mapLevel maps a Wazuh rule level to compliance.risk.level.
+func mapLevel(level int) string {
+	switch {
+	case level <= 3:
+		return "Informational"
+	case level <= 6:
+		return "Low"
+	case level <= 11:
+		return "Medium"
+	case level <= 14:
+		return "High"
+	default:
+		return "Critical"
+	}
+}
+
+func parseTime(value string, fallback time.Time) time.Time {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(timestampLayout, value); err == nil {
+		return t
+	}
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t
+	}
+	return fallback
+}
+
+func putStr(attrs pcommon.Map, key, value string) {
+	if value != "" {
+		attrs.PutStr(key, value)
+	}
+}
+
+func putSlice(attrs pcommon.Map, key string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	slice := attrs.PutEmptySlice(key)
+	for _, v := range values {
+		slice.AppendEmpty().SetStr(v)
+	}
+}
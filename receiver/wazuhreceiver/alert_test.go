@@ -0,0 +1,145 @@
+package wazuhreceiver
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+var observed = time.Date(2026, 6, 2, 10, 0, 0, 0, time.UTC)
+
+func readTestdata(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return data
+}
+
+func convert(t *testing.T, data []byte) plog.LogRecord {
+	t.Helper()
+	a, body, err := parseAlert(data)
+	require.NoError(t, err)
+	records := plog.NewLogRecordSlice()
+	appendAlert(records, a, body, observed)
+	require.Equal(t, 1, records.Len())
+	return records.At(0)
+}
+
+func TestAppendAlertSCACheck(t *testing.T) {
+	record := convert(t, readTestdata(t, "sca-alert.json"))
+
+	assert.Equal(t, time.Date(2026, 6, 2, 8, 30, 0, 123e6, time.UTC), record.Timestamp().AsTime().UTC())
+	assert.Equal(t, observed, record.ObservedTimestamp().AsTime().UTC())
+	assert.Equal(t, map[string]any{
+		proofwatch.POLICY_ENGINE_NAME:                 "Wazuh",
+		proofwatch.POLICY_RULE_ID:                     "28612",
+		proofwatch.POLICY_RULE_NAME:                   "Ensure auditd service is enabled and active.",
+		proofwatch.POLICY_EVALUATION_RESULT:           "Failed",
+		proofwatch.POLICY_EVALUATION_MESSAGE:          "auditd.service is not enabled",
+		proofwatch.POLICY_TARGET_ID:                   "001",
+		proofwatch.POLICY_TARGET_NAME:                 "web-01",
+		proofwatch.POLICY_TARGET_TYPE:                 "host",
+		proofwatch.COMPLIANCE_REMEDIATION_DESCRIPTION: "Run the following command to enable and start auditd: # systemctl --now enable auditd",
+		proofwatch.COMPLIANCE_FRAMEWORKS:              []any{"CIS", "NIST-800-53", "PCI-DSS"},
+		proofwatch.COMPLIANCE_REQUIREMENTS:            []any{"CIS 4.1.1.2", "NIST-800-53 AU.2", "PCI-DSS 10.2.1", "PCI-DSS 10.7"},
+	}, record.Attributes().AsRaw())
+
+	policy, ok := record.Body().Map().Get("data")
+	require.True(t, ok)
+	assert.Contains(t, policy.AsString(), "cis_ubuntu22-04")
+}
+
+func TestAppendAlertRule(t *testing.T) {
+	record := convert(t, readTestdata(t, "alert.json"))
+
+	assert.Equal(t, map[string]any{
+		proofwatch.POLICY_ENGINE_NAME:        "Wazuh",
+		proofwatch.POLICY_RULE_ID:            "5551",
+		proofwatch.POLICY_RULE_NAME:          "PAM: Multiple failed logins in a small period of time.",
+		proofwatch.POLICY_EVALUATION_RESULT:  "Needs Review",
+		proofwatch.POLICY_EVALUATION_MESSAGE: "Jun  2 08:31:15 db-01 sshd[1234]: pam_unix(sshd:auth): authentication failure; user=root",
+		proofwatch.POLICY_TARGET_ID:          "002",
+		proofwatch.POLICY_TARGET_NAME:        "db-01",
+		proofwatch.POLICY_TARGET_TYPE:        "host",
+		proofwatch.COMPLIANCE_RISK_LEVEL:     "Medium",
+		proofwatch.COMPLIANCE_FRAMEWORKS:     []any{"PCI-DSS", "HIPAA", "GDPR", "NIST-800-53", "TSC"},
+		proofwatch.COMPLIANCE_REQUIREMENTS: []any{
+			"PCI-DSS 10.2.4", "PCI-DSS 10.2.5", "PCI-DSS 11.4",
+			"HIPAA 164.312.b",
+			"GDPR IV_35.7.d", "GDPR IV_32.2",
+			"NIST-800-53 AU.14", "NIST-800-53 AC.7", "NIST-800-53 SI.4",
+			"TSC CC6.1", "TSC CC6.8", "TSC CC7.2", "TSC CC7.3",
+		},
+	}, record.Attributes().AsRaw())
+}
+
+func TestParseAlert(t *testing.T) {
+	t.Run("syslog header", func(t *testing.T) {
+		data := append([]byte("<132>Jun  2 08:31:15 wazuh-manager ossec: "), readTestdata(t, "alert.json")...)
+		a, _, err := parseAlert(data)
+		require.NoError(t, err)
+		assert.Equal(t, "5551", a.Rule.ID)
+	})
+
+	t.Run("no JSON", func(t *testing.T) {
+		_, _, err := parseAlert([]byte("ossec: started"))
+		assert.ErrorIs(t, err, errNoAlert)
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, _, err := parseAlert([]byte(`{"rule": `))
+		assert.ErrorContains(t, err, "failed to decode alert")
+	})
+}
+
+func TestAlertFilters(t *testing.T) {
+	sca, _, err := parseAlert(readTestdata(t, "sca-alert.json"))
+	require.NoError(t, err)
+	rule, _, err := parseAlert(readTestdata(t, "alert.json"))
+	require.NoError(t, err)
+	summary, _, err := parseAlert([]byte(`{"rule": {"level": 3, "groups": ["sca"]}, "data": {"sca": {"type": "summary"}}}`))
+	require.NoError(t, err)
+
+	assert.True(t, sca.evidence())
+	assert.True(t, rule.evidence())
+	assert.False(t, summary.evidence())
+
+	assert.True(t, rule.matches(&Config{MinLevel: 10}))
+	assert.False(t, rule.matches(&Config{MinLevel: 11}))
+	assert.True(t, sca.matches(&Config{RuleGroups: []string{"audit", "sca"}}))
+	assert.False(t, rule.matches(&Config{RuleGroups: []string{"audit", "sca"}}))
+}
+
+func TestMapSCAResult(t *testing.T) {
+	for result, want := range map[string]string{
+		"passed":         "Passed",
+		"failed":         "Failed",
+		"not applicable": "Not Applicable",
+		"":               "Unknown",
+	} {
+		assert.Equal(t, want, mapSCAResult(result), result)
+	}
+}
+
+func TestMapLevel(t *testing.T) {
+	for level, want := range map[int]string{
+		0:  "Informational",
+		3:  "Informational",
+		4:  "Low",
+		6:  "Low",
+		7:  "Medium",
+		11: "Medium",
+		12: "High",
+		14: "High",
+		15: "Critical",
+	} {
+		assert.Equal(t, want, mapLevel(level), level)
+	}
+}
@@ -0,0 +1,140 @@
+package wazuhreceiver
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const (
+	// StartAtEnd reads only alerts written after the receiver starts.
+	StartAtEnd = "end"
+	// StartAtBeginning reads the alerts file from its first line.
+	StartAtBeginning = "beginning"
+
+	// TransportUDP receives one alert per datagram, as Wazuh syslog output sends them.
+	TransportUDP = "udp"
+	// TransportTCP receives newline-delimited alerts.
+	TransportTCP = "tcp"
+	// TransportUnix receives newline-delimited alerts on a Unix stream socket.
+	TransportUnix = "unix"
+	// TransportUnixgram receives one alert per datagram on a Unix datagram socket.
+	TransportUnixgram = "unixgram"
+
+	defaultAlertsPath   = "/var/ossec/logs/alerts/alerts.json"
+	defaultPollInterval = time.Second
+	defaultEndpoint     = "localhost:5514"
+
+	// maxRuleLevel is the highest Wazuh rule level.
+	maxRuleLevel = 15
+
+	fileKey   = "file"
+	socketKey = "socket"
+)
+
+var (
+	_ component.Config    = (*Config)(nil)
+	_ confmap.Unmarshaler = (*Config)(nil)
+)
+
+// Config defines the configuration for the Wazuh receiver.
+// At least one of File or Socket must be set.
+type Config struct {
+	// File tails the JSON alerts file of a Wazuh manager.
+	File *FileConfig `mapstructure:"file"`
+
+	// Socket receives alerts sent by Wazuh syslog output in JSON format.
+	Socket *SocketConfig `mapstructure:"socket"`
+
+	// MinLevel drops alerts of rules below this level.
+	MinLevel int `mapstructure:"min_level"`
+
+	// RuleGroups keeps only alerts of rules in at least one of these groups.
+	// Empty keeps alerts of every group.
+	RuleGroups []string `mapstructure:"rule_groups"`
+}
+
+// FileConfig configures the alerts file tail.
+type FileConfig struct {
+	// Path is the alerts file, written one JSON alert per line.
+	Path string `mapstructure:"path"`
+
+	// StartAt is "end" or "beginning".
+	StartAt string `mapstructure:"start_at"`
+
+	// PollInterval is how often the file is checked for new alerts.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+}
+
+// SocketConfig configures the alert listener.
+type SocketConfig struct {
+	// Endpoint is the address to listen on, or the socket path for Unix transports.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Transport is "udp", "tcp", "unix" or "unixgram".
+	Transport string `mapstructure:"transport"`
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		File: &FileConfig{
+			Path:         defaultAlertsPath,
+			StartAt:      StartAtEnd,
+			PollInterval: defaultPollInterval,
+		},
+		Socket: &SocketConfig{
+			Endpoint:  defaultEndpoint,
+			Transport: TransportUDP,
+		},
+	}
+}
+
+// Unmarshal keeps only the inputs that are present in the user configuration.
+func (c *Config) Unmarshal(conf *confmap.Conf) error {
+	if err := conf.Unmarshal(c); err != nil {
+		return err
+	}
+	if !conf.IsSet(fileKey) {
+		c.File = nil
+	}
+	if !conf.IsSet(socketKey) {
+		c.Socket = nil
+	}
+	return nil
+}
+
+// Validate checks the receiver configuration is valid.
+func (c *Config) Validate() error {
+	if c.File == nil && c.Socket == nil {
+		return errors.New("at least one of file or socket must be configured")
+	}
+	if c.MinLevel < 0 || c.MinLevel > maxRuleLevel {
+		return fmt.Errorf("min_level must be between 0 and %d", maxRuleLevel)
+	}
+	if c.File != nil {
+		if c.File.Path == "" {
+			return errors.New("file.path must not be empty")
+		}
+		if c.File.StartAt != StartAtEnd && c.File.StartAt != StartAtBeginning {
+			return fmt.Errorf("file.start_at must be %q or %q, got %q", StartAtEnd, StartAtBeginning, c.File.StartAt)
+		}
+		if c.File.PollInterval <= 0 {
+			return errors.New("file.poll_interval must be positive")
+		}
+	}
+	if c.Socket != nil {
+		if c.Socket.Endpoint == "" {
+			return errors.New("socket.endpoint must not be empty")
+		}
+		switch c.Socket.Transport {
+		case TransportUDP, TransportTCP, TransportUnix, TransportUnixgram:
+		default:
+			return fmt.Errorf("socket.transport must be %q, %q, %q or %q, got %q",
+				TransportUDP, TransportTCP, TransportUnix, TransportUnixgram, c.Socket.Transport)
+		}
+	}
+	return nil
+}
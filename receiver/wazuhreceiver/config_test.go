@@ -0,0 +1,141 @@
+package wazuhreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id         component.ID
+		wantFile   bool
+		wantSocket bool
+		check      func(t *testing.T, cfg *Config)
+	}{
+		{
+			id:       component.NewID(componentType),
+			wantFile: true,
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, defaultAlertsPath, cfg.File.Path)
+				assert.Equal(t, StartAtEnd, cfg.File.StartAt)
+				assert.Equal(t, 2*time.Second, cfg.File.PollInterval)
+			},
+		},
+		{
+			id:         component.NewIDWithName(componentType, "socket"),
+			wantSocket: true,
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "0.0.0.0:5514", cfg.Socket.Endpoint)
+				assert.Equal(t, TransportTCP, cfg.Socket.Transport)
+			},
+		},
+		{
+			id:         component.NewIDWithName(componentType, "filtered"),
+			wantFile:   true,
+			wantSocket: true,
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, 7, cfg.MinLevel)
+				assert.Equal(t, []string{"sca", "audit"}, cfg.RuleGroups)
+				assert.Equal(t, "/var/ossec/logs/alerts/alerts-archive.json", cfg.File.Path)
+				assert.Equal(t, StartAtBeginning, cfg.File.StartAt)
+				assert.Equal(t, 5*time.Second, cfg.File.PollInterval)
+				assert.Equal(t, "/var/run/wazuh-alerts.sock", cfg.Socket.Endpoint)
+				assert.Equal(t, TransportUnixgram, cfg.Socket.Transport)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, cfg.Unmarshal(sub))
+			require.NoError(t, cfg.Validate())
+
+			assert.Equal(t, tt.wantFile, cfg.File != nil)
+			assert.Equal(t, tt.wantSocket, cfg.Socket != nil)
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name: "no input",
+			mutate: func(cfg *Config) {
+				cfg.File = nil
+				cfg.Socket = nil
+			},
+			wantErr: "at least one of file or socket must be configured",
+		},
+		{
+			name:    "negative min level",
+			mutate:  func(cfg *Config) { cfg.MinLevel = -1 },
+			wantErr: "min_level must be between 0 and 15",
+		},
+		{
+			name:    "min level above the highest rule level",
+			mutate:  func(cfg *Config) { cfg.MinLevel = 16 },
+			wantErr: "min_level must be between 0 and 15",
+		},
+		{
+			name:    "empty file path",
+			mutate:  func(cfg *Config) { cfg.File.Path = "" },
+			wantErr: "file.path must not be empty",
+		},
+		{
+			name:    "invalid start_at",
+			mutate:  func(cfg *Config) { cfg.File.StartAt = "middle" },
+			wantErr: `file.start_at must be "end" or "beginning", got "middle"`,
+		},
+		{
+			name:    "non-positive poll interval",
+			mutate:  func(cfg *Config) { cfg.File.PollInterval = 0 },
+			wantErr: "file.poll_interval must be positive",
+		},
+		{
+			name:    "empty socket endpoint",
+			mutate:  func(cfg *Config) { cfg.Socket.Endpoint = "" },
+			wantErr: "socket.endpoint must not be empty",
+		},
+		{
+			name:    "invalid transport",
+			mutate:  func(cfg *Config) { cfg.Socket.Transport = "sctp" },
+			wantErr: `socket.transport must be "udp", "tcp", "unix" or "unixgram", got "sctp"`,
+		},
+		{
+			name:   "valid",
+			mutate: func(*Config) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package wazuhreceiver
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// maxBatchLines bounds the number of alerts passed on in one batch.
+const maxBatchLines = 1000
+
+// tailer follows the alerts file across rotation and truncation. The offset
+// only advances past lines that were passed on, so alerts are re-read after a
+// pipeline failure.
+type tailer struct {
+	path string
+
+	file   *os.File
+	offset int64
+}
+
+// open opens the alerts file, at its end when atEnd is set. A missing file
+// is not an error; it is opened by a later poll.
+func (t *tailer) open(atEnd bool) error {
+	f, err := os.Open(filepath.Clean(t.path))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	t.file, t.offset = f, 0
+	if atEnd {
+		info, err := f.Stat()
+		if err != nil {
+			_ = f.Close()
+			t.file = nil
+			return err
+		}
+		t.offset = info.Size()
+	}
+	return nil
+}
+
+// poll passes on the complete lines written since the previous poll. Once the
+// open file is read to its end, a rotated file is replaced by the new one,
+// read from its beginning, and a truncated file is read again from its start.
+func (t *tailer) poll(ctx context.Context, consume func([][]byte) error) error {
+	if t.file == nil {
+		if err := t.open(false); err != nil || t.file == nil {
+			return err
+		}
+	}
+
+	for ctx.Err() == nil {
+		lines, n, err := t.read()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			break
+		}
+		if len(lines) > 0 {
+			if err := consume(lines); err != nil {
+				return err
+			}
+		}
+		t.offset += n
+	}
+
+	info, err := os.Stat(t.path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			// Rotated away; the new file has not been created yet.
+			return nil
+		}
+		return err
+	}
+	current, err := t.file.Stat()
+	if err != nil {
+		return err
+	}
+	switch {
+	case !os.SameFile(info, current):
+		_ = t.file.Close()
+		t.file = nil
+		return t.open(false)
+	case info.Size() < t.offset:
+		t.offset = 0
+	}
+	return nil
+}
+
+// read returns up to maxBatchLines non-empty complete lines from the offset,
+// and the number of bytes they span. A partial last line is left for a later
+// poll.
+func (t *tailer) read() ([][]byte, int64, error) {
+	if _, err := t.file.Seek(t.offset, io.SeekStart); err != nil {
+		return nil, 0, err
+	}
+	reader := bufio.NewReader(t.file)
+
+	var lines [][]byte
+	var n int64
+	for len(lines) < maxBatchLines {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, 0, err
+		}
+		n += int64(len(line))
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			lines = append(lines, line)
+		}
+	}
+	return lines, n, nil
+}
+
+func (t *tailer) close() error {
+	if t.file == nil {
+		return nil
+	}
+	err := t.file.Close()
+	t.file = nil
+	return err
+}
@@ -0,0 +1,127 @@
+package wazuhreceiver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+)
+
+var _ receiver.Logs = (*wazuhReceiver)(nil)
+
+type wazuhReceiver struct {
+	cfg      *Config
+	settings receiver.Settings
+	next     consumer.Logs
+	now      func() time.Time
+
+	tailer     *tailer
+	packetConn net.PacketConn
+	listener   net.Listener
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+
+	mu      sync.Mutex
+	conns   map[net.Conn]struct{}
+	closing bool
+}
+
+func newWazuhReceiver(cfg *Config, set receiver.Settings, next consumer.Logs) *wazuhReceiver {
+	return &wazuhReceiver{
+		cfg:      cfg,
+		settings: set,
+		next:     next,
+		now:      time.Now,
+		conns:    make(map[net.Conn]struct{}),
+	}
+}
+
+// Start binds the socket and begins tailing the alerts file.
+func (r *wazuhReceiver) Start(context.Context, component.Host) error {
+	runCtx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	if r.cfg.Socket != nil {
+		if err := r.listen(runCtx); err != nil {
+			return err
+		}
+	}
+
+	if r.cfg.File != nil {
+		r.tailer = &tailer{path: r.cfg.File.Path}
+		if err := r.tailer.open(r.cfg.File.StartAt == StartAtEnd); err != nil {
+			return err
+		}
+		r.wg.Add(1)
+		go r.pollFile(runCtx)
+	}
+	return nil
+}
+
+// Shutdown stops receiving and tailing alerts.
+func (r *wazuhReceiver) Shutdown(context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	err := r.closeSocket()
+	r.wg.Wait()
+	if r.tailer != nil {
+		err = errors.Join(err, r.tailer.close())
+	}
+	return err
+}
+
+func (r *wazuhReceiver) pollFile(ctx context.Context) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.cfg.File.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := r.tailer.poll(ctx, func(lines [][]byte) error {
+			return r.consume(ctx, lines)
+		}); err != nil {
+			// The unread alerts are retried on the next poll.
+			r.settings.Logger.Warn("failed to read alerts file", zap.String("path", r.cfg.File.Path), zap.Error(err))
+		}
+	}
+}
+
+// consume converts alert lines into evidence and passes it on. Lines that are
+// not alerts are logged and skipped, and alerts without evidence or that do
+// not pass the filters are dropped.
+func (r *wazuhReceiver) consume(ctx context.Context, lines [][]byte) error {
+	observed := r.now()
+	logs := plog.NewLogs()
+	sl := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	sl.Scope().SetName(scopeName)
+
+	for _, line := range lines {
+		a, body, err := parseAlert(line)
+		if err != nil {
+			r.settings.Logger.Warn("skipped invalid alert", zap.Error(err))
+			continue
+		}
+		if !a.evidence() || !a.matches(r.cfg) {
+			continue
+		}
+		appendAlert(sl.LogRecords(), a, body, observed)
+	}
+
+	if sl.LogRecords().Len() == 0 {
+		return nil
+	}
+	return r.next.ConsumeLogs(ctx, logs)
+}
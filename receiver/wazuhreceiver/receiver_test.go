@@ -0,0 +1,200 @@
+package wazuhreceiver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+func newTestReceiver(t *testing.T, cfg *Config, next consumer.Logs) *wazuhReceiver {
+	t.Helper()
+	r := newWazuhReceiver(cfg, receivertest.NewNopSettings(componentType), next)
+	r.now = func() time.Time { return observed }
+	return r
+}
+
+func appendLine(t *testing.T, path string, data []byte) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+}
+
+func ruleIDs(logs []plog.Logs) []string {
+	var ids []string
+	for _, l := range logs {
+		rls := l.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			sls := rls.At(i).ScopeLogs()
+			for j := 0; j < sls.Len(); j++ {
+				records := sls.At(j).LogRecords()
+				for k := 0; k < records.Len(); k++ {
+					v, _ := records.At(k).Attributes().Get(proofwatch.POLICY_RULE_ID)
+					ids = append(ids, v.Str())
+				}
+			}
+		}
+	}
+	return ids
+}
+
+func TestConsume(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MinLevel = 5
+	sink := new(consumertest.LogsSink)
+	r := newTestReceiver(t, cfg, sink)
+
+	require.NoError(t, r.consume(context.Background(), [][]byte{
+		readTestdata(t, "sca-alert.json"),
+		[]byte("not an alert"),
+		[]byte(`{"rule": {"id": "530", "level": 3}}`),
+		[]byte(`{"rule": {"id": "19004", "level": 7}, "data": {"sca": {"type": "summary"}}}`),
+		readTestdata(t, "alert.json"),
+	}))
+	assert.Equal(t, []string{"28612", "5551"}, ruleIDs(sink.AllLogs()))
+	assert.Equal(t, scopeName, sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).Scope().Name())
+
+	sink.Reset()
+	require.NoError(t, r.consume(context.Background(), [][]byte{[]byte("not an alert")}))
+	assert.Empty(t, sink.AllLogs(), "a batch without evidence is not passed on")
+}
+
+func TestTailer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alerts.json")
+	alert := readTestdata(t, "alert.json")
+	appendLine(t, path, alert)
+
+	var consumed [][]byte
+	consume := func(lines [][]byte) error {
+		consumed = append(consumed, lines...)
+		return nil
+	}
+	tl := &tailer{path: path}
+	require.NoError(t, tl.open(true))
+	require.NoError(t, tl.poll(context.Background(), consume))
+	assert.Empty(t, consumed, "start_at end skips existing alerts")
+
+	appendLine(t, path, []byte(`{"id": "1"}`+"\n\n"+`{"id": "2"`))
+	require.NoError(t, tl.poll(context.Background(), consume))
+	assert.Equal(t, [][]byte{[]byte(`{"id": "1"}`)}, consumed, "a partial line waits for its end")
+
+	appendLine(t, path, []byte("}\n"))
+	require.NoError(t, tl.poll(context.Background(), consume))
+	assert.Equal(t, [][]byte{[]byte(`{"id": "1"}`), []byte(`{"id": "2"}`)}, consumed)
+
+	t.Run("pipeline failure", func(t *testing.T) {
+		consumed = nil
+		appendLine(t, path, []byte(`{"id": "3"}`+"\n"))
+		errPipeline := errors.New("pipeline unavailable")
+		require.ErrorIs(t, tl.poll(context.Background(), func([][]byte) error { return errPipeline }), errPipeline)
+		require.NoError(t, tl.poll(context.Background(), consume))
+		assert.Equal(t, [][]byte{[]byte(`{"id": "3"}`)}, consumed, "unconsumed alerts are read again")
+	})
+
+	t.Run("rotation", func(t *testing.T) {
+		consumed = nil
+		appendLine(t, path, []byte(`{"id": "4"}`+"\n"))
+		require.NoError(t, os.Rename(path, path+".1"))
+		appendLine(t, path, []byte(`{"id": "5"}`+"\n"))
+
+		require.NoError(t, tl.poll(context.Background(), consume))
+		require.NoError(t, tl.poll(context.Background(), consume))
+		assert.Equal(t, [][]byte{[]byte(`{"id": "4"}`), []byte(`{"id": "5"}`)}, consumed,
+			"the rotated file is drained before the new file is read from its beginning")
+	})
+
+	t.Run("truncation", func(t *testing.T) {
+		consumed = nil
+		require.NoError(t, os.Truncate(path, 0))
+		require.NoError(t, tl.poll(context.Background(), consume))
+		appendLine(t, path, []byte(`{"id": "6"}`+"\n"))
+		require.NoError(t, tl.poll(context.Background(), consume))
+		assert.Equal(t, [][]byte{[]byte(`{"id": "6"}`)}, consumed)
+	})
+
+	require.NoError(t, tl.close())
+}
+
+func TestTailerMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alerts.json")
+	tl := &tailer{path: path}
+	require.NoError(t, tl.open(true))
+
+	var consumed [][]byte
+	consume := func(lines [][]byte) error {
+		consumed = append(consumed, lines...)
+		return nil
+	}
+	require.NoError(t, tl.poll(context.Background(), consume))
+
+	appendLine(t, path, []byte(`{"id": "1"}`+"\n"))
+	require.NoError(t, tl.poll(context.Background(), consume))
+	assert.Equal(t, [][]byte{[]byte(`{"id": "1"}`)}, consumed, "a file created after start is read from its beginning")
+	require.NoError(t, tl.close())
+}
+
+func TestReceiveFile(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Socket = nil
+	cfg.File.Path = filepath.Join(t.TempDir(), "alerts.json")
+	cfg.File.StartAt = StartAtBeginning
+	cfg.File.PollInterval = 10 * time.Millisecond
+	appendLine(t, cfg.File.Path, append(readTestdata(t, "sca-alert.json"), '\n'))
+
+	sink := new(consumertest.LogsSink)
+	r := newTestReceiver(t, cfg, sink)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"28612"}, ruleIDs(sink.AllLogs()))
+}
+
+func TestReceiveSocket(t *testing.T) {
+	for _, transport := range []string{TransportUDP, TransportTCP} {
+		t.Run(transport, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.File = nil
+			cfg.Socket.Endpoint = "localhost:0"
+			cfg.Socket.Transport = transport
+
+			sink := new(consumertest.LogsSink)
+			r := newTestReceiver(t, cfg, sink)
+			require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+			t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+			var addr net.Addr
+			if r.packetConn != nil {
+				addr = r.packetConn.LocalAddr()
+			} else {
+				addr = r.listener.Addr()
+			}
+			conn, err := net.Dial(transport, addr.String())
+			require.NoError(t, err)
+			defer conn.Close()
+
+			header := []byte("<132>Jun  2 08:31:15 wazuh-manager ossec: ")
+			_, err = conn.Write(append(append(header, readTestdata(t, "alert.json")...), '\n'))
+			require.NoError(t, err)
+
+			require.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+			assert.Equal(t, []string{"5551"}, ruleIDs(sink.AllLogs()))
+		})
+	}
+}
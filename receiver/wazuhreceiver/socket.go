@@ -0,0 +1,142 @@
+package wazuhreceiver
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// maxDatagramBytes is the largest datagram read; larger alerts are truncated.
+	maxDatagramBytes = 64 << 10
+	// maxLineBytes bounds a newline-delimited alert on a stream connection.
+	maxLineBytes = 1 << 20
+)
+
+// listen binds the configured socket and starts receiving alerts.
+func (r *wazuhReceiver) listen(ctx context.Context) error {
+	cfg := r.cfg.Socket
+	switch cfg.Transport {
+	case TransportUDP, TransportUnixgram:
+		conn, err := net.ListenPacket(cfg.Transport, cfg.Endpoint)
+		if err != nil {
+			return fmt.Errorf("failed to bind to %s: %w", cfg.Endpoint, err)
+		}
+		r.packetConn = conn
+		r.wg.Add(1)
+		go r.readDatagrams(ctx, conn)
+	default:
+		listener, err := net.Listen(cfg.Transport, cfg.Endpoint)
+		if err != nil {
+			return fmt.Errorf("failed to bind to %s: %w", cfg.Endpoint, err)
+		}
+		r.listener = listener
+		r.wg.Add(1)
+		go r.accept(ctx, listener)
+	}
+	return nil
+}
+
+// readDatagrams receives one alert per datagram until the connection is closed.
+func (r *wazuhReceiver) readDatagrams(ctx context.Context, conn net.PacketConn) {
+	defer r.wg.Done()
+	buf := make([]byte, maxDatagramBytes)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				r.settings.Logger.Error("failed to read alert datagram", zap.Error(err))
+			}
+			return
+		}
+		r.consumeSocket(ctx, [][]byte{append([]byte(nil), buf[:n]...)})
+	}
+}
+
+// accept serves stream connections until the listener is closed.
+func (r *wazuhReceiver) accept(ctx context.Context, listener net.Listener) {
+	defer r.wg.Done()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				r.settings.Logger.Error("failed to accept alert connection", zap.Error(err))
+			}
+			return
+		}
+		if !r.track(conn) {
+			_ = conn.Close()
+			return
+		}
+		r.wg.Add(1)
+		go r.readStream(ctx, conn)
+	}
+}
+
+// readStream receives newline-delimited alerts until the connection is closed.
+func (r *wazuhReceiver) readStream(ctx context.Context, conn net.Conn) {
+	defer r.wg.Done()
+	defer r.untrack(conn)
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxLineBytes)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		r.consumeSocket(ctx, [][]byte{append([]byte(nil), scanner.Bytes()...)})
+	}
+	if err := scanner.Err(); err != nil && !errors.Is(err, net.ErrClosed) {
+		r.settings.Logger.Warn("failed to read alert connection", zap.Stringer("remote", conn.RemoteAddr()), zap.Error(err))
+	}
+}
+
+// consumeSocket passes on received alerts. The sender cannot be asked to
+// resend them, so alerts the pipeline refuses are dropped.
+func (r *wazuhReceiver) consumeSocket(ctx context.Context, lines [][]byte) {
+	if err := r.consume(ctx, lines); err != nil {
+		r.settings.Logger.Warn("dropped received alerts", zap.Int("alerts", len(lines)), zap.Error(err))
+	}
+}
+
+// track records an open connection so Shutdown can close it. It reports
+// false once the receiver is shutting down.
+func (r *wazuhReceiver) track(conn net.Conn) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closing {
+		return false
+	}
+	r.conns[conn] = struct{}{}
+	return true
+}
+
+func (r *wazuhReceiver) untrack(conn net.Conn) {
+	r.mu.Lock()
+	delete(r.conns, conn)
+	r.mu.Unlock()
+	_ = conn.Close()
+}
+
+// closeSocket stops receiving alerts and closes open connections.
+func (r *wazuhReceiver) closeSocket() error {
+	r.mu.Lock()
+	r.closing = true
+	for conn := range r.conns {
+		_ = conn.Close()
+	}
+	r.mu.Unlock()
+
+	var err error
+	if r.packetConn != nil {
+		err = r.packetConn.Close()
+	}
+	if r.listener != nil {
+		err = errors.Join(err, r.listener.Close())
+	}
+	return err
+}
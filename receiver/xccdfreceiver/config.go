@@ -0,0 +1,116 @@
+package xccdfreceiver
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const (
+	defaultEndpoint     = "localhost:8089"
+	defaultPath         = "/xccdf/results"
+	defaultInclude      = "*.xml"
+	defaultPollInterval = 30 * time.Second
+	defaultEngineName   = "OpenSCAP"
+
+	httpKey      = "http"
+	directoryKey = "directory"
+)
+
+var (
+	_ component.Config    = (*Config)(nil)
+	_ confmap.Unmarshaler = (*Config)(nil)
+)
+
+// Config defines the configuration for the XCCDF receiver.
+// At least one of HTTP or Directory must be set.
+type Config struct {
+	// HTTP accepts XCCDF or ARF documents uploaded with POST.
+	HTTP *HTTPConfig `mapstructure:"http"`
+
+	// Directory polls a local directory for XCCDF or ARF result files.
+	Directory *DirectoryConfig `mapstructure:"directory"`
+
+	// EngineName is written to policy.engine.name on every emitted record.
+	EngineName string `mapstructure:"engine_name"`
+}
+
+// HTTPConfig configures the upload endpoint.
+type HTTPConfig struct {
+	confighttp.ServerConfig `mapstructure:",squash"`
+
+	// Path is the URL path that accepts result uploads.
+	Path string `mapstructure:"path"`
+}
+
+// DirectoryConfig configures the directory watch.
+type DirectoryConfig struct {
+	// Path is the directory that scanners write result files to.
+	Path string `mapstructure:"path"`
+
+	// Include is a glob pattern, relative to Path, selecting result files.
+	Include string `mapstructure:"include"`
+
+	// PollInterval is how often the directory is scanned for new or modified files.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+}
+
+func createDefaultConfig() component.Config {
+	httpCfg := confighttp.NewDefaultServerConfig()
+	httpCfg.NetAddr.Endpoint = defaultEndpoint
+	return &Config{
+		HTTP: &HTTPConfig{
+			ServerConfig: httpCfg,
+			Path:         defaultPath,
+		},
+		Directory: &DirectoryConfig{
+			Include:      defaultInclude,
+			PollInterval: defaultPollInterval,
+		},
+		EngineName: defaultEngineName,
+	}
+}
+
+// Unmarshal keeps only the ingestion modes that are present in the user configuration.
+func (c *Config) Unmarshal(conf *confmap.Conf) error {
+	if err := conf.Unmarshal(c); err != nil {
+		return err
+	}
+	if !conf.IsSet(httpKey) {
+		c.HTTP = nil
+	}
+	if !conf.IsSet(directoryKey) {
+		c.Directory = nil
+	}
+	return nil
+}
+
+// Validate checks the receiver configuration is valid.
+func (c *Config) Validate() error {
+	if c.HTTP == nil && c.Directory == nil {
+		return errors.New("at least one of http or directory must be configured")
+	}
+	if c.EngineName == "" {
+		return errors.New("engine_name must not be empty")
+	}
+	if c.HTTP != nil && c.HTTP.Path == "" {
+		return errors.New("http.path must not be empty")
+	}
+	if c.Directory != nil {
+		if c.Directory.Path == "" {
+			return errors.New("directory.path must not be empty")
+		}
+		if _, err := filepath.Match(c.Directory.Include, ""); err != nil {
+			return fmt.Errorf("directory.include is not a valid glob pattern: %w", err)
+		}
+		if c.Directory.PollInterval <= 0 {
+			return errors.New("directory.poll_interval must be positive")
+		}
+	}
+	return nil
+}
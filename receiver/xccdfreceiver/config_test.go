@@ -0,0 +1,130 @@
+package xccdfreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id            component.ID
+		wantHTTP      bool
+		wantDirectory bool
+		check         func(t *testing.T, cfg *Config)
+	}{
+		{
+			id:       component.NewID(componentType),
+			wantHTTP: true,
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "0.0.0.0:8089", cfg.HTTP.NetAddr.Endpoint)
+				assert.Equal(t, defaultPath, cfg.HTTP.Path)
+				assert.Equal(t, defaultEngineName, cfg.EngineName)
+			},
+		},
+		{
+			id:            component.NewIDWithName(componentType, "directory"),
+			wantDirectory: true,
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "/var/lib/openscap/results", cfg.Directory.Path)
+				assert.Equal(t, defaultInclude, cfg.Directory.Include)
+				assert.Equal(t, time.Minute, cfg.Directory.PollInterval)
+			},
+		},
+		{
+			id:            component.NewIDWithName(componentType, "both"),
+			wantHTTP:      true,
+			wantDirectory: true,
+			check: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "oscap-nightly", cfg.EngineName)
+				assert.Equal(t, "/upload", cfg.HTTP.Path)
+				assert.Equal(t, "arf-*.xml", cfg.Directory.Include)
+				assert.Equal(t, defaultPollInterval, cfg.Directory.PollInterval)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig().(*Config)
+			require.NoError(t, cfg.Unmarshal(sub))
+			require.NoError(t, cfg.Validate())
+
+			assert.Equal(t, tt.wantHTTP, cfg.HTTP != nil)
+			assert.Equal(t, tt.wantDirectory, cfg.Directory != nil)
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name: "no ingestion mode",
+			mutate: func(cfg *Config) {
+				cfg.HTTP = nil
+				cfg.Directory = nil
+			},
+			wantErr: "at least one of http or directory must be configured",
+		},
+		{
+			name:    "empty engine name",
+			mutate:  func(cfg *Config) { cfg.EngineName = "" },
+			wantErr: "engine_name must not be empty",
+		},
+		{
+			name:    "empty http path",
+			mutate:  func(cfg *Config) { cfg.HTTP.Path = "" },
+			wantErr: "http.path must not be empty",
+		},
+		{
+			name:    "empty directory path",
+			mutate:  func(cfg *Config) { cfg.Directory.Path = "" },
+			wantErr: "directory.path must not be empty",
+		},
+		{
+			name:    "invalid include glob",
+			mutate:  func(cfg *Config) { cfg.Directory.Include = "[" },
+			wantErr: "directory.include is not a valid glob pattern",
+		},
+		{
+			name:    "non-positive poll interval",
+			mutate:  func(cfg *Config) { cfg.Directory.PollInterval = 0 },
+			wantErr: "directory.poll_interval must be positive",
+		},
+		{
+			name:   "valid",
+			mutate: func(*Config) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Directory.Path = t.TempDir()
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package xccdfreceiver
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+const scopeName = "github.com/complytime/complybeacon/receiver/xccdfreceiver"
+
+// errInvalidDocument wraps failures caused by the content of a document rather than
+// by the pipeline, so callers can reject the input instead of retrying it.
+var errInvalidDocument = errors.New("invalid XCCDF document")
+
+// testResult is the subset of an XCCDF 1.2 TestResult element used to build evidence.
+// Tags omit namespaces so both standalone XCCDF results and results embedded in an
+// ARF asset-report-collection are matched.
+type testResult struct {
+	ID          string       `xml:"id,attr"`
+	StartTime   string       `xml:"start-time,attr"`
+	EndTime     string       `xml:"end-time,attr"`
+	TestSystem  string       `xml:"test-system,attr"`
+	Benchmark   idAttr       `xml:"benchmark"`
+	Targets     []string     `xml:"target"`
+	RuleResults []ruleResult `xml:"rule-result"`
+}
+
+type idAttr struct {
+	ID string `xml:"id,attr"`
+}
+
+type ruleResult struct {
+	IDRef    string   `xml:"idref,attr"`
+	Severity string   `xml:"severity,attr"`
+	Time     string   `xml:"time,attr"`
+	Result   string   `xml:"result"`
+	Idents   []string `xml:"ident"`
+	Messages []string `xml:"message"`
+}
+
+// parseResults decodes every TestResult in an XCCDF or ARF document and converts each
+// rule-result into a log record.
+func parseResults(r io.Reader, engineName string, observed time.Time) (plog.Logs, error) {
+	logs := plog.NewLogs()
+	decoder := xml.NewDecoder(r)
+	found := false
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return plog.Logs{}, fmt.Errorf("%w: %w", errInvalidDocument, err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "TestResult" {
+			continue
+		}
+
+		var tr testResult
+		if err := decoder.DecodeElement(&tr, &start); err != nil {
+			return plog.Logs{}, fmt.Errorf("%w: TestResult: %w", errInvalidDocument, err)
+		}
+		appendTestResult(logs, tr, engineName, observed)
+		found = true
+	}
+
+	if !found {
+		return plog.Logs{}, fmt.Errorf("%w: no TestResult element found", errInvalidDocument)
+	}
+	return logs, nil
+}
+
+func appendTestResult(logs plog.Logs, tr testResult, engineName string, observed time.Time) {
+	sl := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	sl.Scope().SetName(scopeName)
+
+	endTime := parseTime(tr.EndTime, observed)
+	target := ""
+	if len(tr.Targets) > 0 {
+		target = strings.TrimSpace(tr.Targets[0])
+	}
+	engineVersion := engineVersionFromCPE(tr.TestSystem)
+
+	for _, rr := range tr.RuleResults {
+		record := sl.LogRecords().AppendEmpty()
+		record.SetObservedTimestamp(pcommon.NewTimestampFromTime(observed))
+		record.SetTimestamp(pcommon.NewTimestampFromTime(parseTime(rr.Time, endTime)))
+		record.SetSeverityNumber(plog.SeverityNumberInfo)
+		record.SetSeverityText(plog.SeverityNumberInfo.String())
+
+		result := strings.TrimSpace(rr.Result)
+		attrs := record.Attributes()
+		attrs.PutStr(proofwatch.POLICY_ENGINE_NAME, engineName)
+		if engineVersion != "" {
+			attrs.PutStr(proofwatch.POLICY_ENGINE_VERSION, engineVersion)
+		}
+		attrs.PutStr(proofwatch.POLICY_RULE_ID, rr.IDRef)
+		attrs.PutStr(proofwatch.POLICY_EVALUATION_RESULT, mapResult(result))
+		if len(rr.Messages) > 0 {
+			attrs.PutStr(proofwatch.POLICY_EVALUATION_MESSAGE, strings.TrimSpace(strings.Join(rr.Messages, "\n")))
+		}
+		if target != "" {
+			attrs.PutStr(proofwatch.POLICY_TARGET_ID, target)
+			attrs.PutStr(proofwatch.POLICY_TARGET_NAME, target)
+		}
+		if tr.ID != "" {
+			attrs.PutStr(proofwatch.COMPLIANCE_ASSESSMENT_ID, tr.ID)
+		}
+		if tr.Benchmark.ID != "" {
+			attrs.PutStr(proofwatch.COMPLIANCE_CONTROL_CATALOG_ID, tr.Benchmark.ID)
+		}
+		if rr.Severity != "" {
+			attrs.PutStr(proofwatch.COMPLIANCE_RISK_LEVEL, mapSeverity(rr.Severity))
+		}
+		if len(rr.Idents) > 0 {
+			idents := attrs.PutEmptySlice(proofwatch.COMPLIANCE_REQUIREMENTS)
+			for _, ident := range rr.Idents {
+				idents.AppendEmpty().SetStr(strings.TrimSpace(ident))
+			}
+		}
+		record.Body().SetStr(fmt.Sprintf("%s: %s", rr.IDRef, result))
+	}
+}
+
+// mapResult maps an XCCDF rule-result value to policy.evaluation.result.
+func mapResult(result string) string {
+	switch result {
+	case "pass", "fixed":
+		return "Passed"
+	case "fail":
+		return "Failed"
+	case "notapplicable":
+		return "Not Applicable"
+	case "notchecked", "notselected":
+		return "Not Run"
+	case "informational":
+		return "Needs Review"
+	default:
+		// error, unknown
+		return "Unknown"
+	}
+}
+
+// mapSeverity maps an XCCDF severity to compliance.risk.level.
+func mapSeverity(severity string) string {
+	switch severity {
+	case "high":
+		return "High"
+	case "medium":
+		return "Medium"
+	case "low":
+		return "Low"
+	default:
+		// info, unknown
+		return "Informational"
+	}
+}
+
+// parseTime parses an xsd:dateTime value, which may omit the time zone.
+func parseTime(value string, fallback time.Time) time.Time {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return fallback
+}
+
+// engineVersionFromCPE extracts the version from a test-system CPE such as
+// "cpe:/a:redhat:openscap:1.3.10".
+func engineVersionFromCPE(cpe string) string {
+	parts := strings.Split(cpe, ":")
+	if len(parts) < 5 || !strings.HasPrefix(cpe, "cpe:") {
+		return ""
+	}
+	return parts[4]
+}
@@ -0,0 +1,151 @@
+package xccdfreceiver
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/complytime/complybeacon/proofwatch"
+)
+
+func parseFixture(t *testing.T, name string, observed time.Time) plog.Logs {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	defer f.Close()
+
+	logs, err := parseResults(f, defaultEngineName, observed)
+	require.NoError(t, err)
+	return logs
+}
+
+func recordAttrs(record plog.LogRecord) map[string]any {
+	return record.Attributes().AsRaw()
+}
+
+func TestParseResultsXCCDF(t *testing.T) {
+	observed := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	logs := parseFixture(t, "xccdf-results.xml", observed)
+
+	require.Equal(t, 3, logs.LogRecordCount())
+	records := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+
+	pass := recordAttrs(records.At(0))
+	assert.Equal(t, "xccdf_org.ssgproject.content_rule_accounts_password_minlen_login_defs", pass[proofwatch.POLICY_RULE_ID])
+	assert.Equal(t, "Passed", pass[proofwatch.POLICY_EVALUATION_RESULT])
+	assert.Equal(t, "OpenSCAP", pass[proofwatch.POLICY_ENGINE_NAME])
+	assert.Equal(t, "1.3.10", pass[proofwatch.POLICY_ENGINE_VERSION])
+	assert.Equal(t, "web-01.example.com", pass[proofwatch.POLICY_TARGET_ID])
+	assert.Equal(t, "xccdf_org.open-scap_testresult_xccdf_org.ssgproject.content_profile_cis", pass[proofwatch.COMPLIANCE_ASSESSMENT_ID])
+	assert.Equal(t, "xccdf_org.ssgproject.content_benchmark_RHEL-9", pass[proofwatch.COMPLIANCE_CONTROL_CATALOG_ID])
+	assert.Equal(t, "Medium", pass[proofwatch.COMPLIANCE_RISK_LEVEL])
+	assert.Equal(t, []any{"CCE-83536-7"}, pass[proofwatch.COMPLIANCE_REQUIREMENTS])
+	assert.Equal(t, time.Date(2026, 6, 1, 10, 1, 0, 0, time.UTC), records.At(0).Timestamp().AsTime())
+	assert.Equal(t, observed, records.At(0).ObservedTimestamp().AsTime())
+
+	fail := recordAttrs(records.At(1))
+	assert.Equal(t, "Failed", fail[proofwatch.POLICY_EVALUATION_RESULT])
+	assert.Equal(t, "High", fail[proofwatch.COMPLIANCE_RISK_LEVEL])
+	assert.Equal(t, "PermitRootLogin is set to yes", fail[proofwatch.POLICY_EVALUATION_MESSAGE])
+	assert.Equal(t, []any{"CCE-90799-2", "SV-257983r925411_rule"}, fail[proofwatch.COMPLIANCE_REQUIREMENTS])
+	// Zone-less xsd:dateTime values are interpreted as UTC.
+	assert.Equal(t, time.Date(2026, 6, 1, 10, 2, 0, 0, time.UTC), records.At(1).Timestamp().AsTime())
+
+	// Rule results without a time attribute fall back to the TestResult end-time.
+	notApplicable := records.At(2)
+	assert.Equal(t, "Not Applicable", recordAttrs(notApplicable)[proofwatch.POLICY_EVALUATION_RESULT])
+	assert.Equal(t, time.Date(2026, 6, 1, 10, 5, 0, 0, time.UTC), notApplicable.Timestamp().AsTime())
+}
+
+func TestParseResultsARF(t *testing.T) {
+	observed := time.Date(2026, 6, 2, 9, 0, 0, 0, time.UTC)
+	logs := parseFixture(t, "arf-results.xml", observed)
+
+	require.Equal(t, 1, logs.LogRecordCount())
+	record := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	attrs := recordAttrs(record)
+
+	assert.Equal(t, "xccdf_org.ssgproject.content_rule_auditd_data_retention_max_log_file", attrs[proofwatch.POLICY_RULE_ID])
+	assert.Equal(t, "Unknown", attrs[proofwatch.POLICY_EVALUATION_RESULT])
+	assert.Equal(t, "db-01.example.com", attrs[proofwatch.POLICY_TARGET_NAME])
+	assert.NotContains(t, attrs, proofwatch.COMPLIANCE_REQUIREMENTS)
+	assert.Equal(t, time.Date(2026, 6, 2, 8, 10, 0, 0, time.UTC), record.Timestamp().AsTime())
+}
+
+func TestParseResultsTimestampFallsBackToObserved(t *testing.T) {
+	doc := `<TestResult id="tr"><rule-result idref="r1"><result>pass</result></rule-result></TestResult>`
+	observed := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	logs, err := parseResults(strings.NewReader(doc), defaultEngineName, observed)
+	require.NoError(t, err)
+
+	record := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, observed, record.Timestamp().AsTime())
+	assert.NotContains(t, recordAttrs(record), proofwatch.POLICY_ENGINE_VERSION)
+}
+
+func TestParseResultsInvalidDocument(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+	}{
+		{name: "malformed xml", doc: "<TestResult><rule-result>"},
+		{name: "no test result", doc: "<Benchmark id=\"b\"/>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseResults(strings.NewReader(tt.doc), defaultEngineName, time.Now())
+			assert.ErrorIs(t, err, errInvalidDocument)
+		})
+	}
+}
+
+func TestMapResult(t *testing.T) {
+	tests := map[string]string{
+		"pass":          "Passed",
+		"fixed":         "Passed",
+		"fail":          "Failed",
+		"notapplicable": "Not Applicable",
+		"notchecked":    "Not Run",
+		"notselected":   "Not Run",
+		"informational": "Needs Review",
+		"error":         "Unknown",
+		"unknown":       "Unknown",
+		"":              "Unknown",
+	}
+
+	for input, expected := range tests {
+		t.Run(input, func(t *testing.T) {
+			assert.Equal(t, expected, mapResult(input))
+		})
+	}
+}
+
+func TestMapSeverity(t *testing.T) {
+	tests := map[string]string{
+		"high":    "High",
+		"medium":  "Medium",
+		"low":     "Low",
+		"info":    "Informational",
+		"unknown": "Informational",
+	}
+
+	for input, expected := range tests {
+		t.Run(input, func(t *testing.T) {
+			assert.Equal(t, expected, mapSeverity(input))
+		})
+	}
+}
+
+func TestEngineVersionFromCPE(t *testing.T) {
+	assert.Equal(t, "1.3.10", engineVersionFromCPE("cpe:/a:redhat:openscap:1.3.10"))
+	assert.Equal(t, "", engineVersionFromCPE("cpe:/a:redhat"))
+	assert.Equal(t, "", engineVersionFromCPE("openscap 1.3.10"))
+}
@@ -0,0 +1,191 @@
+package xccdfreceiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+)
+
+// defaultMaxUploadBytes bounds the size of a single uploaded result document.
+const defaultMaxUploadBytes = 64 << 20
+
+var _ receiver.Logs = (*xccdfReceiver)(nil)
+
+type xccdfReceiver struct {
+	cfg      *Config
+	settings receiver.Settings
+	next     consumer.Logs
+
+	server         *http.Server
+	maxUploadBytes int64
+	cancel         context.CancelFunc
+	wg             sync.WaitGroup
+
+	// seen tracks the modification time of files already consumed from the watched directory.
+	seen map[string]time.Time
+}
+
+func newXCCDFReceiver(cfg *Config, set receiver.Settings, next consumer.Logs) *xccdfReceiver {
+	return &xccdfReceiver{
+		cfg:            cfg,
+		settings:       set,
+		next:           next,
+		maxUploadBytes: defaultMaxUploadBytes,
+		seen:           make(map[string]time.Time),
+	}
+}
+
+// Start begins accepting uploads and/or watching the configured directory.
+func (r *xccdfReceiver) Start(ctx context.Context, host component.Host) error {
+	runCtx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	if r.cfg.HTTP != nil {
+		if err := r.startHTTP(ctx, host); err != nil {
+			return err
+		}
+	}
+
+	if r.cfg.Directory != nil {
+		r.wg.Add(1)
+		go r.pollDirectory(runCtx)
+	}
+	return nil
+}
+
+// Shutdown stops the HTTP server and the directory watch.
+func (r *xccdfReceiver) Shutdown(ctx context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	var err error
+	if r.server != nil {
+		err = r.server.Shutdown(ctx)
+	}
+	r.wg.Wait()
+	return err
+}
+
+func (r *xccdfReceiver) startHTTP(ctx context.Context, host component.Host) error {
+	listener, err := r.cfg.HTTP.ToListener(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to bind to %s: %w", r.cfg.HTTP.NetAddr.Endpoint, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(r.cfg.HTTP.Path, r.handleUpload)
+
+	r.server, err = r.cfg.HTTP.ToServer(ctx, host.GetExtensions(), r.settings.TelemetrySettings, mux)
+	if err != nil {
+		return err
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		if errHTTP := r.server.Serve(listener); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+			componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(errHTTP))
+		}
+	}()
+	return nil
+}
+
+func (r *xccdfReceiver) handleUpload(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body := http.MaxBytesReader(w, req.Body, r.maxUploadBytes)
+	defer body.Close()
+
+	if err := r.consume(req.Context(), body); err != nil {
+		r.settings.Logger.Warn("rejected XCCDF upload", zap.Error(err))
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, "result document too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		if errors.Is(err, errInvalidDocument) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "failed to process results", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (r *xccdfReceiver) pollDirectory(ctx context.Context) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.cfg.Directory.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		r.scanDirectory(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// scanDirectory consumes files that are new or modified since the previous scan.
+// Processed files are tracked in memory only, so a restart re-reads the directory.
+func (r *xccdfReceiver) scanDirectory(ctx context.Context) {
+	matches, err := filepath.Glob(filepath.Join(r.cfg.Directory.Path, r.cfg.Directory.Include))
+	if err != nil {
+		r.settings.Logger.Error("failed to list result files", zap.Error(err))
+		return
+	}
+
+	for _, path := range matches {
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() {
+			continue
+		}
+		if modTime, ok := r.seen[path]; ok && modTime.Equal(info.ModTime()) {
+			continue
+		}
+		if err := r.consumeFile(ctx, path); err != nil {
+			r.settings.Logger.Warn("failed to process result file", zap.String("path", path), zap.Error(err))
+			if !errors.Is(err, errInvalidDocument) {
+				// Retry on the next scan; the pipeline may be temporarily unavailable.
+				continue
+			}
+		}
+		r.seen[path] = info.ModTime()
+	}
+}
+
+func (r *xccdfReceiver) consumeFile(ctx context.Context, path string) error {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return r.consume(ctx, f)
+}
+
+func (r *xccdfReceiver) consume(ctx context.Context, reader io.Reader) error {
+	logs, err := parseResults(reader, r.cfg.EngineName, time.Now())
+	if err != nil {
+		return err
+	}
+	return r.next.ConsumeLogs(ctx, logs)
+}
@@ -0,0 +1,133 @@
+package xccdfreceiver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+const minimalDocument = `<TestResult id="tr"><rule-result idref="r1"><result>pass</result></rule-result></TestResult>`
+
+func newTestReceiver(t *testing.T, next consumer.Logs) *xccdfReceiver {
+	t.Helper()
+	cfg := createDefaultConfig().(*Config)
+	cfg.Directory.Path = t.TempDir()
+	return newXCCDFReceiver(cfg, receivertest.NewNopSettings(componentType), next)
+}
+
+func TestHandleUpload(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		next       consumer.Logs
+		wantStatus int
+		wantLogs   int
+	}{
+		{
+			name:       "non-POST is rejected",
+			method:     http.MethodGet,
+			next:       new(consumertest.LogsSink),
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "invalid document",
+			method:     http.MethodPost,
+			body:       "not xml",
+			next:       new(consumertest.LogsSink),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "oversized document",
+			method:     http.MethodPost,
+			body:       "<TestResult>" + strings.Repeat(" ", 1024) + "</TestResult>",
+			next:       new(consumertest.LogsSink),
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "pipeline failure",
+			method:     http.MethodPost,
+			body:       minimalDocument,
+			next:       consumertest.NewErr(errors.New("pipeline unavailable")),
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:       "accepted",
+			method:     http.MethodPost,
+			body:       minimalDocument,
+			next:       new(consumertest.LogsSink),
+			wantStatus: http.StatusAccepted,
+			wantLogs:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReceiver(t, tt.next)
+			r.maxUploadBytes = 512
+			req := httptest.NewRequest(tt.method, defaultPath, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			r.handleUpload(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if sink, ok := tt.next.(*consumertest.LogsSink); ok {
+				assert.Equal(t, tt.wantLogs, sink.LogRecordCount())
+			}
+		})
+	}
+}
+
+func TestScanDirectory(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	r := newTestReceiver(t, sink)
+
+	path := filepath.Join(r.cfg.Directory.Path, "results.xml")
+	require.NoError(t, os.WriteFile(path, []byte(minimalDocument), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(r.cfg.Directory.Path, "notes.txt"), []byte("ignored"), 0o600))
+
+	r.scanDirectory(context.Background())
+	assert.Equal(t, 1, sink.LogRecordCount(), "new file is consumed")
+
+	r.scanDirectory(context.Background())
+	assert.Equal(t, 1, sink.LogRecordCount(), "unchanged file is skipped")
+
+	modified := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, modified, modified))
+	r.scanDirectory(context.Background())
+	assert.Equal(t, 2, sink.LogRecordCount(), "modified file is re-read")
+}
+
+func TestScanDirectorySkipsInvalidFilesOnce(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	r := newTestReceiver(t, sink)
+
+	path := filepath.Join(r.cfg.Directory.Path, "broken.xml")
+	require.NoError(t, os.WriteFile(path, []byte("<TestResult>"), 0o600))
+
+	r.scanDirectory(context.Background())
+	assert.Contains(t, r.seen, path, "invalid documents are not retried until modified")
+	assert.Equal(t, 0, sink.LogRecordCount())
+}
+
+func TestScanDirectoryRetriesOnPipelineFailure(t *testing.T) {
+	r := newTestReceiver(t, consumertest.NewErr(errors.New("pipeline unavailable")))
+
+	path := filepath.Join(r.cfg.Directory.Path, "results.xml")
+	require.NoError(t, os.WriteFile(path, []byte(minimalDocument), 0o600))
+
+	r.scanDirectory(context.Background())
+	assert.NotContains(t, r.seen, path)
+}